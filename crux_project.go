@@ -2,11 +2,13 @@ package main
 
 import (
 	"context"
+	"os"
 
 	"github.com/gofiber/fiber/v2"
 	"go.uber.org/zap"
 
 	awsClient "github.com/jwallace145/crux-backend/internal/aws"
+	"github.com/jwallace145/crux-backend/internal/cli"
 	"github.com/jwallace145/crux-backend/internal/utils"
 
 	"github.com/jwallace145/crux-backend/internal/config"
@@ -14,22 +16,40 @@ import (
 
 	"github.com/jwallace145/crux-backend/internal/db"
 	"github.com/jwallace145/crux-backend/internal/routes"
+	"github.com/jwallace145/crux-backend/internal/services"
+	"github.com/jwallace145/crux-backend/models"
 )
 
 func main() {
-	// Initialize app, config, and logger
-	app := fiber.New()
+	// Run a subcommand instead of the API server when one is given
+	if len(os.Args) > 1 && os.Args[1] == "import-gyms" {
+		os.Exit(cli.ImportGyms(os.Args[2:]))
+	}
+
+	// Initialize config, app, and logger
 	cfg := config.Load()
+	app := fiber.New(fiber.Config{
+		// Bodies over the limit are streamed to the handler instead of rejected, and the body
+		// limit middleware applies the default and upload limits per route
+		BodyLimit:                    cfg.BodyLimit,
+		StreamRequestBody:            true,
+		DisablePreParseMultipartForm: true,
+	})
 	log := utils.Log
 
 	// Initialize middleware
 	corsMiddelware := middleware.CORSMiddleware()
 	loggerMiddleware := middleware.LoggerMiddleware()
 	authMiddleware := middleware.AuthMiddleware()
+	idempotencyMiddleware := middleware.IdempotencyMiddleware(cfg.IdempotencyTTL)
+	adminMiddleware := middleware.AdminMiddleware()
+	bodyLimitMiddleware := middleware.BodyLimitMiddleware(cfg.BodyLimit, "/media", "/gyms/import", "/gyms/*/climbs/*/photo")
+	uploadLimitMiddleware := middleware.BodyLimitMiddleware(cfg.UploadBodyLimit)
 
-	// Attach global middleware for CORS and logging
+	// Attach global middleware for CORS, logging and request body limits
 	app.Use(corsMiddelware)
 	app.Use(loggerMiddleware)
+	app.Use(bodyLimitMiddleware)
 
 	// Connect to db and perform schema migrations
 	db.ConnectDB()
@@ -39,13 +59,31 @@ func main() {
 		log.Fatal("Failed to initialize S3 client", zap.Error(err))
 	}
 
+	// Close live training sessions left open past the idle timeout
+	services.StartIdleSessionCloser(context.Background(), cfg.LiveSessionIdleTimeout)
+
+	// Purge idempotency keys whose responses can no longer be replayed
+	services.StartIdempotencyKeyPurger(context.Background())
+
 	// Setup routes
 	routes.SetupHealthCheckRoute(app)
 	routes.SetupAuthRoutes(app, authMiddleware)
 	routes.SetupUserRoutes(app, authMiddleware)
-	routes.SetupClimbRoutes(app, authMiddleware)
-	routes.SetupGymRoutes(app, authMiddleware)
-	routes.SetupTrainingSessionRoutes(app, authMiddleware)
+	routes.SetupClimbRoutes(app, authMiddleware, idempotencyMiddleware)
+	routes.SetupGymRoutes(app, authMiddleware, adminMiddleware, uploadLimitMiddleware)
+	routes.SetupTrainingSessionRoutes(app, authMiddleware, idempotencyMiddleware)
+	routes.SetupMediaRoutes(app, authMiddleware, uploadLimitMiddleware)
+	routes.SetupProjectRoutes(app, authMiddleware)
+	routes.SetupActivityRoutes(app, authMiddleware)
+	routes.SetupSyncRoutes(app, authMiddleware, idempotencyMiddleware)
+	routes.SetupExerciseRoutes(app, authMiddleware)
+	routes.SetupWorkoutTemplateRoutes(app, authMiddleware)
+	routes.SetupPlannedSessionRoutes(app, authMiddleware)
+	routes.SetupTrainingLoadRoutes(app, authMiddleware, models.TrainingLoadThresholds{
+		ACWR:           cfg.TrainingLoadACWRThreshold,
+		WeeklyIncrease: cfg.TrainingLoadWeeklyIncreaseThreshold,
+		Monotony:       cfg.TrainingLoadMonotonyThreshold,
+	})
 	routes.SetupDocsRoutes(app)
 
 	log.Info("Starting CruxProject API server",
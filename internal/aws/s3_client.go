@@ -12,6 +12,9 @@ import (
 	"go.uber.org/zap"
 )
 
+// MediaBucket is the S3 bucket that stores user uploaded media
+const MediaBucket = "crux-project-dev"
+
 var (
 	S3Client *s3.Client
 	logger   *zap.Logger
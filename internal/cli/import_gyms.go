@@ -0,0 +1,127 @@
+package cli
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"go.uber.org/zap"
+
+	"github.com/jwallace145/crux-backend/internal/db"
+	"github.com/jwallace145/crux-backend/internal/services"
+	"github.com/jwallace145/crux-backend/internal/utils"
+	"github.com/jwallace145/crux-backend/models"
+)
+
+// ImportGyms runs the import-gyms subcommand, which imports gyms from a CSV or GeoJSON file with
+// the same validation and upsert rules as POST /gyms/import. The report is written to stdout as
+// JSON. Returns the process exit code: 0 when every row was imported, 1 when rows failed or the
+// file could not be read, and 2 for invalid arguments
+func ImportGyms(args []string) int {
+	log := utils.Log
+
+	fs := flag.NewFlagSet("import-gyms", flag.ContinueOnError)
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: crux-api import-gyms [flags] <file|->")
+		fs.PrintDefaults()
+	}
+	format := fs.String("format", "", "csv or geojson, detected from the file extension when omitted")
+	dryRun := fs.Bool("dry-run", false, "validate the file and report what would change without writing")
+	batchSize := fs.Int("batch-size", models.DefaultGymImportBatchSize, fmt.Sprintf("rows written per transaction (max %d)", models.MaxGymImportBatchSize))
+	mapping := fs.String("mapping", "", "comma separated field=source pairs, e.g. name=title,city=town")
+	defaultType := fs.String("default-type", "", "gym type for rows without one (bouldering, roped, full)")
+	defaultCountry := fs.String("default-country", "", "country for rows without one")
+	createdBy := fs.Uint("created-by", 0, "user ID recorded as the creator of new gyms")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return 2
+	}
+	path := fs.Arg(0)
+
+	opts := models.GymImportOptions{
+		Format:         strings.ToLower(*format),
+		DryRun:         *dryRun,
+		BatchSize:      *batchSize,
+		DefaultType:    strings.ToLower(*defaultType),
+		DefaultCountry: *defaultCountry,
+	}
+	if opts.Format == "" {
+		opts.Format = models.DetectGymImportFormat(path, "")
+	}
+	if !models.IsValidGymImportFormat(opts.Format) {
+		fmt.Fprintln(os.Stderr, "-format must be csv or geojson")
+		return 2
+	}
+	if opts.BatchSize < 1 || opts.BatchSize > models.MaxGymImportBatchSize {
+		fmt.Fprintf(os.Stderr, "-batch-size must be between 1 and %d\n", models.MaxGymImportBatchSize)
+		return 2
+	}
+	if opts.DefaultType != "" {
+		if err := services.ValidateGymType(opts.DefaultType); err != nil {
+			fmt.Fprintln(os.Stderr, "-default-type must be one of: bouldering, roped, full")
+			return 2
+		}
+	}
+	if *mapping != "" {
+		parsed, err := models.ParseGymImportMapping(*mapping)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "-mapping: %v\n", err)
+			return 2
+		}
+		opts.Mapping = parsed
+	}
+	if *createdBy > 0 {
+		userID := *createdBy
+		opts.CreatedByID = &userID
+	}
+
+	var source io.Reader = os.Stdin
+	if path != "-" {
+		file, err := os.Open(path)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to open %s: %v\n", path, err)
+			return 1
+		}
+		defer file.Close()
+		source = file
+	}
+
+	// Connect to db and perform schema migrations so the import runs against the current schema
+	db.ConnectDB()
+
+	log.Info("Importing gyms",
+		zap.String("file", path),
+		zap.String("format", opts.Format),
+		zap.Bool("dry_run", opts.DryRun),
+		zap.Int("batch_size", opts.BatchSize),
+	)
+
+	report, err := services.ImportGyms(db.DB, source, opts)
+	if report != nil {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		_ = encoder.Encode(report)
+	}
+	if err != nil {
+		log.Error("Gym import file could not be read", zap.Error(err))
+		return 1
+	}
+
+	log.Info("Gym import completed",
+		zap.Bool("dry_run", report.DryRun),
+		zap.Int("rows", report.Rows),
+		zap.Int("created", report.Created),
+		zap.Int("updated", report.Updated),
+		zap.Int("failed", report.Failed),
+	)
+	if report.Failed > 0 {
+		return 1
+	}
+	return 0
+}
@@ -1,24 +1,49 @@
 package config
 
-import "os"
+import (
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
 
 type AppConfig struct {
-	ServiceName string
-	Version     string
-	Environment string
-	Port        string
-	Address     string
+	ServiceName     string
+	Version         string
+	Environment     string
+	Port            string
+	Address         string
+	BodyLimit       int           // Maximum request body size in bytes
+	UploadBodyLimit int           // Maximum request body size in bytes for file uploads to POST /media, POST /gyms/import and PUT /gyms/:id/climbs/:climb_id/photo
+	IdempotencyTTL  time.Duration // How long Idempotency-Key responses are replayed
+
+	LiveSessionIdleTimeout time.Duration // Live training sessions with no activity for this long are closed
+
+	// Training load warning thresholds used by GET /training-load
+	TrainingLoadACWRThreshold           float64 // Acute:chronic workload ratio above which a week is flagged
+	TrainingLoadWeeklyIncreaseThreshold float64 // Week over week load increase (0.3 = 30%) above which a week is flagged
+	TrainingLoadMonotonyThreshold       float64 // Monotony above which a week is flagged
 }
 
 func Load() *AppConfig {
 	port := getEnvOrDefault("PORT", "3000")
 
 	return &AppConfig{
-		ServiceName: "CruxProject API",
-		Version:     "1.0.0",
-		Environment: "development",
-		Port:        port,
-		Address:     "0.0.0.0:" + port,
+		ServiceName:     "CruxProject API",
+		Version:         "1.0.0",
+		Environment:     "development",
+		Port:            port,
+		Address:         "0.0.0.0:" + port,
+		BodyLimit:       fiber.DefaultBodyLimit,
+		UploadBodyLimit: 60 * 1024 * 1024, // Large enough for video uploads to POST /media
+		IdempotencyTTL:  getDurationOrDefault("IDEMPOTENCY_TTL", 24*time.Hour),
+
+		LiveSessionIdleTimeout: getDurationOrDefault("LIVE_SESSION_IDLE_TIMEOUT", 4*time.Hour),
+
+		TrainingLoadACWRThreshold:           getFloatOrDefault("TRAINING_LOAD_ACWR_THRESHOLD", 1.5),
+		TrainingLoadWeeklyIncreaseThreshold: getFloatOrDefault("TRAINING_LOAD_WEEKLY_INCREASE_THRESHOLD", 0.3),
+		TrainingLoadMonotonyThreshold:       getFloatOrDefault("TRAINING_LOAD_MONOTONY_THRESHOLD", 2.0),
 	}
 }
 
@@ -28,3 +53,19 @@ func getEnvOrDefault(key, defaultValue string) string {
 	}
 	return defaultValue
 }
+
+// getDurationOrDefault parses a Go duration (e.g., "24h", "90m") from the environment
+func getDurationOrDefault(key string, defaultValue time.Duration) time.Duration {
+	if value, err := time.ParseDuration(os.Getenv(key)); err == nil && value > 0 {
+		return value
+	}
+	return defaultValue
+}
+
+// getFloatOrDefault parses a positive number (e.g., "1.5") from the environment
+func getFloatOrDefault(key string, defaultValue float64) float64 {
+	if value, err := strconv.ParseFloat(os.Getenv(key), 64); err == nil && value > 0 {
+		return value
+	}
+	return defaultValue
+}
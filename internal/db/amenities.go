@@ -0,0 +1,99 @@
+package db
+
+import (
+	"strings"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/jwallace145/crux-backend/internal/utils"
+	"github.com/jwallace145/crux-backend/models"
+)
+
+// seedAmenities adds the built-in amenities that are missing from the catalog. Amenities that
+// already exist are left as they are so that admin edits are kept
+func seedAmenities(db *gorm.DB) {
+	log := utils.Log
+
+	amenities := append([]models.Amenity{}, models.DefaultAmenities...)
+	result := db.Clauses(clause.OnConflict{Columns: []clause.Column{{Name: "slug"}}, DoNothing: true}).Create(&amenities)
+	if result.Error != nil {
+		log.Warn("Failed to seed amenities", zap.Error(result.Error))
+		return
+	}
+
+	log.Info("Amenity seed complete",
+		zap.Int("defaults", len(amenities)),
+		zap.Int64("added", result.RowsAffected),
+	)
+}
+
+// backfillGymAmenities links gyms to the amenities set by their legacy has_* flags. The flags are
+// cleared in the same transaction so that an amenity later removed from a gym is not added back
+func backfillGymAmenities(db *gorm.DB) {
+	log := utils.Log
+
+	var gyms []models.Gym
+	if err := db.Select(append([]string{"id"}, legacyAmenityColumns...)).
+		Where(strings.Join(legacyAmenityColumns, " OR ")).
+		Find(&gyms).Error; err != nil {
+		log.Warn("Failed to load gyms for amenity backfill", zap.Error(err))
+		return
+	}
+
+	migrated := 0
+	for i := range gyms {
+		gym := &gyms[i]
+		err := db.Transaction(func(tx *gorm.DB) error {
+			var amenityIDs []uint
+			if err := tx.Model(&models.Amenity{}).Where("slug IN ?", gym.LegacyAmenities()).Pluck("id", &amenityIDs).Error; err != nil {
+				return err
+			}
+			links := make([]models.GymAmenity, len(amenityIDs))
+			for i, amenityID := range amenityIDs {
+				links[i] = models.GymAmenity{GymID: gym.ID, AmenityID: amenityID}
+			}
+			if len(links) > 0 {
+				if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&links).Error; err != nil {
+					return err
+				}
+			}
+
+			flags := map[string]interface{}{}
+			for _, column := range legacyAmenityColumns {
+				flags[column] = false
+			}
+			return tx.Model(gym).UpdateColumns(flags).Error
+		})
+		if err != nil {
+			log.Warn("Failed to backfill gym amenities",
+				zap.Error(err),
+				zap.Uint("gym_id", gym.ID),
+			)
+			continue
+		}
+		migrated++
+	}
+
+	log.Info("Gym amenity backfill complete",
+		zap.Int("candidates", len(gyms)),
+		zap.Int("migrated", migrated),
+	)
+}
+
+// legacyAmenityColumns are the gym columns replaced by amenities, in models.LegacyAmenitySlugs order
+var legacyAmenityColumns = []string{
+	"has_bouldering",
+	"has_top_rope",
+	"has_lead_climbing",
+	"has_auto_belay",
+	"has_kids_area",
+	"has_training_area",
+	"has_yoga_classes",
+	"has_shower",
+	"has_parking",
+	"has_gear_rental",
+	"has_pro_shop",
+	"has_cafe",
+}
@@ -0,0 +1,39 @@
+package db
+
+import (
+	"time"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	"github.com/jwallace145/crux-backend/internal/utils"
+)
+
+// deleteOrphanedClimbAttemptsSQL soft deletes the attempts whose indoor boulder or rope climb has
+// been deleted
+const deleteOrphanedClimbAttemptsSQL = `
+	UPDATE climb_attempts a
+	SET deleted_at = @now
+	WHERE a.deleted_at IS NULL AND NOT EXISTS (
+		SELECT 1 FROM indoor_boulders ib
+		WHERE a.parent_type = 'indoor_boulder' AND ib.id = a.parent_id AND ib.deleted_at IS NULL
+	) AND NOT EXISTS (
+		SELECT 1 FROM rope_climbs rc
+		WHERE a.parent_type = 'rope_climb' AND rc.id = a.parent_id AND rc.deleted_at IS NULL
+	)`
+
+// deleteOrphanedClimbAttempts cleans up the attempts left behind by climbs that were deleted
+// before attempts were deleted along with them
+func deleteOrphanedClimbAttempts(db *gorm.DB) {
+	log := utils.Log
+
+	result := db.Exec(deleteOrphanedClimbAttemptsSQL, map[string]interface{}{"now": time.Now()})
+	if result.Error != nil {
+		log.Warn("Failed to delete orphaned climb attempts", zap.Error(result.Error))
+		return
+	}
+
+	log.Info("Orphaned climb attempt cleanup complete",
+		zap.Int64("deleted", result.RowsAffected),
+	)
+}
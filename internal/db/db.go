@@ -75,12 +75,39 @@ func ConnectDB() {
 	// Assign to global variable
 	DB = db
 
+	// Use the custom join table for training session partners so invitations carry a status
+	if err := DB.SetupJoinTable(&models.TrainingSession{}, "Partners", &models.TrainingSessionPartner{}); err != nil {
+		log.Fatal("Failed to setup training session partners join table", zap.Error(err))
+	}
+
+	// Use the custom join table for gym amenities so links record when they were added
+	if err := DB.SetupJoinTable(&models.Gym{}, "Amenities", &models.GymAmenity{}); err != nil {
+		log.Fatal("Failed to setup gym amenities join table", zap.Error(err))
+	}
+
 	// Perform schema migrations
 	log.Info("Starting schema migration")
 	if err := migrateModels(DB); err != nil {
 		log.Fatal("Schema migration failed", zap.Error(err))
 	}
 
+	// Enable the spatial index used by nearby gym searches and the text indexes used by gym search
+	setupEarthDistance(DB)
+	setupGymSearch(DB)
+
+	// Parse existing free-form gym hours into structured opening hours where possible
+	backfillGymOpeningHours(DB)
+
+	// Move the legacy flat gym prices into currency-aware pricing tiers
+	backfillGymPrices(DB)
+
+	// Seed the amenity catalog and move the legacy gym facility flags into it
+	seedAmenities(DB)
+	backfillGymAmenities(DB)
+
+	// Remove the attempts of climbs that were deleted without them
+	deleteOrphanedClimbAttempts(DB)
+
 	log.Info("Database initialization complete")
 }
 
@@ -91,15 +118,37 @@ func migrateModels(db *gorm.DB) error {
 	modelsToMigrate := []interface{}{
 		&models.User{},
 		&models.Session{},
+		&models.UserBlock{},
 		&models.Crag{},
 		&models.Wall{},
 		&models.Route{},
+		&models.OutdoorBoulder{},
+		&models.Amenity{},
 		&models.Gym{},
+		&models.GymAmenity{},
+		&models.GymClaim{},
+		&models.GymOpeningHours{},
+		&models.GymHoursException{},
+		&models.GymPrice{},
+		&models.GymWall{},
+		&models.GymClimb{},
+		&models.GymReview{},
+		&models.GymReviewReport{},
+		&models.Project{},
 		&models.Climb{},
 		&models.TrainingSession{},
+		&models.GymCheckIn{},
+		&models.GymMerge{},
+		&models.TrainingSessionPartner{},
 		&models.RopeClimb{},
 		&models.IndoorBoulder{},
-		&models.OutdoorBoulder{},
+		&models.ClimbAttempt{},
+		&models.Exercise{},
+		&models.WorkoutTemplate{},
+		&models.WorkoutTemplateItem{},
+		&models.PlannedSession{},
+		&models.MediaAttachment{},
+		&models.IdempotencyKey{},
 	}
 
 	log.Info("Starting model migration", zap.Int("modelCount", len(modelsToMigrate)))
@@ -0,0 +1,41 @@
+package db
+
+import (
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	"github.com/jwallace145/crux-backend/internal/utils"
+)
+
+// EarthDistanceEnabled is true when the cube and earthdistance extensions and the gym location
+// index are available. When false, nearby gym searches fall back to a bounding box query on the
+// latitude/longitude index and rank the results in Go
+var EarthDistanceEnabled bool
+
+// earthDistanceStatements enable the earthdistance extension and index gym locations with it
+var earthDistanceStatements = []string{
+	"CREATE EXTENSION IF NOT EXISTS cube",
+	"CREATE EXTENSION IF NOT EXISTS earthdistance",
+	"CREATE INDEX IF NOT EXISTS idx_gym_earth_location ON gyms USING gist (ll_to_earth(latitude, longitude)) " +
+		"WHERE latitude IS NOT NULL AND longitude IS NOT NULL AND deleted_at IS NULL",
+}
+
+// setupEarthDistance enables the spatial index used for nearby gym searches. Creating extensions
+// needs elevated privileges, so failures are logged and the Go fallback is used instead
+func setupEarthDistance(db *gorm.DB) {
+	log := utils.Log
+
+	for _, statement := range earthDistanceStatements {
+		if err := db.Exec(statement).Error; err != nil {
+			log.Warn("Earthdistance spatial index unavailable, nearby gym search will rank results in Go",
+				zap.Error(err),
+				zap.String("statement", statement),
+			)
+			EarthDistanceEnabled = false
+			return
+		}
+	}
+
+	EarthDistanceEnabled = true
+	log.Info("Earthdistance spatial index enabled for nearby gym search")
+}
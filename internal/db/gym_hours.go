@@ -0,0 +1,48 @@
+package db
+
+import (
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	"github.com/jwallace145/crux-backend/internal/utils"
+	"github.com/jwallace145/crux-backend/models"
+)
+
+// backfillGymOpeningHours parses the free-form hours text of gyms without a structured schedule
+// into weekly opening hours. Text that cannot be parsed is left as is for staff to fill in
+func backfillGymOpeningHours(db *gorm.DB) {
+	log := utils.Log
+
+	var gyms []models.Gym
+	if err := db.Select("id", "hours").
+		Where("hours <> ''").
+		Where("NOT EXISTS (SELECT 1 FROM gym_opening_hours WHERE gym_opening_hours.gym_id = gyms.id AND gym_opening_hours.deleted_at IS NULL)").
+		Find(&gyms).Error; err != nil {
+		log.Warn("Failed to load gyms for opening hours backfill", zap.Error(err))
+		return
+	}
+
+	parsed := 0
+	for _, gym := range gyms {
+		hours, ok := models.ParseOpeningHoursText(gym.Hours)
+		if !ok {
+			continue
+		}
+		for i := range hours {
+			hours[i].GymID = gym.ID
+		}
+		if err := db.Create(&hours).Error; err != nil {
+			log.Warn("Failed to backfill gym opening hours",
+				zap.Error(err),
+				zap.Uint("gym_id", gym.ID),
+			)
+			continue
+		}
+		parsed++
+	}
+
+	log.Info("Gym opening hours backfill complete",
+		zap.Int("candidates", len(gyms)),
+		zap.Int("parsed", parsed),
+	)
+}
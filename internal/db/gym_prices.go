@@ -0,0 +1,61 @@
+package db
+
+import (
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	"github.com/jwallace145/crux-backend/internal/utils"
+	"github.com/jwallace145/crux-backend/models"
+)
+
+// backfillGymPrices moves the legacy flat prices of gyms into adult pricing tiers in the usual
+// currency of the gym's country. The legacy prices are cleared in the same transaction so that a
+// gym whose tiers are later removed is not backfilled again
+func backfillGymPrices(db *gorm.DB) {
+	log := utils.Log
+
+	var gyms []models.Gym
+	if err := db.Select("id", "country", "day_pass_price", "monthly_price", "yearly_price", "gear_rental_price").
+		Where("day_pass_price > 0 OR monthly_price > 0 OR yearly_price > 0 OR gear_rental_price > 0").
+		Find(&gyms).Error; err != nil {
+		log.Warn("Failed to load gyms for price backfill", zap.Error(err))
+		return
+	}
+
+	migrated := 0
+	for i := range gyms {
+		gym := &gyms[i]
+		err := db.Transaction(func(tx *gorm.DB) error {
+			// Tiers entered since the legacy prices were set take precedence over them
+			var existing int64
+			if err := tx.Model(&models.GymPrice{}).Where("gym_id = ?", gym.ID).Count(&existing).Error; err != nil {
+				return err
+			}
+			if existing == 0 {
+				prices := models.LegacyGymPrices(gym)
+				if err := tx.Create(&prices).Error; err != nil {
+					return err
+				}
+			}
+			return tx.Model(gym).Updates(map[string]interface{}{
+				"day_pass_price":    0,
+				"monthly_price":     0,
+				"yearly_price":      0,
+				"gear_rental_price": 0,
+			}).Error
+		})
+		if err != nil {
+			log.Warn("Failed to backfill gym prices",
+				zap.Error(err),
+				zap.Uint("gym_id", gym.ID),
+			)
+			continue
+		}
+		migrated++
+	}
+
+	log.Info("Gym price backfill complete",
+		zap.Int("candidates", len(gyms)),
+		zap.Int("migrated", migrated),
+	)
+}
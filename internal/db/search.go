@@ -0,0 +1,53 @@
+package db
+
+import (
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	"github.com/jwallace145/crux-backend/internal/utils"
+)
+
+// GymSearchDocument is the weighted full-text document of a gym. Queries must use this exact
+// expression so that the idx_gym_search_document index is used
+const GymSearchDocument = "(setweight(to_tsvector('simple', coalesce(name, '')), 'A') || " +
+	"setweight(to_tsvector('simple', coalesce(city, '') || ' ' || coalesce(state, '') || ' ' || coalesce(country, '')), 'B') || " +
+	"setweight(to_tsvector('english', coalesce(description, '')), 'C'))"
+
+// GymSearchLocation is the location text of a gym used for trigram matching
+const GymSearchLocation = "(coalesce(city, '') || ' ' || coalesce(state, '') || ' ' || coalesce(country, ''))"
+
+// TrigramSearchEnabled is true when the pg_trgm extension and the gym trigram indexes are
+// available. When false, gym search only uses full-text matching and typos are not tolerated
+var TrigramSearchEnabled bool
+
+// trigramSearchStatements enable pg_trgm and index the gym name and location with it
+var trigramSearchStatements = []string{
+	"CREATE EXTENSION IF NOT EXISTS pg_trgm",
+	"CREATE INDEX IF NOT EXISTS idx_gym_name_trgm ON gyms USING gin (name gin_trgm_ops)",
+	"CREATE INDEX IF NOT EXISTS idx_gym_location_trgm ON gyms USING gin (" + GymSearchLocation + " gin_trgm_ops)",
+}
+
+// setupGymSearch creates the full-text index used by gym search and enables the trigram indexes
+// used for typo tolerance. Creating extensions needs elevated privileges, so trigram failures are
+// logged and search continues without fuzzy matching
+func setupGymSearch(db *gorm.DB) {
+	log := utils.Log
+
+	if err := db.Exec("CREATE INDEX IF NOT EXISTS idx_gym_search_document ON gyms USING gin (" + GymSearchDocument + ")").Error; err != nil {
+		log.Warn("Failed to create gym full-text search index", zap.Error(err))
+	}
+
+	for _, statement := range trigramSearchStatements {
+		if err := db.Exec(statement).Error; err != nil {
+			log.Warn("Trigram indexes unavailable, gym search will not tolerate typos",
+				zap.Error(err),
+				zap.String("statement", statement),
+			)
+			TrigramSearchEnabled = false
+			return
+		}
+	}
+
+	TrigramSearchEnabled = true
+	log.Info("Trigram indexes enabled for gym search")
+}
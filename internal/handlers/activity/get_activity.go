@@ -0,0 +1,245 @@
+package activity
+
+import (
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+
+	"github.com/jwallace145/crux-backend/internal/db"
+	"github.com/jwallace145/crux-backend/internal/handlers"
+	"github.com/jwallace145/crux-backend/internal/query"
+	"github.com/jwallace145/crux-backend/internal/utils"
+	"github.com/jwallace145/crux-backend/models"
+)
+
+// activityListSpec defines the whitelisted sort fields and filters for GET /activity
+// The "id" tiebreaker is the source-qualified activity_id since row IDs repeat across sources
+var activityListSpec = &query.Spec{
+	SortFields: map[string]query.SortField{
+		"date": {Column: "activity_date", Type: query.TypeTime},
+		"id":   {Column: "activity_id", Type: query.TypeString},
+	},
+	DefaultSort: "-date",
+	Filters: []query.Filter{
+		{Param: "type", Column: "activity_type", Type: query.TypeString, Kind: query.FilterIn},
+		{Param: "grade", Column: "grade", Kind: query.FilterGradeRange},
+		{Param: "gym_id", Column: "gym_id", Type: query.TypeUint, Kind: query.FilterEquals},
+		{Param: "completed", Column: "completed", Type: query.TypeBool, Kind: query.FilterEquals},
+	},
+	DateRange: &query.DateRange{Column: "activity_date", StartParam: "start_date", EndParam: "end_date"},
+}
+
+// activityUnionSQL normalizes free-standing climbs and training session climbs into one row shape.
+// Filters, the keyset cursor and ordering are applied on top of the union and pushed down by Postgres.
+const activityUnionSQL = `
+	SELECT
+		'climb:' || c.id AS activity_id,
+		'climb' AS activity_type,
+		c.id AS source_id,
+		c.climb_date AS activity_date,
+		c.grade,
+		COALESCE(c.style, '') AS style,
+		'' AS outcome,
+		c.completed,
+		COALESCE(g.name, cr.name, '') AS location,
+		c.gym_id,
+		c.route_id,
+		CAST(NULL AS bigint) AS training_session_id,
+		COALESCE(c.notes, '') AS notes
+	FROM climbs c
+	LEFT JOIN gyms g ON g.id = c.gym_id
+	LEFT JOIN routes r ON r.id = c.route_id
+	LEFT JOIN outdoor_boulders ob ON ob.id = c.outdoor_boulder_id
+	LEFT JOIN walls w ON w.id = COALESCE(r.wall_id, ob.wall_id)
+	LEFT JOIN crags cr ON cr.id = w.crag_id
+	WHERE c.user_id = @user_id AND c.deleted_at IS NULL
+
+	UNION ALL
+
+	SELECT
+		'rope_climb:' || rc.id,
+		'rope_climb',
+		rc.id,
+		ts.session_date,
+		rc.grade,
+		rc.climb_type,
+		rc.outcome,
+		rc.outcome IN @sent_outcomes,
+		COALESCE(g.name, ''),
+		ts.gym_id,
+		CAST(NULL AS bigint),
+		ts.id,
+		COALESCE(rc.notes, '')
+	FROM rope_climbs rc
+	JOIN training_sessions ts ON ts.id = rc.training_session_id AND ts.deleted_at IS NULL
+	LEFT JOIN gyms g ON g.id = ts.gym_id
+	WHERE ts.user_id = @user_id AND rc.deleted_at IS NULL
+
+	UNION ALL
+
+	SELECT
+		'indoor_boulder:' || ib.id,
+		'indoor_boulder',
+		ib.id,
+		ts.session_date,
+		ib.grade,
+		'boulder',
+		ib.outcome,
+		ib.outcome IN @sent_outcomes,
+		COALESCE(g.name, ''),
+		ts.gym_id,
+		CAST(NULL AS bigint),
+		ts.id,
+		COALESCE(ib.notes, '')
+	FROM indoor_boulders ib
+	JOIN training_sessions ts ON ts.id = ib.training_session_id AND ts.deleted_at IS NULL
+	LEFT JOIN gyms g ON g.id = ts.gym_id
+	WHERE ts.user_id = @user_id AND ib.deleted_at IS NULL`
+
+// activityRow is a single row of the activity union
+type activityRow struct {
+	ActivityID        string
+	ActivityType      string
+	SourceID          uint
+	ActivityDate      time.Time
+	Grade             string
+	Style             string
+	Outcome           string
+	Completed         bool
+	Location          string
+	GymID             *uint
+	RouteID           *uint
+	TrainingSessionID *uint
+	Notes             string
+}
+
+// GetActivity handles GET /activity requests to retrieve a unified, chronological feed of the user's climbs
+// Merges free-standing climbs with the rope climbs and indoor boulders of the user's training sessions
+// Query parameters:
+//   - start_date (optional): The start date for filtering activity (RFC3339 format, e.g., "2024-01-01T00:00:00Z")
+//   - end_date (optional): The end date for filtering activity (RFC3339 format, e.g., "2024-12-31T23:59:59Z")
+//   - type (optional): Comma separated activity types ("climb", "rope_climb", "indoor_boulder")
+//   - grade_min, grade_max (optional): Inclusive grade range on a single scale (e.g., "V3" to "V6")
+//   - gym_id (optional): Only activity at this gym
+//   - completed (optional): Only sent (true) or unsent (false) climbs
+//   - sort (optional): "-date" (default) or "date"
+//   - limit (optional): Page size (default 50, max 200)
+//   - cursor (optional): The next_cursor value returned by the previous page
+//
+// Requires AuthMiddleware to be applied - reads user_id from context
+func GetActivity(c *fiber.Ctx) error {
+	apiName := "get_activity"
+	log := utils.GetLoggerFromContext(c)
+
+	log.Info("Starting get activity process",
+		zap.String("api", apiName),
+	)
+
+	// Get user ID from context (set by AuthMiddleware)
+	userID, ok := c.Locals("user_id").(uint)
+	if !ok {
+		log.Error("User ID not found in context",
+			zap.String("api", apiName),
+		)
+		return handlers.InternalErrorResponse(c, apiName, "Authentication context missing", nil)
+	}
+
+	// Parse pagination, filtering, sorting and date range query parameters
+	params, err := query.Parse(c, activityListSpec)
+	if err != nil {
+		log.Warn("Invalid list query parameters",
+			zap.Error(err),
+			zap.String("api", apiName),
+		)
+		return handlers.BadRequestResponse(c, apiName, err.Error(), nil)
+	}
+
+	log.Info("Querying activity for user within date range",
+		zap.String("api", apiName),
+		zap.Uint("user_id", userID),
+		zap.Time("start_date", params.StartDate),
+		zap.Time("end_date", params.EndDate),
+		zap.Int("limit", params.Limit),
+		zap.Bool("has_cursor", params.Cursor != nil),
+		zap.Any("filters", params.Applied),
+	)
+
+	union := db.DB.Raw(activityUnionSQL, map[string]interface{}{
+		"user_id": userID,
+		"sent_outcomes": []string{
+			models.RopeClimbOutcomeFlash,
+			models.RopeClimbOutcomeOnSight,
+			models.RopeClimbOutcomeRedpoint,
+		},
+	})
+
+	var rows []activityRow
+	if err := params.Apply(db.DB.Table("(?) AS activity", union)).Find(&rows).Error; err != nil {
+		log.Error("Database error while querying activity",
+			zap.Error(err),
+			zap.String("api", apiName),
+			zap.Uint("user_id", userID),
+		)
+		return handlers.InternalErrorResponse(c, apiName, "Failed to retrieve activity", nil)
+	}
+
+	rows, nextCursor, err := query.Paginate(params, rows, activityCursorKey)
+	if err != nil {
+		log.Error("Failed to encode next page cursor",
+			zap.Error(err),
+			zap.String("api", apiName),
+		)
+		return handlers.InternalErrorResponse(c, apiName, "Failed to paginate activity", nil)
+	}
+
+	items := make([]*models.ActivityItemResponse, len(rows))
+	for i := range rows {
+		items[i] = rows[i].toActivityItemResponse()
+	}
+
+	log.Info("Activity retrieved successfully",
+		zap.String("api", apiName),
+		zap.Uint("user_id", userID),
+		zap.Int("count", len(items)),
+		zap.Bool("has_more", nextCursor != ""),
+	)
+
+	responseData := map[string]interface{}{
+		"activity":    items,
+		"count":       len(items),
+		"start_date":  params.StartDate.Format(time.RFC3339),
+		"end_date":    params.EndDate.Format(time.RFC3339),
+		"limit":       params.Limit,
+		"next_cursor": nextCursor,
+	}
+
+	return handlers.SuccessResponse(c, apiName, responseData, "Activity retrieved successfully")
+}
+
+// toActivityItemResponse converts an activity row to its response DTO
+func (r *activityRow) toActivityItemResponse() *models.ActivityItemResponse {
+	return &models.ActivityItemResponse{
+		ID:                r.ActivityID,
+		Type:              r.ActivityType,
+		SourceID:          r.SourceID,
+		Date:              r.ActivityDate,
+		Grade:             r.Grade,
+		Style:             r.Style,
+		Outcome:           r.Outcome,
+		Completed:         r.Completed,
+		Location:          r.Location,
+		GymID:             r.GymID,
+		RouteID:           r.RouteID,
+		TrainingSessionID: r.TrainingSessionID,
+		Notes:             r.Notes,
+	}
+}
+
+// activityCursorKey returns the sort field values of a row used to build the next page cursor
+func activityCursorKey(row activityRow) query.Key {
+	return query.Key{
+		"id":   row.ActivityID,
+		"date": row.ActivityDate,
+	}
+}
@@ -0,0 +1,318 @@
+package climbs
+
+import (
+	"fmt"
+
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	"github.com/jwallace145/crux-backend/internal/db"
+	"github.com/jwallace145/crux-backend/internal/handlers"
+	"github.com/jwallace145/crux-backend/internal/services"
+	"github.com/jwallace145/crux-backend/internal/utils"
+	"github.com/jwallace145/crux-backend/models"
+)
+
+// MaxBatchClimbs is the maximum number of climbs accepted in a single batch request
+const MaxBatchClimbs = 100
+
+// BatchCreateClimbs handles POST /climbs/batch requests to log multiple climbs at once
+// Every item goes through the same validation and route/gym checks as POST /climbs.
+// In "all_or_nothing" mode (default) the batch is rejected if any item fails and all climbs
+// are created in a single transaction. In "partial" mode valid items are created and
+// failing items are reported individually.
+// Requires AuthMiddleware to be applied - reads user_id from context
+func BatchCreateClimbs(c *fiber.Ctx) error {
+	apiName := "batch_create_climbs"
+	log := utils.GetLoggerFromContext(c)
+
+	log.Info("Starting batch climb creation process",
+		zap.String("api", apiName),
+	)
+
+	// Validate Content-Type header
+	if err := handlers.ValidateJSONContentType(c, apiName); err != nil {
+		return err
+	}
+
+	// Parse request body
+	var req models.BatchCreateClimbsRequest
+	if err := c.BodyParser(&req); err != nil {
+		log.Error("Failed to parse request body",
+			zap.Error(err),
+			zap.String("api", apiName),
+		)
+		return handlers.BadRequestResponse(c, apiName, "Invalid request body", err.Error())
+	}
+
+	if req.Mode == "" {
+		req.Mode = models.BatchModeAllOrNothing
+	}
+
+	log.Info("Request body parsed successfully",
+		zap.String("api", apiName),
+		zap.String("mode", req.Mode),
+		zap.Int("climb_count", len(req.Climbs)),
+	)
+
+	// Validate batch envelope
+	if err := validateBatchCreateClimbsRequest(&req); err != nil {
+		log.Warn("Request validation failed",
+			zap.Error(err),
+			zap.String("api", apiName),
+		)
+		return handlers.ValidationErrorResponse(c, apiName, err.Error(), nil)
+	}
+
+	// Get user ID from context (set by AuthMiddleware)
+	userID, ok := c.Locals("user_id").(uint)
+	if !ok {
+		log.Error("User ID not found in context",
+			zap.String("api", apiName),
+		)
+		return handlers.InternalErrorResponse(c, apiName, "Authentication context missing", nil)
+	}
+
+	log.Info("User ID retrieved from context",
+		zap.String("api", apiName),
+		zap.Uint("user_id", userID),
+	)
+
+	response := &models.BatchCreateClimbsResponse{
+		Mode:    req.Mode,
+		Total:   len(req.Climbs),
+		Results: make([]models.BatchClimbResult, len(req.Climbs)),
+	}
+	for i := range response.Results {
+		response.Results[i].Index = i
+	}
+
+	// Validate every item
+	for i := range req.Climbs {
+		if err := ValidateCreateClimbRequest(&req.Climbs[i]); err != nil {
+			setBatchItemError(response, i, models.ErrorCodeValidationFail, err.Error())
+		}
+	}
+
+	// Verify route and gym references of the valid items
+	if err := verifyBatchReferences(req.Climbs, response); err != nil {
+		log.Error("Database error while verifying routes and gyms",
+			zap.Error(err),
+			zap.String("api", apiName),
+		)
+		return handlers.InternalErrorResponse(c, apiName, "Failed to verify routes and gyms", nil)
+	}
+
+	log.Info("Batch items validated",
+		zap.String("api", apiName),
+		zap.Int("total", response.Total),
+		zap.Int("invalid", response.Failed),
+	)
+
+	// Reject the whole batch in all-or-nothing mode if any item failed
+	if req.Mode == models.BatchModeAllOrNothing && response.Failed > 0 {
+		log.Warn("Batch rejected due to invalid items",
+			zap.String("api", apiName),
+			zap.Int("failed", response.Failed),
+		)
+		return handlers.ValidationErrorResponse(c, apiName, "One or more climbs are invalid, no climbs were created", response)
+	}
+
+	// Create climbs
+	log.Info("Creating batch climbs in db",
+		zap.String("api", apiName),
+		zap.Uint("user_id", userID),
+		zap.String("mode", req.Mode),
+	)
+
+	if req.Mode == models.BatchModeAllOrNothing {
+		if err := createBatchClimbsInTransaction(userID, req.Climbs, response); err != nil {
+			log.Error("Failed to create batch climbs in db",
+				zap.Error(err),
+				zap.String("api", apiName),
+				zap.Uint("user_id", userID),
+			)
+			return handlers.InternalErrorResponse(c, apiName, "Failed to create climbs", nil)
+		}
+	} else {
+		createBatchClimbsIndividually(c, apiName, userID, req.Climbs, response)
+	}
+
+	log.Info("Batch climb creation completed",
+		zap.String("api", apiName),
+		zap.Uint("user_id", userID),
+		zap.Int("succeeded", response.Succeeded),
+		zap.Int("failed", response.Failed),
+	)
+
+	if response.Succeeded == 0 {
+		return handlers.ValidationErrorResponse(c, apiName, "No climbs were created", response)
+	}
+	if response.Failed > 0 {
+		return handlers.NewResponse(apiName).
+			WithData(response).
+			WithMessage("Some climbs were created successfully").
+			SendJSON(c, fiber.StatusMultiStatus)
+	}
+	return handlers.CreatedResponse(c, apiName, response, "Climbs created successfully")
+}
+
+// validateBatchCreateClimbsRequest validates the batch mode and size
+func validateBatchCreateClimbsRequest(req *models.BatchCreateClimbsRequest) error {
+	if req.Mode != models.BatchModeAllOrNothing && req.Mode != models.BatchModePartial {
+		return fiber.NewError(fiber.StatusBadRequest, "Mode must be 'all_or_nothing' or 'partial'")
+	}
+	if len(req.Climbs) == 0 {
+		return fiber.NewError(fiber.StatusBadRequest, "At least one climb is required")
+	}
+	if len(req.Climbs) > MaxBatchClimbs {
+		return fiber.NewError(fiber.StatusBadRequest, fmt.Sprintf("A batch must not exceed %d climbs", MaxBatchClimbs))
+	}
+	return nil
+}
+
+// batchReference is a single row returned by the combined route/gym existence query
+type batchReference struct {
+	Kind string
+	ID   uint
+}
+
+// verifyBatchReferences checks every referenced route, boulder and gym with a single query
+// and marks items referencing a missing route, boulder or gym as failed
+func verifyBatchReferences(climbs []models.CreateClimbRequest, response *models.BatchCreateClimbsResponse) error {
+	routeIDs := []uint{}
+	boulderIDs := []uint{}
+	gymIDs := []uint{}
+	for i, climb := range climbs {
+		if response.Results[i].Status == models.BatchItemStatusError {
+			continue
+		}
+		if climb.RouteID != nil {
+			routeIDs = append(routeIDs, *climb.RouteID)
+		}
+		if climb.OutdoorBoulderID != nil {
+			boulderIDs = append(boulderIDs, *climb.OutdoorBoulderID)
+		}
+		if climb.GymID != nil {
+			gymIDs = append(gymIDs, *climb.GymID)
+		}
+	}
+
+	if len(routeIDs) == 0 && len(boulderIDs) == 0 && len(gymIDs) == 0 {
+		return nil
+	}
+
+	var references []batchReference
+	if err := db.DB.Raw(`
+		SELECT 'route' AS kind, id FROM routes WHERE id IN ? AND deleted_at IS NULL
+		UNION ALL
+		SELECT 'outdoor_boulder' AS kind, id FROM outdoor_boulders WHERE id IN ? AND deleted_at IS NULL
+		UNION ALL
+		SELECT 'gym' AS kind, id FROM gyms WHERE id IN ? AND deleted_at IS NULL`,
+		routeIDs, boulderIDs, gymIDs,
+	).Scan(&references).Error; err != nil {
+		return err
+	}
+
+	routes := map[uint]bool{}
+	boulders := map[uint]bool{}
+	gyms := map[uint]bool{}
+	for _, ref := range references {
+		switch ref.Kind {
+		case "route":
+			routes[ref.ID] = true
+		case "outdoor_boulder":
+			boulders[ref.ID] = true
+		default:
+			gyms[ref.ID] = true
+		}
+	}
+
+	for i, climb := range climbs {
+		if response.Results[i].Status == models.BatchItemStatusError {
+			continue
+		}
+		if climb.RouteID != nil && !routes[*climb.RouteID] {
+			setBatchItemError(response, i, models.ErrorCodeInvalidInput, "Route not found")
+			continue
+		}
+		if climb.OutdoorBoulderID != nil && !boulders[*climb.OutdoorBoulderID] {
+			setBatchItemError(response, i, models.ErrorCodeInvalidInput, "Outdoor boulder not found")
+			continue
+		}
+		if climb.GymID != nil && !gyms[*climb.GymID] {
+			setBatchItemError(response, i, models.ErrorCodeInvalidInput, "Gym not found")
+		}
+	}
+
+	return nil
+}
+
+// createBatchClimbsInTransaction creates every climb in a single transaction
+func createBatchClimbsInTransaction(userID uint, reqs []models.CreateClimbRequest, response *models.BatchCreateClimbsResponse) error {
+	climbs := make([]models.Climb, len(reqs))
+	links := make([]*models.Climb, len(reqs))
+	for i := range reqs {
+		climbs[i] = *NewClimbFromRequest(userID, &reqs[i])
+		links[i] = &climbs[i]
+	}
+
+	if err := db.DB.Transaction(func(tx *gorm.DB) error {
+		if err := services.LinkClimbsToProjects(tx, userID, links); err != nil {
+			return err
+		}
+		return tx.Create(&climbs).Error
+	}); err != nil {
+		return err
+	}
+
+	for i := range climbs {
+		setBatchItemCreated(response, i, &climbs[i])
+	}
+	return nil
+}
+
+// createBatchClimbsIndividually creates each valid climb on its own so that a
+// database failure on one item does not affect the others
+func createBatchClimbsIndividually(c *fiber.Ctx, apiName string, userID uint, reqs []models.CreateClimbRequest, response *models.BatchCreateClimbsResponse) {
+	log := utils.GetLoggerFromContext(c)
+
+	for i := range reqs {
+		if response.Results[i].Status == models.BatchItemStatusError {
+			continue
+		}
+
+		climb := NewClimbFromRequest(userID, &reqs[i])
+		if err := db.DB.Transaction(func(tx *gorm.DB) error {
+			if err := services.LinkClimbsToProjects(tx, userID, []*models.Climb{climb}); err != nil {
+				return err
+			}
+			return tx.Create(climb).Error
+		}); err != nil {
+			log.Error("Failed to create batch climb in db",
+				zap.Error(err),
+				zap.String("api", apiName),
+				zap.Int("index", i),
+			)
+			setBatchItemError(response, i, models.ErrorCodeInternalError, "Failed to create climb")
+			continue
+		}
+
+		setBatchItemCreated(response, i, climb)
+	}
+}
+
+// setBatchItemError records a failed batch item
+func setBatchItemError(response *models.BatchCreateClimbsResponse, index int, code, message string) {
+	response.Results[index].Status = models.BatchItemStatusError
+	response.Results[index].Error = &models.APIError{Code: code, Message: message}
+	response.Failed++
+}
+
+// setBatchItemCreated records a successfully created batch item
+func setBatchItemCreated(response *models.BatchCreateClimbsResponse, index int, climb *models.Climb) {
+	response.Results[index].Status = models.BatchItemStatusCreated
+	response.Results[index].Climb = climb.ToClimbResponse()
+	response.Succeeded++
+}
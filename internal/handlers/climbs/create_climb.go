@@ -9,12 +9,14 @@ import (
 
 	"github.com/jwallace145/crux-backend/internal/db"
 	"github.com/jwallace145/crux-backend/internal/handlers"
+	"github.com/jwallace145/crux-backend/internal/services"
 	"github.com/jwallace145/crux-backend/internal/utils"
 	"github.com/jwallace145/crux-backend/models"
 )
 
 // CreateClimb handles POST /climbs requests to create a new climb log entry
-// It validates the request, verifies optional route/gym references, and persists the climb
+// It validates the request, verifies optional route/boulder/gym references, and persists the climb
+// Climbs on a route or boulder the user is projecting are linked to that project
 // Requires AuthMiddleware to be applied - reads user_id from context
 func CreateClimb(c *fiber.Ctx) error {
 	apiName := "create_climb"
@@ -46,7 +48,7 @@ func CreateClimb(c *fiber.Ctx) error {
 	)
 
 	// Validate request
-	if err := validateCreateClimbRequest(&req); err != nil {
+	if err := ValidateCreateClimbRequest(&req); err != nil {
 		log.Warn("Request validation failed",
 			zap.Error(err),
 			zap.String("api", apiName),
@@ -103,6 +105,37 @@ func CreateClimb(c *fiber.Ctx) error {
 		)
 	}
 
+	// Verify outdoor boulder exists if OutdoorBoulderID is provided
+	if req.OutdoorBoulderID != nil {
+		log.Info("Verifying outdoor boulder exists",
+			zap.String("api", apiName),
+			zap.Uint("outdoor_boulder_id", *req.OutdoorBoulderID),
+		)
+
+		var boulder models.OutdoorBoulder
+		if err := db.DB.First(&boulder, *req.OutdoorBoulderID).Error; err != nil {
+			if err == gorm.ErrRecordNotFound {
+				log.Warn("Outdoor boulder not found",
+					zap.String("api", apiName),
+					zap.Uint("outdoor_boulder_id", *req.OutdoorBoulderID),
+				)
+				return handlers.BadRequestResponse(c, apiName, "Outdoor boulder not found", map[string]interface{}{
+					"outdoor_boulder_id": *req.OutdoorBoulderID,
+				})
+			}
+			log.Error("Database error while checking outdoor boulder",
+				zap.Error(err),
+				zap.String("api", apiName),
+			)
+			return handlers.InternalErrorResponse(c, apiName, "Failed to verify outdoor boulder", nil)
+		}
+
+		log.Info("Outdoor boulder verified",
+			zap.String("api", apiName),
+			zap.Uint("outdoor_boulder_id", *req.OutdoorBoulderID),
+		)
+	}
+
 	// Verify gym exists if GymID is provided
 	if req.GymID != nil {
 		log.Info("Verifying gym exists",
@@ -134,11 +167,6 @@ func CreateClimb(c *fiber.Ctx) error {
 		)
 	}
 
-	// Set defaults if not provided
-	if req.Attempts == 0 {
-		req.Attempts = 1
-	}
-
 	// Create climb
 	log.Info("Creating climb in db",
 		zap.String("api", apiName),
@@ -147,22 +175,15 @@ func CreateClimb(c *fiber.Ctx) error {
 		zap.String("grade", req.Grade),
 	)
 
-	climb := &models.Climb{
-		UserID:    userID,
-		RouteID:   req.RouteID,
-		GymID:     req.GymID,
-		ClimbType: req.ClimbType,
-		ClimbDate: req.ClimbDate,
-		Grade:     req.Grade,
-		Style:     req.Style,
-		Completed: req.Completed,
-		Attempts:  req.Attempts,
-		Falls:     req.Falls,
-		Rating:    req.Rating,
-		Notes:     req.Notes,
-	}
-
-	if err := db.DB.Create(climb).Error; err != nil {
+	climb := NewClimbFromRequest(userID, &req)
+
+	// Link the climb to the user's project on the same route or boulder and create it
+	if err := db.DB.Transaction(func(tx *gorm.DB) error {
+		if err := services.LinkClimbsToProjects(tx, userID, []*models.Climb{climb}); err != nil {
+			return err
+		}
+		return tx.Create(climb).Error
+	}); err != nil {
 		log.Error("Failed to create climb in db",
 			zap.Error(err),
 			zap.String("api", apiName),
@@ -190,8 +211,33 @@ func CreateClimb(c *fiber.Ctx) error {
 	return handlers.CreatedResponse(c, apiName, response, "Climb created successfully")
 }
 
-// validateCreateClimbRequest validates the create climb request
-func validateCreateClimbRequest(req *models.CreateClimbRequest) error {
+// NewClimbFromRequest builds a Climb model from a validated create request, applying defaults
+func NewClimbFromRequest(userID uint, req *models.CreateClimbRequest) *models.Climb {
+	// Set defaults if not provided
+	attempts := req.Attempts
+	if attempts == 0 {
+		attempts = 1
+	}
+
+	return &models.Climb{
+		UserID:           userID,
+		RouteID:          req.RouteID,
+		OutdoorBoulderID: req.OutdoorBoulderID,
+		GymID:            req.GymID,
+		ClimbType:        req.ClimbType,
+		ClimbDate:        req.ClimbDate,
+		Grade:            req.Grade,
+		Style:            req.Style,
+		Completed:        req.Completed,
+		Attempts:         attempts,
+		Falls:            req.Falls,
+		Rating:           req.Rating,
+		Notes:            req.Notes,
+	}
+}
+
+// ValidateCreateClimbRequest validates the create climb request
+func ValidateCreateClimbRequest(req *models.CreateClimbRequest) error {
 	// Validate climb type
 	if req.ClimbType != models.ClimbTypeIndoor && req.ClimbType != models.ClimbTypeOutdoor {
 		return fiber.NewError(fiber.StatusBadRequest, "Climb type must be 'indoor' or 'outdoor'")
@@ -206,6 +252,11 @@ func validateCreateClimbRequest(req *models.CreateClimbRequest) error {
 		return fiber.NewError(fiber.StatusBadRequest, "Grade must not exceed 20 characters")
 	}
 
+	// A climb is either on a route or on a boulder problem
+	if req.RouteID != nil && req.OutdoorBoulderID != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Climb cannot reference both a route and an outdoor boulder")
+	}
+
 	// Validate climb date
 	if req.ClimbDate.IsZero() {
 		return fiber.NewError(fiber.StatusBadRequest, "Climb date is required")
@@ -0,0 +1,86 @@
+package climbs
+
+import (
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	"github.com/jwallace145/crux-backend/internal/db"
+	"github.com/jwallace145/crux-backend/internal/handlers"
+	"github.com/jwallace145/crux-backend/internal/services"
+	"github.com/jwallace145/crux-backend/internal/utils"
+	"github.com/jwallace145/crux-backend/models"
+)
+
+// DeleteClimb handles DELETE /climbs/:id requests to delete a climb log entry
+// Media attachments of the climb are removed along with their S3 objects
+// Requires AuthMiddleware to be applied - reads user_id from context
+func DeleteClimb(c *fiber.Ctx) error {
+	apiName := "delete_climb"
+	log := utils.GetLoggerFromContext(c)
+
+	log.Info("Starting delete climb process",
+		zap.String("api", apiName),
+	)
+
+	// Get user ID from context (set by AuthMiddleware)
+	userID, ok := c.Locals("user_id").(uint)
+	if !ok {
+		log.Error("User ID not found in context",
+			zap.String("api", apiName),
+		)
+		return handlers.InternalErrorResponse(c, apiName, "Authentication context missing", nil)
+	}
+
+	climbID, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return handlers.BadRequestResponse(c, apiName, "id must be a valid number", nil)
+	}
+
+	var climb models.Climb
+	if err := db.DB.Where("id = ? AND user_id = ?", climbID, userID).First(&climb).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			log.Warn("Climb not found",
+				zap.String("api", apiName),
+				zap.Uint64("climb_id", climbID),
+			)
+			return handlers.NotFoundResponse(c, apiName, "Climb not found")
+		}
+		log.Error("Database error while looking up climb",
+			zap.Error(err),
+			zap.String("api", apiName),
+		)
+		return handlers.InternalErrorResponse(c, apiName, "Failed to delete climb", nil)
+	}
+
+	// Delete the climb and its media attachments in a transaction
+	var mediaKeys []string
+	if err := db.DB.Transaction(func(tx *gorm.DB) error {
+		keys, err := services.DeleteParentMedia(tx, models.MediaParentTypeClimb, climb.ID)
+		if err != nil {
+			return err
+		}
+		mediaKeys = keys
+		return tx.Delete(&climb).Error
+	}); err != nil {
+		log.Error("Failed to delete climb in db",
+			zap.Error(err),
+			zap.String("api", apiName),
+			zap.Uint64("climb_id", climbID),
+		)
+		return handlers.InternalErrorResponse(c, apiName, "Failed to delete climb", nil)
+	}
+
+	// Remove media objects from S3 once the rows are gone
+	services.DeleteMediaObjects(c.Context(), mediaKeys)
+
+	log.Info("Climb deleted successfully",
+		zap.String("api", apiName),
+		zap.Uint64("climb_id", climbID),
+		zap.Int("media_deleted", len(mediaKeys)),
+	)
+
+	return handlers.SuccessResponse(c, apiName, map[string]interface{}{"id": climb.ID}, "Climb deleted successfully")
+}
@@ -10,15 +10,45 @@ import (
 	"github.com/jwallace145/crux-backend/internal/handlers"
 
 	"github.com/jwallace145/crux-backend/internal/db"
+	"github.com/jwallace145/crux-backend/internal/query"
+	"github.com/jwallace145/crux-backend/internal/services"
 	"github.com/jwallace145/crux-backend/internal/utils"
 	"github.com/jwallace145/crux-backend/models"
 )
 
+// climbListSpec defines the whitelisted sort fields and filters for GET /climbs
+var climbListSpec = &query.Spec{
+	SortFields: map[string]query.SortField{
+		"climb_date": {Column: "climb_date", Type: query.TypeTime},
+		"created_at": {Column: "created_at", Type: query.TypeTime},
+		"rating":     {Column: "rating", Type: query.TypeInt},
+		"attempts":   {Column: "attempts", Type: query.TypeInt},
+		"id":         {Column: "id", Type: query.TypeUint},
+	},
+	DefaultSort: "-climb_date",
+	Filters: []query.Filter{
+		{Param: "grade", Column: "grade", Kind: query.FilterGradeRange},
+		{Param: "style", Column: "style", Type: query.TypeString, Kind: query.FilterIn},
+		{Param: "climb_type", Column: "climb_type", Type: query.TypeString, Kind: query.FilterEquals},
+		{Param: "gym_id", Column: "gym_id", Type: query.TypeUint, Kind: query.FilterEquals},
+		{Param: "completed", Column: "completed", Type: query.TypeBool, Kind: query.FilterEquals},
+	},
+	DateRange: &query.DateRange{Column: "climb_date", StartParam: "start_date", EndParam: "end_date"},
+}
+
 // GetClimbs handles GET /climbs requests to retrieve user climbs
 // Query parameters:
 //   - user_id (required): The ID of the user whose climbs to retrieve
 //   - start_date (optional): Start date in RFC3339 format (e.g., "2024-01-01T00:00:00Z")
 //   - end_date (optional): End date in RFC3339 format (e.g., "2024-12-31T23:59:59Z")
+//   - grade_min, grade_max (optional): Inclusive grade range on a single scale (e.g., "V3" to "V6")
+//   - style (optional): Comma separated list of styles (e.g., "lead,top_rope")
+//   - climb_type (optional): "indoor" or "outdoor"
+//   - gym_id (optional): Only climbs logged at this gym
+//   - completed (optional): "true" or "false"
+//   - sort (optional): Comma separated sort fields, prefix with "-" for descending (default "-climb_date")
+//   - limit (optional): Page size (default 50, max 200)
+//   - cursor (optional): The next_cursor value returned by the previous page
 //
 // If start_date is not provided, returns climbs from the beginning of time
 // If end_date is not provided, returns climbs up to now
@@ -54,113 +84,73 @@ func GetClimbs(c *fiber.Ctx) error {
 		zap.Uint64("user_id", userID),
 	)
 
-	// Get optional start_date and end_date query parameters
-	startDateStr := c.Query("start_date")
-	endDateStr := c.Query("end_date")
-
-	// Set default dates
-	// Use Unix epoch (January 1, 1970) as minimum date
-	startDate := time.Unix(0, 0).UTC()
-	// Use current time as maximum date
-	endDate := time.Now().UTC()
-
-	// Parse start_date if provided
-	if startDateStr != "" {
-		parsedStartDate, err := time.Parse(time.RFC3339, startDateStr)
-		if err != nil {
-			log.Warn("Invalid start_date format",
-				zap.Error(err),
-				zap.String("api", apiName),
-				zap.String("start_date", startDateStr),
-			)
-			return handlers.BadRequestResponse(c, apiName, "start_date must be in RFC3339 format (e.g., 2024-01-01T00:00:00Z)", nil)
-		}
-		startDate = parsedStartDate.UTC()
-		log.Info("Start date parsed",
-			zap.String("api", apiName),
-			zap.Time("start_date", startDate),
-		)
-	} else {
-		log.Info("No start_date provided, using minimum date",
-			zap.String("api", apiName),
-			zap.Time("start_date", startDate),
-		)
-	}
-
-	// Parse end_date if provided
-	if endDateStr != "" {
-		parsedEndDate, err := time.Parse(time.RFC3339, endDateStr)
-		if err != nil {
-			log.Warn("Invalid end_date format",
-				zap.Error(err),
-				zap.String("api", apiName),
-				zap.String("end_date", endDateStr),
-			)
-			return handlers.BadRequestResponse(c, apiName, "end_date must be in RFC3339 format (e.g., 2024-12-31T23:59:59Z)", nil)
-		}
-		endDate = parsedEndDate.UTC()
-		log.Info("End date parsed",
-			zap.String("api", apiName),
-			zap.Time("end_date", endDate),
-		)
-	} else {
-		log.Info("No end_date provided, using current time",
+	// Parse pagination, filtering, sorting and date range query parameters
+	params, err := query.Parse(c, climbListSpec)
+	if err != nil {
+		log.Warn("Invalid list query parameters",
+			zap.Error(err),
 			zap.String("api", apiName),
-			zap.Time("end_date", endDate),
 		)
+		return handlers.BadRequestResponse(c, apiName, err.Error(), nil)
 	}
 
-	// Validate that start_date is before end_date
-	if startDate.After(endDate) {
-		log.Warn("Start date is after end date",
-			zap.String("api", apiName),
-			zap.Time("start_date", startDate),
-			zap.Time("end_date", endDate),
-		)
-		return handlers.BadRequestResponse(c, apiName, "start_date must be before or equal to end_date", nil)
-	}
+	log.Info("List query parameters parsed",
+		zap.String("api", apiName),
+		zap.Int("limit", params.Limit),
+		zap.Bool("has_cursor", params.Cursor != nil),
+		zap.Any("filters", params.Applied),
+		zap.Time("start_date", params.StartDate),
+		zap.Time("end_date", params.EndDate),
+	)
 
 	// Query climbs from db
 	log.Info("Querying climbs from db",
 		zap.String("api", apiName),
 		zap.Uint64("user_id", userID),
-		zap.Time("start_date", startDate),
-		zap.Time("end_date", endDate),
 	)
 
 	var climbs []models.Climb
-	query := db.DB.Where("user_id = ? AND climb_date >= ? AND climb_date <= ?", uint(userID), startDate, endDate).
-		Order("climb_date DESC"). // Most recent first
-		Find(&climbs)
-
-	if query.Error != nil {
+	if err := params.Apply(db.DB.Where("user_id = ?", uint(userID))).Preload("Media").Find(&climbs).Error; err != nil {
 		log.Error("Database error while querying climbs",
-			zap.Error(query.Error),
+			zap.Error(err),
 			zap.String("api", apiName),
 			zap.Uint64("user_id", userID),
 		)
 		return handlers.InternalErrorResponse(c, apiName, "Failed to retrieve climbs", nil)
 	}
 
+	climbs, nextCursor, err := query.Paginate(params, climbs, climbCursorKey)
+	if err != nil {
+		log.Error("Failed to encode next page cursor",
+			zap.Error(err),
+			zap.String("api", apiName),
+		)
+		return handlers.InternalErrorResponse(c, apiName, "Failed to paginate climbs", nil)
+	}
+
 	log.Info("Climbs retrieved successfully",
 		zap.String("api", apiName),
 		zap.Uint64("user_id", userID),
 		zap.Int("count", len(climbs)),
+		zap.Bool("has_more", nextCursor != ""),
 	)
 
 	// Convert climbs to response DTOs
 	climbResponses := make([]*models.ClimbResponse, len(climbs))
 	for i, climb := range climbs {
 		climbResponses[i] = climb.ToClimbResponse()
+		climbResponses[i].Media = services.PresignMediaAttachments(c.Context(), climb.Media)
 	}
 
 	// Prepare response
 	responseData := map[string]interface{}{
-		"climbs":     climbResponses,
-		"count":      len(climbResponses),
-		"user_id":    uint(userID),
-		"start_date": startDate.Format(time.RFC3339),
-		"end_date":   endDate.Format(time.RFC3339),
+		"climbs":      climbResponses,
+		"count":       len(climbResponses),
+		"user_id":     uint(userID),
+		"start_date":  params.StartDate.Format(time.RFC3339),
+		"end_date":    params.EndDate.Format(time.RFC3339),
+		"limit":       params.Limit,
+		"next_cursor": nextCursor,
 	}
 
 	log.Info("Get climbs completed successfully",
@@ -171,3 +161,14 @@ func GetClimbs(c *fiber.Ctx) error {
 
 	return handlers.SuccessResponse(c, apiName, responseData, "Climbs retrieved successfully")
 }
+
+// climbCursorKey returns the sort field values of a climb used to build the next page cursor
+func climbCursorKey(climb models.Climb) query.Key {
+	return query.Key{
+		"id":         climb.ID,
+		"climb_date": climb.ClimbDate,
+		"created_at": climb.CreatedAt,
+		"rating":     climb.Rating,
+		"attempts":   climb.Attempts,
+	}
+}
@@ -0,0 +1,152 @@
+package exercises
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+
+	"github.com/jwallace145/crux-backend/internal/db"
+	"github.com/jwallace145/crux-backend/internal/handlers"
+	"github.com/jwallace145/crux-backend/internal/query"
+	"github.com/jwallace145/crux-backend/internal/utils"
+	"github.com/jwallace145/crux-backend/models"
+)
+
+// exerciseProgressionSpec defines the whitelisted filters for GET /exercises/progression
+var exerciseProgressionSpec = &query.Spec{
+	Filters: []query.Filter{
+		{Param: "type", Column: "e.type", Type: query.TypeString, Kind: query.FilterEquals},
+		{Param: "edge_size_mm", Column: "e.edge_size_mm", Type: query.TypeInt, Kind: query.FilterEquals},
+		{Param: "grip", Column: "e.grip", Type: query.TypeString, Kind: query.FilterEquals},
+	},
+	DateRange: &query.DateRange{
+		Column:     "ts.session_date",
+		StartParam: "start_date",
+		EndParam:   "end_date",
+	},
+}
+
+// exerciseProgressionMetrics maps the supported metrics to their aggregate SQL expression
+var exerciseProgressionMetrics = map[string]string{
+	"max_added_weight_kg":      "MAX(e.added_weight_kg)",
+	"max_hang_seconds":         "MAX(e.hang_seconds)",
+	"min_edge_size_mm":         "MIN(e.edge_size_mm)",
+	"total_reps":               "SUM(e.sets * e.reps)",
+	"total_time_under_tension": "SUM(e.sets * e.reps * COALESCE(e.hang_seconds, 0))",
+}
+
+// defaultExerciseMetrics is the metric used for each exercise type when none is requested
+var defaultExerciseMetrics = map[string]string{
+	models.ExerciseTypeHangboard: "max_added_weight_kg",
+	models.ExerciseTypeCampus:    "total_reps",
+	models.ExerciseTypeStrength:  "max_added_weight_kg",
+}
+
+// GetExerciseProgression handles GET /exercises/progression requests to chart an exercise metric over time
+// Query parameters:
+//   - type (required): Exercise type ("hangboard", "campus" or "strength")
+//   - metric (optional): One of max_added_weight_kg, max_hang_seconds, min_edge_size_mm, total_reps or
+//     total_time_under_tension (default max_added_weight_kg, or total_reps for campus)
+//   - interval (optional): Period to group by ("day", "week" or "month", default "week")
+//   - name (optional): Only exercises with this name (case insensitive)
+//   - edge_size_mm (optional): Only exercises on this edge size
+//   - grip (optional): Only exercises with this grip
+//   - start_date, end_date (optional): RFC3339 session date range
+//
+// For example, ?type=hangboard&edge_size_mm=20&metric=max_added_weight_kg charts the heaviest
+// added weight on a 20mm edge per week
+// Requires AuthMiddleware to be applied - reads user_id from context
+func GetExerciseProgression(c *fiber.Ctx) error {
+	apiName := "get_exercise_progression"
+	log := utils.GetLoggerFromContext(c)
+
+	log.Info("Starting get exercise progression process",
+		zap.String("api", apiName),
+	)
+
+	// Get user ID from context (set by AuthMiddleware)
+	userID, ok := c.Locals("user_id").(uint)
+	if !ok {
+		log.Error("User ID not found in context",
+			zap.String("api", apiName),
+		)
+		return handlers.InternalErrorResponse(c, apiName, "Authentication context missing", nil)
+	}
+
+	exerciseType := c.Query("type")
+	defaultMetric, ok := defaultExerciseMetrics[exerciseType]
+	if !ok {
+		return handlers.BadRequestResponse(c, apiName, "type must be 'hangboard', 'campus', or 'strength'", nil)
+	}
+
+	metric := c.Query("metric", defaultMetric)
+	metricSQL, ok := exerciseProgressionMetrics[metric]
+	if !ok {
+		return handlers.BadRequestResponse(c, apiName, "metric must be one of max_added_weight_kg, max_hang_seconds, min_edge_size_mm, total_reps, total_time_under_tension", nil)
+	}
+
+	interval := c.Query("interval", "week")
+	if interval != "day" && interval != "week" && interval != "month" {
+		return handlers.BadRequestResponse(c, apiName, "interval must be 'day', 'week', or 'month'", nil)
+	}
+
+	// Parse filter and date range query parameters
+	params, err := query.Parse(c, exerciseProgressionSpec)
+	if err != nil {
+		log.Warn("Invalid query parameters",
+			zap.Error(err),
+			zap.String("api", apiName),
+		)
+		return handlers.BadRequestResponse(c, apiName, err.Error(), nil)
+	}
+
+	progressionQuery := params.ApplyFilters(db.DB.Table("exercises e").
+		Joins("JOIN training_sessions ts ON ts.id = e.training_session_id").
+		Where("ts.user_id = ? AND e.deleted_at IS NULL AND ts.deleted_at IS NULL", userID))
+
+	name := strings.TrimSpace(c.Query("name"))
+	if name != "" {
+		progressionQuery = progressionQuery.Where("LOWER(e.name) = LOWER(?)", name)
+	}
+
+	points := []models.ExerciseProgressionPoint{}
+	if err := progressionQuery.
+		Select("date_trunc(?, ts.session_date) AS period, "+metricSQL+" AS value, COUNT(DISTINCT ts.id) AS sessions", interval).
+		Group("period").
+		Having(metricSQL + " IS NOT NULL").
+		Order("period ASC").
+		Scan(&points).Error; err != nil {
+		log.Error("Database error while querying exercise progression",
+			zap.Error(err),
+			zap.String("api", apiName),
+			zap.Uint("user_id", userID),
+		)
+		return handlers.InternalErrorResponse(c, apiName, "Failed to retrieve exercise progression", nil)
+	}
+
+	response := &models.ExerciseProgressionResponse{
+		Type:      exerciseType,
+		Metric:    metric,
+		Interval:  interval,
+		Name:      name,
+		Grip:      c.Query("grip"),
+		StartDate: params.StartDate,
+		EndDate:   params.EndDate,
+		Points:    points,
+	}
+	if edgeSize, err := strconv.Atoi(c.Query("edge_size_mm")); err == nil {
+		response.EdgeSizeMM = &edgeSize
+	}
+
+	log.Info("Exercise progression retrieved successfully",
+		zap.String("api", apiName),
+		zap.Uint("user_id", userID),
+		zap.String("type", exerciseType),
+		zap.String("metric", metric),
+		zap.Int("points", len(points)),
+	)
+
+	return handlers.SuccessResponse(c, apiName, response, "Exercise progression retrieved successfully")
+}
@@ -0,0 +1,123 @@
+package gyms
+
+import (
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	"github.com/jwallace145/crux-backend/internal/db"
+	"github.com/jwallace145/crux-backend/internal/handlers"
+	"github.com/jwallace145/crux-backend/internal/services"
+	"github.com/jwallace145/crux-backend/internal/utils"
+	"github.com/jwallace145/crux-backend/models"
+)
+
+// CheckInGym handles POST /gyms/:id/check-in requests to record that the user has arrived at a gym
+// Any open check-in at another gym is checked out first. Check-ins without a check-out end after
+// models.MaxGymVisitDuration. Starting a live training session checks the user in implicitly
+// Requires AuthMiddleware to be applied - reads user_id from context
+func CheckInGym(c *fiber.Ctx) error {
+	apiName := "check_in_gym"
+	log := utils.GetLoggerFromContext(c)
+
+	log.Info("Starting gym check-in process",
+		zap.String("api", apiName),
+	)
+
+	// Get user ID from context (set by AuthMiddleware)
+	userID, ok := c.Locals("user_id").(uint)
+	if !ok {
+		log.Error("User ID not found in context",
+			zap.String("api", apiName),
+		)
+		return handlers.InternalErrorResponse(c, apiName, "Authentication context missing", nil)
+	}
+
+	gym, err := loadGym(c, apiName)
+	if err != nil {
+		return err
+	}
+
+	if !gym.Active {
+		return handlers.ValidationErrorResponse(c, apiName, "Gym is no longer active", nil)
+	}
+
+	var checkIn *models.GymCheckIn
+	created := false
+	if err := db.DB.Transaction(func(tx *gorm.DB) error {
+		var err error
+		checkIn, created, err = services.CheckInAtGym(tx, userID, gym.ID, nil, time.Now())
+		return err
+	}); err != nil {
+		log.Error("Failed to check in at gym in db",
+			zap.Error(err),
+			zap.String("api", apiName),
+			zap.Uint("gym_id", gym.ID),
+		)
+		return handlers.InternalErrorResponse(c, apiName, "Failed to check in", nil)
+	}
+
+	if !created {
+		return handlers.ConflictResponse(c, apiName, "You are already checked in at this gym", map[string]interface{}{
+			"gym_check_in_id": checkIn.ID,
+		})
+	}
+
+	log.Info("Checked in at gym successfully",
+		zap.String("api", apiName),
+		zap.Uint("gym_check_in_id", checkIn.ID),
+		zap.Uint("gym_id", gym.ID),
+		zap.Uint("user_id", userID),
+	)
+
+	return handlers.CreatedResponse(c, apiName, checkIn.ToGymCheckInResponse(), "Checked in successfully")
+}
+
+// CheckOutGym handles POST /gyms/:id/check-out requests to record that the user has left a gym
+// Requires AuthMiddleware to be applied - reads user_id from context
+func CheckOutGym(c *fiber.Ctx) error {
+	apiName := "check_out_gym"
+	log := utils.GetLoggerFromContext(c)
+
+	log.Info("Starting gym check-out process",
+		zap.String("api", apiName),
+	)
+
+	// Get user ID from context (set by AuthMiddleware)
+	userID, ok := c.Locals("user_id").(uint)
+	if !ok {
+		log.Error("User ID not found in context",
+			zap.String("api", apiName),
+		)
+		return handlers.InternalErrorResponse(c, apiName, "Authentication context missing", nil)
+	}
+
+	gym, err := loadGym(c, apiName)
+	if err != nil {
+		return err
+	}
+
+	checkIn, err := services.CheckOutOfGym(db.DB, userID, gym.ID, time.Now())
+	if err != nil {
+		log.Error("Failed to check out of gym in db",
+			zap.Error(err),
+			zap.String("api", apiName),
+			zap.Uint("gym_id", gym.ID),
+		)
+		return handlers.InternalErrorResponse(c, apiName, "Failed to check out", nil)
+	}
+	if checkIn == nil {
+		return handlers.NotFoundResponse(c, apiName, "You are not checked in at this gym")
+	}
+
+	log.Info("Checked out of gym successfully",
+		zap.String("api", apiName),
+		zap.Uint("gym_check_in_id", checkIn.ID),
+		zap.Uint("gym_id", gym.ID),
+		zap.Uint("user_id", userID),
+	)
+
+	return handlers.SuccessResponse(c, apiName, checkIn.ToGymCheckInResponse(), "Checked out successfully")
+}
@@ -0,0 +1,139 @@
+package gyms
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	"github.com/jwallace145/crux-backend/internal/db"
+	"github.com/jwallace145/crux-backend/internal/handlers"
+	"github.com/jwallace145/crux-backend/internal/utils"
+	"github.com/jwallace145/crux-backend/models"
+)
+
+// ClaimGym handles POST /gyms/:id/claims requests from gym staff to become the owner of a gym
+// The claim stays pending until an admin approves or rejects it
+// Requires AuthMiddleware to be applied - reads user_id from context
+func ClaimGym(c *fiber.Ctx) error {
+	apiName := "claim_gym"
+	log := utils.GetLoggerFromContext(c)
+
+	log.Info("Starting gym claim process",
+		zap.String("api", apiName),
+	)
+
+	// Get user ID from context (set by AuthMiddleware)
+	userID, ok := c.Locals("user_id").(uint)
+	if !ok {
+		log.Error("User ID not found in context",
+			zap.String("api", apiName),
+		)
+		return handlers.InternalErrorResponse(c, apiName, "Authentication context missing", nil)
+	}
+
+	// Validate Content-Type header
+	if err := handlers.ValidateJSONContentType(c, apiName); err != nil {
+		// Error response is already sent by ValidateJSONContentType
+		return err
+	}
+
+	gymID, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return handlers.BadRequestResponse(c, apiName, "id must be a valid number", nil)
+	}
+
+	// Parse request body
+	var req models.CreateGymClaimRequest
+	if err := c.BodyParser(&req); err != nil {
+		log.Error("Failed to parse request body",
+			zap.Error(err),
+			zap.String("api", apiName),
+			zap.ByteString("raw_body", c.Body()),
+		)
+		return handlers.BadRequestResponse(c, apiName, "Invalid request body", err.Error())
+	}
+
+	req.Role = strings.TrimSpace(req.Role)
+	req.Message = strings.TrimSpace(req.Message)
+	if err := validateCreateGymClaimRequest(&req); err != nil {
+		log.Warn("Request validation failed",
+			zap.Error(err),
+			zap.String("api", apiName),
+		)
+		return handlers.ValidationErrorResponse(c, apiName, err.Error(), nil)
+	}
+
+	var gym models.Gym
+	if err := db.DB.First(&gym, gymID).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return handlers.NotFoundResponse(c, apiName, "Gym not found")
+		}
+		log.Error("Database error while looking up gym",
+			zap.Error(err),
+			zap.String("api", apiName),
+		)
+		return handlers.InternalErrorResponse(c, apiName, "Failed to retrieve gym", nil)
+	}
+
+	if gym.OwnerID != nil && *gym.OwnerID == userID {
+		return handlers.ConflictResponse(c, apiName, "You already own this gym", nil)
+	}
+
+	var pending int64
+	if err := db.DB.Model(&models.GymClaim{}).
+		Where("gym_id = ? AND user_id = ? AND status = ?", gym.ID, userID, models.GymClaimStatusPending).
+		Count(&pending).Error; err != nil {
+		log.Error("Database error while checking existing claims",
+			zap.Error(err),
+			zap.String("api", apiName),
+		)
+		return handlers.InternalErrorResponse(c, apiName, "Failed to create gym claim", nil)
+	}
+	if pending > 0 {
+		return handlers.ConflictResponse(c, apiName, "You already have a pending claim for this gym", nil)
+	}
+
+	claim := &models.GymClaim{
+		GymID:   gym.ID,
+		UserID:  userID,
+		Role:    req.Role,
+		Message: req.Message,
+		Status:  models.GymClaimStatusPending,
+	}
+	if err := db.DB.Create(claim).Error; err != nil {
+		log.Error("Failed to create gym claim in db",
+			zap.Error(err),
+			zap.String("api", apiName),
+			zap.Uint("gym_id", gym.ID),
+		)
+		return handlers.InternalErrorResponse(c, apiName, "Failed to create gym claim", nil)
+	}
+	claim.Gym = gym
+
+	log.Info("Gym claim created successfully",
+		zap.String("api", apiName),
+		zap.Uint("gym_claim_id", claim.ID),
+		zap.Uint("gym_id", gym.ID),
+		zap.Uint("user_id", userID),
+		zap.Bool("gym_already_claimed", gym.IsClaimed()),
+	)
+
+	return handlers.CreatedResponse(c, apiName, claim.ToGymClaimResponse(), "Gym claim submitted for review")
+}
+
+// validateCreateGymClaimRequest validates the create gym claim request
+func validateCreateGymClaimRequest(req *models.CreateGymClaimRequest) error {
+	if req.Message == "" {
+		return fiber.NewError(fiber.StatusBadRequest, "Message is required so an admin can verify the claim")
+	}
+	if len(req.Message) > 2000 {
+		return fiber.NewError(fiber.StatusBadRequest, "Message must not exceed 2000 characters")
+	}
+	if len(req.Role) > 100 {
+		return fiber.NewError(fiber.StatusBadRequest, "Role must not exceed 100 characters")
+	}
+	return nil
+}
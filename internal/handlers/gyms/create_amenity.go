@@ -0,0 +1,107 @@
+package gyms
+
+import (
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+
+	"github.com/jwallace145/crux-backend/internal/db"
+	"github.com/jwallace145/crux-backend/internal/handlers"
+	"github.com/jwallace145/crux-backend/internal/utils"
+	"github.com/jwallace145/crux-backend/models"
+)
+
+// CreateAmenity handles POST /gyms/amenities requests to add an amenity to the catalog
+// Gyms can list the new amenity and be filtered by it straight away
+// Requires AuthMiddleware and AdminMiddleware to be applied
+func CreateAmenity(c *fiber.Ctx) error {
+	apiName := "create_amenity"
+	log := utils.GetLoggerFromContext(c)
+
+	log.Info("Starting amenity creation process",
+		zap.String("api", apiName),
+	)
+
+	// Validate Content-Type header
+	if err := handlers.ValidateJSONContentType(c, apiName); err != nil {
+		// Error response is already sent by ValidateJSONContentType
+		return err
+	}
+
+	var req models.CreateAmenityRequest
+	if err := c.BodyParser(&req); err != nil {
+		log.Error("Failed to parse request body",
+			zap.Error(err),
+			zap.String("api", apiName),
+			zap.ByteString("raw_body", c.Body()),
+		)
+		return handlers.BadRequestResponse(c, apiName, "Invalid request body", err.Error())
+	}
+
+	req.Slug = strings.ToLower(strings.TrimSpace(req.Slug))
+	req.Name = strings.TrimSpace(req.Name)
+	req.Category = strings.ToLower(strings.TrimSpace(req.Category))
+	if err := validateCreateAmenityRequest(&req); err != nil {
+		log.Warn("Request validation failed",
+			zap.Error(err),
+			zap.String("api", apiName),
+		)
+		return handlers.ValidationErrorResponse(c, apiName, err.Error(), nil)
+	}
+
+	// Deleted amenities still hold their slug in the unique index
+	var existing int64
+	if err := db.DB.Unscoped().Model(&models.Amenity{}).Where("slug = ?", req.Slug).Count(&existing).Error; err != nil {
+		log.Error("Database error while checking existing amenities",
+			zap.Error(err),
+			zap.String("api", apiName),
+		)
+		return handlers.InternalErrorResponse(c, apiName, "Failed to create amenity", nil)
+	}
+	if existing > 0 {
+		return handlers.ConflictResponse(c, apiName, "An amenity with this slug already exists", nil)
+	}
+
+	amenity := &models.Amenity{
+		Slug:     req.Slug,
+		Name:     req.Name,
+		Category: req.Category,
+	}
+	if err := db.DB.Create(amenity).Error; err != nil {
+		log.Error("Failed to create amenity in db",
+			zap.Error(err),
+			zap.String("api", apiName),
+			zap.String("slug", req.Slug),
+		)
+		return handlers.InternalErrorResponse(c, apiName, "Failed to create amenity", nil)
+	}
+
+	log.Info("Amenity created successfully",
+		zap.String("api", apiName),
+		zap.Uint("amenity_id", amenity.ID),
+		zap.String("slug", amenity.Slug),
+	)
+
+	return handlers.CreatedResponse(c, apiName, amenity.ToAmenityResponse(), "Amenity created successfully")
+}
+
+// validateCreateAmenityRequest validates the create amenity request
+func validateCreateAmenityRequest(req *models.CreateAmenityRequest) error {
+	if req.Slug == "" {
+		return fiber.NewError(fiber.StatusBadRequest, "Slug is required")
+	}
+	if !models.IsValidAmenitySlug(req.Slug) {
+		return fiber.NewError(fiber.StatusBadRequest, "Slug must be lowercase snake_case of at most 50 characters (e.g., spray_wall)")
+	}
+	if req.Name == "" {
+		return fiber.NewError(fiber.StatusBadRequest, "Name is required")
+	}
+	if len(req.Name) > 100 {
+		return fiber.NewError(fiber.StatusBadRequest, "Name must not exceed 100 characters")
+	}
+	if !models.IsValidAmenityCategory(req.Category) {
+		return fiber.NewError(fiber.StatusBadRequest, "Category must be one of: climbing, training, facilities, services")
+	}
+	return nil
+}
@@ -1,6 +1,7 @@
 package gyms
 
 import (
+	"strconv"
 	"strings"
 
 	"github.com/gofiber/fiber/v2"
@@ -8,12 +9,17 @@ import (
 
 	"github.com/jwallace145/crux-backend/internal/db"
 	"github.com/jwallace145/crux-backend/internal/handlers"
+	"github.com/jwallace145/crux-backend/internal/services"
 	"github.com/jwallace145/crux-backend/internal/utils"
 	"github.com/jwallace145/crux-backend/models"
 )
 
 // CreateGym handles POST /gyms requests to create a new climbing gym
 // It validates the request, checks for required fields, and persists the new gym to the db
+// Gyms with a similar name close by are returned as duplicate candidates with a 409 Conflict unless
+// the allow_duplicate query parameter is true. The creating user can edit the gym until it is
+// claimed by its staff
+// Requires AuthMiddleware to be applied - reads user_id from context
 func CreateGym(c *fiber.Ctx) error {
 	apiName := "create_gym"
 	log := utils.GetLoggerFromContext(c)
@@ -22,6 +28,15 @@ func CreateGym(c *fiber.Ctx) error {
 		zap.String("api", apiName),
 	)
 
+	// Get user ID from context (set by AuthMiddleware)
+	userID, ok := c.Locals("user_id").(uint)
+	if !ok {
+		log.Error("User ID not found in context",
+			zap.String("api", apiName),
+		)
+		return handlers.InternalErrorResponse(c, apiName, "Authentication context missing", nil)
+	}
+
 	// Validate Content-Type header
 	if err := handlers.ValidateJSONContentType(c, apiName); err != nil {
 		// Error response is already sent by ValidateJSONContentType
@@ -39,6 +54,18 @@ func CreateGym(c *fiber.Ctx) error {
 		return handlers.BadRequestResponse(c, apiName, "Invalid request body", err.Error())
 	}
 
+	// Older clients send flat prices, which become adult tiers unless tiers are also given
+	if fields := req.LegacyPriceFields(); len(fields) > 0 {
+		log.Warn("Request uses legacy price fields",
+			zap.String("api", apiName),
+			zap.Strings("fields", fields),
+			zap.Bool("ignored", len(req.Prices) > 0),
+		)
+		if len(req.Prices) == 0 {
+			req.Prices = req.ApplyLegacyPrices(nil, req.Country)
+		}
+	}
+
 	log.Info("Request body parsed successfully",
 		zap.String("api", apiName),
 		zap.String("name", req.Name),
@@ -52,7 +79,7 @@ func CreateGym(c *fiber.Ctx) error {
 		zap.String("api", apiName),
 	)
 
-	if err := validateCreateGymRequest(&req); err != nil {
+	if err := services.ValidateCreateGymRequest(&req); err != nil {
 		log.Warn("Request validation failed",
 			zap.Error(err),
 			zap.String("api", apiName),
@@ -67,12 +94,7 @@ func CreateGym(c *fiber.Ctx) error {
 
 	// Normalize fields
 	originalName := req.Name
-	req.Name = strings.TrimSpace(req.Name)
-	req.City = strings.TrimSpace(req.City)
-	req.Country = strings.TrimSpace(req.Country)
-	if req.Email != "" {
-		req.Email = strings.ToLower(strings.TrimSpace(req.Email))
-	}
+	services.NormalizeGymRequest(&req)
 
 	log.Info("Normalized request parameters",
 		zap.String("api", apiName),
@@ -89,42 +111,43 @@ func CreateGym(c *fiber.Ctx) error {
 		zap.String("country", req.Country),
 	)
 
-	gym := &models.Gym{
-		Name:            req.Name,
-		Description:     req.Description,
-		Type:            req.Type,
-		Address:         req.Address,
-		City:            req.City,
-		State:           req.State,
-		Province:        req.Province,
-		Country:         req.Country,
-		PostalCode:      req.PostalCode,
-		Latitude:        req.Latitude,
-		Longitude:       req.Longitude,
-		Phone:           req.Phone,
-		Email:           req.Email,
-		Website:         req.Website,
-		Hours:           req.Hours,
-		HasBouldering:   req.HasBouldering,
-		HasTopRope:      req.HasTopRope,
-		HasLeadClimbing: req.HasLeadClimbing,
-		HasAutoBelay:    req.HasAutoBelay,
-		HasKidsArea:     req.HasKidsArea,
-		HasTrainingArea: req.HasTrainingArea,
-		HasYogaClasses:  req.HasYogaClasses,
-		HasShower:       req.HasShower,
-		HasParking:      req.HasParking,
-		HasGearRental:   req.HasGearRental,
-		HasProShop:      req.HasProShop,
-		HasCafe:         req.HasCafe,
-		WallHeight:      req.WallHeight,
-		SquareFeet:      req.SquareFeet,
-		DayPassPrice:    req.DayPassPrice,
-		MonthlyPrice:    req.MonthlyPrice,
-		YearlyPrice:     req.YearlyPrice,
-		GearRentalPrice: req.GearRentalPrice,
-		Notes:           req.Notes,
-		Active:          req.Active,
+	amenities, err := resolveGymAmenities(c, apiName, &req)
+	if err != nil {
+		return err
+	}
+
+	gym := services.NewGymFromRequest(&req)
+	gym.CreatedByID = &userID
+	gym.Amenities = amenities
+
+	// Warn about likely duplicates unless the caller has confirmed the gym is new
+	allowDuplicate := false
+	if raw := c.Query("allow_duplicate"); raw != "" {
+		var err error
+		if allowDuplicate, err = strconv.ParseBool(raw); err != nil {
+			return handlers.BadRequestResponse(c, apiName, "allow_duplicate must be 'true' or 'false'", nil)
+		}
+	}
+	if !allowDuplicate {
+		candidates, err := services.FindDuplicateGyms(db.DB, gym)
+		if err != nil {
+			// Duplicate detection is advisory, so a failure does not block creating the gym
+			log.Warn("Failed to check for duplicate gyms",
+				zap.Error(err),
+				zap.String("api", apiName),
+				zap.String("name", req.Name),
+			)
+		} else if len(candidates) > 0 {
+			log.Info("Gym looks like a duplicate of an existing gym",
+				zap.String("api", apiName),
+				zap.String("name", req.Name),
+				zap.Int("candidates", len(candidates)),
+			)
+			return handlers.ConflictResponse(c, apiName, "A similar gym already exists nearby", map[string]interface{}{
+				"candidates": candidates,
+				"hint":       "Retry with allow_duplicate=true to create the gym anyway",
+			})
+		}
 	}
 
 	if err := db.DB.Create(gym).Error; err != nil {
@@ -157,80 +180,26 @@ func CreateGym(c *fiber.Ctx) error {
 	return handlers.CreatedResponse(c, apiName, response, "Gym created successfully")
 }
 
-// validateCreateGymRequest validates the create gym request
-func validateCreateGymRequest(req *models.CreateGymRequest) error {
-	if err := validateGymName(req.Name); err != nil {
-		return err
-	}
-	if err := validateGymType(req.Type); err != nil {
-		return err
-	}
-	if err := validateGymLocation(req.City, req.Country); err != nil {
-		return err
-	}
-	if err := validateGymContactInfo(req.Email); err != nil {
-		return err
-	}
-	if err := validateGymCoordinates(req.Latitude, req.Longitude); err != nil {
-		return err
-	}
-	return nil
-}
-
-// validateGymName validates the gym name
-func validateGymName(name string) error {
-	if name == "" {
-		return fiber.NewError(fiber.StatusBadRequest, "Name is required")
-	}
-	if len(name) < 1 || len(name) > 200 {
-		return fiber.NewError(fiber.StatusBadRequest, "Name must be between 1 and 200 characters")
-	}
-	return nil
-}
-
-// validateGymType validates the gym type
-func validateGymType(gymType string) error {
-	if gymType == "" {
-		return fiber.NewError(fiber.StatusBadRequest, "Type is required")
-	}
-	if gymType != models.GymTypeBouldering && gymType != models.GymTypeRoped && gymType != models.GymTypeFull {
-		return fiber.NewError(fiber.StatusBadRequest, "Type must be one of: bouldering, roped, full")
-	}
-	return nil
-}
-
-// validateGymLocation validates the city and country
-func validateGymLocation(city, country string) error {
-	if city == "" {
-		return fiber.NewError(fiber.StatusBadRequest, "City is required")
-	}
-	if len(city) > 100 {
-		return fiber.NewError(fiber.StatusBadRequest, "City must not exceed 100 characters")
-	}
-	if country == "" {
-		return fiber.NewError(fiber.StatusBadRequest, "Country is required")
-	}
-	if len(country) > 100 {
-		return fiber.NewError(fiber.StatusBadRequest, "Country must not exceed 100 characters")
-	}
-	return nil
-}
-
-// validateGymContactInfo validates email format
-func validateGymContactInfo(email string) error {
-	if email != "" && !strings.Contains(email, "@") {
-		return fiber.NewError(fiber.StatusBadRequest, "Invalid email format")
-	}
-	return nil
-}
+// resolveGymAmenities looks up the catalog entries for the amenities of a validated request. On
+// failure the error response has already been sent
+func resolveGymAmenities(c *fiber.Ctx, apiName string, req *models.CreateGymRequest) ([]models.Amenity, error) {
+	log := utils.GetLoggerFromContext(c)
 
-// validateGymCoordinates validates latitude and longitude
-func validateGymCoordinates(latitude, longitude *float64) error {
-	if latitude != nil && (*latitude < -90 || *latitude > 90) {
-		return fiber.NewError(fiber.StatusBadRequest, "Latitude must be between -90 and 90")
+	slugs, _ := req.AmenitySlugs()
+	amenities, unknown, err := services.FindAmenities(db.DB, slugs)
+	if err != nil {
+		log.Error("Database error while looking up amenities",
+			zap.Error(err),
+			zap.String("api", apiName),
+		)
+		return nil, handlers.InternalErrorResponse(c, apiName, "Failed to look up amenities", nil)
 	}
-	if longitude != nil && (*longitude < -180 || *longitude > 180) {
-		return fiber.NewError(fiber.StatusBadRequest, "Longitude must be between -180 and 180")
+	if len(unknown) > 0 {
+		log.Warn("Request references unknown amenities",
+			zap.String("api", apiName),
+			zap.Strings("amenities", unknown),
+		)
+		return nil, handlers.ValidationErrorResponse(c, apiName, "Unknown amenities: "+strings.Join(unknown, ", "), nil)
 	}
-	return nil
+	return amenities, nil
 }
@@ -0,0 +1,200 @@
+package gyms
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+
+	"github.com/jwallace145/crux-backend/internal/db"
+	"github.com/jwallace145/crux-backend/internal/handlers"
+	"github.com/jwallace145/crux-backend/internal/utils"
+	"github.com/jwallace145/crux-backend/models"
+)
+
+// CreateGymClimb handles POST /gyms/:id/climbs requests to add a set problem or route to a gym's catalog
+// Climbers can then reference the catalog entry when they log an indoor boulder or rope climb at the gym
+// Requires AuthMiddleware to be applied - reads user_id from context
+func CreateGymClimb(c *fiber.Ctx) error {
+	apiName := "create_gym_climb"
+	log := utils.GetLoggerFromContext(c)
+
+	log.Info("Starting gym climb creation process",
+		zap.String("api", apiName),
+	)
+
+	// Get user ID from context (set by AuthMiddleware)
+	userID, ok := c.Locals("user_id").(uint)
+	if !ok {
+		log.Error("User ID not found in context",
+			zap.String("api", apiName),
+		)
+		return handlers.InternalErrorResponse(c, apiName, "Authentication context missing", nil)
+	}
+
+	// Validate Content-Type header
+	if err := handlers.ValidateJSONContentType(c, apiName); err != nil {
+		return err
+	}
+
+	gym, err := loadEditableGym(c, apiName, userID)
+	if err != nil {
+		return err
+	}
+
+	// Parse request body
+	var req models.CreateGymClimbRequest
+	if err := c.BodyParser(&req); err != nil {
+		log.Error("Failed to parse request body",
+			zap.Error(err),
+			zap.String("api", apiName),
+		)
+		return handlers.BadRequestResponse(c, apiName, "Invalid request body", err.Error())
+	}
+
+	normalizeGymClimbRequest(&req)
+	if req.SetDate == nil {
+		now := time.Now()
+		req.SetDate = &now
+	}
+	if err := validateGymClimbRequest(&req); err != nil {
+		log.Warn("Request validation failed",
+			zap.Error(err),
+			zap.String("api", apiName),
+		)
+		return handlers.ValidationErrorResponse(c, apiName, err.Error(), nil)
+	}
+
+	if err := verifyGymWall(c, apiName, gym.ID, req.GymWallID); err != nil {
+		return err
+	}
+
+	climb := newGymClimbFromRequest(&req)
+	climb.GymID = gym.ID
+	if err := db.DB.Create(climb).Error; err != nil {
+		log.Error("Failed to create gym climb in db",
+			zap.Error(err),
+			zap.String("api", apiName),
+			zap.Uint("gym_id", gym.ID),
+		)
+		return handlers.InternalErrorResponse(c, apiName, "Failed to create gym climb", nil)
+	}
+
+	log.Info("Gym climb created successfully",
+		zap.String("api", apiName),
+		zap.Uint("gym_id", gym.ID),
+		zap.Uint("gym_climb_id", climb.ID),
+		zap.String("discipline", climb.Discipline),
+		zap.String("grade", climb.Grade),
+		zap.Uint("user_id", userID),
+	)
+
+	return handlers.CreatedResponse(c, apiName, climb.ToGymClimbResponse(), "Gym climb created successfully")
+}
+
+// newGymClimbFromRequest builds a gym climb from a validated request
+func newGymClimbFromRequest(req *models.CreateGymClimbRequest) *models.GymClimb {
+	climb := &models.GymClimb{
+		GymWallID:  req.GymWallID,
+		Discipline: req.Discipline,
+		Name:       req.Name,
+		Color:      req.Color,
+		Grade:      req.Grade,
+		Setter:     req.Setter,
+		SetDate:    *req.SetDate,
+		StripDate:  req.StripDate,
+	}
+	if len(req.StyleTags) > 0 {
+		climb.StyleTags = models.StyleTags(req.StyleTags)
+	}
+	return climb
+}
+
+// normalizeGymClimbRequest trims the text fields and normalizes the grade and style tags
+func normalizeGymClimbRequest(req *models.CreateGymClimbRequest) {
+	req.Discipline = strings.ToLower(strings.TrimSpace(req.Discipline))
+	req.Name = strings.TrimSpace(req.Name)
+	req.Color = strings.TrimSpace(req.Color)
+	req.Grade = models.NormalizeGrade(req.Grade)
+	req.Setter = strings.TrimSpace(req.Setter)
+	for i, tag := range req.StyleTags {
+		req.StyleTags[i] = strings.ToLower(strings.TrimSpace(tag))
+	}
+}
+
+// validateGymClimbRequest validates a gym climb as it will be stored
+func validateGymClimbRequest(req *models.CreateGymClimbRequest) error {
+	if !models.IsValidGymClimbDiscipline(req.Discipline) {
+		return fiber.NewError(fiber.StatusBadRequest, "Discipline must be 'boulder' or 'rope'")
+	}
+	if len(req.Name) > 150 {
+		return fiber.NewError(fiber.StatusBadRequest, "Name must not exceed 150 characters")
+	}
+	if req.Color == "" {
+		return fiber.NewError(fiber.StatusBadRequest, "Color is required")
+	}
+	if len(req.Color) > 50 {
+		return fiber.NewError(fiber.StatusBadRequest, "Color must not exceed 50 characters")
+	}
+	if req.Grade == "" {
+		return fiber.NewError(fiber.StatusBadRequest, "Grade is required")
+	}
+	if len(req.Grade) > 20 {
+		return fiber.NewError(fiber.StatusBadRequest, "Grade must not exceed 20 characters")
+	}
+	if len(req.Setter) > 100 {
+		return fiber.NewError(fiber.StatusBadRequest, "Setter must not exceed 100 characters")
+	}
+	if len(req.StyleTags) > models.MaxGymClimbStyleTags {
+		return fiber.NewError(fiber.StatusBadRequest, "A climb can have at most "+strconv.Itoa(models.MaxGymClimbStyleTags)+" style tags")
+	}
+	seen := make(map[string]bool, len(req.StyleTags))
+	for _, tag := range req.StyleTags {
+		if !models.IsValidStyleTag(tag) {
+			return fiber.NewError(fiber.StatusBadRequest, "Style tags must be one of: crimpy, slopey, pinchy, juggy, pockets, dyno, coordination, compression, balance, powerful, endurance, technical, slab, vertical, overhang, roof")
+		}
+		if seen[tag] {
+			return fiber.NewError(fiber.StatusBadRequest, "Style tags must not contain duplicates")
+		}
+		seen[tag] = true
+	}
+	if req.SetDate.After(time.Now()) {
+		return fiber.NewError(fiber.StatusBadRequest, "Set date cannot be in the future")
+	}
+	if req.StripDate != nil && req.StripDate.Before(*req.SetDate) {
+		return fiber.NewError(fiber.StatusBadRequest, "Strip date must not be before the set date")
+	}
+	return nil
+}
+
+// verifyGymWall verifies that the wall, when given, belongs to the gym
+func verifyGymWall(c *fiber.Ctx, apiName string, gymID uint, wallID *uint) error {
+	log := utils.GetLoggerFromContext(c)
+
+	if wallID == nil {
+		return nil
+	}
+
+	var count int64
+	if err := db.DB.Model(&models.GymWall{}).Where("id = ? AND gym_id = ?", *wallID, gymID).Count(&count).Error; err != nil {
+		log.Error("Database error while checking gym wall",
+			zap.Error(err),
+			zap.String("api", apiName),
+		)
+		return handlers.InternalErrorResponse(c, apiName, "Failed to verify gym wall", nil)
+	}
+	if count == 0 {
+		log.Warn("Gym wall not found",
+			zap.String("api", apiName),
+			zap.Uint("gym_id", gymID),
+			zap.Uint("gym_wall_id", *wallID),
+		)
+		return handlers.BadRequestResponse(c, apiName, "Gym wall not found", map[string]interface{}{
+			"gym_wall_id": *wallID,
+		})
+	}
+
+	return nil
+}
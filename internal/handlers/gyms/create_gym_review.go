@@ -0,0 +1,166 @@
+package gyms
+
+import (
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/jwallace145/crux-backend/internal/db"
+	"github.com/jwallace145/crux-backend/internal/handlers"
+	"github.com/jwallace145/crux-backend/internal/services"
+	"github.com/jwallace145/crux-backend/internal/utils"
+	"github.com/jwallace145/crux-backend/models"
+)
+
+// CreateGymReview handles POST /gyms/:id/reviews requests to rate a gym overall and on setting
+// quality, crowding, cleanliness and value. Each user has one review per gym, so posting again
+// replaces the user's existing review. A review hidden by an admin stays hidden when it is edited
+// Requires AuthMiddleware to be applied - reads user_id from context
+func CreateGymReview(c *fiber.Ctx) error {
+	apiName := "create_gym_review"
+	log := utils.GetLoggerFromContext(c)
+
+	log.Info("Starting gym review process",
+		zap.String("api", apiName),
+	)
+
+	// Get user ID from context (set by AuthMiddleware)
+	userID, ok := c.Locals("user_id").(uint)
+	if !ok {
+		log.Error("User ID not found in context",
+			zap.String("api", apiName),
+		)
+		return handlers.InternalErrorResponse(c, apiName, "Authentication context missing", nil)
+	}
+
+	// Validate Content-Type header
+	if err := handlers.ValidateJSONContentType(c, apiName); err != nil {
+		return err
+	}
+
+	gym, err := loadGym(c, apiName)
+	if err != nil {
+		return err
+	}
+
+	if gym.OwnerID != nil && *gym.OwnerID == userID {
+		return handlers.ForbiddenResponse(c, apiName, "Gym owners cannot review their own gym")
+	}
+
+	// Parse request body
+	var req models.CreateGymReviewRequest
+	if err := c.BodyParser(&req); err != nil {
+		log.Error("Failed to parse request body",
+			zap.Error(err),
+			zap.String("api", apiName),
+		)
+		return handlers.BadRequestResponse(c, apiName, "Invalid request body", err.Error())
+	}
+
+	req.Title = strings.TrimSpace(req.Title)
+	req.Body = strings.TrimSpace(req.Body)
+	if err := validateGymReviewRequest(&req); err != nil {
+		log.Warn("Request validation failed",
+			zap.Error(err),
+			zap.String("api", apiName),
+		)
+		return handlers.ValidationErrorResponse(c, apiName, err.Error(), nil)
+	}
+
+	review := models.GymReview{
+		GymID:             gym.ID,
+		UserID:            userID,
+		OverallRating:     req.OverallRating,
+		SettingRating:     req.SettingRating,
+		CrowdingRating:    req.CrowdingRating,
+		CleanlinessRating: req.CleanlinessRating,
+		ValueRating:       req.ValueRating,
+		Title:             req.Title,
+		Body:              req.Body,
+	}
+
+	var existing int64
+	err = db.DB.Transaction(func(tx *gorm.DB) error {
+		if err := services.LockGymRatings(tx, gym.ID); err != nil {
+			return err
+		}
+
+		if err := tx.Model(&models.GymReview{}).
+			Where("gym_id = ? AND user_id = ?", gym.ID, userID).
+			Count(&existing).Error; err != nil {
+			return err
+		}
+
+		// Upsert on the one review per user per gym index, leaving the moderation state untouched
+		if err := tx.Clauses(clause.OnConflict{
+			Columns: []clause.Column{{Name: "gym_id"}, {Name: "user_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{
+				"overall_rating", "setting_rating", "crowding_rating", "cleanliness_rating",
+				"value_rating", "title", "body", "updated_at",
+			}),
+		}).Create(&review).Error; err != nil {
+			return err
+		}
+
+		if err := tx.Preload("User").
+			Where("gym_id = ? AND user_id = ?", gym.ID, userID).
+			First(&review).Error; err != nil {
+			return err
+		}
+
+		return services.RefreshGymRatings(tx, gym.ID)
+	})
+	if err != nil {
+		log.Error("Failed to save gym review in db",
+			zap.Error(err),
+			zap.String("api", apiName),
+			zap.Uint("gym_id", gym.ID),
+		)
+		return handlers.InternalErrorResponse(c, apiName, "Failed to save gym review", nil)
+	}
+
+	log.Info("Gym review saved successfully",
+		zap.String("api", apiName),
+		zap.Uint("gym_review_id", review.ID),
+		zap.Uint("gym_id", gym.ID),
+		zap.Uint("user_id", userID),
+		zap.Bool("updated", existing > 0),
+	)
+
+	response := review.ToGymReviewResponse().WithModeration(&review)
+	if existing > 0 {
+		return handlers.SuccessResponse(c, apiName, response, "Gym review updated successfully")
+	}
+	return handlers.CreatedResponse(c, apiName, response, "Gym review created successfully")
+}
+
+// validateGymReviewRequest validates the ratings and text of a gym review
+func validateGymReviewRequest(req *models.CreateGymReviewRequest) error {
+	if !models.IsValidGymRating(req.OverallRating) {
+		return fiber.NewError(fiber.StatusBadRequest, "Overall rating is required and must be between 1 and 5")
+	}
+	ratings := []struct {
+		name   string
+		rating *int
+	}{
+		{"Setting rating", req.SettingRating},
+		{"Crowding rating", req.CrowdingRating},
+		{"Cleanliness rating", req.CleanlinessRating},
+		{"Value rating", req.ValueRating},
+	}
+	for _, r := range ratings {
+		if r.rating != nil && !models.IsValidGymRating(*r.rating) {
+			return fiber.NewError(fiber.StatusBadRequest, r.name+" must be between 1 and 5")
+		}
+	}
+	if len(req.Title) > 150 {
+		return fiber.NewError(fiber.StatusBadRequest, "Title must not exceed 150 characters")
+	}
+	if len(req.Body) > 5000 {
+		return fiber.NewError(fiber.StatusBadRequest, "Review must not exceed 5000 characters")
+	}
+	return nil
+}
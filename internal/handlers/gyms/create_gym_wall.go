@@ -0,0 +1,102 @@
+package gyms
+
+import (
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+
+	"github.com/jwallace145/crux-backend/internal/db"
+	"github.com/jwallace145/crux-backend/internal/handlers"
+	"github.com/jwallace145/crux-backend/internal/utils"
+	"github.com/jwallace145/crux-backend/models"
+)
+
+// CreateGymWall handles POST /gyms/:id/walls requests to add a wall to a gym's route-setting catalog
+// Only the staff who can edit the gym (its owner, or its creator until it is claimed) and admins can
+// manage the catalog
+// Requires AuthMiddleware to be applied - reads user_id from context
+func CreateGymWall(c *fiber.Ctx) error {
+	apiName := "create_gym_wall"
+	log := utils.GetLoggerFromContext(c)
+
+	log.Info("Starting gym wall creation process",
+		zap.String("api", apiName),
+	)
+
+	// Get user ID from context (set by AuthMiddleware)
+	userID, ok := c.Locals("user_id").(uint)
+	if !ok {
+		log.Error("User ID not found in context",
+			zap.String("api", apiName),
+		)
+		return handlers.InternalErrorResponse(c, apiName, "Authentication context missing", nil)
+	}
+
+	// Validate Content-Type header
+	if err := handlers.ValidateJSONContentType(c, apiName); err != nil {
+		return err
+	}
+
+	gym, err := loadEditableGym(c, apiName, userID)
+	if err != nil {
+		return err
+	}
+
+	// Parse request body
+	var req models.CreateGymWallRequest
+	if err := c.BodyParser(&req); err != nil {
+		log.Error("Failed to parse request body",
+			zap.Error(err),
+			zap.String("api", apiName),
+		)
+		return handlers.BadRequestResponse(c, apiName, "Invalid request body", err.Error())
+	}
+
+	req.Name = strings.TrimSpace(req.Name)
+	req.Description = strings.TrimSpace(req.Description)
+	if err := validateGymWallRequest(&req); err != nil {
+		log.Warn("Request validation failed",
+			zap.Error(err),
+			zap.String("api", apiName),
+		)
+		return handlers.ValidationErrorResponse(c, apiName, err.Error(), nil)
+	}
+
+	wall := &models.GymWall{
+		GymID:       gym.ID,
+		Name:        req.Name,
+		Description: req.Description,
+	}
+	if err := db.DB.Create(wall).Error; err != nil {
+		log.Error("Failed to create gym wall in db",
+			zap.Error(err),
+			zap.String("api", apiName),
+			zap.Uint("gym_id", gym.ID),
+		)
+		return handlers.InternalErrorResponse(c, apiName, "Failed to create gym wall", nil)
+	}
+
+	log.Info("Gym wall created successfully",
+		zap.String("api", apiName),
+		zap.Uint("gym_id", gym.ID),
+		zap.Uint("gym_wall_id", wall.ID),
+		zap.Uint("user_id", userID),
+	)
+
+	return handlers.CreatedResponse(c, apiName, wall.ToGymWallResponse(), "Gym wall created successfully")
+}
+
+// validateGymWallRequest validates the name and description of a gym wall
+func validateGymWallRequest(req *models.CreateGymWallRequest) error {
+	if req.Name == "" {
+		return fiber.NewError(fiber.StatusBadRequest, "Wall name is required")
+	}
+	if len(req.Name) > 100 {
+		return fiber.NewError(fiber.StatusBadRequest, "Wall name must not exceed 100 characters")
+	}
+	if len(req.Description) > 1000 {
+		return fiber.NewError(fiber.StatusBadRequest, "Wall description must not exceed 1000 characters")
+	}
+	return nil
+}
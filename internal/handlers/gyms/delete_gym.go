@@ -0,0 +1,105 @@
+package gyms
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	"github.com/jwallace145/crux-backend/internal/db"
+	"github.com/jwallace145/crux-backend/internal/handlers"
+	"github.com/jwallace145/crux-backend/internal/utils"
+	"github.com/jwallace145/crux-backend/models"
+)
+
+// DeleteGym handles DELETE /gyms/:id requests to remove a gym
+// Gyms that are referenced by climbs, training sessions or planned sessions are marked inactive so
+// that the logged history keeps its gym, other gyms are soft deleted. Claimed gyms can only be
+// deleted by their owner, unclaimed gyms by the user who created them, and admins can delete any gym
+// Requires AuthMiddleware to be applied - reads user_id from context
+func DeleteGym(c *fiber.Ctx) error {
+	apiName := "delete_gym"
+	log := utils.GetLoggerFromContext(c)
+
+	log.Info("Starting gym deletion process",
+		zap.String("api", apiName),
+	)
+
+	// Get user ID from context (set by AuthMiddleware)
+	userID, ok := c.Locals("user_id").(uint)
+	if !ok {
+		log.Error("User ID not found in context",
+			zap.String("api", apiName),
+		)
+		return handlers.InternalErrorResponse(c, apiName, "Authentication context missing", nil)
+	}
+
+	gym, err := loadEditableGym(c, apiName, userID)
+	if err != nil {
+		return err
+	}
+
+	deleted := false
+	err = db.DB.Transaction(func(tx *gorm.DB) error {
+		inUse, err := isGymReferenced(tx, gym.ID)
+		if err != nil {
+			return err
+		}
+
+		if inUse {
+			return tx.Model(gym).Update("active", false).Error
+		}
+
+		// Pending claims are moot once the gym is gone
+		if err := tx.Where("gym_id = ? AND status = ?", gym.ID, models.GymClaimStatusPending).
+			Delete(&models.GymClaim{}).Error; err != nil {
+			return err
+		}
+		deleted = true
+		return tx.Delete(gym).Error
+	})
+	if err != nil {
+		log.Error("Failed to delete gym in db",
+			zap.Error(err),
+			zap.String("api", apiName),
+			zap.Uint("gym_id", gym.ID),
+		)
+		return handlers.InternalErrorResponse(c, apiName, "Failed to delete gym", nil)
+	}
+
+	responseData := map[string]interface{}{
+		"id":      gym.ID,
+		"deleted": deleted,
+		"active":  false,
+	}
+
+	if !deleted {
+		log.Info("Gym is referenced by logged activity, marked inactive instead of deleted",
+			zap.String("api", apiName),
+			zap.Uint("gym_id", gym.ID),
+			zap.Uint("user_id", userID),
+		)
+		return handlers.SuccessResponse(c, apiName, responseData, "Gym has logged activity and was marked inactive")
+	}
+
+	log.Info("Gym deleted successfully",
+		zap.String("api", apiName),
+		zap.Uint("gym_id", gym.ID),
+		zap.Uint("user_id", userID),
+	)
+
+	return handlers.SuccessResponse(c, apiName, responseData, "Gym deleted successfully")
+}
+
+// isGymReferenced returns true if any climb, training session or planned session points at the gym
+func isGymReferenced(tx *gorm.DB, gymID uint) (bool, error) {
+	for _, model := range []interface{}{&models.TrainingSession{}, &models.PlannedSession{}, &models.Climb{}} {
+		var count int64
+		if err := tx.Model(model).Where("gym_id = ?", gymID).Limit(1).Count(&count).Error; err != nil {
+			return false, err
+		}
+		if count > 0 {
+			return true, nil
+		}
+	}
+	return false, nil
+}
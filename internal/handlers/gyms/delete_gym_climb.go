@@ -0,0 +1,84 @@
+package gyms
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	"github.com/jwallace145/crux-backend/internal/db"
+	"github.com/jwallace145/crux-backend/internal/handlers"
+	"github.com/jwallace145/crux-backend/internal/services"
+	"github.com/jwallace145/crux-backend/internal/utils"
+	"github.com/jwallace145/crux-backend/models"
+)
+
+// DeleteGymClimb handles DELETE /gyms/:id/climbs/:climb_id requests to remove a climb added to the
+// catalog by mistake. Logged boulders and rope climbs that referenced it are unlinked and keep their
+// own grade and outcome. Climbs that have been taken down should be stripped with PATCH instead
+// Requires AuthMiddleware to be applied - reads user_id from context
+func DeleteGymClimb(c *fiber.Ctx) error {
+	apiName := "delete_gym_climb"
+	log := utils.GetLoggerFromContext(c)
+
+	log.Info("Starting gym climb deletion process",
+		zap.String("api", apiName),
+	)
+
+	// Get user ID from context (set by AuthMiddleware)
+	userID, ok := c.Locals("user_id").(uint)
+	if !ok {
+		log.Error("User ID not found in context",
+			zap.String("api", apiName),
+		)
+		return handlers.InternalErrorResponse(c, apiName, "Authentication context missing", nil)
+	}
+
+	gym, err := loadEditableGym(c, apiName, userID)
+	if err != nil {
+		return err
+	}
+
+	climb, err := loadGymClimb(c, apiName, gym.ID)
+	if err != nil {
+		return err
+	}
+
+	if err := db.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&models.IndoorBoulder{}).
+			Where("gym_climb_id = ?", climb.ID).
+			Update("gym_climb_id", nil).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&models.RopeClimb{}).
+			Where("gym_climb_id = ?", climb.ID).
+			Update("gym_climb_id", nil).Error; err != nil {
+			return err
+		}
+		return tx.Delete(climb).Error
+	}); err != nil {
+		log.Error("Failed to delete gym climb in db",
+			zap.Error(err),
+			zap.String("api", apiName),
+			zap.Uint("gym_climb_id", climb.ID),
+		)
+		return handlers.InternalErrorResponse(c, apiName, "Failed to delete gym climb", nil)
+	}
+
+	if climb.HasPhoto() {
+		services.DeleteMediaObjects(c.Context(), []string{climb.PhotoS3Key})
+	}
+
+	log.Info("Gym climb deleted successfully",
+		zap.String("api", apiName),
+		zap.Uint("gym_id", gym.ID),
+		zap.Uint("gym_climb_id", climb.ID),
+		zap.Uint("user_id", userID),
+	)
+
+	responseData := map[string]interface{}{
+		"id":      climb.ID,
+		"deleted": true,
+	}
+
+	return handlers.SuccessResponse(c, apiName, responseData, "Gym climb deleted successfully")
+}
@@ -0,0 +1,108 @@
+package gyms
+
+import (
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	"github.com/jwallace145/crux-backend/internal/db"
+	"github.com/jwallace145/crux-backend/internal/handlers"
+	"github.com/jwallace145/crux-backend/internal/services"
+	"github.com/jwallace145/crux-backend/internal/utils"
+	"github.com/jwallace145/crux-backend/models"
+)
+
+// DeleteGymReview handles DELETE /gyms/:id/reviews/:review_id requests from the author of a review
+// to remove it. The review and its reports are hard deleted so the user can review the gym again
+// Requires AuthMiddleware to be applied - reads user_id from context
+func DeleteGymReview(c *fiber.Ctx) error {
+	apiName := "delete_gym_review"
+	log := utils.GetLoggerFromContext(c)
+
+	log.Info("Starting gym review deletion process",
+		zap.String("api", apiName),
+	)
+
+	// Get user ID from context (set by AuthMiddleware)
+	userID, ok := c.Locals("user_id").(uint)
+	if !ok {
+		log.Error("User ID not found in context",
+			zap.String("api", apiName),
+		)
+		return handlers.InternalErrorResponse(c, apiName, "Authentication context missing", nil)
+	}
+
+	gym, err := loadGym(c, apiName)
+	if err != nil {
+		return err
+	}
+
+	review, err := loadGymReview(c, apiName, gym.ID)
+	if err != nil {
+		return err
+	}
+
+	if review.UserID != userID {
+		return handlers.ForbiddenResponse(c, apiName, "You can only delete your own review")
+	}
+
+	if err := db.DB.Transaction(func(tx *gorm.DB) error {
+		if err := services.LockGymRatings(tx, gym.ID); err != nil {
+			return err
+		}
+
+		if err := tx.Unscoped().Where("gym_review_id = ?", review.ID).Delete(&models.GymReviewReport{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Unscoped().Delete(review).Error; err != nil {
+			return err
+		}
+		return services.RefreshGymRatings(tx, gym.ID)
+	}); err != nil {
+		log.Error("Failed to delete gym review in db",
+			zap.Error(err),
+			zap.String("api", apiName),
+			zap.Uint("gym_review_id", review.ID),
+		)
+		return handlers.InternalErrorResponse(c, apiName, "Failed to delete gym review", nil)
+	}
+
+	log.Info("Gym review deleted successfully",
+		zap.String("api", apiName),
+		zap.Uint("gym_review_id", review.ID),
+		zap.Uint("gym_id", gym.ID),
+		zap.Uint("user_id", userID),
+	)
+
+	return handlers.SuccessResponse(c, apiName, map[string]interface{}{"id": review.ID}, "Gym review deleted successfully")
+}
+
+// loadGymReview looks up the review in the :review_id path parameter and ensures it belongs to the gym
+func loadGymReview(c *fiber.Ctx, apiName string, gymID uint) (*models.GymReview, error) {
+	log := utils.GetLoggerFromContext(c)
+
+	reviewID, err := strconv.ParseUint(c.Params("review_id"), 10, 32)
+	if err != nil {
+		return nil, handlers.BadRequestResponse(c, apiName, "review_id must be a valid number", nil)
+	}
+
+	var review models.GymReview
+	if err := db.DB.Where("id = ? AND gym_id = ?", reviewID, gymID).First(&review).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			log.Warn("Gym review not found",
+				zap.String("api", apiName),
+				zap.Uint64("gym_review_id", reviewID),
+			)
+			return nil, handlers.NotFoundResponse(c, apiName, "Gym review not found")
+		}
+		log.Error("Database error while looking up gym review",
+			zap.Error(err),
+			zap.String("api", apiName),
+		)
+		return nil, handlers.InternalErrorResponse(c, apiName, "Failed to retrieve gym review", nil)
+	}
+
+	return &review, nil
+}
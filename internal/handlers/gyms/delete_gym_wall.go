@@ -0,0 +1,73 @@
+package gyms
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	"github.com/jwallace145/crux-backend/internal/db"
+	"github.com/jwallace145/crux-backend/internal/handlers"
+	"github.com/jwallace145/crux-backend/internal/utils"
+	"github.com/jwallace145/crux-backend/models"
+)
+
+// DeleteGymWall handles DELETE /gyms/:id/walls/:wall_id requests to remove a gym wall
+// The problems and routes set on the wall stay in the catalog without a wall
+// Requires AuthMiddleware to be applied - reads user_id from context
+func DeleteGymWall(c *fiber.Ctx) error {
+	apiName := "delete_gym_wall"
+	log := utils.GetLoggerFromContext(c)
+
+	log.Info("Starting gym wall deletion process",
+		zap.String("api", apiName),
+	)
+
+	// Get user ID from context (set by AuthMiddleware)
+	userID, ok := c.Locals("user_id").(uint)
+	if !ok {
+		log.Error("User ID not found in context",
+			zap.String("api", apiName),
+		)
+		return handlers.InternalErrorResponse(c, apiName, "Authentication context missing", nil)
+	}
+
+	gym, err := loadEditableGym(c, apiName, userID)
+	if err != nil {
+		return err
+	}
+
+	wall, err := loadGymWall(c, apiName, gym.ID)
+	if err != nil {
+		return err
+	}
+
+	if err := db.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&models.GymClimb{}).
+			Where("gym_wall_id = ?", wall.ID).
+			Update("gym_wall_id", nil).Error; err != nil {
+			return err
+		}
+		return tx.Delete(wall).Error
+	}); err != nil {
+		log.Error("Failed to delete gym wall in db",
+			zap.Error(err),
+			zap.String("api", apiName),
+			zap.Uint("gym_wall_id", wall.ID),
+		)
+		return handlers.InternalErrorResponse(c, apiName, "Failed to delete gym wall", nil)
+	}
+
+	log.Info("Gym wall deleted successfully",
+		zap.String("api", apiName),
+		zap.Uint("gym_id", gym.ID),
+		zap.Uint("gym_wall_id", wall.ID),
+		zap.Uint("user_id", userID),
+	)
+
+	responseData := map[string]interface{}{
+		"id":      wall.ID,
+		"deleted": true,
+	}
+
+	return handlers.SuccessResponse(c, apiName, responseData, "Gym wall deleted successfully")
+}
@@ -0,0 +1,45 @@
+package gyms
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+
+	"github.com/jwallace145/crux-backend/internal/db"
+	"github.com/jwallace145/crux-backend/internal/handlers"
+	"github.com/jwallace145/crux-backend/internal/utils"
+	"github.com/jwallace145/crux-backend/models"
+)
+
+// GetAmenities handles GET /gyms/amenities requests to list the amenity catalog
+// Amenities are ordered by category and name. Their slugs are used in gym requests and in the
+// amenities filter of GET /gyms
+// Requires AuthMiddleware to be applied
+func GetAmenities(c *fiber.Ctx) error {
+	apiName := "get_amenities"
+	log := utils.GetLoggerFromContext(c)
+
+	log.Info("Starting get amenities process",
+		zap.String("api", apiName),
+	)
+
+	var amenities []models.Amenity
+	if err := db.DB.Find(&amenities).Error; err != nil {
+		log.Error("Database error while querying amenities",
+			zap.Error(err),
+			zap.String("api", apiName),
+		)
+		return handlers.InternalErrorResponse(c, apiName, "Failed to retrieve amenities", nil)
+	}
+
+	responses := models.ToAmenityResponses(amenities)
+
+	log.Info("Amenities retrieved successfully",
+		zap.String("api", apiName),
+		zap.Int("count", len(responses)),
+	)
+
+	return handlers.SuccessResponse(c, apiName, map[string]interface{}{
+		"amenities": responses,
+		"count":     len(responses),
+	}, "Amenities retrieved successfully")
+}
@@ -0,0 +1,62 @@
+package gyms
+
+import (
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+
+	"github.com/jwallace145/crux-backend/internal/db"
+	"github.com/jwallace145/crux-backend/internal/handlers"
+	"github.com/jwallace145/crux-backend/internal/services"
+	"github.com/jwallace145/crux-backend/internal/utils"
+	"github.com/jwallace145/crux-backend/models"
+)
+
+// GetGymBusyness handles GET /gyms/:id/busyness requests for the estimated current occupancy of a
+// gym and a typical busyness histogram by day of week and hour. Both are built from check-ins and
+// training sessions at the gym over the last models.GymBusynessHistoryWeeks weeks. Only anonymous
+// aggregates are returned, and counts below models.GymBusynessMinCount are suppressed
+// Requires AuthMiddleware to be applied
+func GetGymBusyness(c *fiber.Ctx) error {
+	apiName := "get_gym_busyness"
+	log := utils.GetLoggerFromContext(c)
+
+	log.Info("Starting get gym busyness process",
+		zap.String("api", apiName),
+	)
+
+	gym, err := loadGym(c, apiName)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	visits, err := services.GymVisits(db.DB, gym.ID, now)
+	if err != nil {
+		log.Error("Database error while querying gym visits",
+			zap.Error(err),
+			zap.String("api", apiName),
+			zap.Uint("gym_id", gym.ID),
+		)
+		return handlers.InternalErrorResponse(c, apiName, "Failed to estimate gym busyness", nil)
+	}
+
+	// The histogram is in the gym's local time when its timezone is known
+	loc, ok := gym.Location()
+	if !ok {
+		loc = time.UTC
+	}
+
+	response := models.BuildGymBusyness(gym.ID, visits, loc, now)
+
+	log.Info("Gym busyness estimated successfully",
+		zap.String("api", apiName),
+		zap.Uint("gym_id", gym.ID),
+		zap.Int("visits", len(visits)),
+		zap.Int("weeks_of_data", response.WeeksOfData),
+		zap.String("level", response.Current.Level),
+	)
+
+	return handlers.SuccessResponse(c, apiName, response, "Gym busyness retrieved successfully")
+}
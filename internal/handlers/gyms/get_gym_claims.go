@@ -0,0 +1,134 @@
+package gyms
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+
+	"github.com/jwallace145/crux-backend/internal/db"
+	"github.com/jwallace145/crux-backend/internal/handlers"
+	"github.com/jwallace145/crux-backend/internal/query"
+	"github.com/jwallace145/crux-backend/internal/services"
+	"github.com/jwallace145/crux-backend/internal/utils"
+	"github.com/jwallace145/crux-backend/models"
+)
+
+// gymClaimListSpec defines the whitelisted sort fields and filters for GET /gyms/claims
+var gymClaimListSpec = &query.Spec{
+	SortFields: map[string]query.SortField{
+		"created_at": {Column: "created_at", Type: query.TypeTime},
+		"id":         {Column: "id", Type: query.TypeUint},
+	},
+	DefaultSort: "created_at",
+	Filters: []query.Filter{
+		{Param: "status", Column: "status", Type: query.TypeString, Kind: query.FilterEquals},
+		{Param: "gym_id", Column: "gym_id", Type: query.TypeUint, Kind: query.FilterEquals},
+	},
+}
+
+// GetGymClaims handles GET /gyms/claims requests to list gym ownership claims
+// Admins see every claim so they can work through the review queue, other users only see their own
+// Query parameters:
+//   - status (optional): Only claims with this status ("pending", "approved" or "rejected")
+//   - gym_id (optional): Only claims for this gym
+//   - sort (optional): Comma separated sort fields, prefix with "-" for descending (default "created_at")
+//   - limit (optional): Page size (default 50, max 200)
+//   - cursor (optional): The next_cursor value returned by the previous page
+//
+// Requires AuthMiddleware to be applied - reads user_id from context
+func GetGymClaims(c *fiber.Ctx) error {
+	apiName := "get_gym_claims"
+	log := utils.GetLoggerFromContext(c)
+
+	log.Info("Starting get gym claims process",
+		zap.String("api", apiName),
+	)
+
+	// Get user ID from context (set by AuthMiddleware)
+	userID, ok := c.Locals("user_id").(uint)
+	if !ok {
+		log.Error("User ID not found in context",
+			zap.String("api", apiName),
+		)
+		return handlers.InternalErrorResponse(c, apiName, "Authentication context missing", nil)
+	}
+
+	if status := c.Query("status"); status != "" && !models.IsValidGymClaimStatus(status) {
+		return handlers.BadRequestResponse(c, apiName, "status must be 'pending', 'approved' or 'rejected'", nil)
+	}
+
+	// Parse pagination, filtering and sorting query parameters
+	params, err := query.Parse(c, gymClaimListSpec)
+	if err != nil {
+		log.Warn("Invalid list query parameters",
+			zap.Error(err),
+			zap.String("api", apiName),
+		)
+		return handlers.BadRequestResponse(c, apiName, err.Error(), nil)
+	}
+
+	isAdmin, err := services.IsAdmin(db.DB, userID)
+	if err != nil {
+		log.Error("Database error while checking user role",
+			zap.Error(err),
+			zap.String("api", apiName),
+			zap.Uint("user_id", userID),
+		)
+		return handlers.InternalErrorResponse(c, apiName, "Failed to verify user role", nil)
+	}
+
+	claimQuery := db.DB.Model(&models.GymClaim{})
+	if !isAdmin {
+		claimQuery = claimQuery.Where("user_id = ?", userID)
+	}
+
+	var claims []models.GymClaim
+	if err := params.Apply(claimQuery).
+		Preload("Gym").
+		Preload("User").
+		Find(&claims).Error; err != nil {
+		log.Error("Database error while querying gym claims",
+			zap.Error(err),
+			zap.String("api", apiName),
+			zap.Uint("user_id", userID),
+		)
+		return handlers.InternalErrorResponse(c, apiName, "Failed to retrieve gym claims", nil)
+	}
+
+	claims, nextCursor, err := query.Paginate(params, claims, gymClaimCursorKey)
+	if err != nil {
+		log.Error("Failed to encode next page cursor",
+			zap.Error(err),
+			zap.String("api", apiName),
+		)
+		return handlers.InternalErrorResponse(c, apiName, "Failed to paginate gym claims", nil)
+	}
+
+	claimResponses := make([]*models.GymClaimResponse, len(claims))
+	for i := range claims {
+		claimResponses[i] = claims[i].ToGymClaimResponse()
+	}
+
+	log.Info("Gym claims retrieved successfully",
+		zap.String("api", apiName),
+		zap.Uint("user_id", userID),
+		zap.Bool("is_admin", isAdmin),
+		zap.Int("count", len(claimResponses)),
+	)
+
+	responseData := map[string]interface{}{
+		"gym_claims":  claimResponses,
+		"count":       len(claimResponses),
+		"limit":       params.Limit,
+		"next_cursor": nextCursor,
+	}
+
+	return handlers.SuccessResponse(c, apiName, responseData, "Gym claims retrieved successfully")
+}
+
+// gymClaimCursorKey returns the sort field values of a claim used to build the next page cursor
+func gymClaimCursorKey(claim models.GymClaim) query.Key {
+	return query.Key{
+		"id":         claim.ID,
+		"created_at": claim.CreatedAt,
+	}
+}
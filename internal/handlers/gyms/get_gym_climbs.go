@@ -0,0 +1,226 @@
+package gyms
+
+import (
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+
+	"github.com/jwallace145/crux-backend/internal/db"
+	"github.com/jwallace145/crux-backend/internal/handlers"
+	"github.com/jwallace145/crux-backend/internal/query"
+	"github.com/jwallace145/crux-backend/internal/services"
+	"github.com/jwallace145/crux-backend/internal/utils"
+	"github.com/jwallace145/crux-backend/models"
+)
+
+// Gym climb status filter values
+const (
+	gymClimbStatusCurrent  = "current"
+	gymClimbStatusStripped = "stripped"
+	gymClimbStatusAll      = "all"
+)
+
+// gymClimbListSpec defines the whitelisted sort fields and filters for GET /gyms/:id/climbs
+var gymClimbListSpec = &query.Spec{
+	SortFields: map[string]query.SortField{
+		"set_date":   {Column: "set_date", Type: query.TypeTime},
+		"created_at": {Column: "created_at", Type: query.TypeTime},
+		"id":         {Column: "id", Type: query.TypeUint},
+	},
+	DefaultSort: "-set_date",
+	Filters: []query.Filter{
+		{Param: "discipline", Column: "discipline", Type: query.TypeString, Kind: query.FilterEquals},
+		{Param: "wall_id", Column: "gym_wall_id", Type: query.TypeUint, Kind: query.FilterEquals},
+		{Param: "grade", Column: "grade", Kind: query.FilterGradeRange},
+	},
+}
+
+// GetGymClimbs handles GET /gyms/:id/climbs requests to list the problems and routes in a gym's catalog
+// Each climb includes its ascents and community grade aggregated from the climbs logged against it
+// Query parameters:
+//   - status (optional): "current" (default), "stripped" or "all"
+//   - discipline (optional): "boulder" or "rope"
+//   - wall_id (optional): Only climbs on this wall
+//   - color (optional): Only climbs with this hold color (case-insensitive)
+//   - grade_min, grade_max (optional): Only climbs graded within the range by the setter
+//   - style (optional): Only climbs with this style tag
+//   - sort (optional): Comma separated sort fields, prefix with "-" for descending (default "-set_date")
+//   - limit (optional): Page size (default 50, max 200)
+//   - cursor (optional): The next_cursor value returned by the previous page
+//
+// Requires AuthMiddleware to be applied
+func GetGymClimbs(c *fiber.Ctx) error {
+	apiName := "get_gym_climbs"
+	log := utils.GetLoggerFromContext(c)
+
+	log.Info("Starting get gym climbs process",
+		zap.String("api", apiName),
+	)
+
+	gym, err := loadGym(c, apiName)
+	if err != nil {
+		return err
+	}
+
+	status := c.Query("status", gymClimbStatusCurrent)
+	if status != gymClimbStatusCurrent && status != gymClimbStatusStripped && status != gymClimbStatusAll {
+		return handlers.BadRequestResponse(c, apiName, "status must be 'current', 'stripped' or 'all'", nil)
+	}
+	if discipline := c.Query("discipline"); discipline != "" && !models.IsValidGymClimbDiscipline(discipline) {
+		return handlers.BadRequestResponse(c, apiName, "discipline must be 'boulder' or 'rope'", nil)
+	}
+	style := c.Query("style")
+	if style != "" && !models.IsValidStyleTag(style) {
+		return handlers.BadRequestResponse(c, apiName, "style must be a known style tag (e.g., crimpy, overhang)", nil)
+	}
+
+	// Parse pagination, filtering and sorting query parameters
+	params, err := query.Parse(c, gymClimbListSpec)
+	if err != nil {
+		log.Warn("Invalid list query parameters",
+			zap.Error(err),
+			zap.String("api", apiName),
+		)
+		return handlers.BadRequestResponse(c, apiName, err.Error(), nil)
+	}
+
+	climbQuery := db.DB.Model(&models.GymClimb{}).Where("gym_id = ?", gym.ID)
+	switch now := time.Now(); status {
+	case gymClimbStatusCurrent:
+		climbQuery = climbQuery.Where("strip_date IS NULL OR strip_date > ?", now)
+	case gymClimbStatusStripped:
+		climbQuery = climbQuery.Where("strip_date <= ?", now)
+	}
+	if color := c.Query("color"); color != "" {
+		climbQuery = climbQuery.Where("LOWER(color) = LOWER(?)", color)
+	}
+	if style != "" {
+		climbQuery = climbQuery.Where("style_tags @> ?::jsonb", models.StyleTags{style})
+	}
+
+	var climbs []models.GymClimb
+	if err := params.Apply(climbQuery).Preload("GymWall").Find(&climbs).Error; err != nil {
+		log.Error("Database error while querying gym climbs",
+			zap.Error(err),
+			zap.String("api", apiName),
+			zap.Uint("gym_id", gym.ID),
+		)
+		return handlers.InternalErrorResponse(c, apiName, "Failed to retrieve gym climbs", nil)
+	}
+
+	climbs, nextCursor, err := query.Paginate(params, climbs, gymClimbCursorKey)
+	if err != nil {
+		log.Error("Failed to encode next page cursor",
+			zap.Error(err),
+			zap.String("api", apiName),
+		)
+		return handlers.InternalErrorResponse(c, apiName, "Failed to paginate gym climbs", nil)
+	}
+
+	stats, err := services.GymClimbStats(db.DB, climbs)
+	if err != nil {
+		log.Error("Database error while aggregating gym climb logs",
+			zap.Error(err),
+			zap.String("api", apiName),
+			zap.Uint("gym_id", gym.ID),
+		)
+		return handlers.InternalErrorResponse(c, apiName, "Failed to retrieve gym climbs", nil)
+	}
+
+	climbResponses := make([]*models.GymClimbResponse, len(climbs))
+	for i := range climbs {
+		climbResponses[i] = climbs[i].ToGymClimbResponse()
+		climbResponses[i].Stats = stats[climbs[i].ID]
+		services.PresignGymClimbPhoto(c.Context(), &climbs[i], climbResponses[i])
+	}
+
+	log.Info("Gym climbs retrieved successfully",
+		zap.String("api", apiName),
+		zap.Uint("gym_id", gym.ID),
+		zap.String("status", status),
+		zap.Int("count", len(climbResponses)),
+	)
+
+	responseData := map[string]interface{}{
+		"gym_id":      gym.ID,
+		"climbs":      climbResponses,
+		"count":       len(climbResponses),
+		"status":      status,
+		"filters":     params.Applied,
+		"limit":       params.Limit,
+		"next_cursor": nextCursor,
+	}
+
+	return handlers.SuccessResponse(c, apiName, responseData, "Gym climbs retrieved successfully")
+}
+
+// GetGymClimb handles GET /gyms/:id/climbs/:climb_id requests to retrieve a gym catalog climb
+// with its ascents and community grade
+// Requires AuthMiddleware to be applied
+func GetGymClimb(c *fiber.Ctx) error {
+	apiName := "get_gym_climb"
+	log := utils.GetLoggerFromContext(c)
+
+	log.Info("Starting get gym climb process",
+		zap.String("api", apiName),
+	)
+
+	gym, err := loadGym(c, apiName)
+	if err != nil {
+		return err
+	}
+
+	climb, err := loadGymClimb(c, apiName, gym.ID)
+	if err != nil {
+		return err
+	}
+
+	return respondWithGymClimb(c, apiName, climb.ID, "Gym climb retrieved successfully")
+}
+
+// respondWithGymClimb loads a gym climb with its wall, stats and photo URL and sends it as the response
+func respondWithGymClimb(c *fiber.Ctx, apiName string, climbID uint, message string) error {
+	log := utils.GetLoggerFromContext(c)
+
+	var climb models.GymClimb
+	if err := db.DB.Preload("GymWall").First(&climb, climbID).Error; err != nil {
+		log.Error("Failed to load gym climb",
+			zap.Error(err),
+			zap.String("api", apiName),
+			zap.Uint("gym_climb_id", climbID),
+		)
+		return handlers.InternalErrorResponse(c, apiName, "Failed to retrieve gym climb", nil)
+	}
+
+	stats, err := services.GymClimbStats(db.DB, []models.GymClimb{climb})
+	if err != nil {
+		log.Error("Database error while aggregating gym climb logs",
+			zap.Error(err),
+			zap.String("api", apiName),
+			zap.Uint("gym_climb_id", climbID),
+		)
+		return handlers.InternalErrorResponse(c, apiName, "Failed to retrieve gym climb", nil)
+	}
+
+	response := climb.ToGymClimbResponse()
+	response.Stats = stats[climb.ID]
+	services.PresignGymClimbPhoto(c.Context(), &climb, response)
+
+	log.Info("Gym climb retrieved",
+		zap.String("api", apiName),
+		zap.Uint("gym_climb_id", climb.ID),
+		zap.Int("ascents", response.Stats.Ascents),
+	)
+
+	return handlers.SuccessResponse(c, apiName, response, message)
+}
+
+// gymClimbCursorKey returns the sort field values of a gym climb used to build the next page cursor
+func gymClimbCursorKey(climb models.GymClimb) query.Key {
+	return query.Key{
+		"id":         climb.ID,
+		"set_date":   climb.SetDate,
+		"created_at": climb.CreatedAt,
+	}
+}
@@ -0,0 +1,107 @@
+package gyms
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+
+	"github.com/jwallace145/crux-backend/internal/db"
+	"github.com/jwallace145/crux-backend/internal/handlers"
+	"github.com/jwallace145/crux-backend/internal/query"
+	"github.com/jwallace145/crux-backend/internal/utils"
+	"github.com/jwallace145/crux-backend/models"
+)
+
+// gymReviewReportListSpec defines the whitelisted sort fields and filters for GET /gyms/reviews/reports
+var gymReviewReportListSpec = &query.Spec{
+	SortFields: map[string]query.SortField{
+		"created_at": {Column: "created_at", Type: query.TypeTime},
+		"id":         {Column: "id", Type: query.TypeUint},
+	},
+	DefaultSort: "created_at",
+	Filters: []query.Filter{
+		{Param: "status", Column: "status", Type: query.TypeString, Kind: query.FilterEquals},
+		{Param: "reason", Column: "reason", Type: query.TypeString, Kind: query.FilterEquals},
+		{Param: "gym_review_id", Column: "gym_review_id", Type: query.TypeUint, Kind: query.FilterEquals},
+	},
+}
+
+// GetGymReviewReports handles GET /gyms/reviews/reports requests from admins to work through the
+// review moderation queue. Each report includes the reported review
+// Query parameters:
+//   - status (optional): Only reports with this status ("pending", "upheld" or "dismissed")
+//   - reason (optional): Only reports with this reason
+//   - gym_review_id (optional): Only reports for this review
+//   - sort (optional): Comma separated sort fields, prefix with "-" for descending (default "created_at")
+//   - limit (optional): Page size (default 50, max 200)
+//   - cursor (optional): The next_cursor value returned by the previous page
+//
+// Requires AuthMiddleware and AdminMiddleware to be applied
+func GetGymReviewReports(c *fiber.Ctx) error {
+	apiName := "get_gym_review_reports"
+	log := utils.GetLoggerFromContext(c)
+
+	log.Info("Starting get gym review reports process",
+		zap.String("api", apiName),
+	)
+
+	if status := c.Query("status"); status != "" && !models.IsValidGymReviewReportStatus(status) {
+		return handlers.BadRequestResponse(c, apiName, "status must be 'pending', 'upheld' or 'dismissed'", nil)
+	}
+
+	// Parse pagination, filtering and sorting query parameters
+	params, err := query.Parse(c, gymReviewReportListSpec)
+	if err != nil {
+		log.Warn("Invalid list query parameters",
+			zap.Error(err),
+			zap.String("api", apiName),
+		)
+		return handlers.BadRequestResponse(c, apiName, err.Error(), nil)
+	}
+
+	var reports []models.GymReviewReport
+	if err := params.Apply(db.DB.Model(&models.GymReviewReport{})).
+		Preload("GymReview.User").
+		Find(&reports).Error; err != nil {
+		log.Error("Database error while querying gym review reports",
+			zap.Error(err),
+			zap.String("api", apiName),
+		)
+		return handlers.InternalErrorResponse(c, apiName, "Failed to retrieve gym review reports", nil)
+	}
+
+	reports, nextCursor, err := query.Paginate(params, reports, gymReviewReportCursorKey)
+	if err != nil {
+		log.Error("Failed to encode next page cursor",
+			zap.Error(err),
+			zap.String("api", apiName),
+		)
+		return handlers.InternalErrorResponse(c, apiName, "Failed to paginate gym review reports", nil)
+	}
+
+	reportResponses := make([]*models.GymReviewReportResponse, len(reports))
+	for i := range reports {
+		reportResponses[i] = reports[i].ToGymReviewReportResponseWithReview()
+	}
+
+	log.Info("Gym review reports retrieved successfully",
+		zap.String("api", apiName),
+		zap.Int("count", len(reportResponses)),
+	)
+
+	responseData := map[string]interface{}{
+		"reports":     reportResponses,
+		"count":       len(reportResponses),
+		"limit":       params.Limit,
+		"next_cursor": nextCursor,
+	}
+
+	return handlers.SuccessResponse(c, apiName, responseData, "Gym review reports retrieved successfully")
+}
+
+// gymReviewReportCursorKey returns the sort field values of a report used to build the next page cursor
+func gymReviewReportCursorKey(report models.GymReviewReport) query.Key {
+	return query.Key{
+		"id":         report.ID,
+		"created_at": report.CreatedAt,
+	}
+}
@@ -0,0 +1,139 @@
+package gyms
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	"github.com/jwallace145/crux-backend/internal/db"
+	"github.com/jwallace145/crux-backend/internal/handlers"
+	"github.com/jwallace145/crux-backend/internal/query"
+	"github.com/jwallace145/crux-backend/internal/utils"
+	"github.com/jwallace145/crux-backend/models"
+)
+
+// gymReviewListSpec defines the whitelisted sort fields and filters for GET /gyms/:id/reviews
+var gymReviewListSpec = &query.Spec{
+	SortFields: map[string]query.SortField{
+		"created_at": {Column: "created_at", Type: query.TypeTime},
+		"updated_at": {Column: "updated_at", Type: query.TypeTime},
+		"rating":     {Column: "overall_rating", Type: query.TypeInt},
+		"id":         {Column: "id", Type: query.TypeUint},
+	},
+	DefaultSort: "-created_at",
+	Filters: []query.Filter{
+		{Param: "rating", Column: "overall_rating", Type: query.TypeInt, Kind: query.FilterEquals},
+	},
+}
+
+// GetGymReviews handles GET /gyms/:id/reviews requests to list the visible reviews of a gym
+// The authenticated user's own review is returned separately as my_review, including when it has
+// been hidden, so clients can offer to edit it
+// Query parameters:
+//   - rating (optional): Only reviews with this overall rating
+//   - sort (optional): Comma separated sort fields, prefix with "-" for descending (default "-created_at")
+//   - limit (optional): Page size (default 50, max 200)
+//   - cursor (optional): The next_cursor value returned by the previous page
+//
+// Requires AuthMiddleware to be applied - reads user_id from context
+func GetGymReviews(c *fiber.Ctx) error {
+	apiName := "get_gym_reviews"
+	log := utils.GetLoggerFromContext(c)
+
+	log.Info("Starting get gym reviews process",
+		zap.String("api", apiName),
+	)
+
+	// Get user ID from context (set by AuthMiddleware)
+	userID, ok := c.Locals("user_id").(uint)
+	if !ok {
+		log.Error("User ID not found in context",
+			zap.String("api", apiName),
+		)
+		return handlers.InternalErrorResponse(c, apiName, "Authentication context missing", nil)
+	}
+
+	gym, err := loadGym(c, apiName)
+	if err != nil {
+		return err
+	}
+
+	// Parse pagination, filtering and sorting query parameters
+	params, err := query.Parse(c, gymReviewListSpec)
+	if err != nil {
+		log.Warn("Invalid list query parameters",
+			zap.Error(err),
+			zap.String("api", apiName),
+		)
+		return handlers.BadRequestResponse(c, apiName, err.Error(), nil)
+	}
+
+	var reviews []models.GymReview
+	if err := params.Apply(db.DB.Where("gym_id = ? AND hidden = ?", gym.ID, false)).
+		Preload("User").
+		Find(&reviews).Error; err != nil {
+		log.Error("Database error while querying gym reviews",
+			zap.Error(err),
+			zap.String("api", apiName),
+			zap.Uint("gym_id", gym.ID),
+		)
+		return handlers.InternalErrorResponse(c, apiName, "Failed to retrieve gym reviews", nil)
+	}
+
+	reviews, nextCursor, err := query.Paginate(params, reviews, gymReviewCursorKey)
+	if err != nil {
+		log.Error("Failed to encode next page cursor",
+			zap.Error(err),
+			zap.String("api", apiName),
+		)
+		return handlers.InternalErrorResponse(c, apiName, "Failed to paginate gym reviews", nil)
+	}
+
+	var myReview *models.GymReviewResponse
+	var own models.GymReview
+	err = db.DB.Preload("User").Where("gym_id = ? AND user_id = ?", gym.ID, userID).First(&own).Error
+	if err != nil && err != gorm.ErrRecordNotFound {
+		log.Error("Database error while looking up the user's gym review",
+			zap.Error(err),
+			zap.String("api", apiName),
+			zap.Uint("gym_id", gym.ID),
+		)
+		return handlers.InternalErrorResponse(c, apiName, "Failed to retrieve gym reviews", nil)
+	}
+	if err == nil {
+		myReview = own.ToGymReviewResponse().WithModeration(&own)
+	}
+
+	reviewResponses := make([]*models.GymReviewResponse, len(reviews))
+	for i := range reviews {
+		reviewResponses[i] = reviews[i].ToGymReviewResponse()
+	}
+
+	log.Info("Gym reviews retrieved successfully",
+		zap.String("api", apiName),
+		zap.Uint("gym_id", gym.ID),
+		zap.Int("count", len(reviewResponses)),
+		zap.Bool("has_more", nextCursor != ""),
+	)
+
+	responseData := map[string]interface{}{
+		"gym_id":      gym.ID,
+		"reviews":     reviewResponses,
+		"count":       len(reviewResponses),
+		"my_review":   myReview,
+		"limit":       params.Limit,
+		"next_cursor": nextCursor,
+	}
+
+	return handlers.SuccessResponse(c, apiName, responseData, "Gym reviews retrieved successfully")
+}
+
+// gymReviewCursorKey returns the sort field values of a review used to build the next page cursor
+func gymReviewCursorKey(review models.GymReview) query.Key {
+	return query.Key{
+		"id":         review.ID,
+		"created_at": review.CreatedAt,
+		"updated_at": review.UpdatedAt,
+		"rating":     review.OverallRating,
+	}
+}
@@ -0,0 +1,114 @@
+package gyms
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	"github.com/jwallace145/crux-backend/internal/db"
+	"github.com/jwallace145/crux-backend/internal/handlers"
+	"github.com/jwallace145/crux-backend/internal/utils"
+	"github.com/jwallace145/crux-backend/models"
+)
+
+// GetGymWalls handles GET /gyms/:id/walls requests to list the walls of a gym
+// Each wall includes the number of problems and routes currently set on it
+// Requires AuthMiddleware to be applied
+func GetGymWalls(c *fiber.Ctx) error {
+	apiName := "get_gym_walls"
+	log := utils.GetLoggerFromContext(c)
+
+	log.Info("Starting get gym walls process",
+		zap.String("api", apiName),
+	)
+
+	gym, err := loadGym(c, apiName)
+	if err != nil {
+		return err
+	}
+
+	var walls []models.GymWall
+	if err := db.DB.Where("gym_id = ?", gym.ID).Order("name ASC, id ASC").Find(&walls).Error; err != nil {
+		log.Error("Database error while querying gym walls",
+			zap.Error(err),
+			zap.String("api", apiName),
+			zap.Uint("gym_id", gym.ID),
+		)
+		return handlers.InternalErrorResponse(c, apiName, "Failed to retrieve gym walls", nil)
+	}
+
+	// Count the climbs currently set on each wall
+	var counts []struct {
+		GymWallID uint
+		Count     int
+	}
+	if err := db.DB.Model(&models.GymClimb{}).
+		Select("gym_wall_id, COUNT(*) AS count").
+		Where("gym_id = ? AND gym_wall_id IS NOT NULL", gym.ID).
+		Where("strip_date IS NULL OR strip_date > ?", time.Now()).
+		Group("gym_wall_id").
+		Scan(&counts).Error; err != nil {
+		log.Error("Database error while counting gym wall climbs",
+			zap.Error(err),
+			zap.String("api", apiName),
+			zap.Uint("gym_id", gym.ID),
+		)
+		return handlers.InternalErrorResponse(c, apiName, "Failed to retrieve gym walls", nil)
+	}
+	currentClimbs := make(map[uint]int, len(counts))
+	for _, count := range counts {
+		currentClimbs[count.GymWallID] = count.Count
+	}
+
+	wallResponses := make([]*models.GymWallResponse, len(walls))
+	for i := range walls {
+		wallResponses[i] = walls[i].ToGymWallResponse()
+		count := currentClimbs[walls[i].ID]
+		wallResponses[i].CurrentClimbs = &count
+	}
+
+	log.Info("Gym walls retrieved successfully",
+		zap.String("api", apiName),
+		zap.Uint("gym_id", gym.ID),
+		zap.Int("count", len(wallResponses)),
+	)
+
+	responseData := map[string]interface{}{
+		"gym_id": gym.ID,
+		"walls":  wallResponses,
+		"count":  len(wallResponses),
+	}
+
+	return handlers.SuccessResponse(c, apiName, responseData, "Gym walls retrieved successfully")
+}
+
+// loadGym looks up the gym in the :id path parameter for read-only catalog endpoints
+func loadGym(c *fiber.Ctx, apiName string) (*models.Gym, error) {
+	log := utils.GetLoggerFromContext(c)
+
+	gymID, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return nil, handlers.BadRequestResponse(c, apiName, "id must be a valid number", nil)
+	}
+
+	var gym models.Gym
+	if err := db.DB.First(&gym, gymID).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			log.Warn("Gym not found",
+				zap.String("api", apiName),
+				zap.Uint64("gym_id", gymID),
+			)
+			return nil, handlers.NotFoundResponse(c, apiName, "Gym not found")
+		}
+		log.Error("Database error while looking up gym",
+			zap.Error(err),
+			zap.String("api", apiName),
+		)
+		return nil, handlers.InternalErrorResponse(c, apiName, "Failed to retrieve gym", nil)
+	}
+
+	return &gym, nil
+}
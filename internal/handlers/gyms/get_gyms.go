@@ -1,7 +1,11 @@
 package gyms
 
 import (
+	"fmt"
+	"math"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/gofiber/fiber/v2"
 	"go.uber.org/zap"
@@ -9,17 +13,53 @@ import (
 
 	"github.com/jwallace145/crux-backend/internal/db"
 	"github.com/jwallace145/crux-backend/internal/handlers"
+	"github.com/jwallace145/crux-backend/internal/query"
+	"github.com/jwallace145/crux-backend/internal/services"
 	"github.com/jwallace145/crux-backend/internal/utils"
 	"github.com/jwallace145/crux-backend/models"
 )
 
+// gymListSpec defines the whitelisted sort fields and filters for GET /gyms
+var gymListSpec = &query.Spec{
+	SortFields: map[string]query.SortField{
+		"name":       {Column: "name", Type: query.TypeString},
+		"city":       {Column: "city", Type: query.TypeString},
+		"created_at": {Column: "created_at", Type: query.TypeTime},
+		"rating":     {Column: "rating_average", Type: query.TypeFloat},
+		"reviews":    {Column: "rating_count", Type: query.TypeInt},
+		"id":         {Column: "id", Type: query.TypeUint},
+	},
+	DefaultSort: "name",
+	Filters: []query.Filter{
+		{Param: "state", Column: "state", Type: query.TypeString, Kind: query.FilterEquals},
+		{Param: "city", Column: "city", Type: query.TypeString, Kind: query.FilterEquals},
+		{Param: "type", Column: "type", Type: query.TypeString, Kind: query.FilterEquals},
+		{Param: "active", Column: "active", Type: query.TypeBool, Kind: query.FilterEquals},
+	},
+}
+
 // GetGyms handles GET /gyms requests to retrieve gyms
 // Query parameters:
 //   - id (optional): The ID of a specific gym to retrieve
 //   - state (optional): Filter gyms by state
 //   - city (optional): Filter gyms by city
+//   - type (optional): Filter gyms by type (bouldering, roped, full)
+//   - active (optional): "true" or "false"
+//   - amenities (optional): Comma separated amenity slugs the gym must all have (e.g., "lead,shower").
+//     See GET /gyms/amenities for the catalog
+//   - sort (optional): Comma separated sort fields, prefix with "-" for descending (default "name").
+//     Sort by "-rating" for the highest rated gyms first, unreviewed gyms have a rating of 0
+//   - limit (optional): Page size (default 50, max 200)
+//   - cursor (optional): The next_cursor value returned by the previous page
+//   - near (optional): "lat,lng" to return the gyms within radius_km of the point, nearest first
+//   - radius_km (optional): Search radius for near (default 25, max 500)
+//   - bbox (optional): "min_lat,min_lng,max_lat,max_lng" to return the gyms inside the box, nearest first
+//   - open_now (optional): "true" for gyms open right now in their own timezone, "false" for the rest
+//   - max_day_pass_price (optional): Gyms with a current adult day pass at or below this price, in
+//     major units of currency (e.g., 25.50). Prices in other currencies are converted with services.FXRates
+//   - currency (optional): ISO 4217 currency of max_day_pass_price (default USD)
 //
-// If id is not provided, returns all gyms (optionally filtered by state/city)
+// If id is not provided, returns a page of gyms (optionally filtered)
 // If id is provided, returns the specific gym with that ID
 func GetGyms(c *fiber.Ctx) error {
 	apiName := "get_gyms"
@@ -29,18 +69,18 @@ func GetGyms(c *fiber.Ctx) error {
 		zap.String("api", apiName),
 	)
 
-	// Get optional query parameters
-	idStr := c.Query("id")
-	state := c.Query("state")
-	city := c.Query("city")
-
 	// If ID is provided, return specific gym
-	if idStr != "" {
+	if idStr := c.Query("id"); idStr != "" {
 		return getGymByID(c, apiName, log, idStr)
 	}
 
-	// Otherwise, return all gyms (optionally filtered by state/city)
-	return getAllGyms(c, apiName, log, state, city)
+	// If a location is provided, return the nearest gyms
+	if c.Query("near") != "" || c.Query("bbox") != "" {
+		return getNearbyGyms(c, apiName, log)
+	}
+
+	// Otherwise, return a page of gyms (optionally filtered)
+	return getAllGyms(c, apiName, log)
 }
 
 // getGymByID retrieves a specific gym by ID
@@ -67,7 +107,7 @@ func getGymByID(c *fiber.Ctx, apiName string, log *zap.Logger, idStr string) err
 
 	// Fetch gym from db
 	var gym models.Gym
-	if err := db.DB.Where("id = ?", uint(gymID)).First(&gym).Error; err != nil {
+	if err := preloadGymDetails(db.DB).Where("id = ?", uint(gymID)).First(&gym).Error; err != nil {
 		if err == gorm.ErrRecordNotFound {
 			log.Warn("Gym not found",
 				zap.String("api", apiName),
@@ -101,35 +141,41 @@ func getGymByID(c *fiber.Ctx, apiName string, log *zap.Logger, idStr string) err
 	return handlers.SuccessResponse(c, apiName, response, "Gym retrieved successfully")
 }
 
-// getAllGyms retrieves all gyms from the database with optional filtering
-func getAllGyms(c *fiber.Ctx, apiName string, log *zap.Logger, state, city string) error {
-	logFields := []zap.Field{zap.String("api", apiName)}
-
-	if state != "" {
-		logFields = append(logFields, zap.String("state", state))
-	}
-	if city != "" {
-		logFields = append(logFields, zap.String("city", city))
+// getAllGyms retrieves a page of gyms from the database with optional filtering and sorting
+func getAllGyms(c *fiber.Ctx, apiName string, log *zap.Logger) error {
+	// Parse pagination, filtering and sorting query parameters
+	params, err := query.Parse(c, gymListSpec)
+	if err != nil {
+		log.Warn("Invalid list query parameters",
+			zap.Error(err),
+			zap.String("api", apiName),
+		)
+		return handlers.BadRequestResponse(c, apiName, err.Error(), nil)
 	}
 
-	log.Info("Retrieving gyms", logFields...)
-
-	// Build query with optional filters
-	var gyms []models.Gym
-	query := db.DB.Order("name ASC")
+	log.Info("Retrieving gyms",
+		zap.String("api", apiName),
+		zap.Int("limit", params.Limit),
+		zap.Bool("has_cursor", params.Cursor != nil),
+		zap.Any("filters", params.Applied),
+	)
 
-	// Apply state filter if provided
-	if state != "" {
-		query = query.Where("state = ?", state)
+	gymQuery, err := applyOpenNowFilter(c, db.DB)
+	if err != nil {
+		return handlers.BadRequestResponse(c, apiName, err.Error(), nil)
 	}
-
-	// Apply city filter if provided
-	if city != "" {
-		query = query.Where("city = ?", city)
+	gymQuery, err = applyDayPassPriceFilter(c, gymQuery)
+	if err != nil {
+		return handlers.BadRequestResponse(c, apiName, err.Error(), nil)
+	}
+	gymQuery, err = applyAmenitiesFilter(c, gymQuery)
+	if err != nil {
+		return handlers.BadRequestResponse(c, apiName, err.Error(), nil)
 	}
 
 	// Execute query
-	if err := query.Find(&gyms).Error; err != nil {
+	var gyms []models.Gym
+	if err := preloadGymDetails(params.Apply(gymQuery)).Find(&gyms).Error; err != nil {
 		log.Error("Database error while querying gyms",
 			zap.Error(err),
 			zap.String("api", apiName),
@@ -137,9 +183,19 @@ func getAllGyms(c *fiber.Ctx, apiName string, log *zap.Logger, state, city strin
 		return handlers.InternalErrorResponse(c, apiName, "Failed to retrieve gyms", nil)
 	}
 
+	gyms, nextCursor, err := query.Paginate(params, gyms, gymCursorKey)
+	if err != nil {
+		log.Error("Failed to encode next page cursor",
+			zap.Error(err),
+			zap.String("api", apiName),
+		)
+		return handlers.InternalErrorResponse(c, apiName, "Failed to paginate gyms", nil)
+	}
+
 	log.Info("Gyms retrieved successfully",
 		zap.String("api", apiName),
 		zap.Int("count", len(gyms)),
+		zap.Bool("has_more", nextCursor != ""),
 	)
 
 	// Convert gyms to response DTOs
@@ -150,8 +206,10 @@ func getAllGyms(c *fiber.Ctx, apiName string, log *zap.Logger, state, city strin
 
 	// Prepare response
 	responseData := map[string]interface{}{
-		"gyms":  gymResponses,
-		"count": len(gymResponses),
+		"gyms":        gymResponses,
+		"count":       len(gymResponses),
+		"limit":       params.Limit,
+		"next_cursor": nextCursor,
 	}
 
 	log.Info("Get all gyms completed successfully",
@@ -161,3 +219,146 @@ func getAllGyms(c *fiber.Ctx, apiName string, log *zap.Logger, state, city strin
 
 	return handlers.SuccessResponse(c, apiName, responseData, "Gyms retrieved successfully")
 }
+
+// gymCursorKey returns the sort field values of a gym used to build the next page cursor
+func gymCursorKey(gym models.Gym) query.Key {
+	return query.Key{
+		"id":         gym.ID,
+		"name":       gym.Name,
+		"city":       gym.City,
+		"created_at": gym.CreatedAt,
+		"rating":     gym.RatingAverage,
+		"reviews":    gym.RatingCount,
+	}
+}
+
+// gymOpenNowSQL is true when a gym is open at the current time in its timezone. A gym is open
+// when an interval starting today, or running past midnight from yesterday, contains the current
+// local time. Dated exceptions replace the weekly intervals that start on their date. Gyms without
+// a timezone are never open since their local time is unknown
+const gymOpenNowSQL = `gyms.timezone <> '' AND EXISTS (
+	SELECT 1 FROM (
+		SELECT lt::date AS today, (extract(hour FROM lt) * 60 + extract(minute FROM lt))::int AS minute, extract(dow FROM lt)::int AS dow
+		FROM (SELECT now() AT TIME ZONE (CASE WHEN gyms.timezone <> '' THEN gyms.timezone ELSE 'UTC' END) AS lt) AS local_time
+	) AS l
+	WHERE EXISTS (
+		SELECT 1 FROM gym_hours_exceptions e
+		WHERE e.gym_id = gyms.id AND e.deleted_at IS NULL AND NOT e.closed
+			AND ((e.date = l.today AND e.opens_at <= l.minute AND l.minute < e.closes_at)
+				OR (e.date = l.today - 1 AND l.minute < e.closes_at - 1440))
+	) OR (
+		NOT EXISTS (SELECT 1 FROM gym_hours_exceptions e WHERE e.gym_id = gyms.id AND e.deleted_at IS NULL AND e.date = l.today)
+		AND EXISTS (
+			SELECT 1 FROM gym_opening_hours h
+			WHERE h.gym_id = gyms.id AND h.deleted_at IS NULL AND h.day_of_week = l.dow
+				AND h.opens_at <= l.minute AND l.minute < h.closes_at
+		)
+	) OR (
+		NOT EXISTS (SELECT 1 FROM gym_hours_exceptions e WHERE e.gym_id = gyms.id AND e.deleted_at IS NULL AND e.date = l.today - 1)
+		AND EXISTS (
+			SELECT 1 FROM gym_opening_hours h
+			WHERE h.gym_id = gyms.id AND h.deleted_at IS NULL AND h.day_of_week = (l.dow + 6) % 7
+				AND l.minute < h.closes_at - 1440
+		)
+	)
+)`
+
+// applyOpenNowFilter restricts a gym query by the open_now query parameter
+func applyOpenNowFilter(c *fiber.Ctx, tx *gorm.DB) (*gorm.DB, error) {
+	raw := c.Query("open_now")
+	if raw == "" {
+		return tx, nil
+	}
+	openNow, err := strconv.ParseBool(raw)
+	if err != nil {
+		return nil, fiber.NewError(fiber.StatusBadRequest, "open_now must be 'true' or 'false'")
+	}
+	if openNow {
+		return tx.Where(gymOpenNowSQL), nil
+	}
+	return tx.Where("NOT (" + gymOpenNowSQL + ")"), nil
+}
+
+// gymDayPassPriceSQL is true when a gym has an adult day pass valid today whose price, converted
+// into the requested currency by the %s CASE expression, is at most the maximum
+const gymDayPassPriceSQL = `EXISTS (
+	SELECT 1 FROM gym_prices p
+	WHERE p.gym_id = gyms.id AND p.deleted_at IS NULL
+		AND p.product = ? AND p.audience = ?
+		AND (p.valid_from IS NULL OR p.valid_from <= CURRENT_DATE)
+		AND (p.valid_until IS NULL OR p.valid_until >= CURRENT_DATE)
+		AND (CASE p.currency %s END) <= ?
+)`
+
+// applyDayPassPriceFilter restricts a gym query by the max_day_pass_price and currency query
+// parameters. Day passes in currencies without an exchange rate to the requested currency never match
+func applyDayPassPriceFilter(c *fiber.Ctx, tx *gorm.DB) (*gorm.DB, error) {
+	raw := c.Query("max_day_pass_price")
+	currency := strings.ToUpper(strings.TrimSpace(c.Query("currency", models.DefaultCurrency)))
+	if raw == "" {
+		if c.Query("currency") != "" {
+			return nil, fiber.NewError(fiber.StatusBadRequest, "currency can only be used with max_day_pass_price")
+		}
+		return tx, nil
+	}
+	maxPrice, err := strconv.ParseFloat(raw, 64)
+	if err != nil || maxPrice < 0 || math.IsInf(maxPrice, 0) {
+		return nil, fiber.NewError(fiber.StatusBadRequest, "max_day_pass_price must be a non-negative number")
+	}
+	if !models.IsValidCurrency(currency) {
+		return nil, fiber.NewError(fiber.StatusBadRequest, "currency must be one of: "+strings.Join(models.SupportedCurrencies(), ", "))
+	}
+
+	// Convert each price from minor units of its currency into minor units of the requested one
+	cases := []string{}
+	vars := []interface{}{models.GymPriceProductDayPass, models.GymPriceAudienceAdult}
+	for _, from := range models.SupportedCurrencies() {
+		rate, ok := services.FXRates.Rate(from, currency)
+		if !ok {
+			continue
+		}
+		factor := rate * math.Pow10(models.CurrencyMinorUnits[currency]-models.CurrencyMinorUnits[from])
+		cases = append(cases, "WHEN ? THEN p.amount_minor * ?")
+		vars = append(vars, from, factor)
+	}
+	vars = append(vars, float64(models.ToMinorUnits(maxPrice, currency)))
+
+	return tx.Where(fmt.Sprintf(gymDayPassPriceSQL, strings.Join(cases, " ")), vars...), nil
+}
+
+// gymAmenitiesSQL is true when a gym has every one of the given amenities
+const gymAmenitiesSQL = `gyms.id IN (
+	SELECT ga.gym_id FROM gym_amenities ga
+	JOIN amenities a ON a.id = ga.amenity_id AND a.deleted_at IS NULL
+	WHERE a.slug IN ?
+	GROUP BY ga.gym_id
+	HAVING COUNT(DISTINCT a.id) = ?
+)`
+
+// applyAmenitiesFilter restricts a gym query to the gyms with all of the amenities in the amenities
+// query parameter. Slugs that are not in the catalog match no gyms
+func applyAmenitiesFilter(c *fiber.Ctx, tx *gorm.DB) (*gorm.DB, error) {
+	raw := c.Query("amenities")
+	if raw == "" {
+		return tx, nil
+	}
+	slugs, err := models.NormalizeAmenitySlugs(strings.Split(raw, ","))
+	if err != nil {
+		return nil, fiber.NewError(fiber.StatusBadRequest, "Invalid amenities: "+err.Error())
+	}
+	if len(slugs) == 0 {
+		return tx, nil
+	}
+	return tx.Where(gymAmenitiesSQL, slugs, len(slugs)), nil
+}
+
+// preloadGymDetails loads the amenities, the pricing tiers that are current or upcoming, the weekly
+// opening hours and the exceptions that can still affect the open now status or are upcoming
+func preloadGymDetails(tx *gorm.DB) *gorm.DB {
+	today := time.Now().UTC().AddDate(0, 0, -1).Format(models.GymPriceDateFormat)
+	return tx.
+		Preload("Amenities").
+		Preload("Prices", "valid_until IS NULL OR valid_until >= ?", today).
+		Preload("OpeningHours").
+		Preload("HoursExceptions", "date >= ?", time.Now().UTC().AddDate(0, 0, -2).Format(models.GymHoursDateFormat))
+}
@@ -0,0 +1,246 @@
+package gyms
+
+import (
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/jwallace145/crux-backend/internal/db"
+	"github.com/jwallace145/crux-backend/internal/handlers"
+	"github.com/jwallace145/crux-backend/internal/query"
+	"github.com/jwallace145/crux-backend/internal/services"
+	"github.com/jwallace145/crux-backend/models"
+)
+
+const (
+	defaultNearbyRadiusKm = 25
+	maxNearbyRadiusKm     = 500
+
+	// nearbyFallbackCandidates caps the gyms ranked in Go when the db has no spatial index. It is
+	// well above the largest page so the flat approximation used to pick them does not drop gyms
+	// that are nearer by great-circle distance
+	nearbyFallbackCandidates = 500
+)
+
+// nearbySearch holds the parsed near, radius_km and bbox query parameters
+type nearbySearch struct {
+	Latitude  float64
+	Longitude float64
+	RadiusKm  float64 // 0 when only a bounding box was requested
+	Box       models.BoundingBox
+}
+
+// getNearbyGyms retrieves the gyms near a point or inside a bounding box, nearest first
+// The state, city, type, active, amenities, open_now and max_day_pass_price filters still apply.
+// Results are limited to one page since distance ordering cannot be resumed with a cursor
+func getNearbyGyms(c *fiber.Ctx, apiName string, log *zap.Logger) error {
+	search, err := parseNearbySearch(c)
+	if err != nil {
+		log.Warn("Invalid nearby search parameters",
+			zap.Error(err),
+			zap.String("api", apiName),
+		)
+		return handlers.BadRequestResponse(c, apiName, err.Error(), nil)
+	}
+
+	if c.Query("sort") != "" || c.Query("cursor") != "" {
+		return handlers.BadRequestResponse(c, apiName, "sort and cursor cannot be combined with near or bbox, results are sorted by distance", nil)
+	}
+
+	// Parse the limit and filter query parameters
+	params, err := query.Parse(c, gymListSpec)
+	if err != nil {
+		log.Warn("Invalid list query parameters",
+			zap.Error(err),
+			zap.String("api", apiName),
+		)
+		return handlers.BadRequestResponse(c, apiName, err.Error(), nil)
+	}
+
+	log.Info("Retrieving nearby gyms",
+		zap.String("api", apiName),
+		zap.Float64("latitude", search.Latitude),
+		zap.Float64("longitude", search.Longitude),
+		zap.Float64("radius_km", search.RadiusKm),
+		zap.Any("bbox", search.Box),
+		zap.Bool("earthdistance", db.EarthDistanceEnabled),
+		zap.Any("filters", params.Applied),
+	)
+
+	openQuery, err := applyOpenNowFilter(c, db.DB)
+	if err != nil {
+		return handlers.BadRequestResponse(c, apiName, err.Error(), nil)
+	}
+	openQuery, err = applyDayPassPriceFilter(c, openQuery)
+	if err != nil {
+		return handlers.BadRequestResponse(c, apiName, err.Error(), nil)
+	}
+	openQuery, err = applyAmenitiesFilter(c, openQuery)
+	if err != nil {
+		return handlers.BadRequestResponse(c, apiName, err.Error(), nil)
+	}
+	gymQuery := preloadGymDetails(whereInBoundingBox(params.ApplyFilters(openQuery), search.Box))
+
+	var gyms []models.Gym
+	if db.EarthDistanceEnabled {
+		// Let the spatial index find and order the candidates
+		origin := clause.Expr{SQL: "ll_to_earth(?, ?)", Vars: []interface{}{search.Latitude, search.Longitude}}
+		if search.RadiusKm > 0 {
+			radiusMeters := search.RadiusKm * 1000
+			gymQuery = gymQuery.Where("earth_box(?, ?) @> ll_to_earth(latitude, longitude) AND earth_distance(?, ll_to_earth(latitude, longitude)) <= ?",
+				origin, radiusMeters, origin, radiusMeters)
+		}
+		gymQuery = gymQuery.
+			Clauses(clause.OrderBy{Expression: clause.Expr{
+				SQL:  "earth_distance(?, ll_to_earth(latitude, longitude)), id",
+				Vars: []interface{}{origin},
+			}}).
+			Limit(params.Limit)
+	} else {
+		// Pick the candidates nearest on a flat projection around the origin, with longitude
+		// differences wrapped at the antimeridian and scaled to the origin's latitude
+		lngScale := math.Cos(search.Latitude * math.Pi / 180)
+		gymQuery = gymQuery.
+			Clauses(clause.OrderBy{Expression: clause.Expr{
+				SQL:  "power(latitude - ?, 2) + power(least(abs(longitude - ?), 360 - abs(longitude - ?)) * ?, 2), id",
+				Vars: []interface{}{search.Latitude, search.Longitude, search.Longitude, lngScale},
+			}}).
+			Limit(nearbyFallbackCandidates)
+	}
+
+	if err := gymQuery.Find(&gyms).Error; err != nil {
+		log.Error("Database error while querying nearby gyms",
+			zap.Error(err),
+			zap.String("api", apiName),
+		)
+		return handlers.InternalErrorResponse(c, apiName, "Failed to retrieve gyms", nil)
+	}
+
+	// Compute great-circle distances, which also ranks and trims the fallback candidates
+	ranked := models.RankGymsByDistance(gyms, search.Latitude, search.Longitude, search.RadiusKm, &search.Box)
+	if len(ranked) > params.Limit {
+		ranked = ranked[:params.Limit]
+	}
+
+	gymResponses := make([]*models.FullGymResponse, len(ranked))
+	for i := range ranked {
+		distance := math.Round(ranked[i].DistanceKm*100) / 100
+		gymResponses[i] = ranked[i].Gym.ToFullGymResponse()
+		gymResponses[i].DistanceKm = &distance
+	}
+
+	log.Info("Nearby gyms retrieved successfully",
+		zap.String("api", apiName),
+		zap.Int("candidates", len(gyms)),
+		zap.Int("count", len(gymResponses)),
+	)
+
+	responseData := map[string]interface{}{
+		"gyms":        gymResponses,
+		"count":       len(gymResponses),
+		"limit":       params.Limit,
+		"next_cursor": "",
+		"near": map[string]float64{
+			"latitude":  search.Latitude,
+			"longitude": search.Longitude,
+		},
+		"bbox": search.Box,
+	}
+	if search.RadiusKm > 0 {
+		responseData["radius_km"] = search.RadiusKm
+	}
+
+	return handlers.SuccessResponse(c, apiName, responseData, "Gyms retrieved successfully")
+}
+
+// parseNearbySearch parses the near, radius_km and bbox query parameters
+//   - near=lat,lng searches within radius_km (default 25, max 500) of the point
+//   - bbox=min_lat,min_lng,max_lat,max_lng searches inside the box, sorted by distance from near
+//     when given or from the center of the box otherwise. A min_lng greater than max_lng crosses
+//     the antimeridian. radius_km only applies to a bbox search when it is set explicitly
+func parseNearbySearch(c *fiber.Ctx) (*nearbySearch, error) {
+	search := &nearbySearch{}
+
+	nearStr := c.Query("near")
+	if nearStr != "" {
+		values, err := parseCoordinateList(nearStr, 2)
+		if err != nil {
+			return nil, fiber.NewError(fiber.StatusBadRequest, "near must be latitude,longitude (e.g., 40.6782,-73.9442)")
+		}
+		if err := services.ValidateGymCoordinates(&values[0], &values[1]); err != nil {
+			return nil, err
+		}
+		search.Latitude, search.Longitude = values[0], values[1]
+	}
+
+	radiusStr := c.Query("radius_km")
+	if radiusStr != "" {
+		if nearStr == "" {
+			return nil, fiber.NewError(fiber.StatusBadRequest, "radius_km requires near")
+		}
+		radius, err := strconv.ParseFloat(radiusStr, 64)
+		if err != nil || radius <= 0 || radius > maxNearbyRadiusKm {
+			return nil, fiber.NewError(fiber.StatusBadRequest, "radius_km must be a number greater than 0 and at most 500")
+		}
+		search.RadiusKm = radius
+	}
+
+	if bboxStr := c.Query("bbox"); bboxStr != "" {
+		values, err := parseCoordinateList(bboxStr, 4)
+		if err != nil {
+			return nil, fiber.NewError(fiber.StatusBadRequest, "bbox must be min_lat,min_lng,max_lat,max_lng")
+		}
+		for _, corner := range [][2]float64{{values[0], values[1]}, {values[2], values[3]}} {
+			if err := services.ValidateGymCoordinates(&corner[0], &corner[1]); err != nil {
+				return nil, err
+			}
+		}
+		if values[0] > values[2] {
+			return nil, fiber.NewError(fiber.StatusBadRequest, "bbox min_lat must not be greater than max_lat")
+		}
+		search.Box = models.BoundingBox{MinLat: values[0], MinLng: values[1], MaxLat: values[2], MaxLng: values[3]}
+		if nearStr == "" {
+			search.Latitude, search.Longitude = search.Box.Center()
+		}
+		return search, nil
+	}
+
+	if search.RadiusKm == 0 {
+		search.RadiusKm = defaultNearbyRadiusKm
+	}
+	search.Box = models.BoundingBoxAround(search.Latitude, search.Longitude, search.RadiusKm)
+	return search, nil
+}
+
+// parseCoordinateList parses a comma separated list of exactly count numbers
+func parseCoordinateList(raw string, count int) ([]float64, error) {
+	parts := strings.Split(raw, ",")
+	if len(parts) != count {
+		return nil, fiber.ErrBadRequest
+	}
+	values := make([]float64, count)
+	for i, part := range parts {
+		value, err := strconv.ParseFloat(strings.TrimSpace(part), 64)
+		if err != nil || math.IsNaN(value) || math.IsInf(value, 0) {
+			return nil, fiber.ErrBadRequest
+		}
+		values[i] = value
+	}
+	return values, nil
+}
+
+// whereInBoundingBox restricts a gym query to gyms with coordinates inside the box, using the
+// latitude/longitude index
+func whereInBoundingBox(tx *gorm.DB, box models.BoundingBox) *gorm.DB {
+	tx = tx.Where("latitude IS NOT NULL AND longitude IS NOT NULL").
+		Where("latitude BETWEEN ? AND ?", box.MinLat, box.MaxLat)
+	if box.CrossesAntimeridian() {
+		return tx.Where("(longitude >= ? OR longitude <= ?)", box.MinLng, box.MaxLng)
+	}
+	return tx.Where("longitude BETWEEN ? AND ?", box.MinLng, box.MaxLng)
+}
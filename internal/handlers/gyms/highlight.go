@@ -0,0 +1,165 @@
+package gyms
+
+import (
+	"html"
+	"strings"
+	"unicode"
+)
+
+const (
+	highlightOpenTag  = "<mark>"
+	highlightCloseTag = "</mark>"
+
+	// highlightFuzzySimilarity is the trigram similarity at which a word counts as a typo of a term
+	highlightFuzzySimilarity = 0.4
+
+	// highlightFragmentWords is the number of words kept on each side of the first match in a fragment
+	highlightFragmentWords = 10
+)
+
+// wordSpan is the byte range of a word within a text
+type wordSpan struct {
+	start, end int
+}
+
+// searchTerms splits a search query into lowercase words
+func searchTerms(q string) []string {
+	return strings.FieldsFunc(strings.ToLower(q), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// highlightText returns the HTML escaped text with the words that match a term wrapped in
+// <mark> tags, and whether anything matched. A word matches when a term is a prefix of it or,
+// for terms of three or more characters, when it is a close trigram match of the term
+func highlightText(text string, terms []string) (string, bool) {
+	spans := wordSpans(text)
+	matched := matchedWords(text, spans, terms)
+	if len(matched) == 0 {
+		return "", false
+	}
+	return markSpans(text, spans, matched, 0, len(spans)), true
+}
+
+// highlightFragment is like highlightText but only keeps the words around the first match, so
+// that long descriptions are returned as a short snippet
+func highlightFragment(text string, terms []string) (string, bool) {
+	spans := wordSpans(text)
+	matched := matchedWords(text, spans, terms)
+	if len(matched) == 0 {
+		return "", false
+	}
+
+	first := len(spans)
+	for i := range matched {
+		if i < first {
+			first = i
+		}
+	}
+	from := first - highlightFragmentWords
+	if from < 0 {
+		from = 0
+	}
+	to := first + highlightFragmentWords + 1
+	if to > len(spans) {
+		to = len(spans)
+	}
+
+	fragment := markSpans(text, spans, matched, from, to)
+	if from > 0 {
+		fragment = "…" + fragment
+	}
+	if to < len(spans) {
+		fragment += "…"
+	}
+	return fragment, true
+}
+
+// wordSpans returns the byte ranges of the letter and digit runs in text
+func wordSpans(text string) []wordSpan {
+	spans := []wordSpan{}
+	start := -1
+	for i, r := range text {
+		isWord := unicode.IsLetter(r) || unicode.IsDigit(r)
+		if isWord && start < 0 {
+			start = i
+		} else if !isWord && start >= 0 {
+			spans = append(spans, wordSpan{start, i})
+			start = -1
+		}
+	}
+	if start >= 0 {
+		spans = append(spans, wordSpan{start, len(text)})
+	}
+	return spans
+}
+
+// matchedWords returns the indexes of the spans that match any term
+func matchedWords(text string, spans []wordSpan, terms []string) map[int]bool {
+	matched := map[int]bool{}
+	for i, span := range spans {
+		word := strings.ToLower(text[span.start:span.end])
+		for _, term := range terms {
+			if strings.HasPrefix(word, term) ||
+				(len(term) >= 3 && trigramSimilarity(term, word) >= highlightFuzzySimilarity) {
+				matched[i] = true
+				break
+			}
+		}
+	}
+	return matched
+}
+
+// markSpans escapes the text of spans from to to-1, wrapping matched spans in <mark> tags. The
+// text before the first span and after the last span is kept when the range reaches them
+func markSpans(text string, spans []wordSpan, matched map[int]bool, from, to int) string {
+	var b strings.Builder
+	pos := 0
+	if from > 0 {
+		pos = spans[from].start
+	}
+	for i := from; i < to; i++ {
+		span := spans[i]
+		b.WriteString(html.EscapeString(text[pos:span.start]))
+		word := html.EscapeString(text[span.start:span.end])
+		if matched[i] {
+			b.WriteString(highlightOpenTag + word + highlightCloseTag)
+		} else {
+			b.WriteString(word)
+		}
+		pos = span.end
+	}
+	if to == len(spans) {
+		b.WriteString(html.EscapeString(text[pos:]))
+	}
+	return b.String()
+}
+
+// trigramSimilarity returns the number of shared trigrams divided by the number of distinct
+// trigrams of both words, padding words the same way as pg_trgm
+func trigramSimilarity(a, b string) float64 {
+	ta := trigrams(a)
+	tb := trigrams(b)
+	if len(ta) == 0 || len(tb) == 0 {
+		return 0
+	}
+
+	shared := 0
+	for trigram := range ta {
+		if tb[trigram] {
+			shared++
+		}
+	}
+	return float64(shared) / float64(len(ta)+len(tb)-shared)
+}
+
+// trigrams returns the set of trigrams of a lowercase word padded with two leading spaces and one
+// trailing space
+func trigrams(word string) map[string]bool {
+	runes := []rune("  " + word + " ")
+	set := map[string]bool{}
+	for i := 0; i+3 <= len(runes); i++ {
+		set[string(runes[i:i+3])] = true
+	}
+	return set
+}
@@ -0,0 +1,152 @@
+package gyms
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+
+	"github.com/jwallace145/crux-backend/internal/db"
+	"github.com/jwallace145/crux-backend/internal/handlers"
+	"github.com/jwallace145/crux-backend/internal/services"
+	"github.com/jwallace145/crux-backend/internal/utils"
+	"github.com/jwallace145/crux-backend/models"
+)
+
+// ImportGyms handles POST /gyms/import requests to create or update gyms in bulk from a CSV or
+// GeoJSON FeatureCollection uploaded as the multipart "file" field
+// Query parameters:
+//   - format (optional): "csv" or "geojson", detected from the file name or content type when omitted
+//   - dry_run (optional): "true" to validate the file and report what would change without writing
+//   - batch_size (optional): Rows written per transaction (default 500, max 1000)
+//   - mapping (optional): Comma separated field=source pairs naming the column or property to read
+//     a gym field from (e.g., "name=title,city=town")
+//   - default_type (optional): Gym type for rows without one (bouldering, roped, full)
+//   - default_country (optional): Country for rows without one
+//
+// Rows with an external ID update the gym imported with the same ID. The report lists the rows
+// that failed validation
+// Requires AuthMiddleware and AdminMiddleware to be applied - reads user_id from context
+func ImportGyms(c *fiber.Ctx) error {
+	apiName := "import_gyms"
+	log := utils.GetLoggerFromContext(c)
+
+	log.Info("Starting gym import process",
+		zap.String("api", apiName),
+	)
+
+	// Get user ID from context (set by AuthMiddleware)
+	userID, ok := c.Locals("user_id").(uint)
+	if !ok {
+		log.Error("User ID not found in context",
+			zap.String("api", apiName),
+		)
+		return handlers.InternalErrorResponse(c, apiName, "Authentication context missing", nil)
+	}
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		return handlers.BadRequestResponse(c, apiName, "file is required", nil)
+	}
+
+	opts, err := parseGymImportOptions(c, fileHeader.Filename, fileHeader.Header.Get("Content-Type"))
+	if err != nil {
+		return handlers.BadRequestResponse(c, apiName, err.Error(), nil)
+	}
+	opts.CreatedByID = &userID
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		log.Error("Failed to open uploaded file",
+			zap.Error(err),
+			zap.String("api", apiName),
+		)
+		return handlers.InternalErrorResponse(c, apiName, "Failed to process file", nil)
+	}
+	defer file.Close()
+
+	log.Info("Importing gyms",
+		zap.String("api", apiName),
+		zap.String("filename", fileHeader.Filename),
+		zap.Int64("size", fileHeader.Size),
+		zap.String("format", opts.Format),
+		zap.Bool("dry_run", opts.DryRun),
+		zap.Int("batch_size", opts.BatchSize),
+	)
+
+	report, err := services.ImportGyms(db.DB, file, opts)
+	if err != nil {
+		log.Warn("Gym import file could not be read",
+			zap.Error(err),
+			zap.String("api", apiName),
+		)
+		// Batches before the unreadable part of the file have already been written
+		return handlers.BadRequestResponse(c, apiName, "Invalid import file: "+err.Error(), report)
+	}
+
+	log.Info("Gym import completed",
+		zap.String("api", apiName),
+		zap.Uint("user_id", userID),
+		zap.Bool("dry_run", report.DryRun),
+		zap.Int("rows", report.Rows),
+		zap.Int("created", report.Created),
+		zap.Int("updated", report.Updated),
+		zap.Int("failed", report.Failed),
+	)
+
+	message := "Gyms imported successfully"
+	if report.DryRun {
+		message = "Gym import dry run completed, nothing was written"
+	}
+	return handlers.SuccessResponse(c, apiName, report, message)
+}
+
+// parseGymImportOptions reads the import options from the query parameters. The format falls back
+// to the one implied by the uploaded file name or content type
+func parseGymImportOptions(c *fiber.Ctx, filename, contentType string) (models.GymImportOptions, error) {
+	opts := models.GymImportOptions{
+		Format:         strings.ToLower(strings.TrimSpace(c.Query("format"))),
+		BatchSize:      models.DefaultGymImportBatchSize,
+		DefaultType:    strings.ToLower(strings.TrimSpace(c.Query("default_type"))),
+		DefaultCountry: strings.TrimSpace(c.Query("default_country")),
+	}
+
+	if opts.Format == "" {
+		opts.Format = models.DetectGymImportFormat(filename, contentType)
+	}
+	if !models.IsValidGymImportFormat(opts.Format) {
+		return opts, fiber.NewError(fiber.StatusBadRequest, "format must be 'csv' or 'geojson'")
+	}
+
+	if raw := c.Query("dry_run"); raw != "" {
+		dryRun, err := strconv.ParseBool(raw)
+		if err != nil {
+			return opts, fiber.NewError(fiber.StatusBadRequest, "dry_run must be 'true' or 'false'")
+		}
+		opts.DryRun = dryRun
+	}
+
+	if raw := c.Query("batch_size"); raw != "" {
+		batchSize, err := strconv.Atoi(raw)
+		if err != nil || batchSize < 1 || batchSize > models.MaxGymImportBatchSize {
+			return opts, fiber.NewError(fiber.StatusBadRequest, "batch_size must be between 1 and "+strconv.Itoa(models.MaxGymImportBatchSize))
+		}
+		opts.BatchSize = batchSize
+	}
+
+	if raw := c.Query("mapping"); raw != "" {
+		mapping, err := models.ParseGymImportMapping(raw)
+		if err != nil {
+			return opts, fiber.NewError(fiber.StatusBadRequest, err.Error())
+		}
+		opts.Mapping = mapping
+	}
+
+	if opts.DefaultType != "" {
+		if err := services.ValidateGymType(opts.DefaultType); err != nil {
+			return opts, fiber.NewError(fiber.StatusBadRequest, "default_type must be one of: bouldering, roped, full")
+		}
+	}
+	return opts, nil
+}
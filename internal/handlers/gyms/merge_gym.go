@@ -0,0 +1,148 @@
+package gyms
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/jwallace145/crux-backend/internal/db"
+	"github.com/jwallace145/crux-backend/internal/handlers"
+	"github.com/jwallace145/crux-backend/internal/services"
+	"github.com/jwallace145/crux-backend/internal/utils"
+	"github.com/jwallace145/crux-backend/models"
+)
+
+// errGymMerged is returned when one of the gyms was merged or deleted by another request
+var errGymMerged = errors.New("gym has already been merged or deleted")
+
+// MergeGym handles POST /gyms/:id/merge requests to merge a duplicate gym into the gym in the path
+// Every training session, planned session, climb, check-in, review, wall, catalog climb and claim
+// of the duplicate is moved to the surviving gym in one transaction, the duplicate is deleted and
+// the merge is recorded
+// Requires AuthMiddleware and AdminMiddleware to be applied - reads user_id from context
+func MergeGym(c *fiber.Ctx) error {
+	apiName := "merge_gym"
+	log := utils.GetLoggerFromContext(c)
+
+	log.Info("Starting gym merge process",
+		zap.String("api", apiName),
+	)
+
+	// Get user ID from context (set by AuthMiddleware)
+	adminID, ok := c.Locals("user_id").(uint)
+	if !ok {
+		log.Error("User ID not found in context",
+			zap.String("api", apiName),
+		)
+		return handlers.InternalErrorResponse(c, apiName, "Authentication context missing", nil)
+	}
+
+	// Validate Content-Type header
+	if err := handlers.ValidateJSONContentType(c, apiName); err != nil {
+		// Error response is already sent by ValidateJSONContentType
+		return err
+	}
+
+	survivor, err := loadGym(c, apiName)
+	if err != nil {
+		return err
+	}
+
+	// Parse request body
+	var req models.MergeGymRequest
+	if err := c.BodyParser(&req); err != nil {
+		log.Error("Failed to parse request body",
+			zap.Error(err),
+			zap.String("api", apiName),
+			zap.ByteString("raw_body", c.Body()),
+		)
+		return handlers.BadRequestResponse(c, apiName, "Invalid request body", err.Error())
+	}
+	req.Reason = strings.TrimSpace(req.Reason)
+
+	if req.DuplicateGymID == 0 {
+		return handlers.ValidationErrorResponse(c, apiName, "duplicate_gym_id is required", nil)
+	}
+	if req.DuplicateGymID == survivor.ID {
+		return handlers.ValidationErrorResponse(c, apiName, "A gym cannot be merged into itself", nil)
+	}
+	if len(req.Reason) > 2000 {
+		return handlers.ValidationErrorResponse(c, apiName, "Reason must not exceed 2000 characters", nil)
+	}
+
+	var duplicate models.Gym
+	if err := db.DB.First(&duplicate, req.DuplicateGymID).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return handlers.NotFoundResponse(c, apiName, "Duplicate gym not found")
+		}
+		log.Error("Database error while looking up duplicate gym",
+			zap.Error(err),
+			zap.String("api", apiName),
+			zap.Uint("duplicate_gym_id", req.DuplicateGymID),
+		)
+		return handlers.InternalErrorResponse(c, apiName, "Failed to retrieve duplicate gym", nil)
+	}
+
+	var merge *models.GymMerge
+	err = db.DB.Transaction(func(tx *gorm.DB) error {
+		// Lock both gyms so that concurrent merges and edits cannot interleave, and merge the
+		// locked rows in case they changed since they were loaded
+		var locked []models.Gym
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id IN ?", []uint{survivor.ID, duplicate.ID}).
+			Order("id ASC").
+			Find(&locked).Error; err != nil {
+			return err
+		}
+		if len(locked) != 2 {
+			return errGymMerged
+		}
+		if locked[0].ID != survivor.ID {
+			locked[0], locked[1] = locked[1], locked[0]
+		}
+
+		var err error
+		merge, err = services.MergeGyms(tx, &locked[0], &locked[1], adminID, req.Reason)
+		return err
+	})
+	if errors.Is(err, errGymMerged) {
+		return handlers.ConflictResponse(c, apiName, "One of the gyms has already been merged or deleted", nil)
+	}
+	if err != nil {
+		log.Error("Failed to merge gyms in db",
+			zap.Error(err),
+			zap.String("api", apiName),
+			zap.Uint("gym_id", survivor.ID),
+			zap.Uint("duplicate_gym_id", duplicate.ID),
+		)
+		return handlers.InternalErrorResponse(c, apiName, "Failed to merge gyms", nil)
+	}
+
+	var merged models.Gym
+	if err := preloadGymDetails(db.DB).First(&merged, survivor.ID).Error; err != nil {
+		log.Error("Failed to reload merged gym",
+			zap.Error(err),
+			zap.String("api", apiName),
+			zap.Uint("gym_id", survivor.ID),
+		)
+		return handlers.InternalErrorResponse(c, apiName, "Failed to retrieve merged gym", nil)
+	}
+
+	log.Info("Gyms merged successfully",
+		zap.String("api", apiName),
+		zap.Uint("gym_merge_id", merge.ID),
+		zap.Uint("gym_id", survivor.ID),
+		zap.Uint("duplicate_gym_id", duplicate.ID),
+		zap.Uint("admin_id", adminID),
+		zap.Any("moved", merge.Moved),
+	)
+
+	response := merge.ToGymMergeResponse()
+	response.Survivor = merged.ToFullGymResponse()
+
+	return handlers.SuccessResponse(c, apiName, response, "Gyms merged successfully")
+}
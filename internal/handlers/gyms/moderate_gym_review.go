@@ -0,0 +1,167 @@
+package gyms
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	"github.com/jwallace145/crux-backend/internal/db"
+	"github.com/jwallace145/crux-backend/internal/handlers"
+	"github.com/jwallace145/crux-backend/internal/services"
+	"github.com/jwallace145/crux-backend/internal/utils"
+	"github.com/jwallace145/crux-backend/models"
+)
+
+// HideGymReview handles POST /gyms/reviews/:id/hide requests to remove a review from the gym's
+// review list and ratings. Pending reports for the review are upheld
+// Requires AuthMiddleware and AdminMiddleware to be applied - reads user_id from context
+func HideGymReview(c *fiber.Ctx) error {
+	return moderateGymReview(c, "hide_gym_review", true)
+}
+
+// RestoreGymReview handles POST /gyms/reviews/:id/restore requests to make a hidden review visible
+// again, or to keep a reported review. Pending reports for the review are dismissed
+// Requires AuthMiddleware and AdminMiddleware to be applied - reads user_id from context
+func RestoreGymReview(c *fiber.Ctx) error {
+	return moderateGymReview(c, "restore_gym_review", false)
+}
+
+// moderateGymReview hides or restores a gym review, resolves its pending reports and refreshes the
+// gym's aggregated ratings
+func moderateGymReview(c *fiber.Ctx, apiName string, hide bool) error {
+	log := utils.GetLoggerFromContext(c)
+
+	log.Info("Starting gym review moderation process",
+		zap.String("api", apiName),
+		zap.Bool("hide", hide),
+	)
+
+	// Get user ID from context (set by AuthMiddleware)
+	moderatorID, ok := c.Locals("user_id").(uint)
+	if !ok {
+		log.Error("User ID not found in context",
+			zap.String("api", apiName),
+		)
+		return handlers.InternalErrorResponse(c, apiName, "Authentication context missing", nil)
+	}
+
+	reviewID, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return handlers.BadRequestResponse(c, apiName, "id must be a valid number", nil)
+	}
+
+	// Parse the optional request body
+	var req models.ModerateGymReviewRequest
+	if len(c.Body()) > 0 {
+		if err := handlers.ValidateJSONContentType(c, apiName); err != nil {
+			return err
+		}
+		if err := c.BodyParser(&req); err != nil {
+			log.Error("Failed to parse request body",
+				zap.Error(err),
+				zap.String("api", apiName),
+			)
+			return handlers.BadRequestResponse(c, apiName, "Invalid request body", err.Error())
+		}
+		req.Reason = strings.TrimSpace(req.Reason)
+		if len(req.Reason) > 2000 {
+			return handlers.ValidationErrorResponse(c, apiName, "Reason must not exceed 2000 characters", nil)
+		}
+	}
+
+	var review models.GymReview
+	if err := db.DB.First(&review, reviewID).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return handlers.NotFoundResponse(c, apiName, "Gym review not found")
+		}
+		log.Error("Database error while looking up gym review",
+			zap.Error(err),
+			zap.String("api", apiName),
+		)
+		return handlers.InternalErrorResponse(c, apiName, "Failed to retrieve gym review", nil)
+	}
+
+	if hide && review.Hidden {
+		return handlers.ConflictResponse(c, apiName, "Gym review is already hidden", nil)
+	}
+
+	now := time.Now()
+	reviewUpdates := map[string]interface{}{
+		"hidden":        false,
+		"hidden_by_id":  nil,
+		"hidden_at":     nil,
+		"hidden_reason": "",
+	}
+	reportStatus := models.GymReviewReportStatusDismissed
+	if hide {
+		reviewUpdates = map[string]interface{}{
+			"hidden":        true,
+			"hidden_by_id":  moderatorID,
+			"hidden_at":     now,
+			"hidden_reason": req.Reason,
+		}
+		reportStatus = models.GymReviewReportStatusUpheld
+	}
+
+	var resolved int64
+	err = db.DB.Transaction(func(tx *gorm.DB) error {
+		if err := services.LockGymRatings(tx, review.GymID); err != nil {
+			return err
+		}
+
+		// UpdateColumns keeps updated_at, which tracks edits by the review's author
+		if err := tx.Model(&review).UpdateColumns(reviewUpdates).Error; err != nil {
+			return err
+		}
+
+		result := tx.Model(&models.GymReviewReport{}).
+			Where("gym_review_id = ? AND status = ?", review.ID, models.GymReviewReportStatusPending).
+			Updates(map[string]interface{}{
+				"status":         reportStatus,
+				"reviewed_by_id": moderatorID,
+				"reviewed_at":    now,
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		resolved = result.RowsAffected
+
+		return services.RefreshGymRatings(tx, review.GymID)
+	})
+	if err != nil {
+		log.Error("Failed to moderate gym review in db",
+			zap.Error(err),
+			zap.String("api", apiName),
+			zap.Uint("gym_review_id", review.ID),
+		)
+		return handlers.InternalErrorResponse(c, apiName, "Failed to moderate gym review", nil)
+	}
+
+	if err := db.DB.Preload("User").First(&review, review.ID).Error; err != nil {
+		log.Error("Failed to reload moderated gym review",
+			zap.Error(err),
+			zap.String("api", apiName),
+			zap.Uint("gym_review_id", review.ID),
+		)
+		return handlers.InternalErrorResponse(c, apiName, "Failed to retrieve gym review", nil)
+	}
+
+	log.Info("Gym review moderated successfully",
+		zap.String("api", apiName),
+		zap.Uint("gym_review_id", review.ID),
+		zap.Uint("gym_id", review.GymID),
+		zap.Uint("moderator_id", moderatorID),
+		zap.Bool("hidden", review.Hidden),
+		zap.Int64("reports_resolved", resolved),
+	)
+
+	message := "Gym review restored successfully"
+	if hide {
+		message = "Gym review hidden successfully"
+	}
+	return handlers.SuccessResponse(c, apiName, review.ToGymReviewResponse().WithModeration(&review), message)
+}
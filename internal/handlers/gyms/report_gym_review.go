@@ -0,0 +1,122 @@
+package gyms
+
+import (
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+	"gorm.io/gorm/clause"
+
+	"github.com/jwallace145/crux-backend/internal/db"
+	"github.com/jwallace145/crux-backend/internal/handlers"
+	"github.com/jwallace145/crux-backend/internal/utils"
+	"github.com/jwallace145/crux-backend/models"
+)
+
+// ReportGymReview handles POST /gyms/:id/reviews/:review_id/reports requests to flag a review for
+// moderation. Each user can report a review once. Reports stay pending until an admin hides or
+// restores the review
+// Requires AuthMiddleware to be applied - reads user_id from context
+func ReportGymReview(c *fiber.Ctx) error {
+	apiName := "report_gym_review"
+	log := utils.GetLoggerFromContext(c)
+
+	log.Info("Starting gym review report process",
+		zap.String("api", apiName),
+	)
+
+	// Get user ID from context (set by AuthMiddleware)
+	userID, ok := c.Locals("user_id").(uint)
+	if !ok {
+		log.Error("User ID not found in context",
+			zap.String("api", apiName),
+		)
+		return handlers.InternalErrorResponse(c, apiName, "Authentication context missing", nil)
+	}
+
+	// Validate Content-Type header
+	if err := handlers.ValidateJSONContentType(c, apiName); err != nil {
+		return err
+	}
+
+	gym, err := loadGym(c, apiName)
+	if err != nil {
+		return err
+	}
+
+	review, err := loadGymReview(c, apiName, gym.ID)
+	if err != nil {
+		return err
+	}
+
+	// Parse request body
+	var req models.CreateGymReviewReportRequest
+	if err := c.BodyParser(&req); err != nil {
+		log.Error("Failed to parse request body",
+			zap.Error(err),
+			zap.String("api", apiName),
+		)
+		return handlers.BadRequestResponse(c, apiName, "Invalid request body", err.Error())
+	}
+
+	req.Reason = strings.TrimSpace(req.Reason)
+	req.Details = strings.TrimSpace(req.Details)
+	if err := validateGymReviewReportRequest(&req); err != nil {
+		log.Warn("Request validation failed",
+			zap.Error(err),
+			zap.String("api", apiName),
+		)
+		return handlers.ValidationErrorResponse(c, apiName, err.Error(), nil)
+	}
+
+	if review.UserID == userID {
+		return handlers.ValidationErrorResponse(c, apiName, "You cannot report your own review", nil)
+	}
+	if review.Hidden {
+		return handlers.ConflictResponse(c, apiName, "Gym review has already been hidden", nil)
+	}
+
+	report := &models.GymReviewReport{
+		GymReviewID: review.ID,
+		UserID:      userID,
+		Reason:      req.Reason,
+		Details:     req.Details,
+		Status:      models.GymReviewReportStatusPending,
+	}
+	result := db.DB.Clauses(clause.OnConflict{DoNothing: true}).Create(report)
+	if result.Error != nil {
+		log.Error("Failed to create gym review report in db",
+			zap.Error(result.Error),
+			zap.String("api", apiName),
+			zap.Uint("gym_review_id", review.ID),
+		)
+		return handlers.InternalErrorResponse(c, apiName, "Failed to report gym review", nil)
+	}
+	if result.RowsAffected == 0 {
+		return handlers.ConflictResponse(c, apiName, "You have already reported this review", nil)
+	}
+
+	log.Info("Gym review reported successfully",
+		zap.String("api", apiName),
+		zap.Uint("gym_review_report_id", report.ID),
+		zap.Uint("gym_review_id", review.ID),
+		zap.Uint("user_id", userID),
+		zap.String("reason", report.Reason),
+	)
+
+	return handlers.CreatedResponse(c, apiName, report.ToGymReviewReportResponse(), "Gym review reported for moderation")
+}
+
+// validateGymReviewReportRequest validates the reason and details of a gym review report
+func validateGymReviewReportRequest(req *models.CreateGymReviewReportRequest) error {
+	if !models.IsValidGymReviewReportReason(req.Reason) {
+		return fiber.NewError(fiber.StatusBadRequest, "Reason must be 'spam', 'offensive', 'off_topic', 'conflict_of_interest' or 'other'")
+	}
+	if req.Reason == models.GymReviewReportReasonOther && req.Details == "" {
+		return fiber.NewError(fiber.StatusBadRequest, "Details are required when the reason is 'other'")
+	}
+	if len(req.Details) > 2000 {
+		return fiber.NewError(fiber.StatusBadRequest, "Details must not exceed 2000 characters")
+	}
+	return nil
+}
@@ -0,0 +1,160 @@
+package gyms
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	"github.com/jwallace145/crux-backend/internal/db"
+	"github.com/jwallace145/crux-backend/internal/handlers"
+	"github.com/jwallace145/crux-backend/internal/utils"
+	"github.com/jwallace145/crux-backend/models"
+)
+
+// errGymClaimReviewed is returned when a claim was reviewed by another admin in the meantime
+var errGymClaimReviewed = errors.New("gym claim has already been reviewed")
+
+// ApproveGymClaim handles POST /gyms/claims/:id/approve requests to make the claiming user the
+// owner of the gym. Any other pending claims for the same gym are rejected
+// Requires AuthMiddleware and AdminMiddleware to be applied - reads user_id from context
+func ApproveGymClaim(c *fiber.Ctx) error {
+	return reviewGymClaim(c, "approve_gym_claim", models.GymClaimStatusApproved)
+}
+
+// RejectGymClaim handles POST /gyms/claims/:id/reject requests to reject a gym ownership claim
+// Requires AuthMiddleware and AdminMiddleware to be applied - reads user_id from context
+func RejectGymClaim(c *fiber.Ctx) error {
+	return reviewGymClaim(c, "reject_gym_claim", models.GymClaimStatusRejected)
+}
+
+// reviewGymClaim moves a pending gym claim to the given status and, when approving, transfers
+// ownership of the gym to the claiming user
+func reviewGymClaim(c *fiber.Ctx, apiName, status string) error {
+	log := utils.GetLoggerFromContext(c)
+
+	log.Info("Starting gym claim review process",
+		zap.String("api", apiName),
+		zap.String("status", status),
+	)
+
+	// Get user ID from context (set by AuthMiddleware)
+	reviewerID, ok := c.Locals("user_id").(uint)
+	if !ok {
+		log.Error("User ID not found in context",
+			zap.String("api", apiName),
+		)
+		return handlers.InternalErrorResponse(c, apiName, "Authentication context missing", nil)
+	}
+
+	claimID, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return handlers.BadRequestResponse(c, apiName, "id must be a valid number", nil)
+	}
+
+	// Parse the optional request body
+	var req models.ReviewGymClaimRequest
+	if len(c.Body()) > 0 {
+		if err := handlers.ValidateJSONContentType(c, apiName); err != nil {
+			return err
+		}
+		if err := c.BodyParser(&req); err != nil {
+			log.Error("Failed to parse request body",
+				zap.Error(err),
+				zap.String("api", apiName),
+			)
+			return handlers.BadRequestResponse(c, apiName, "Invalid request body", err.Error())
+		}
+		req.Note = strings.TrimSpace(req.Note)
+		if len(req.Note) > 2000 {
+			return handlers.ValidationErrorResponse(c, apiName, "Note must not exceed 2000 characters", nil)
+		}
+	}
+
+	var claim models.GymClaim
+	if err := db.DB.First(&claim, claimID).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return handlers.NotFoundResponse(c, apiName, "Gym claim not found")
+		}
+		log.Error("Database error while looking up gym claim",
+			zap.Error(err),
+			zap.String("api", apiName),
+		)
+		return handlers.InternalErrorResponse(c, apiName, "Failed to retrieve gym claim", nil)
+	}
+
+	if !claim.IsPending() {
+		return handlers.ConflictResponse(c, apiName, "Gym claim has already been "+claim.Status, nil)
+	}
+
+	now := time.Now()
+	err = db.DB.Transaction(func(tx *gorm.DB) error {
+		// Guard on the pending status so two admins cannot review the same claim
+		result := tx.Model(&models.GymClaim{}).
+			Where("id = ? AND status = ?", claim.ID, models.GymClaimStatusPending).
+			Updates(map[string]interface{}{
+				"status":         status,
+				"reviewed_by_id": reviewerID,
+				"reviewed_at":    now,
+				"review_note":    req.Note,
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return errGymClaimReviewed
+		}
+
+		if status != models.GymClaimStatusApproved {
+			return nil
+		}
+
+		if err := tx.Model(&models.Gym{}).Where("id = ?", claim.GymID).Update("owner_id", claim.UserID).Error; err != nil {
+			return err
+		}
+
+		return tx.Model(&models.GymClaim{}).
+			Where("gym_id = ? AND status = ? AND id <> ?", claim.GymID, models.GymClaimStatusPending, claim.ID).
+			Updates(map[string]interface{}{
+				"status":         models.GymClaimStatusRejected,
+				"reviewed_by_id": reviewerID,
+				"reviewed_at":    now,
+				"review_note":    "Another claim for this gym was approved",
+			}).Error
+	})
+	if errors.Is(err, errGymClaimReviewed) {
+		return handlers.ConflictResponse(c, apiName, "Gym claim has already been reviewed", nil)
+	}
+	if err != nil {
+		log.Error("Failed to review gym claim in db",
+			zap.Error(err),
+			zap.String("api", apiName),
+			zap.Uint("gym_claim_id", claim.ID),
+		)
+		return handlers.InternalErrorResponse(c, apiName, "Failed to review gym claim", nil)
+	}
+
+	if err := db.DB.Preload("Gym").Preload("User").First(&claim, claim.ID).Error; err != nil {
+		log.Error("Failed to reload reviewed gym claim",
+			zap.Error(err),
+			zap.String("api", apiName),
+			zap.Uint("gym_claim_id", claim.ID),
+		)
+		return handlers.InternalErrorResponse(c, apiName, "Failed to retrieve gym claim", nil)
+	}
+
+	log.Info("Gym claim reviewed successfully",
+		zap.String("api", apiName),
+		zap.Uint("gym_claim_id", claim.ID),
+		zap.Uint("gym_id", claim.GymID),
+		zap.Uint("claimant_id", claim.UserID),
+		zap.Uint("reviewer_id", reviewerID),
+		zap.String("status", status),
+	)
+
+	return handlers.SuccessResponse(c, apiName, claim.ToGymClaimResponse(), "Gym claim "+status+" successfully")
+}
@@ -0,0 +1,206 @@
+package gyms
+
+import (
+	"math"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+	"gorm.io/gorm/clause"
+
+	"github.com/jwallace145/crux-backend/internal/db"
+	"github.com/jwallace145/crux-backend/internal/handlers"
+	"github.com/jwallace145/crux-backend/internal/query"
+	"github.com/jwallace145/crux-backend/internal/utils"
+	"github.com/jwallace145/crux-backend/models"
+)
+
+const (
+	minGymSearchLength = 2
+	maxGymSearchLength = 200
+	maxGymSearchTerms  = 10
+)
+
+// gymSearchSpec defines the limits and filters for GET /gyms/search. Results are ordered by
+// relevance so no sort fields are accepted
+var gymSearchSpec = &query.Spec{
+	DefaultLimit: 20,
+	MaxLimit:     50,
+	Filters: []query.Filter{
+		{Param: "type", Column: "type", Type: query.TypeString, Kind: query.FilterEquals},
+		{Param: "active", Column: "active", Type: query.TypeBool, Kind: query.FilterEquals},
+	},
+}
+
+// gymSearchRow is a gym with its relevance score
+type gymSearchRow struct {
+	models.Gym
+	SearchRank float64
+}
+
+// SearchGyms handles GET /gyms/search requests to find gyms by free text
+// The query is matched with Postgres full-text search across the name, city, state, country and
+// description, and with trigram similarity on the name and location so that typos still match
+// Query parameters:
+//   - q (required): Search text (e.g., "movement bk" or "brooklin boulders")
+//   - type (optional): Filter gyms by type (bouldering, roped, full)
+//   - active (optional): "true" or "false"
+//   - limit (optional): Number of results (default 20, max 50)
+//
+// Requires AuthMiddleware to be applied
+func SearchGyms(c *fiber.Ctx) error {
+	apiName := "search_gyms"
+	log := utils.GetLoggerFromContext(c)
+
+	log.Info("Starting gym search process",
+		zap.String("api", apiName),
+	)
+
+	q := strings.TrimSpace(c.Query("q"))
+	if utf8.RuneCountInString(q) < minGymSearchLength || utf8.RuneCountInString(q) > maxGymSearchLength {
+		return handlers.BadRequestResponse(c, apiName, "q must be between 2 and 200 characters", nil)
+	}
+
+	terms := searchTerms(q)
+	if len(terms) == 0 {
+		return handlers.BadRequestResponse(c, apiName, "q must contain at least one letter or digit", nil)
+	}
+	if len(terms) > maxGymSearchTerms {
+		terms = terms[:maxGymSearchTerms]
+	}
+
+	if c.Query("sort") != "" || c.Query("cursor") != "" {
+		return handlers.BadRequestResponse(c, apiName, "sort and cursor are not supported, results are ordered by relevance", nil)
+	}
+
+	// Parse the limit and filter query parameters
+	params, err := query.Parse(c, gymSearchSpec)
+	if err != nil {
+		log.Warn("Invalid search query parameters",
+			zap.Error(err),
+			zap.String("api", apiName),
+		)
+		return handlers.BadRequestResponse(c, apiName, err.Error(), nil)
+	}
+
+	log.Info("Searching gyms",
+		zap.String("api", apiName),
+		zap.String("q", q),
+		zap.Strings("terms", terms),
+		zap.Bool("trigram", db.TrigramSearchEnabled),
+		zap.Any("filters", params.Applied),
+	)
+
+	// Every term is matched as a prefix, against both the unstemmed name and location and the
+	// stemmed description. Terms are sanitized to letters and digits so they are valid tsquery input
+	prefixTerms := make([]string, len(terms))
+	for i, term := range terms {
+		prefixTerms[i] = term + ":*"
+	}
+	tsQuery := clause.Expr{
+		SQL:  "(to_tsquery('simple', ?) || to_tsquery('english', ?))",
+		Vars: []interface{}{strings.Join(prefixTerms, " | "), strings.Join(prefixTerms, " | ")},
+	}
+	document := clause.Expr{SQL: db.GymSearchDocument}
+	location := clause.Expr{SQL: db.GymSearchLocation}
+
+	gymQuery := params.ApplyFilters(db.DB.Model(&models.Gym{}))
+	if db.TrigramSearchEnabled {
+		gymQuery = gymQuery.
+			Select("gyms.*, ts_rank_cd(?, ?) + word_similarity(?, name) + 0.5 * word_similarity(?, ?) AS search_rank",
+				document, tsQuery, q, q, location).
+			Where("? @@ ? OR ? <% name OR ? <% ?", document, tsQuery, q, q, location)
+	} else {
+		pattern := "%" + escapeLikePattern(q) + "%"
+		gymQuery = gymQuery.
+			Select("gyms.*, ts_rank_cd(?, ?) AS search_rank", document, tsQuery).
+			Where("? @@ ? OR name ILIKE ? OR ? ILIKE ?", document, tsQuery, pattern, location, pattern)
+	}
+
+	var rows []gymSearchRow
+	if err := gymQuery.
+		Order("search_rank DESC").
+		Order("id ASC").
+		Limit(params.Limit).
+		Find(&rows).Error; err != nil {
+		log.Error("Database error while searching gyms",
+			zap.Error(err),
+			zap.String("api", apiName),
+		)
+		return handlers.InternalErrorResponse(c, apiName, "Failed to search gyms", nil)
+	}
+
+	// Load the prices and opening hours of the matched gyms, the hours drive their open now status
+	gymIDs := make([]uint, len(rows))
+	for i := range rows {
+		gymIDs[i] = rows[i].ID
+	}
+	var scheduled []models.Gym
+	if err := preloadGymDetails(db.DB.Select("id")).Where("id IN ?", gymIDs).Find(&scheduled).Error; err != nil {
+		log.Error("Database error while loading gym opening hours",
+			zap.Error(err),
+			zap.String("api", apiName),
+		)
+		return handlers.InternalErrorResponse(c, apiName, "Failed to search gyms", nil)
+	}
+	for i := range scheduled {
+		for j := range rows {
+			if rows[j].ID == scheduled[i].ID {
+				rows[j].Amenities = scheduled[i].Amenities
+				rows[j].Prices = scheduled[i].Prices
+				rows[j].OpeningHours = scheduled[i].OpeningHours
+				rows[j].HoursExceptions = scheduled[i].HoursExceptions
+			}
+		}
+	}
+
+	results := make([]*models.GymSearchResultResponse, len(rows))
+	for i := range rows {
+		results[i] = &models.GymSearchResultResponse{
+			Gym:        rows[i].Gym.ToFullGymResponse(),
+			Score:      math.Round(rows[i].SearchRank*1000) / 1000,
+			Highlights: gymHighlights(&rows[i].Gym, terms),
+		}
+	}
+
+	log.Info("Gym search completed successfully",
+		zap.String("api", apiName),
+		zap.String("q", q),
+		zap.Int("count", len(results)),
+	)
+
+	responseData := map[string]interface{}{
+		"query":   q,
+		"results": results,
+		"count":   len(results),
+		"limit":   params.Limit,
+	}
+
+	return handlers.SuccessResponse(c, apiName, responseData, "Gyms retrieved successfully")
+}
+
+// gymHighlights returns the highlighted name and location fields that matched the search terms,
+// and a highlighted snippet of the description
+func gymHighlights(gym *models.Gym, terms []string) map[string]string {
+	highlights := map[string]string{}
+	for field, text := range map[string]string{
+		"name":    gym.Name,
+		"city":    gym.City,
+		"state":   gym.State,
+		"country": gym.Country,
+	} {
+		if highlighted, ok := highlightText(text, terms); ok {
+			highlights[field] = highlighted
+		}
+	}
+	if fragment, ok := highlightFragment(gym.Description, terms); ok {
+		highlights["description"] = fragment
+	}
+	return highlights
+}
+
+// escapeLikePattern escapes the LIKE wildcards in user input
+func escapeLikePattern(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(s)
+}
@@ -0,0 +1,285 @@
+package gyms
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/jwallace145/crux-backend/internal/db"
+	"github.com/jwallace145/crux-backend/internal/handlers"
+	"github.com/jwallace145/crux-backend/internal/services"
+	"github.com/jwallace145/crux-backend/internal/utils"
+	"github.com/jwallace145/crux-backend/models"
+)
+
+// UpdateGym handles PATCH /gyms/:id requests to update an existing gym
+// Only the fields present in the request are updated. The merged gym is validated with the same
+// rules as a new gym. Claimed gyms can only be edited by their owner, unclaimed gyms by the user
+// who created them, and admins can edit any gym
+// Requires AuthMiddleware to be applied - reads user_id from context
+func UpdateGym(c *fiber.Ctx) error {
+	apiName := "update_gym"
+	log := utils.GetLoggerFromContext(c)
+
+	log.Info("Starting gym update process",
+		zap.String("api", apiName),
+	)
+
+	// Get user ID from context (set by AuthMiddleware)
+	userID, ok := c.Locals("user_id").(uint)
+	if !ok {
+		log.Error("User ID not found in context",
+			zap.String("api", apiName),
+		)
+		return handlers.InternalErrorResponse(c, apiName, "Authentication context missing", nil)
+	}
+
+	// Validate Content-Type header
+	if err := handlers.ValidateJSONContentType(c, apiName); err != nil {
+		// Error response is already sent by ValidateJSONContentType
+		return err
+	}
+
+	gym, err := loadEditableGym(c, apiName, userID)
+	if err != nil {
+		return err
+	}
+
+	// Parse request body
+	var req models.UpdateGymRequest
+	if err := c.BodyParser(&req); err != nil {
+		log.Error("Failed to parse request body",
+			zap.Error(err),
+			zap.String("api", apiName),
+			zap.ByteString("raw_body", c.Body()),
+		)
+		return handlers.BadRequestResponse(c, apiName, "Invalid request body", err.Error())
+	}
+
+	if fields := req.LegacyPriceFields(); len(fields) > 0 {
+		log.Warn("Request uses legacy price fields",
+			zap.String("api", apiName),
+			zap.Strings("fields", fields),
+			zap.Bool("ignored", req.Prices != nil),
+		)
+	}
+
+	// Merge the changes into the current gym and validate the result as a whole
+	merged := gym.ToCreateGymRequest()
+	columns := applyGymUpdates(&req, merged)
+	if len(columns) == 0 && req.OpeningHours == nil && req.HoursExceptions == nil && !req.ChangesPrices() && !req.ChangesAmenities() {
+		return handlers.BadRequestResponse(c, apiName, "No fields to update", nil)
+	}
+
+	services.NormalizeGymRequest(merged)
+	if err := services.ValidateCreateGymRequest(merged); err != nil {
+		log.Warn("Request validation failed",
+			zap.Error(err),
+			zap.String("api", apiName),
+			zap.Uint("gym_id", gym.ID),
+		)
+		return handlers.ValidationErrorResponse(c, apiName, err.Error(), nil)
+	}
+
+	var amenities []models.Amenity
+	if req.ChangesAmenities() {
+		if amenities, err = resolveGymAmenities(c, apiName, merged); err != nil {
+			return err
+		}
+	}
+
+	// Replace the weekly schedule when it is given, or when the free-form hours change on a gym
+	// that has no structured schedule yet and the new text can be parsed
+	updatedGym := services.NewGymFromRequest(merged)
+	if req.OpeningHours != nil {
+		// An explicitly empty schedule clears it rather than falling back to the free-form text
+		updatedGym.OpeningHours, _ = models.ToGymOpeningHours(*req.OpeningHours)
+	}
+	replaceOpeningHours := req.OpeningHours != nil ||
+		(req.Hours != nil && len(gym.OpeningHours) == 0 && len(updatedGym.OpeningHours) > 0)
+
+	err = db.DB.Transaction(func(tx *gorm.DB) error {
+		// Only the columns present in the request are written, including zero values. A schedule,
+		// pricing or amenity only change still bumps updated_at so offline clients pull it
+		if len(columns) > 0 {
+			if err := tx.Model(gym).Select(columns).Omit(clause.Associations).Updates(updatedGym).Error; err != nil {
+				return err
+			}
+		} else if err := tx.Model(gym).Update("updated_at", time.Now()).Error; err != nil {
+			return err
+		}
+		if replaceOpeningHours {
+			if err := services.ReplaceGymOpeningHours(tx, gym.ID, updatedGym.OpeningHours); err != nil {
+				return err
+			}
+		}
+		if req.HoursExceptions != nil {
+			if err := services.ReplaceGymHoursExceptions(tx, gym.ID, updatedGym.HoursExceptions); err != nil {
+				return err
+			}
+		}
+		if req.ChangesPrices() {
+			if err := services.ReplaceGymPrices(tx, gym.ID, updatedGym.Prices); err != nil {
+				return err
+			}
+		}
+		if req.ChangesAmenities() {
+			if err := services.SetGymAmenities(tx, gym.ID, amenities); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		log.Error("Failed to update gym in db",
+			zap.Error(err),
+			zap.String("api", apiName),
+			zap.Uint("gym_id", gym.ID),
+		)
+		return handlers.InternalErrorResponse(c, apiName, "Failed to update gym", nil)
+	}
+
+	var updated models.Gym
+	if err := preloadGymDetails(db.DB).First(&updated, gym.ID).Error; err != nil {
+		log.Error("Failed to reload updated gym",
+			zap.Error(err),
+			zap.String("api", apiName),
+			zap.Uint("gym_id", gym.ID),
+		)
+		return handlers.InternalErrorResponse(c, apiName, "Failed to retrieve updated gym", nil)
+	}
+
+	log.Info("Gym updated successfully",
+		zap.String("api", apiName),
+		zap.Uint("gym_id", updated.ID),
+		zap.Uint("user_id", userID),
+		zap.Strings("fields", columns),
+		zap.Bool("opening_hours_replaced", replaceOpeningHours),
+		zap.Bool("hours_exceptions_replaced", req.HoursExceptions != nil),
+		zap.Bool("prices_replaced", req.ChangesPrices()),
+		zap.Bool("amenities_replaced", req.ChangesAmenities()),
+	)
+
+	return handlers.SuccessResponse(c, apiName, updated.ToFullGymResponse(), "Gym updated successfully")
+}
+
+// loadEditableGym loads the gym from the id path parameter and checks that the user is allowed to
+// edit it. On failure the error response has already been sent
+func loadEditableGym(c *fiber.Ctx, apiName string, userID uint) (*models.Gym, error) {
+	log := utils.GetLoggerFromContext(c)
+
+	gymID, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return nil, handlers.BadRequestResponse(c, apiName, "id must be a valid number", nil)
+	}
+
+	var gym models.Gym
+	if err := db.DB.Preload("Amenities").Preload("Prices").Preload("OpeningHours").Preload("HoursExceptions").First(&gym, gymID).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			log.Warn("Gym not found",
+				zap.String("api", apiName),
+				zap.Uint64("gym_id", gymID),
+			)
+			return nil, handlers.NotFoundResponse(c, apiName, "Gym not found")
+		}
+		log.Error("Database error while looking up gym",
+			zap.Error(err),
+			zap.String("api", apiName),
+		)
+		return nil, handlers.InternalErrorResponse(c, apiName, "Failed to retrieve gym", nil)
+	}
+
+	isAdmin, err := services.IsAdmin(db.DB, userID)
+	if err != nil {
+		log.Error("Database error while checking user role",
+			zap.Error(err),
+			zap.String("api", apiName),
+			zap.Uint("user_id", userID),
+		)
+		return nil, handlers.InternalErrorResponse(c, apiName, "Failed to verify user role", nil)
+	}
+
+	if !gym.CanBeEditedBy(userID, isAdmin) {
+		log.Warn("User is not allowed to edit gym",
+			zap.String("api", apiName),
+			zap.Uint("gym_id", gym.ID),
+			zap.Uint("user_id", userID),
+		)
+		if gym.IsClaimed() {
+			return nil, handlers.ForbiddenResponse(c, apiName, "Only the gym owner or an admin can edit this gym")
+		}
+		return nil, handlers.ForbiddenResponse(c, apiName, "Only the user who added this gym or an admin can edit it until it is claimed")
+	}
+
+	return &gym, nil
+}
+
+// applyGymUpdates copies the fields present in the update request onto the merged request and
+// returns the db columns that changed. The opening hours, exceptions, prices and amenities are
+// copied but are not columns, they are replaced separately
+func applyGymUpdates(req *models.UpdateGymRequest, merged *models.CreateGymRequest) []string {
+	columns := []string{}
+
+	setGymField(req.Name, &merged.Name, "name", &columns)
+	setGymField(req.Type, &merged.Type, "type", &columns)
+	setGymField(req.Description, &merged.Description, "description", &columns)
+
+	setGymField(req.Address, &merged.Address, "address", &columns)
+	setGymField(req.City, &merged.City, "city", &columns)
+	setGymField(req.State, &merged.State, "state", &columns)
+	setGymField(req.Province, &merged.Province, "province", &columns)
+	setGymField(req.Country, &merged.Country, "country", &columns)
+	setGymField(req.PostalCode, &merged.PostalCode, "postal_code", &columns)
+
+	if req.Latitude != nil {
+		merged.Latitude = req.Latitude
+		columns = append(columns, "latitude")
+	}
+	if req.Longitude != nil {
+		merged.Longitude = req.Longitude
+		columns = append(columns, "longitude")
+	}
+
+	setGymField(req.Phone, &merged.Phone, "phone", &columns)
+	setGymField(req.Email, &merged.Email, "email", &columns)
+	setGymField(req.Website, &merged.Website, "website", &columns)
+	setGymField(req.Hours, &merged.Hours, "hours", &columns)
+	setGymField(req.Timezone, &merged.Timezone, "timezone", &columns)
+	if req.OpeningHours != nil {
+		merged.OpeningHours = *req.OpeningHours
+	}
+	if req.HoursExceptions != nil {
+		merged.HoursExceptions = *req.HoursExceptions
+	}
+	if req.ChangesAmenities() {
+		merged.Amenities = req.ApplyAmenities(merged.Amenities)
+	}
+
+	setGymField(req.WallHeight, &merged.WallHeight, "wall_height", &columns)
+	setGymField(req.SquareFeet, &merged.SquareFeet, "square_feet", &columns)
+
+	// Legacy flat prices from older clients replace the matching adult tiers unless tiers are given
+	if req.Prices != nil {
+		merged.Prices = *req.Prices
+	} else if len(req.LegacyPriceFields()) > 0 {
+		merged.Prices = req.ApplyLegacyPrices(merged.Prices, merged.Country)
+	}
+
+	setGymField(req.Notes, &merged.Notes, "notes", &columns)
+	setGymField(req.Active, &merged.Active, "active", &columns)
+
+	return columns
+}
+
+// setGymField copies value onto field and records the column when the value is present
+func setGymField[T any](value *T, field *T, column string, columns *[]string) {
+	if value == nil {
+		return
+	}
+	*field = *value
+	*columns = append(*columns, column)
+}
@@ -0,0 +1,161 @@
+package gyms
+
+import (
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/jwallace145/crux-backend/internal/db"
+	"github.com/jwallace145/crux-backend/internal/handlers"
+	"github.com/jwallace145/crux-backend/internal/services"
+	"github.com/jwallace145/crux-backend/internal/utils"
+	"github.com/jwallace145/crux-backend/models"
+)
+
+// UpdateGymClimb handles PATCH /gyms/:id/climbs/:climb_id requests to update a gym catalog climb
+// Only the fields present in the request are updated, and setting strip_date marks the climb as stripped.
+// The discipline of a climb that has already been logged cannot be changed
+// Requires AuthMiddleware to be applied - reads user_id from context
+func UpdateGymClimb(c *fiber.Ctx) error {
+	apiName := "update_gym_climb"
+	log := utils.GetLoggerFromContext(c)
+
+	log.Info("Starting gym climb update process",
+		zap.String("api", apiName),
+	)
+
+	// Get user ID from context (set by AuthMiddleware)
+	userID, ok := c.Locals("user_id").(uint)
+	if !ok {
+		log.Error("User ID not found in context",
+			zap.String("api", apiName),
+		)
+		return handlers.InternalErrorResponse(c, apiName, "Authentication context missing", nil)
+	}
+
+	// Validate Content-Type header
+	if err := handlers.ValidateJSONContentType(c, apiName); err != nil {
+		return err
+	}
+
+	gym, err := loadEditableGym(c, apiName, userID)
+	if err != nil {
+		return err
+	}
+
+	climb, err := loadGymClimb(c, apiName, gym.ID)
+	if err != nil {
+		return err
+	}
+
+	// Parse request body
+	var req models.UpdateGymClimbRequest
+	if err := c.BodyParser(&req); err != nil {
+		log.Error("Failed to parse request body",
+			zap.Error(err),
+			zap.String("api", apiName),
+		)
+		return handlers.BadRequestResponse(c, apiName, "Invalid request body", err.Error())
+	}
+
+	// Merge the changes into the current climb and validate the result as a whole
+	merged := climb.ToCreateGymClimbRequest()
+	columns := []string{}
+	if req.GymWallID != nil {
+		merged.GymWallID = req.GymWallID
+		if *req.GymWallID == 0 {
+			merged.GymWallID = nil
+		}
+		columns = append(columns, "gym_wall_id")
+	}
+	setGymField(req.Discipline, &merged.Discipline, "discipline", &columns)
+	setGymField(req.Name, &merged.Name, "name", &columns)
+	setGymField(req.Color, &merged.Color, "color", &columns)
+	setGymField(req.Grade, &merged.Grade, "grade", &columns)
+	setGymField(req.Setter, &merged.Setter, "setter", &columns)
+	setGymField(req.StyleTags, &merged.StyleTags, "style_tags", &columns)
+	if req.SetDate != nil {
+		merged.SetDate = req.SetDate
+		columns = append(columns, "set_date")
+	}
+	if req.StripDate != nil {
+		merged.StripDate = req.StripDate
+		columns = append(columns, "strip_date")
+	}
+	if len(columns) == 0 {
+		return handlers.BadRequestResponse(c, apiName, "No fields to update", nil)
+	}
+
+	normalizeGymClimbRequest(merged)
+	if err := validateGymClimbRequest(merged); err != nil {
+		log.Warn("Request validation failed",
+			zap.Error(err),
+			zap.String("api", apiName),
+			zap.Uint("gym_climb_id", climb.ID),
+		)
+		return handlers.ValidationErrorResponse(c, apiName, err.Error(), nil)
+	}
+
+	if err := verifyGymWall(c, apiName, gym.ID, merged.GymWallID); err != nil {
+		return err
+	}
+
+	// Logged boulders and rope climbs must keep matching the discipline of the climb they reference
+	if merged.Discipline != climb.Discipline {
+		logs, err := services.GymClimbLogs(db.DB, []uint{climb.ID})
+		if err != nil {
+			log.Error("Database error while checking gym climb logs",
+				zap.Error(err),
+				zap.String("api", apiName),
+				zap.Uint("gym_climb_id", climb.ID),
+			)
+			return handlers.InternalErrorResponse(c, apiName, "Failed to update gym climb", nil)
+		}
+		if len(logs) > 0 {
+			return handlers.ConflictResponse(c, apiName, "The discipline of a climb that has been logged cannot be changed", nil)
+		}
+	}
+
+	// Only the columns present in the request are written, including zero values
+	if err := db.DB.Model(climb).Select(columns).Omit(clause.Associations).Updates(newGymClimbFromRequest(merged)).Error; err != nil {
+		log.Error("Failed to update gym climb in db",
+			zap.Error(err),
+			zap.String("api", apiName),
+			zap.Uint("gym_climb_id", climb.ID),
+		)
+		return handlers.InternalErrorResponse(c, apiName, "Failed to update gym climb", nil)
+	}
+
+	return respondWithGymClimb(c, apiName, climb.ID, "Gym climb updated successfully")
+}
+
+// loadGymClimb looks up the catalog climb in the :climb_id path parameter and ensures it belongs to the gym
+func loadGymClimb(c *fiber.Ctx, apiName string, gymID uint) (*models.GymClimb, error) {
+	log := utils.GetLoggerFromContext(c)
+
+	climbID, err := strconv.ParseUint(c.Params("climb_id"), 10, 32)
+	if err != nil {
+		return nil, handlers.BadRequestResponse(c, apiName, "climb_id must be a valid number", nil)
+	}
+
+	var climb models.GymClimb
+	if err := db.DB.Preload("GymWall").Where("id = ? AND gym_id = ?", climbID, gymID).First(&climb).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			log.Warn("Gym climb not found",
+				zap.String("api", apiName),
+				zap.Uint64("gym_climb_id", climbID),
+			)
+			return nil, handlers.NotFoundResponse(c, apiName, "Gym climb not found")
+		}
+		log.Error("Database error while looking up gym climb",
+			zap.Error(err),
+			zap.String("api", apiName),
+		)
+		return nil, handlers.InternalErrorResponse(c, apiName, "Failed to retrieve gym climb", nil)
+	}
+
+	return &climb, nil
+}
@@ -0,0 +1,130 @@
+package gyms
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	"github.com/jwallace145/crux-backend/internal/db"
+	"github.com/jwallace145/crux-backend/internal/handlers"
+	"github.com/jwallace145/crux-backend/internal/utils"
+	"github.com/jwallace145/crux-backend/models"
+)
+
+// UpdateGymWall handles PATCH /gyms/:id/walls/:wall_id requests to rename or describe a gym wall
+// Only the fields present in the request are updated
+// Requires AuthMiddleware to be applied - reads user_id from context
+func UpdateGymWall(c *fiber.Ctx) error {
+	apiName := "update_gym_wall"
+	log := utils.GetLoggerFromContext(c)
+
+	log.Info("Starting gym wall update process",
+		zap.String("api", apiName),
+	)
+
+	// Get user ID from context (set by AuthMiddleware)
+	userID, ok := c.Locals("user_id").(uint)
+	if !ok {
+		log.Error("User ID not found in context",
+			zap.String("api", apiName),
+		)
+		return handlers.InternalErrorResponse(c, apiName, "Authentication context missing", nil)
+	}
+
+	// Validate Content-Type header
+	if err := handlers.ValidateJSONContentType(c, apiName); err != nil {
+		return err
+	}
+
+	gym, err := loadEditableGym(c, apiName, userID)
+	if err != nil {
+		return err
+	}
+
+	wall, err := loadGymWall(c, apiName, gym.ID)
+	if err != nil {
+		return err
+	}
+
+	// Parse request body
+	var req models.UpdateGymWallRequest
+	if err := c.BodyParser(&req); err != nil {
+		log.Error("Failed to parse request body",
+			zap.Error(err),
+			zap.String("api", apiName),
+		)
+		return handlers.BadRequestResponse(c, apiName, "Invalid request body", err.Error())
+	}
+
+	if req.Name == nil && req.Description == nil {
+		return handlers.BadRequestResponse(c, apiName, "No fields to update", nil)
+	}
+
+	// Merge the changes into the current wall and validate the result
+	merged := models.CreateGymWallRequest{Name: wall.Name, Description: wall.Description}
+	if req.Name != nil {
+		merged.Name = strings.TrimSpace(*req.Name)
+	}
+	if req.Description != nil {
+		merged.Description = strings.TrimSpace(*req.Description)
+	}
+	if err := validateGymWallRequest(&merged); err != nil {
+		log.Warn("Request validation failed",
+			zap.Error(err),
+			zap.String("api", apiName),
+		)
+		return handlers.ValidationErrorResponse(c, apiName, err.Error(), nil)
+	}
+
+	if err := db.DB.Model(wall).Updates(map[string]interface{}{
+		"name":        merged.Name,
+		"description": merged.Description,
+	}).Error; err != nil {
+		log.Error("Failed to update gym wall in db",
+			zap.Error(err),
+			zap.String("api", apiName),
+			zap.Uint("gym_wall_id", wall.ID),
+		)
+		return handlers.InternalErrorResponse(c, apiName, "Failed to update gym wall", nil)
+	}
+
+	log.Info("Gym wall updated successfully",
+		zap.String("api", apiName),
+		zap.Uint("gym_id", gym.ID),
+		zap.Uint("gym_wall_id", wall.ID),
+		zap.Uint("user_id", userID),
+	)
+
+	return handlers.SuccessResponse(c, apiName, wall.ToGymWallResponse(), "Gym wall updated successfully")
+}
+
+// loadGymWall looks up the wall in the :wall_id path parameter and ensures it belongs to the gym
+func loadGymWall(c *fiber.Ctx, apiName string, gymID uint) (*models.GymWall, error) {
+	log := utils.GetLoggerFromContext(c)
+
+	wallID, err := strconv.ParseUint(c.Params("wall_id"), 10, 32)
+	if err != nil {
+		return nil, handlers.BadRequestResponse(c, apiName, "wall_id must be a valid number", nil)
+	}
+
+	var wall models.GymWall
+	if err := db.DB.Where("id = ? AND gym_id = ?", wallID, gymID).First(&wall).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			log.Warn("Gym wall not found",
+				zap.String("api", apiName),
+				zap.Uint64("gym_wall_id", wallID),
+			)
+			return nil, handlers.NotFoundResponse(c, apiName, "Gym wall not found")
+		}
+		log.Error("Database error while looking up gym wall",
+			zap.Error(err),
+			zap.String("api", apiName),
+		)
+		return nil, handlers.InternalErrorResponse(c, apiName, "Failed to retrieve gym wall", nil)
+	}
+
+	return &wall, nil
+}
@@ -0,0 +1,130 @@
+package gyms
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+
+	awsClient "github.com/jwallace145/crux-backend/internal/aws"
+	"github.com/jwallace145/crux-backend/internal/db"
+	"github.com/jwallace145/crux-backend/internal/handlers"
+	"github.com/jwallace145/crux-backend/internal/services"
+	"github.com/jwallace145/crux-backend/internal/utils"
+)
+
+// MaxGymClimbPhotoSize is the largest accepted gym climb photo
+const MaxGymClimbPhotoSize = 10 * 1024 * 1024 // 10MB
+
+// allowedGymClimbPhotoTypes maps accepted photo content types to their file extension
+var allowedGymClimbPhotoTypes = map[string]string{
+	"image/jpeg": ".jpg",
+	"image/png":  ".png",
+	"image/webp": ".webp",
+	"image/heic": ".heic",
+}
+
+// UploadGymClimbPhoto handles PUT /gyms/:id/climbs/:climb_id/photo requests to set the photo of a gym catalog climb
+// Accepts multipart/form-data with a single "file" field holding a JPEG, PNG, WebP or HEIC image up to 10MB.
+// Any previous photo is replaced and removed from S3
+// Requires AuthMiddleware to be applied - reads user_id from context
+func UploadGymClimbPhoto(c *fiber.Ctx) error {
+	apiName := "upload_gym_climb_photo"
+	log := utils.GetLoggerFromContext(c)
+
+	log.Info("Starting gym climb photo upload process",
+		zap.String("api", apiName),
+	)
+
+	// Get user ID from context (set by AuthMiddleware)
+	userID, ok := c.Locals("user_id").(uint)
+	if !ok {
+		log.Error("User ID not found in context",
+			zap.String("api", apiName),
+		)
+		return handlers.InternalErrorResponse(c, apiName, "Authentication context missing", nil)
+	}
+
+	gym, err := loadEditableGym(c, apiName, userID)
+	if err != nil {
+		return err
+	}
+
+	climb, err := loadGymClimb(c, apiName, gym.ID)
+	if err != nil {
+		return err
+	}
+
+	// Read and validate the uploaded photo
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		return handlers.BadRequestResponse(c, apiName, "file is required", nil)
+	}
+
+	contentType := fileHeader.Header.Get("Content-Type")
+	ext, ok := allowedGymClimbPhotoTypes[contentType]
+	if !ok {
+		return handlers.BadRequestResponse(c, apiName, "Photo must be a JPEG, PNG, WebP or HEIC image", map[string]string{
+			"received": contentType,
+		})
+	}
+	if fileHeader.Size > MaxGymClimbPhotoSize {
+		return handlers.BadRequestResponse(c, apiName, "Photos must not exceed 10MB", nil)
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		log.Error("Failed to open uploaded file",
+			zap.Error(err),
+			zap.String("api", apiName),
+		)
+		return handlers.InternalErrorResponse(c, apiName, "Failed to process file", nil)
+	}
+	defer file.Close()
+
+	fileBytes, err := io.ReadAll(file)
+	if err != nil {
+		log.Error("Failed to read uploaded file",
+			zap.Error(err),
+			zap.String("api", apiName),
+		)
+		return handlers.InternalErrorResponse(c, apiName, "Failed to process file", nil)
+	}
+
+	// Upload to S3
+	key := fmt.Sprintf("gyms/id=%d/climbs/id=%d/%s%s", gym.ID, climb.ID, uuid.New().String(), ext)
+	if _, err := awsClient.UploadFile(c.Context(), awsClient.MediaBucket, key, bytes.NewReader(fileBytes), contentType); err != nil {
+		log.Error("Failed to upload gym climb photo to S3",
+			zap.Error(err),
+			zap.String("api", apiName),
+		)
+		return handlers.InternalErrorResponse(c, apiName, "Failed to upload photo", nil)
+	}
+
+	// Point the climb at the new photo, removing the new object if the update fails
+	previousKey := climb.PhotoS3Key
+	if err := db.DB.Model(climb).Update("photo_s3_key", key).Error; err != nil {
+		log.Error("Failed to update gym climb photo in db",
+			zap.Error(err),
+			zap.String("api", apiName),
+			zap.Uint("gym_climb_id", climb.ID),
+		)
+		services.DeleteMediaObjects(c.Context(), []string{key})
+		return handlers.InternalErrorResponse(c, apiName, "Failed to upload photo", nil)
+	}
+	if previousKey != "" {
+		services.DeleteMediaObjects(c.Context(), []string{previousKey})
+	}
+
+	log.Info("Gym climb photo uploaded successfully",
+		zap.String("api", apiName),
+		zap.Uint("gym_climb_id", climb.ID),
+		zap.String("s3_key", key),
+		zap.Int64("size", fileHeader.Size),
+	)
+
+	return respondWithGymClimb(c, apiName, climb.ID, "Gym climb photo uploaded successfully")
+}
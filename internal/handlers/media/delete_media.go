@@ -0,0 +1,75 @@
+package media
+
+import (
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	"github.com/jwallace145/crux-backend/internal/db"
+	"github.com/jwallace145/crux-backend/internal/handlers"
+	"github.com/jwallace145/crux-backend/internal/services"
+	"github.com/jwallace145/crux-backend/internal/utils"
+	"github.com/jwallace145/crux-backend/models"
+)
+
+// DeleteMedia handles DELETE /media/:id requests to remove an attachment and its S3 object
+// Only the user who uploaded the attachment can delete it
+// Requires AuthMiddleware to be applied - reads user_id from context
+func DeleteMedia(c *fiber.Ctx) error {
+	apiName := "delete_media"
+	log := utils.GetLoggerFromContext(c)
+
+	log.Info("Starting delete media process",
+		zap.String("api", apiName),
+	)
+
+	// Get user ID from context (set by AuthMiddleware)
+	userID, ok := c.Locals("user_id").(uint)
+	if !ok {
+		log.Error("User ID not found in context",
+			zap.String("api", apiName),
+		)
+		return handlers.InternalErrorResponse(c, apiName, "Authentication context missing", nil)
+	}
+
+	mediaID, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return handlers.BadRequestResponse(c, apiName, "id must be a valid number", nil)
+	}
+
+	var attachment models.MediaAttachment
+	if err := db.DB.Where("id = ? AND user_id = ?", mediaID, userID).First(&attachment).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			log.Warn("Media attachment not found",
+				zap.String("api", apiName),
+				zap.Uint64("media_id", mediaID),
+			)
+			return handlers.NotFoundResponse(c, apiName, "Media not found")
+		}
+		log.Error("Database error while looking up media attachment",
+			zap.Error(err),
+			zap.String("api", apiName),
+		)
+		return handlers.InternalErrorResponse(c, apiName, "Failed to delete media", nil)
+	}
+
+	if err := db.DB.Delete(&attachment).Error; err != nil {
+		log.Error("Failed to delete media attachment in db",
+			zap.Error(err),
+			zap.String("api", apiName),
+			zap.Uint64("media_id", mediaID),
+		)
+		return handlers.InternalErrorResponse(c, apiName, "Failed to delete media", nil)
+	}
+
+	services.DeleteMediaObjects(c.Context(), []string{attachment.S3Key})
+
+	log.Info("Media attachment deleted successfully",
+		zap.String("api", apiName),
+		zap.Uint64("media_id", mediaID),
+	)
+
+	return handlers.SuccessResponse(c, apiName, map[string]interface{}{"id": attachment.ID}, "Media deleted successfully")
+}
@@ -0,0 +1,82 @@
+package media
+
+import (
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+
+	"github.com/jwallace145/crux-backend/internal/db"
+	"github.com/jwallace145/crux-backend/internal/handlers"
+	"github.com/jwallace145/crux-backend/internal/services"
+	"github.com/jwallace145/crux-backend/internal/utils"
+	"github.com/jwallace145/crux-backend/models"
+)
+
+// GetMedia handles GET /media requests to list the attachments of a climb or training session
+// Query parameters:
+//   - parent_type (required): "climb" or "training_session"
+//   - parent_id (required): The ID of the climb or training session
+//
+// Each attachment includes a presigned URL valid for 60 minutes
+// Requires AuthMiddleware to be applied - reads user_id from context
+func GetMedia(c *fiber.Ctx) error {
+	apiName := "get_media"
+	log := utils.GetLoggerFromContext(c)
+
+	log.Info("Starting get media process",
+		zap.String("api", apiName),
+	)
+
+	// Get user ID from context (set by AuthMiddleware)
+	userID, ok := c.Locals("user_id").(uint)
+	if !ok {
+		log.Error("User ID not found in context",
+			zap.String("api", apiName),
+		)
+		return handlers.InternalErrorResponse(c, apiName, "Authentication context missing", nil)
+	}
+
+	parentType := c.Query("parent_type")
+	parentID, err := strconv.ParseUint(c.Query("parent_id"), 10, 32)
+	if err != nil {
+		return handlers.BadRequestResponse(c, apiName, "parent_id must be a valid number", nil)
+	}
+
+	if err := verifyMediaParent(c, apiName, userID, parentType, uint(parentID)); err != nil {
+		return err
+	}
+
+	var attachments []models.MediaAttachment
+	if err := db.DB.
+		Where("parent_type = ? AND parent_id = ?", parentType, parentID).
+		Order("created_at ASC").
+		Find(&attachments).Error; err != nil {
+		log.Error("Database error while querying media attachments",
+			zap.Error(err),
+			zap.String("api", apiName),
+		)
+		return handlers.InternalErrorResponse(c, apiName, "Failed to retrieve media", nil)
+	}
+
+	log.Info("Media attachments retrieved successfully",
+		zap.String("api", apiName),
+		zap.String("parent_type", parentType),
+		zap.Uint64("parent_id", parentID),
+		zap.Int("count", len(attachments)),
+	)
+
+	responses := services.PresignMediaAttachments(c.Context(), attachments)
+	if responses == nil {
+		responses = []models.MediaAttachmentResponse{}
+	}
+
+	responseData := map[string]interface{}{
+		"media":       responses,
+		"count":       len(responses),
+		"parent_type": parentType,
+		"parent_id":   uint(parentID),
+	}
+
+	return handlers.SuccessResponse(c, apiName, responseData, "Media retrieved successfully")
+}
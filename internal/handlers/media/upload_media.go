@@ -0,0 +1,276 @@
+package media
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	_ "image/gif"  // register GIF decoder for image.DecodeConfig
+	_ "image/jpeg" // register JPEG decoder for image.DecodeConfig
+	_ "image/png"  // register PNG decoder for image.DecodeConfig
+	"io"
+	"mime/multipart"
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	awsClient "github.com/jwallace145/crux-backend/internal/aws"
+	"github.com/jwallace145/crux-backend/internal/db"
+	"github.com/jwallace145/crux-backend/internal/handlers"
+	"github.com/jwallace145/crux-backend/internal/services"
+	"github.com/jwallace145/crux-backend/internal/utils"
+	"github.com/jwallace145/crux-backend/models"
+)
+
+// Upload limits for media attachments
+const (
+	MaxImageSize          = 10 * 1024 * 1024 // 10MB
+	MaxVideoSize          = 50 * 1024 * 1024 // 50MB
+	MaxAttachmentsPerItem = 20
+	MaxCaptionLength      = 500
+)
+
+// allowedMediaTypes maps accepted content types to their file extension
+var allowedMediaTypes = map[string]string{
+	"image/jpeg":      ".jpg",
+	"image/png":       ".png",
+	"image/gif":       ".gif",
+	"image/webp":      ".webp",
+	"image/heic":      ".heic",
+	"video/mp4":       ".mp4",
+	"video/quicktime": ".mov",
+	"video/webm":      ".webm",
+}
+
+// UploadMedia handles POST /media requests to attach a photo or video to a climb or training session
+// Accepts multipart/form-data with the following fields:
+//   - file (required): The image or video file
+//   - parent_type (required): "climb" or "training_session"
+//   - parent_id (required): The ID of the climb or training session
+//   - caption (optional): Up to 500 characters
+//   - width, height (optional): Dimensions in pixels, detected automatically for JPEG, PNG and GIF images
+//   - duration_seconds (optional): Video duration
+//
+// Requires AuthMiddleware to be applied - reads user_id from context
+func UploadMedia(c *fiber.Ctx) error {
+	apiName := "upload_media"
+	log := utils.GetLoggerFromContext(c)
+
+	log.Info("Starting media upload process",
+		zap.String("api", apiName),
+	)
+
+	// Get user ID from context (set by AuthMiddleware)
+	userID, ok := c.Locals("user_id").(uint)
+	if !ok {
+		log.Error("User ID not found in context",
+			zap.String("api", apiName),
+		)
+		return handlers.InternalErrorResponse(c, apiName, "Authentication context missing", nil)
+	}
+
+	// Parse and validate parent reference
+	parentType := c.FormValue("parent_type")
+	parentID, err := strconv.ParseUint(c.FormValue("parent_id"), 10, 32)
+	if err != nil {
+		return handlers.BadRequestResponse(c, apiName, "parent_id must be a valid number", nil)
+	}
+
+	if err := verifyMediaParent(c, apiName, userID, parentType, uint(parentID)); err != nil {
+		return err
+	}
+
+	// Parse and validate optional metadata
+	attachment := &models.MediaAttachment{
+		UserID:     userID,
+		ParentType: parentType,
+		ParentID:   uint(parentID),
+		Caption:    c.FormValue("caption"),
+	}
+	if err := parseMediaMetadata(c, attachment); err != nil {
+		log.Warn("Media metadata validation failed",
+			zap.Error(err),
+			zap.String("api", apiName),
+		)
+		return handlers.ValidationErrorResponse(c, apiName, err.Error(), nil)
+	}
+
+	// Enforce the per-parent attachment limit
+	var existing int64
+	if err := db.DB.Model(&models.MediaAttachment{}).
+		Where("parent_type = ? AND parent_id = ?", parentType, parentID).
+		Count(&existing).Error; err != nil {
+		log.Error("Database error while counting attachments",
+			zap.Error(err),
+			zap.String("api", apiName),
+		)
+		return handlers.InternalErrorResponse(c, apiName, "Failed to upload media", nil)
+	}
+	if existing >= MaxAttachmentsPerItem {
+		return handlers.BadRequestResponse(c, apiName, fmt.Sprintf("A %s cannot have more than %d attachments", parentType, MaxAttachmentsPerItem), nil)
+	}
+
+	// Read and validate the uploaded file
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		return handlers.BadRequestResponse(c, apiName, "file is required", nil)
+	}
+
+	contentType := fileHeader.Header.Get("Content-Type")
+	ext, ok := allowedMediaTypes[contentType]
+	if !ok {
+		return handlers.BadRequestResponse(c, apiName, "File must be a JPEG, PNG, GIF, WebP or HEIC image, or an MP4, MOV or WebM video", map[string]string{
+			"received": contentType,
+		})
+	}
+	attachment.ContentType = contentType
+	attachment.SizeBytes = fileHeader.Size
+
+	if err := validateMediaSize(attachment); err != nil {
+		return handlers.BadRequestResponse(c, apiName, err.Error(), nil)
+	}
+
+	fileBytes, err := readMediaFile(fileHeader)
+	if err != nil {
+		log.Error("Failed to process uploaded file",
+			zap.Error(err),
+			zap.String("api", apiName),
+		)
+		return handlers.InternalErrorResponse(c, apiName, "Failed to process file", nil)
+	}
+
+	// Detect image dimensions when the client did not provide them
+	if attachment.IsImage() && (attachment.Width == nil || attachment.Height == nil) {
+		if cfg, _, err := image.DecodeConfig(bytes.NewReader(fileBytes)); err == nil {
+			attachment.Width = &cfg.Width
+			attachment.Height = &cfg.Height
+		}
+	}
+
+	// Upload to S3
+	attachment.S3Key = fmt.Sprintf("users/id=%d/media/%s/id=%d/%s%s", userID, parentType, parentID, uuid.New().String(), ext)
+
+	log.Info("Uploading media to S3",
+		zap.String("api", apiName),
+		zap.String("s3_key", attachment.S3Key),
+		zap.Int64("size", attachment.SizeBytes),
+	)
+
+	if _, err := awsClient.UploadFile(c.Context(), awsClient.MediaBucket, attachment.S3Key, bytes.NewReader(fileBytes), contentType); err != nil {
+		log.Error("Failed to upload media to S3",
+			zap.Error(err),
+			zap.String("api", apiName),
+		)
+		return handlers.InternalErrorResponse(c, apiName, "Failed to upload media", nil)
+	}
+
+	// Persist attachment, removing the S3 object if the insert fails
+	if err := db.DB.Create(attachment).Error; err != nil {
+		log.Error("Failed to create media attachment in db",
+			zap.Error(err),
+			zap.String("api", apiName),
+		)
+		services.DeleteMediaObjects(c.Context(), []string{attachment.S3Key})
+		return handlers.InternalErrorResponse(c, apiName, "Failed to upload media", nil)
+	}
+
+	log.Info("Media attachment created successfully",
+		zap.String("api", apiName),
+		zap.Uint("media_id", attachment.ID),
+		zap.String("parent_type", parentType),
+		zap.Uint64("parent_id", parentID),
+	)
+
+	response := services.PresignMediaAttachments(c.Context(), []models.MediaAttachment{*attachment})[0]
+	return handlers.CreatedResponse(c, apiName, response, "Media uploaded successfully")
+}
+
+// verifyMediaParent verifies that the parent climb or training session exists and belongs to the user
+func verifyMediaParent(c *fiber.Ctx, apiName string, userID uint, parentType string, parentID uint) error {
+	log := utils.GetLoggerFromContext(c)
+
+	var model interface{}
+	switch parentType {
+	case models.MediaParentTypeClimb:
+		model = &models.Climb{}
+	case models.MediaParentTypeTrainingSession:
+		model = &models.TrainingSession{}
+	default:
+		return handlers.BadRequestResponse(c, apiName, "parent_type must be 'climb' or 'training_session'", nil)
+	}
+
+	if err := db.DB.Where("id = ? AND user_id = ?", parentID, userID).First(model).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			log.Warn("Media parent not found",
+				zap.String("api", apiName),
+				zap.String("parent_type", parentType),
+				zap.Uint("parent_id", parentID),
+			)
+			return handlers.NotFoundResponse(c, apiName, "Parent "+parentType+" not found")
+		}
+		log.Error("Database error while checking media parent",
+			zap.Error(err),
+			zap.String("api", apiName),
+		)
+		return handlers.InternalErrorResponse(c, apiName, "Failed to verify parent", nil)
+	}
+
+	return nil
+}
+
+// parseMediaMetadata parses and validates the optional caption, dimension and duration form fields
+func parseMediaMetadata(c *fiber.Ctx, attachment *models.MediaAttachment) error {
+	if len(attachment.Caption) > MaxCaptionLength {
+		return fiber.NewError(fiber.StatusBadRequest, "Caption must not exceed 500 characters")
+	}
+
+	if value := c.FormValue("width"); value != "" {
+		width, err := strconv.Atoi(value)
+		if err != nil || width <= 0 {
+			return fiber.NewError(fiber.StatusBadRequest, "Width must be a positive number")
+		}
+		attachment.Width = &width
+	}
+
+	if value := c.FormValue("height"); value != "" {
+		height, err := strconv.Atoi(value)
+		if err != nil || height <= 0 {
+			return fiber.NewError(fiber.StatusBadRequest, "Height must be a positive number")
+		}
+		attachment.Height = &height
+	}
+
+	if value := c.FormValue("duration_seconds"); value != "" {
+		duration, err := strconv.ParseFloat(value, 64)
+		if err != nil || duration <= 0 {
+			return fiber.NewError(fiber.StatusBadRequest, "Duration must be a positive number of seconds")
+		}
+		attachment.DurationSeconds = &duration
+	}
+
+	return nil
+}
+
+// validateMediaSize validates the uploaded file size for its media type
+func validateMediaSize(attachment *models.MediaAttachment) error {
+	if attachment.IsVideo() && attachment.SizeBytes > MaxVideoSize {
+		return fiber.NewError(fiber.StatusBadRequest, "Videos must not exceed 50MB")
+	}
+	if attachment.IsImage() && attachment.SizeBytes > MaxImageSize {
+		return fiber.NewError(fiber.StatusBadRequest, "Images must not exceed 10MB")
+	}
+	return nil
+}
+
+// readMediaFile reads the content of an uploaded file
+func readMediaFile(fileHeader *multipart.FileHeader) ([]byte, error) {
+	file, err := fileHeader.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	return io.ReadAll(file)
+}
@@ -0,0 +1,203 @@
+package offline_sync
+
+import (
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+
+	"github.com/jwallace145/crux-backend/internal/db"
+	"github.com/jwallace145/crux-backend/internal/handlers"
+	"github.com/jwallace145/crux-backend/internal/utils"
+	"github.com/jwallace145/crux-backend/models"
+)
+
+// tombstonesSQL lists the user's records soft deleted after the since time
+const tombstonesSQL = `
+	SELECT 'climb' AS type, id, deleted_at FROM climbs
+	WHERE user_id = @user_id AND deleted_at > @since
+	UNION ALL
+	SELECT 'training_session', id, deleted_at FROM training_sessions
+	WHERE user_id = @user_id AND deleted_at > @since
+	UNION ALL
+	SELECT 'rope_climb', rc.id, rc.deleted_at FROM rope_climbs rc
+	JOIN training_sessions ts ON ts.id = rc.training_session_id
+	WHERE ts.user_id = @user_id AND rc.deleted_at > @since
+	UNION ALL
+	SELECT 'indoor_boulder', ib.id, ib.deleted_at FROM indoor_boulders ib
+	JOIN training_sessions ts ON ts.id = ib.training_session_id
+	WHERE ts.user_id = @user_id AND ib.deleted_at > @since
+	UNION ALL
+	SELECT 'exercise', e.id, e.deleted_at FROM exercises e
+	JOIN training_sessions ts ON ts.id = e.training_session_id
+	WHERE ts.user_id = @user_id AND e.deleted_at > @since
+	ORDER BY deleted_at`
+
+// PullChanges handles GET /sync requests to retrieve everything the user changed since a sync token
+// Query parameters:
+//   - since (optional): The sync_token returned by the previous sync. Omit for a full sync.
+//
+// Returns changed climbs, training sessions (with rope climbs, indoor boulders and exercises), the gyms they
+// reference, tombstones for deleted records and a new sync token for the next pull
+// Requires AuthMiddleware to be applied - reads user_id from context
+func PullChanges(c *fiber.Ctx) error {
+	apiName := "pull_changes"
+	log := utils.GetLoggerFromContext(c)
+
+	log.Info("Starting pull changes process",
+		zap.String("api", apiName),
+	)
+
+	// Get user ID from context (set by AuthMiddleware)
+	userID, ok := c.Locals("user_id").(uint)
+	if !ok {
+		log.Error("User ID not found in context",
+			zap.String("api", apiName),
+		)
+		return handlers.InternalErrorResponse(c, apiName, "Authentication context missing", nil)
+	}
+
+	since, err := decodeSyncToken(c.Query("since"))
+	if err != nil {
+		log.Warn("Invalid sync token",
+			zap.String("api", apiName),
+		)
+		return handlers.BadRequestResponse(c, apiName, err.Error(), nil)
+	}
+	fullSync := since.IsZero()
+
+	// Capture the next token before querying so concurrent writes are picked up next time
+	nextToken, err := encodeSyncToken(time.Now().Add(-syncTokenOverlap))
+	if err != nil {
+		log.Error("Failed to encode sync token",
+			zap.Error(err),
+			zap.String("api", apiName),
+		)
+		return handlers.InternalErrorResponse(c, apiName, "Failed to sync", nil)
+	}
+
+	log.Info("Pulling changes for user",
+		zap.String("api", apiName),
+		zap.Uint("user_id", userID),
+		zap.Bool("full_sync", fullSync),
+		zap.Time("since", since),
+	)
+
+	// Changed climbs
+	var climbs []models.Climb
+	climbQuery := db.DB.Where("user_id = ?", userID)
+	if !fullSync {
+		climbQuery = climbQuery.Where("updated_at > ?", since)
+	}
+	if err := climbQuery.Order("updated_at ASC, id ASC").Find(&climbs).Error; err != nil {
+		log.Error("Database error while querying changed climbs",
+			zap.Error(err),
+			zap.String("api", apiName),
+		)
+		return handlers.InternalErrorResponse(c, apiName, "Failed to sync", nil)
+	}
+
+	// Changed training sessions, including sessions whose rope climbs, indoor boulders or exercises changed
+	var sessions []models.TrainingSession
+	sessionQuery := db.DB.Where("user_id = ?", userID)
+	if !fullSync {
+		changedRopeClimbs := db.DB.Unscoped().Model(&models.RopeClimb{}).
+			Select("training_session_id").
+			Where("updated_at > ? OR deleted_at > ?", since, since)
+		changedBoulders := db.DB.Unscoped().Model(&models.IndoorBoulder{}).
+			Select("training_session_id").
+			Where("updated_at > ? OR deleted_at > ?", since, since)
+		changedExercises := db.DB.Unscoped().Model(&models.Exercise{}).
+			Select("training_session_id").
+			Where("updated_at > ? OR deleted_at > ?", since, since)
+		sessionQuery = sessionQuery.Where("(updated_at > ? OR id IN (?) OR id IN (?) OR id IN (?))", since, changedRopeClimbs, changedBoulders, changedExercises)
+	}
+	if err := sessionQuery.
+		Preload("Gym").
+		Preload("Partners").
+		Preload("IndoorBoulders").
+		Preload("RopeClimbs").
+		Preload("IndoorBoulders.Attempts").
+		Preload("RopeClimbs.Attempts").
+		Preload("Exercises").
+		Order("updated_at ASC, id ASC").
+		Find(&sessions).Error; err != nil {
+		log.Error("Database error while querying changed training sessions",
+			zap.Error(err),
+			zap.String("api", apiName),
+		)
+		return handlers.InternalErrorResponse(c, apiName, "Failed to sync", nil)
+	}
+
+	// Gyms referenced by changed records, plus changed gyms referenced by any of the user's records
+	gymIDs := []uint{}
+	for _, climb := range climbs {
+		if climb.GymID != nil {
+			gymIDs = append(gymIDs, *climb.GymID)
+		}
+	}
+	for _, session := range sessions {
+		gymIDs = append(gymIDs, session.GymID)
+	}
+
+	var gyms []models.Gym
+	gymQuery := db.DB
+	if fullSync {
+		gymQuery = gymQuery.Where("id IN ?", gymIDs)
+	} else {
+		climbGyms := db.DB.Model(&models.Climb{}).Select("gym_id").Where("user_id = ?", userID)
+		sessionGyms := db.DB.Model(&models.TrainingSession{}).Select("gym_id").Where("user_id = ?", userID)
+		gymQuery = gymQuery.Where("(id IN ? OR (updated_at > ? AND (id IN (?) OR id IN (?))))", gymIDs, since, climbGyms, sessionGyms)
+	}
+	if err := gymQuery.Preload("Amenities").Preload("Prices").Preload("OpeningHours").Preload("HoursExceptions").Order("id ASC").Find(&gyms).Error; err != nil {
+		log.Error("Database error while querying referenced gyms",
+			zap.Error(err),
+			zap.String("api", apiName),
+		)
+		return handlers.InternalErrorResponse(c, apiName, "Failed to sync", nil)
+	}
+
+	// Tombstones for records deleted since the last sync
+	tombstones := []models.SyncTombstone{}
+	if !fullSync {
+		if err := db.DB.Raw(tombstonesSQL, map[string]interface{}{
+			"user_id": userID,
+			"since":   since,
+		}).Scan(&tombstones).Error; err != nil {
+			log.Error("Database error while querying tombstones",
+				zap.Error(err),
+				zap.String("api", apiName),
+			)
+			return handlers.InternalErrorResponse(c, apiName, "Failed to sync", nil)
+		}
+	}
+
+	response := &models.SyncPullResponse{
+		Climbs:           make([]*models.ClimbResponse, len(climbs)),
+		TrainingSessions: make([]*models.TrainingSessionResponse, len(sessions)),
+		Gyms:             make([]*models.FullGymResponse, len(gyms)),
+		Tombstones:       tombstones,
+		FullSync:         fullSync,
+		SyncToken:        nextToken,
+	}
+	for i := range climbs {
+		response.Climbs[i] = climbs[i].ToClimbResponse()
+	}
+	for i := range sessions {
+		response.TrainingSessions[i] = sessions[i].ToTrainingSessionResponse()
+	}
+	for i := range gyms {
+		response.Gyms[i] = gyms[i].ToFullGymResponse()
+	}
+
+	log.Info("Changes pulled successfully",
+		zap.String("api", apiName),
+		zap.Uint("user_id", userID),
+		zap.Int("climbs", len(climbs)),
+		zap.Int("training_sessions", len(sessions)),
+		zap.Int("gyms", len(gyms)),
+		zap.Int("tombstones", len(tombstones)),
+	)
+
+	return handlers.SuccessResponse(c, apiName, response, "Changes retrieved successfully")
+}
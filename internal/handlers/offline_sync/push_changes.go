@@ -0,0 +1,565 @@
+package offline_sync
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	"github.com/jwallace145/crux-backend/internal/db"
+	"github.com/jwallace145/crux-backend/internal/handlers"
+	"github.com/jwallace145/crux-backend/internal/handlers/climbs"
+	"github.com/jwallace145/crux-backend/internal/handlers/training_sessions"
+	"github.com/jwallace145/crux-backend/internal/services"
+	"github.com/jwallace145/crux-backend/internal/utils"
+	"github.com/jwallace145/crux-backend/models"
+)
+
+// MaxSyncChanges is the maximum number of changes accepted in a single push request
+const MaxSyncChanges = 500
+
+// maxClientClockSkew is how far in the future a client updated_at may be before it is rejected
+const maxClientClockSkew = 5 * time.Minute
+
+// PushChanges handles POST /sync requests to apply climbs and training sessions changed on the client
+// Each change is applied independently using last-writer-wins on updated_at:
+//   - Changes without an id are created
+//   - Changes newer than the server version are applied (deleted records are restored by a newer edit)
+//   - Changes older than the server version are rejected with status "conflict" and the server version
+//
+// Training session changes replace the session's rope climbs and indoor boulders
+// Requires AuthMiddleware to be applied - reads user_id from context
+func PushChanges(c *fiber.Ctx) error {
+	apiName := "push_changes"
+	log := utils.GetLoggerFromContext(c)
+
+	log.Info("Starting push changes process",
+		zap.String("api", apiName),
+	)
+
+	// Validate Content-Type header
+	if err := handlers.ValidateJSONContentType(c, apiName); err != nil {
+		return err
+	}
+
+	// Parse request body
+	var req models.SyncPushRequest
+	if err := c.BodyParser(&req); err != nil {
+		log.Error("Failed to parse request body",
+			zap.Error(err),
+			zap.String("api", apiName),
+		)
+		return handlers.BadRequestResponse(c, apiName, "Invalid request body", err.Error())
+	}
+
+	total := len(req.Climbs) + len(req.TrainingSessions)
+	if total == 0 {
+		return handlers.ValidationErrorResponse(c, apiName, "At least one change is required", nil)
+	}
+	if total > MaxSyncChanges {
+		return handlers.ValidationErrorResponse(c, apiName, fmt.Sprintf("A push must not exceed %d changes", MaxSyncChanges), nil)
+	}
+
+	// Get user ID from context (set by AuthMiddleware)
+	userID, ok := c.Locals("user_id").(uint)
+	if !ok {
+		log.Error("User ID not found in context",
+			zap.String("api", apiName),
+		)
+		return handlers.InternalErrorResponse(c, apiName, "Authentication context missing", nil)
+	}
+
+	log.Info("Applying pushed changes",
+		zap.String("api", apiName),
+		zap.Uint("user_id", userID),
+		zap.Int("climbs", len(req.Climbs)),
+		zap.Int("training_sessions", len(req.TrainingSessions)),
+	)
+
+	response := &models.SyncPushResponse{
+		Results: make([]models.SyncChangeResult, 0, total),
+	}
+
+	var mediaKeys []string
+	for i := range req.Climbs {
+		result, keys := applyClimbChange(c, apiName, userID, &req.Climbs[i])
+		mediaKeys = append(mediaKeys, keys...)
+		addSyncResult(response, result)
+	}
+	for i := range req.TrainingSessions {
+		result, keys := applyTrainingSessionChange(c, apiName, userID, &req.TrainingSessions[i])
+		mediaKeys = append(mediaKeys, keys...)
+		addSyncResult(response, result)
+	}
+
+	// Remove media of deleted records from S3 once the rows are gone
+	services.DeleteMediaObjects(c.Context(), mediaKeys)
+
+	log.Info("Pushed changes applied",
+		zap.String("api", apiName),
+		zap.Uint("user_id", userID),
+		zap.Int("applied", response.Applied),
+		zap.Int("conflicts", response.Conflicts),
+		zap.Int("failed", response.Failed),
+	)
+
+	return handlers.SuccessResponse(c, apiName, response, "Changes applied")
+}
+
+// applyClimbChange applies a single pushed climb change and returns its result and any S3 keys to delete
+func applyClimbChange(c *fiber.Ctx, apiName string, userID uint, change *models.SyncClimbChange) (models.SyncChangeResult, []string) {
+	log := utils.GetLoggerFromContext(c)
+	result := models.SyncChangeResult{ClientID: change.ClientID, Type: models.SyncEntityClimb}
+
+	if err := validateSyncChange(change.UpdatedAt, change.Deleted, change.Climb == nil); err != nil {
+		return syncError(result, models.ErrorCodeValidationFail, err.Error()), nil
+	}
+	if !change.Deleted {
+		if err := climbs.ValidateCreateClimbRequest(change.Climb); err != nil {
+			return syncError(result, models.ErrorCodeValidationFail, err.Error()), nil
+		}
+		if message, err := verifyClimbReferences(change.Climb); err != nil {
+			log.Error("Database error while verifying climb references",
+				zap.Error(err),
+				zap.String("api", apiName),
+			)
+			return syncError(result, models.ErrorCodeInternalError, "Failed to apply change"), nil
+		} else if message != "" {
+			return syncError(result, models.ErrorCodeInvalidInput, message), nil
+		}
+	}
+
+	// Climbs created offline
+	if change.ID == nil {
+		if change.Deleted {
+			result.Status = models.SyncChangeStatusDeleted
+			return result, nil
+		}
+
+		climb := climbs.NewClimbFromRequest(userID, change.Climb)
+		if err := db.DB.Transaction(func(tx *gorm.DB) error {
+			if err := services.LinkClimbsToProjects(tx, userID, []*models.Climb{climb}); err != nil {
+				return err
+			}
+			return tx.Create(climb).Error
+		}); err != nil {
+			log.Error("Failed to create synced climb",
+				zap.Error(err),
+				zap.String("api", apiName),
+			)
+			return syncError(result, models.ErrorCodeInternalError, "Failed to apply change"), nil
+		}
+
+		result.ID = climb.ID
+		result.Status = models.SyncChangeStatusCreated
+		return result, nil
+	}
+
+	result.ID = *change.ID
+
+	var existing models.Climb
+	if err := db.DB.Unscoped().Where("id = ? AND user_id = ?", *change.ID, userID).First(&existing).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return syncError(result, models.ErrorCodeNotFound, "Climb not found"), nil
+		}
+		log.Error("Database error while looking up synced climb",
+			zap.Error(err),
+			zap.String("api", apiName),
+		)
+		return syncError(result, models.ErrorCodeInternalError, "Failed to apply change"), nil
+	}
+
+	// Last writer wins - keep the server version if it is newer
+	if serverModifiedAt(existing.Model).After(change.UpdatedAt) {
+		result.Status = models.SyncChangeStatusConflict
+		if existing.DeletedAt.Valid {
+			result.Server = models.SyncTombstone{Type: models.SyncEntityClimb, ID: existing.ID, DeletedAt: existing.DeletedAt.Time}
+		} else {
+			result.Server = existing.ToClimbResponse()
+		}
+		return result, nil
+	}
+
+	if change.Deleted {
+		if existing.DeletedAt.Valid {
+			result.Status = models.SyncChangeStatusDeleted
+			return result, nil
+		}
+
+		var keys []string
+		if err := db.DB.Transaction(func(tx *gorm.DB) error {
+			mediaKeys, err := services.DeleteParentMedia(tx, models.MediaParentTypeClimb, existing.ID)
+			if err != nil {
+				return err
+			}
+			keys = mediaKeys
+			return tx.Delete(&existing).Error
+		}); err != nil {
+			log.Error("Failed to delete synced climb",
+				zap.Error(err),
+				zap.String("api", apiName),
+			)
+			return syncError(result, models.ErrorCodeInternalError, "Failed to apply change"), nil
+		}
+
+		result.Status = models.SyncChangeStatusDeleted
+		return result, keys
+	}
+
+	// Apply the client version, restoring the climb if it was deleted
+	updated := climbs.NewClimbFromRequest(userID, change.Climb)
+	if err := db.DB.Transaction(func(tx *gorm.DB) error {
+		if err := services.LinkClimbsToProjects(tx, userID, []*models.Climb{updated}); err != nil {
+			return err
+		}
+		return tx.Unscoped().Model(&existing).Updates(map[string]interface{}{
+			"route_id":           updated.RouteID,
+			"outdoor_boulder_id": updated.OutdoorBoulderID,
+			"gym_id":             updated.GymID,
+			"project_id":         updated.ProjectID,
+			"climb_type":         updated.ClimbType,
+			"climb_date":         updated.ClimbDate,
+			"grade":              updated.Grade,
+			"style":              updated.Style,
+			"completed":          updated.Completed,
+			"attempts":           updated.Attempts,
+			"falls":              updated.Falls,
+			"rating":             updated.Rating,
+			"notes":              updated.Notes,
+			"deleted_at":         nil,
+		}).Error
+	}); err != nil {
+		log.Error("Failed to update synced climb",
+			zap.Error(err),
+			zap.String("api", apiName),
+		)
+		return syncError(result, models.ErrorCodeInternalError, "Failed to apply change"), nil
+	}
+
+	result.Status = models.SyncChangeStatusUpdated
+	return result, nil
+}
+
+// applyTrainingSessionChange applies a single pushed training session change and returns its result
+// and any S3 keys to delete
+func applyTrainingSessionChange(c *fiber.Ctx, apiName string, userID uint, change *models.SyncTrainingSessionChange) (models.SyncChangeResult, []string) {
+	log := utils.GetLoggerFromContext(c)
+	result := models.SyncChangeResult{ClientID: change.ClientID, Type: models.SyncEntityTrainingSession}
+
+	if err := validateSyncChange(change.UpdatedAt, change.Deleted, change.TrainingSession == nil); err != nil {
+		return syncError(result, models.ErrorCodeValidationFail, err.Error()), nil
+	}
+
+	var partners []models.User
+	if !change.Deleted {
+		req := change.TrainingSession
+		if err := training_sessions.ValidateCreateTrainingSessionRequest(req); err != nil {
+			return syncError(result, models.ErrorCodeValidationFail, err.Error()), nil
+		}
+
+		message, found, err := verifyTrainingSessionReferences(userID, req)
+		if err != nil {
+			log.Error("Database error while verifying training session references",
+				zap.Error(err),
+				zap.String("api", apiName),
+			)
+			return syncError(result, models.ErrorCodeInternalError, "Failed to apply change"), nil
+		}
+		if message != "" {
+			return syncError(result, models.ErrorCodeInvalidInput, message), nil
+		}
+		partners = found
+	}
+
+	// Sessions created offline
+	if change.ID == nil {
+		if change.Deleted {
+			result.Status = models.SyncChangeStatusDeleted
+			return result, nil
+		}
+
+		req := change.TrainingSession
+		session := &models.TrainingSession{
+			UserID:          userID,
+			GymID:           req.GymID,
+			SessionDate:     req.SessionDate,
+			Description:     req.Description,
+			RPE:             req.RPE,
+			DurationMinutes: req.DurationMinutes,
+			PainFlags:       req.PainFlags,
+		}
+		err := training_sessions.CreateTrainingSessionWithRelations(session, partners, req)
+		if errors.Is(err, services.ErrPlannedSessionUnavailable) {
+			return syncError(result, models.ErrorCodeConflict, "Planned session not found or already completed"), nil
+		}
+		if err != nil {
+			log.Error("Failed to create synced training session",
+				zap.Error(err),
+				zap.String("api", apiName),
+			)
+			return syncError(result, models.ErrorCodeInternalError, "Failed to apply change"), nil
+		}
+
+		result.ID = session.ID
+		result.Status = models.SyncChangeStatusCreated
+		return result, nil
+	}
+
+	result.ID = *change.ID
+
+	var existing models.TrainingSession
+	if err := db.DB.Unscoped().Where("id = ? AND user_id = ?", *change.ID, userID).First(&existing).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return syncError(result, models.ErrorCodeNotFound, "Training session not found"), nil
+		}
+		log.Error("Database error while looking up synced training session",
+			zap.Error(err),
+			zap.String("api", apiName),
+		)
+		return syncError(result, models.ErrorCodeInternalError, "Failed to apply change"), nil
+	}
+
+	// Last writer wins - keep the server version if it is newer
+	if serverModifiedAt(existing.Model).After(change.UpdatedAt) {
+		result.Status = models.SyncChangeStatusConflict
+		if existing.DeletedAt.Valid {
+			result.Server = models.SyncTombstone{Type: models.SyncEntityTrainingSession, ID: existing.ID, DeletedAt: existing.DeletedAt.Time}
+			return result, nil
+		}
+		if err := db.DB.
+			Preload("Gym").
+			Preload("Partners").
+			Preload("IndoorBoulders").
+			Preload("RopeClimbs").
+			Preload("IndoorBoulders.Attempts").
+			Preload("RopeClimbs.Attempts").
+			Preload("Exercises").
+			First(&existing, existing.ID).Error; err != nil {
+			log.Error("Failed to load server training session",
+				zap.Error(err),
+				zap.String("api", apiName),
+			)
+			return syncError(result, models.ErrorCodeInternalError, "Failed to apply change"), nil
+		}
+		result.Server = existing.ToTrainingSessionResponse()
+		return result, nil
+	}
+
+	if change.Deleted {
+		if existing.DeletedAt.Valid {
+			result.Status = models.SyncChangeStatusDeleted
+			return result, nil
+		}
+
+		var keys []string
+		if err := db.DB.Transaction(func(tx *gorm.DB) error {
+			mediaKeys, err := services.DeleteTrainingSession(tx, &existing)
+			keys = mediaKeys
+			return err
+		}); err != nil {
+			log.Error("Failed to delete synced training session",
+				zap.Error(err),
+				zap.String("api", apiName),
+			)
+			return syncError(result, models.ErrorCodeInternalError, "Failed to apply change"), nil
+		}
+
+		result.Status = models.SyncChangeStatusDeleted
+		return result, keys
+	}
+
+	// Apply the client version, restoring the session if it was deleted. Partners keep their
+	// invitations, and the planned session is linked with the same checks as a new session
+	req := change.TrainingSession
+	if err := db.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Unscoped().Model(&existing).Updates(map[string]interface{}{
+			"gym_id":           req.GymID,
+			"session_date":     req.SessionDate,
+			"description":      req.Description,
+			"rpe":              req.RPE,
+			"duration_minutes": req.DurationMinutes,
+			"pain_flags":       models.PainFlags(req.PainFlags),
+			"deleted_at":       nil,
+		}).Error; err != nil {
+			return err
+		}
+		if err := services.ReplaceSessionPartners(tx, existing.ID, partners); err != nil {
+			return err
+		}
+		if err := services.SetPlannedSession(tx, userID, existing.ID, req.PlannedSessionID); err != nil {
+			return err
+		}
+		return replaceSessionClimbs(tx, existing.ID, req)
+	}); err != nil {
+		if errors.Is(err, services.ErrPlannedSessionUnavailable) {
+			return syncError(result, models.ErrorCodeConflict, "Planned session not found or already completed"), nil
+		}
+		log.Error("Failed to update synced training session",
+			zap.Error(err),
+			zap.String("api", apiName),
+		)
+		return syncError(result, models.ErrorCodeInternalError, "Failed to apply change"), nil
+	}
+
+	result.Status = models.SyncChangeStatusUpdated
+	return result, nil
+}
+
+// replaceSessionClimbs soft deletes the rope climbs, indoor boulders, their attempts and exercises of a session and creates those in the request
+func replaceSessionClimbs(tx *gorm.DB, sessionID uint, req *models.CreateTrainingSessionRequest) error {
+	if err := services.DeleteSessionClimbAttempts(tx, sessionID); err != nil {
+		return err
+	}
+	if err := tx.Where("training_session_id = ?", sessionID).Delete(&models.RopeClimb{}).Error; err != nil {
+		return err
+	}
+	if err := tx.Where("training_session_id = ?", sessionID).Delete(&models.IndoorBoulder{}).Error; err != nil {
+		return err
+	}
+	if err := tx.Where("training_session_id = ?", sessionID).Delete(&models.Exercise{}).Error; err != nil {
+		return err
+	}
+
+	if len(req.RopeClimbs) > 0 {
+		ropeClimbs := make([]models.RopeClimb, len(req.RopeClimbs))
+		for i := range req.RopeClimbs {
+			ropeClimbs[i] = *req.RopeClimbs[i].ToRopeClimb()
+			ropeClimbs[i].TrainingSessionID = sessionID
+		}
+		if err := tx.Create(&ropeClimbs).Error; err != nil {
+			return err
+		}
+	}
+
+	if len(req.IndoorBoulders) > 0 {
+		boulders := make([]models.IndoorBoulder, len(req.IndoorBoulders))
+		for i := range req.IndoorBoulders {
+			boulders[i] = *req.IndoorBoulders[i].ToIndoorBoulder()
+			boulders[i].TrainingSessionID = sessionID
+		}
+		if err := tx.Create(&boulders).Error; err != nil {
+			return err
+		}
+	}
+
+	if len(req.Exercises) > 0 {
+		exercises := make([]models.Exercise, len(req.Exercises))
+		for i := range req.Exercises {
+			exercises[i] = *req.Exercises[i].ToExercise()
+			exercises[i].TrainingSessionID = sessionID
+		}
+		if err := tx.Create(&exercises).Error; err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// validateSyncChange validates the fields shared by every pushed change
+func validateSyncChange(updatedAt time.Time, deleted bool, missingRecord bool) error {
+	if updatedAt.IsZero() {
+		return fiber.NewError(fiber.StatusBadRequest, "updated_at is required")
+	}
+	if updatedAt.After(time.Now().Add(maxClientClockSkew)) {
+		return fiber.NewError(fiber.StatusBadRequest, "updated_at cannot be in the future")
+	}
+	if !deleted && missingRecord {
+		return fiber.NewError(fiber.StatusBadRequest, "The changed record is required unless deleted is true")
+	}
+	return nil
+}
+
+// verifyClimbReferences checks the route, outdoor boulder and gym referenced by a climb exist
+// It returns a message describing the first missing reference, or an empty string
+func verifyClimbReferences(req *models.CreateClimbRequest) (string, error) {
+	checks := []struct {
+		id      *uint
+		model   interface{}
+		message string
+	}{
+		{req.RouteID, &models.Route{}, "Route not found"},
+		{req.OutdoorBoulderID, &models.OutdoorBoulder{}, "Outdoor boulder not found"},
+		{req.GymID, &models.Gym{}, "Gym not found"},
+	}
+
+	for _, check := range checks {
+		if check.id == nil {
+			continue
+		}
+		var count int64
+		if err := db.DB.Model(check.model).Where("id = ?", *check.id).Count(&count).Error; err != nil {
+			return "", err
+		}
+		if count == 0 {
+			return check.message, nil
+		}
+	}
+
+	return "", nil
+}
+
+// verifyTrainingSessionReferences checks the gym, gym catalog climbs and partners referenced by a training
+// session exist and that none of the partners have blocked the user
+// It returns a message describing the first invalid reference, or an empty string, and the partners
+func verifyTrainingSessionReferences(userID uint, req *models.CreateTrainingSessionRequest) (string, []models.User, error) {
+	var count int64
+	if err := db.DB.Model(&models.Gym{}).Where("id = ?", req.GymID).Count(&count).Error; err != nil {
+		return "", nil, err
+	}
+	if count == 0 {
+		return "Gym not found", nil, nil
+	}
+
+	ok, err := services.GymClimbsBelongToGym(db.DB, req.GymID, req.IndoorBoulders, req.RopeClimbs)
+	if err != nil {
+		return "", nil, err
+	}
+	if !ok {
+		return "One or more gym climbs not found at this gym", nil, nil
+	}
+
+	partners, err := services.VerifySessionPartners(db.DB, userID, req.PartnerIDs)
+	if errors.Is(err, services.ErrPartnersNotFound) {
+		return "One or more partners not found", nil, nil
+	}
+	if errors.Is(err, services.ErrPartnersBlocked) {
+		return "One or more partners cannot be tagged", nil, nil
+	}
+	if err != nil {
+		return "", nil, err
+	}
+
+	return "", partners, nil
+}
+
+// serverModifiedAt returns when a record was last changed on the server, including deletion
+func serverModifiedAt(model gorm.Model) time.Time {
+	if model.DeletedAt.Valid && model.DeletedAt.Time.After(model.UpdatedAt) {
+		return model.DeletedAt.Time
+	}
+	return model.UpdatedAt
+}
+
+// syncError records a failed change on a result
+func syncError(result models.SyncChangeResult, code, message string) models.SyncChangeResult {
+	result.Status = models.SyncChangeStatusError
+	result.Error = &models.APIError{Code: code, Message: message}
+	return result
+}
+
+// addSyncResult appends a result to the push response and updates the counters
+func addSyncResult(response *models.SyncPushResponse, result models.SyncChangeResult) {
+	switch result.Status {
+	case models.SyncChangeStatusConflict:
+		response.Conflicts++
+	case models.SyncChangeStatusError:
+		response.Failed++
+	default:
+		response.Applied++
+	}
+	response.Results = append(response.Results, result)
+}
@@ -0,0 +1,53 @@
+package offline_sync
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// syncTokenOverlap is subtracted from the issued token time so that rows written by transactions
+// that commit after the pull started are returned again on the next pull. Clients must treat
+// pulled records as upserts.
+const syncTokenOverlap = 5 * time.Second
+
+// syncTokenPayload is the JSON structure encoded inside the opaque sync token
+type syncTokenPayload struct {
+	Since string `json:"t"`
+}
+
+// encodeSyncToken builds an opaque, URL-safe sync token for changes after the given time
+func encodeSyncToken(since time.Time) (string, error) {
+	raw, err := json.Marshal(syncTokenPayload{Since: since.UTC().Format(time.RFC3339Nano)})
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(raw), nil
+}
+
+// decodeSyncToken parses a sync token. An empty token returns the zero time, meaning a full sync.
+func decodeSyncToken(token string) (time.Time, error) {
+	if token == "" {
+		return time.Time{}, nil
+	}
+
+	invalid := fiber.NewError(fiber.StatusBadRequest, "since must be a sync_token returned by a previous sync")
+
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return time.Time{}, invalid
+	}
+
+	var payload syncTokenPayload
+	if err := json.Unmarshal(raw, &payload); err != nil {
+		return time.Time{}, invalid
+	}
+
+	since, err := time.Parse(time.RFC3339Nano, payload.Since)
+	if err != nil || since.After(time.Now()) {
+		return time.Time{}, invalid
+	}
+	return since, nil
+}
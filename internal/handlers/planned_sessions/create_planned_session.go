@@ -0,0 +1,166 @@
+package planned_sessions
+
+import (
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	"github.com/jwallace145/crux-backend/internal/db"
+	"github.com/jwallace145/crux-backend/internal/handlers"
+	"github.com/jwallace145/crux-backend/internal/utils"
+	"github.com/jwallace145/crux-backend/models"
+)
+
+// CreatePlannedSession handles POST /planned-sessions requests to schedule a workout template on a date
+// The template must belong to the user or be public. Log a training session with planned_session_id
+// set to complete the plan and get a compliance score
+// Requires AuthMiddleware to be applied - reads user_id from context
+func CreatePlannedSession(c *fiber.Ctx) error {
+	apiName := "create_planned_session"
+	log := utils.GetLoggerFromContext(c)
+
+	log.Info("Starting planned session creation process",
+		zap.String("api", apiName),
+	)
+
+	// Validate Content-Type header
+	if err := handlers.ValidateJSONContentType(c, apiName); err != nil {
+		return err
+	}
+
+	// Get user ID from context (set by AuthMiddleware)
+	userID, ok := c.Locals("user_id").(uint)
+	if !ok {
+		log.Error("User ID not found in context",
+			zap.String("api", apiName),
+		)
+		return handlers.InternalErrorResponse(c, apiName, "Authentication context missing", nil)
+	}
+
+	// Parse request body
+	var req models.CreatePlannedSessionRequest
+	if err := c.BodyParser(&req); err != nil {
+		log.Error("Failed to parse request body",
+			zap.Error(err),
+			zap.String("api", apiName),
+		)
+		return handlers.BadRequestResponse(c, apiName, "Invalid request body", err.Error())
+	}
+
+	// Validate request
+	if err := validateCreatePlannedSessionRequest(&req); err != nil {
+		log.Warn("Request validation failed",
+			zap.Error(err),
+			zap.String("api", apiName),
+		)
+		return handlers.ValidationErrorResponse(c, apiName, err.Error(), nil)
+	}
+
+	// Verify the template can be scheduled by the user
+	var count int64
+	if err := db.DB.Model(&models.WorkoutTemplate{}).
+		Where("id = ? AND (user_id = ? OR is_public = ?)", req.WorkoutTemplateID, userID, true).
+		Count(&count).Error; err != nil {
+		log.Error("Database error while checking workout template",
+			zap.Error(err),
+			zap.String("api", apiName),
+		)
+		return handlers.InternalErrorResponse(c, apiName, "Failed to verify workout template", nil)
+	}
+	if count == 0 {
+		log.Warn("Workout template not found",
+			zap.String("api", apiName),
+			zap.Uint("workout_template_id", req.WorkoutTemplateID),
+		)
+		return handlers.BadRequestResponse(c, apiName, "Workout template not found", map[string]interface{}{
+			"workout_template_id": req.WorkoutTemplateID,
+		})
+	}
+
+	// Verify the gym exists if provided
+	if req.GymID != nil {
+		if err := db.DB.Model(&models.Gym{}).Where("id = ?", *req.GymID).Count(&count).Error; err != nil {
+			log.Error("Database error while checking gym",
+				zap.Error(err),
+				zap.String("api", apiName),
+			)
+			return handlers.InternalErrorResponse(c, apiName, "Failed to verify gym", nil)
+		}
+		if count == 0 {
+			log.Warn("Gym not found",
+				zap.String("api", apiName),
+				zap.Uint("gym_id", *req.GymID),
+			)
+			return handlers.BadRequestResponse(c, apiName, "Gym not found", map[string]interface{}{
+				"gym_id": *req.GymID,
+			})
+		}
+	}
+
+	plannedSession := &models.PlannedSession{
+		UserID:            userID,
+		WorkoutTemplateID: req.WorkoutTemplateID,
+		PlannedDate:       req.PlannedDate,
+		GymID:             req.GymID,
+		Notes:             req.Notes,
+	}
+	if err := db.DB.Create(plannedSession).Error; err != nil {
+		log.Error("Failed to create planned session in db",
+			zap.Error(err),
+			zap.String("api", apiName),
+			zap.Uint("user_id", userID),
+		)
+		return handlers.InternalErrorResponse(c, apiName, "Failed to create planned session", nil)
+	}
+
+	created, err := loadPlannedSession(c, apiName, plannedSession.ID, userID)
+	if err != nil {
+		return err
+	}
+
+	log.Info("Planned session created successfully",
+		zap.String("api", apiName),
+		zap.Uint("planned_session_id", created.ID),
+		zap.Uint("user_id", userID),
+		zap.Time("planned_date", created.PlannedDate),
+	)
+
+	return handlers.CreatedResponse(c, apiName, created.ToPlannedSessionResponse(), "Planned session created successfully")
+}
+
+// validateCreatePlannedSessionRequest validates the create planned session request
+func validateCreatePlannedSessionRequest(req *models.CreatePlannedSessionRequest) error {
+	if req.WorkoutTemplateID == 0 {
+		return fiber.NewError(fiber.StatusBadRequest, "Workout template ID is required")
+	}
+	if req.PlannedDate.IsZero() {
+		return fiber.NewError(fiber.StatusBadRequest, "Planned date is required")
+	}
+	now := time.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	if req.PlannedDate.Before(today) {
+		return fiber.NewError(fiber.StatusBadRequest, "Planned date cannot be in the past")
+	}
+	if len(req.Notes) > 1000 {
+		return fiber.NewError(fiber.StatusBadRequest, "Notes must not exceed 1000 characters")
+	}
+	return nil
+}
+
+// preloadPlannedSession loads the template, gym and linked training session needed for compliance
+// Deleted templates are still loaded so plans made from them keep their compliance scores
+func preloadPlannedSession(tx *gorm.DB) *gorm.DB {
+	return tx.
+		Preload("WorkoutTemplate", func(tx *gorm.DB) *gorm.DB {
+			return tx.Unscoped()
+		}).
+		Preload("WorkoutTemplate.Items", func(tx *gorm.DB) *gorm.DB {
+			return tx.Order("position ASC")
+		}).
+		Preload("Gym").
+		Preload("TrainingSession.IndoorBoulders").
+		Preload("TrainingSession.RopeClimbs").
+		Preload("TrainingSession.Exercises")
+}
@@ -0,0 +1,66 @@
+package planned_sessions
+
+import (
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+
+	"github.com/jwallace145/crux-backend/internal/db"
+	"github.com/jwallace145/crux-backend/internal/handlers"
+	"github.com/jwallace145/crux-backend/internal/utils"
+	"github.com/jwallace145/crux-backend/models"
+)
+
+// DeletePlannedSession handles DELETE /planned-sessions/:id requests to remove a planned session
+// A linked training session is kept
+// Requires AuthMiddleware to be applied - reads user_id from context
+func DeletePlannedSession(c *fiber.Ctx) error {
+	apiName := "delete_planned_session"
+	log := utils.GetLoggerFromContext(c)
+
+	log.Info("Starting delete planned session process",
+		zap.String("api", apiName),
+	)
+
+	// Get user ID from context (set by AuthMiddleware)
+	userID, ok := c.Locals("user_id").(uint)
+	if !ok {
+		log.Error("User ID not found in context",
+			zap.String("api", apiName),
+		)
+		return handlers.InternalErrorResponse(c, apiName, "Authentication context missing", nil)
+	}
+
+	plannedSessionID, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return handlers.BadRequestResponse(c, apiName, "id must be a valid number", nil)
+	}
+
+	// Hard delete so the linked training session can be linked to another plan
+	result := db.DB.Unscoped().Where("id = ? AND user_id = ?", plannedSessionID, userID).Delete(&models.PlannedSession{})
+	if result.Error != nil {
+		log.Error("Failed to delete planned session in db",
+			zap.Error(result.Error),
+			zap.String("api", apiName),
+			zap.Uint64("planned_session_id", plannedSessionID),
+		)
+		return handlers.InternalErrorResponse(c, apiName, "Failed to delete planned session", nil)
+	}
+
+	if result.RowsAffected == 0 {
+		log.Warn("Planned session not found",
+			zap.String("api", apiName),
+			zap.Uint64("planned_session_id", plannedSessionID),
+		)
+		return handlers.NotFoundResponse(c, apiName, "Planned session not found")
+	}
+
+	log.Info("Planned session deleted successfully",
+		zap.String("api", apiName),
+		zap.Uint64("planned_session_id", plannedSessionID),
+		zap.Uint("user_id", userID),
+	)
+
+	return handlers.SuccessResponse(c, apiName, map[string]interface{}{"id": plannedSessionID}, "Planned session deleted successfully")
+}
@@ -0,0 +1,81 @@
+package planned_sessions
+
+import (
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	"github.com/jwallace145/crux-backend/internal/db"
+	"github.com/jwallace145/crux-backend/internal/handlers"
+	"github.com/jwallace145/crux-backend/internal/utils"
+	"github.com/jwallace145/crux-backend/models"
+)
+
+// GetPlannedSession handles GET /planned-sessions/:id requests to retrieve a planned session
+// Once a training session is linked, the response includes its compliance score
+// Requires AuthMiddleware to be applied - reads user_id from context
+func GetPlannedSession(c *fiber.Ctx) error {
+	apiName := "get_planned_session"
+	log := utils.GetLoggerFromContext(c)
+
+	log.Info("Starting get planned session process",
+		zap.String("api", apiName),
+	)
+
+	// Get user ID from context (set by AuthMiddleware)
+	userID, ok := c.Locals("user_id").(uint)
+	if !ok {
+		log.Error("User ID not found in context",
+			zap.String("api", apiName),
+		)
+		return handlers.InternalErrorResponse(c, apiName, "Authentication context missing", nil)
+	}
+
+	plannedSessionID, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return handlers.BadRequestResponse(c, apiName, "id must be a valid number", nil)
+	}
+
+	plannedSession, err := loadPlannedSession(c, apiName, uint(plannedSessionID), userID)
+	if err != nil {
+		return err
+	}
+
+	response := plannedSession.ToPlannedSessionResponse()
+
+	log.Info("Planned session retrieved successfully",
+		zap.String("api", apiName),
+		zap.Uint("planned_session_id", plannedSession.ID),
+		zap.Uint("user_id", userID),
+		zap.String("status", response.Status),
+	)
+
+	return handlers.SuccessResponse(c, apiName, response, "Planned session retrieved successfully")
+}
+
+// loadPlannedSession fetches one of the user's planned sessions with everything needed for compliance
+func loadPlannedSession(c *fiber.Ctx, apiName string, plannedSessionID, userID uint) (*models.PlannedSession, error) {
+	log := utils.GetLoggerFromContext(c)
+
+	var plannedSession models.PlannedSession
+	if err := preloadPlannedSession(db.DB).
+		Where("id = ? AND user_id = ?", plannedSessionID, userID).
+		First(&plannedSession).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			log.Warn("Planned session not found",
+				zap.String("api", apiName),
+				zap.Uint("planned_session_id", plannedSessionID),
+			)
+			return nil, handlers.NotFoundResponse(c, apiName, "Planned session not found")
+		}
+		log.Error("Database error while looking up planned session",
+			zap.Error(err),
+			zap.String("api", apiName),
+		)
+		return nil, handlers.InternalErrorResponse(c, apiName, "Failed to retrieve planned session", nil)
+	}
+
+	return &plannedSession, nil
+}
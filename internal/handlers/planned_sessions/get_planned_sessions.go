@@ -0,0 +1,143 @@
+package planned_sessions
+
+import (
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+
+	"github.com/jwallace145/crux-backend/internal/db"
+	"github.com/jwallace145/crux-backend/internal/handlers"
+	"github.com/jwallace145/crux-backend/internal/query"
+	"github.com/jwallace145/crux-backend/internal/utils"
+	"github.com/jwallace145/crux-backend/models"
+)
+
+// plannedSessionListSpec defines the whitelisted sort fields for GET /planned-sessions
+var plannedSessionListSpec = &query.Spec{
+	SortFields: map[string]query.SortField{
+		"planned_date": {Column: "planned_date", Type: query.TypeTime},
+		"created_at":   {Column: "created_at", Type: query.TypeTime},
+		"id":           {Column: "id", Type: query.TypeUint},
+	},
+	DefaultSort: "planned_date",
+}
+
+// GetPlannedSessions handles GET /planned-sessions requests to list the user's planned sessions
+// Query parameters:
+//   - status (optional): Only "planned", "completed" or "missed" sessions
+//   - from, to (optional): RFC3339 planned date range. Unlike other list endpoints, dates in the future are included
+//   - sort (optional): Comma separated sort fields, prefix with "-" for descending (default "planned_date")
+//   - limit (optional): Page size (default 50, max 200)
+//   - cursor (optional): The next_cursor value returned by the previous page
+//
+// Requires AuthMiddleware to be applied - reads user_id from context
+func GetPlannedSessions(c *fiber.Ctx) error {
+	apiName := "get_planned_sessions"
+	log := utils.GetLoggerFromContext(c)
+
+	log.Info("Starting get planned sessions process",
+		zap.String("api", apiName),
+	)
+
+	// Get user ID from context (set by AuthMiddleware)
+	userID, ok := c.Locals("user_id").(uint)
+	if !ok {
+		log.Error("User ID not found in context",
+			zap.String("api", apiName),
+		)
+		return handlers.InternalErrorResponse(c, apiName, "Authentication context missing", nil)
+	}
+
+	// Parse pagination and sorting query parameters
+	params, err := query.Parse(c, plannedSessionListSpec)
+	if err != nil {
+		log.Warn("Invalid list query parameters",
+			zap.Error(err),
+			zap.String("api", apiName),
+		)
+		return handlers.BadRequestResponse(c, apiName, err.Error(), nil)
+	}
+
+	plannedQuery := db.DB.Where("user_id = ?", userID)
+
+	for _, bound := range []struct {
+		param string
+		sql   string
+	}{
+		{"from", "planned_date >= ?"},
+		{"to", "planned_date <= ?"},
+	} {
+		raw := c.Query(bound.param)
+		if raw == "" {
+			continue
+		}
+		value, err := time.Parse(time.RFC3339, raw)
+		if err != nil {
+			return handlers.BadRequestResponse(c, apiName, bound.param+" must be in RFC3339 format (e.g., 2024-01-01T00:00:00Z)", nil)
+		}
+		plannedQuery = plannedQuery.Where(bound.sql, value)
+	}
+
+	now := time.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	switch c.Query("status") {
+	case "":
+	case models.PlannedSessionStatusCompleted:
+		plannedQuery = plannedQuery.Where("training_session_id IS NOT NULL")
+	case models.PlannedSessionStatusPlanned:
+		plannedQuery = plannedQuery.Where("training_session_id IS NULL AND planned_date >= ?", today)
+	case models.PlannedSessionStatusMissed:
+		plannedQuery = plannedQuery.Where("training_session_id IS NULL AND planned_date < ?", today)
+	default:
+		return handlers.BadRequestResponse(c, apiName, "status must be 'planned', 'completed' or 'missed'", nil)
+	}
+
+	var plannedSessions []models.PlannedSession
+	if err := preloadPlannedSession(params.Apply(plannedQuery)).Find(&plannedSessions).Error; err != nil {
+		log.Error("Database error while querying planned sessions",
+			zap.Error(err),
+			zap.String("api", apiName),
+			zap.Uint("user_id", userID),
+		)
+		return handlers.InternalErrorResponse(c, apiName, "Failed to retrieve planned sessions", nil)
+	}
+
+	plannedSessions, nextCursor, err := query.Paginate(params, plannedSessions, plannedSessionCursorKey)
+	if err != nil {
+		log.Error("Failed to encode next page cursor",
+			zap.Error(err),
+			zap.String("api", apiName),
+		)
+		return handlers.InternalErrorResponse(c, apiName, "Failed to paginate planned sessions", nil)
+	}
+
+	plannedResponses := make([]*models.PlannedSessionResponse, len(plannedSessions))
+	for i := range plannedSessions {
+		plannedResponses[i] = plannedSessions[i].ToPlannedSessionResponse()
+	}
+
+	log.Info("Planned sessions retrieved successfully",
+		zap.String("api", apiName),
+		zap.Uint("user_id", userID),
+		zap.Int("count", len(plannedResponses)),
+	)
+
+	responseData := map[string]interface{}{
+		"planned_sessions": plannedResponses,
+		"count":            len(plannedResponses),
+		"limit":            params.Limit,
+		"next_cursor":      nextCursor,
+	}
+
+	return handlers.SuccessResponse(c, apiName, responseData, "Planned sessions retrieved successfully")
+}
+
+// plannedSessionCursorKey returns the sort field values of a planned session used to build the next page cursor
+func plannedSessionCursorKey(plannedSession models.PlannedSession) query.Key {
+	return query.Key{
+		"id":           plannedSession.ID,
+		"planned_date": plannedSession.PlannedDate,
+		"created_at":   plannedSession.CreatedAt,
+	}
+}
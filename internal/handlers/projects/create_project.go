@@ -0,0 +1,258 @@
+package projects
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	"github.com/jwallace145/crux-backend/internal/db"
+	"github.com/jwallace145/crux-backend/internal/handlers"
+	"github.com/jwallace145/crux-backend/internal/utils"
+	"github.com/jwallace145/crux-backend/models"
+)
+
+// CreateProject handles POST /projects requests to start projecting a route or outdoor boulder
+// Existing climbs of the user on the same route or boulder are linked to the new project
+// Only one active project per route or boulder is allowed
+// Requires AuthMiddleware to be applied - reads user_id from context
+func CreateProject(c *fiber.Ctx) error {
+	apiName := "create_project"
+	log := utils.GetLoggerFromContext(c)
+
+	log.Info("Starting project creation process",
+		zap.String("api", apiName),
+	)
+
+	// Validate Content-Type header
+	if err := handlers.ValidateJSONContentType(c, apiName); err != nil {
+		return err
+	}
+
+	// Parse request body
+	var req models.CreateProjectRequest
+	if err := c.BodyParser(&req); err != nil {
+		log.Error("Failed to parse request body",
+			zap.Error(err),
+			zap.String("api", apiName),
+		)
+		return handlers.BadRequestResponse(c, apiName, "Invalid request body", err.Error())
+	}
+
+	// Validate request
+	if err := validateCreateProjectRequest(&req); err != nil {
+		log.Warn("Request validation failed",
+			zap.Error(err),
+			zap.String("api", apiName),
+		)
+		return handlers.ValidationErrorResponse(c, apiName, err.Error(), nil)
+	}
+
+	// Get user ID from context (set by AuthMiddleware)
+	userID, ok := c.Locals("user_id").(uint)
+	if !ok {
+		log.Error("User ID not found in context",
+			zap.String("api", apiName),
+		)
+		return handlers.InternalErrorResponse(c, apiName, "Authentication context missing", nil)
+	}
+
+	log.Info("User ID retrieved from context",
+		zap.String("api", apiName),
+		zap.Uint("user_id", userID),
+	)
+
+	// Verify the route or boulder exists
+	targetColumn := "route_id"
+	targetID := req.RouteID
+	var target interface{} = &models.Route{}
+	if req.OutdoorBoulderID != nil {
+		targetColumn = "outdoor_boulder_id"
+		targetID = req.OutdoorBoulderID
+		target = &models.OutdoorBoulder{}
+	}
+
+	if err := db.DB.First(target, *targetID).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			log.Warn("Project target not found",
+				zap.String("api", apiName),
+				zap.String("target", targetColumn),
+				zap.Uint("target_id", *targetID),
+			)
+			message := "Route not found"
+			if req.OutdoorBoulderID != nil {
+				message = "Outdoor boulder not found"
+			}
+			return handlers.BadRequestResponse(c, apiName, message, map[string]interface{}{
+				targetColumn: *targetID,
+			})
+		}
+		log.Error("Database error while checking project target",
+			zap.Error(err),
+			zap.String("api", apiName),
+		)
+		return handlers.InternalErrorResponse(c, apiName, "Failed to verify project target", nil)
+	}
+
+	// Only one active project per route or boulder
+	var existing models.Project
+	err := db.DB.
+		Where("user_id = ? AND status = ?", userID, models.ProjectStatusActive).
+		Where(targetColumn+" = ?", *targetID).
+		First(&existing).Error
+	if err == nil {
+		log.Warn("Active project already exists",
+			zap.String("api", apiName),
+			zap.Uint("project_id", existing.ID),
+		)
+		return handlers.ConflictResponse(c, apiName, "An active project already exists for this climb", map[string]interface{}{
+			"project_id": existing.ID,
+		})
+	}
+	if err != gorm.ErrRecordNotFound {
+		log.Error("Database error while checking existing projects",
+			zap.Error(err),
+			zap.String("api", apiName),
+		)
+		return handlers.InternalErrorResponse(c, apiName, "Failed to create project", nil)
+	}
+
+	project := &models.Project{
+		UserID:           userID,
+		RouteID:          req.RouteID,
+		OutdoorBoulderID: req.OutdoorBoulderID,
+		Status:           models.ProjectStatusActive,
+		HighPoint:        req.HighPoint,
+		BetaNotes:        req.BetaNotes,
+	}
+
+	// Create the project and link earlier unlinked climbs on the same target
+	var linked int64
+	if err := db.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(project).Error; err != nil {
+			return err
+		}
+		result := tx.Model(&models.Climb{}).
+			Where("user_id = ? AND project_id IS NULL", userID).
+			Where(targetColumn+" = ?", *targetID).
+			Update("project_id", project.ID)
+		linked = result.RowsAffected
+		return result.Error
+	}); err != nil {
+		log.Error("Failed to create project in db",
+			zap.Error(err),
+			zap.String("api", apiName),
+			zap.Uint("user_id", userID),
+		)
+		return handlers.InternalErrorResponse(c, apiName, "Failed to create project", nil)
+	}
+
+	log.Info("Project created successfully in db",
+		zap.String("api", apiName),
+		zap.Uint("project_id", project.ID),
+		zap.Uint("user_id", userID),
+		zap.Int64("climbs_linked", linked),
+	)
+
+	project, err = loadProject(project.ID, userID)
+	if err != nil {
+		log.Error("Failed to reload project",
+			zap.Error(err),
+			zap.String("api", apiName),
+		)
+		return handlers.InternalErrorResponse(c, apiName, "Failed to create project", nil)
+	}
+
+	response := project.ToProjectResponse()
+	if err := applyProjectStats([]*models.ProjectResponse{response}); err != nil {
+		log.Error("Failed to compute project stats",
+			zap.Error(err),
+			zap.String("api", apiName),
+		)
+		return handlers.InternalErrorResponse(c, apiName, "Failed to create project", nil)
+	}
+
+	return handlers.CreatedResponse(c, apiName, response, "Project created successfully")
+}
+
+// validateCreateProjectRequest validates the create project request
+func validateCreateProjectRequest(req *models.CreateProjectRequest) error {
+	// Exactly one target is required
+	if req.RouteID == nil && req.OutdoorBoulderID == nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Either route_id or outdoor_boulder_id is required")
+	}
+
+	if req.RouteID != nil && req.OutdoorBoulderID != nil {
+		return fiber.NewError(fiber.StatusBadRequest, "Project cannot reference both a route and an outdoor boulder")
+	}
+
+	return validateProjectDetails(req.HighPoint, req.BetaNotes)
+}
+
+// validateProjectDetails validates the free text fields of a project
+func validateProjectDetails(highPoint, betaNotes string) error {
+	if len(highPoint) > 200 {
+		return fiber.NewError(fiber.StatusBadRequest, "High point must not exceed 200 characters")
+	}
+
+	if len(betaNotes) > 5000 {
+		return fiber.NewError(fiber.StatusBadRequest, "Beta notes must not exceed 5000 characters")
+	}
+
+	return nil
+}
+
+// loadProject fetches a project owned by the user along with its route or boulder
+func loadProject(projectID, userID uint) (*models.Project, error) {
+	var project models.Project
+	if err := db.DB.
+		Preload("Route").
+		Preload("OutdoorBoulder").
+		Where("id = ? AND user_id = ?", projectID, userID).
+		First(&project).Error; err != nil {
+		return nil, err
+	}
+	return &project, nil
+}
+
+// projectStats holds the aggregated climb totals of a project
+type projectStats struct {
+	ProjectID     uint
+	TotalSessions int
+	TotalAttempts int
+	TotalFalls    int
+}
+
+// applyProjectStats fills in the session, attempt and fall totals of each project with a single query
+func applyProjectStats(responses []*models.ProjectResponse) error {
+	if len(responses) == 0 {
+		return nil
+	}
+
+	ids := make([]uint, len(responses))
+	for i, response := range responses {
+		ids[i] = response.ID
+	}
+
+	var stats []projectStats
+	if err := db.DB.Model(&models.Climb{}).
+		Select("project_id, COUNT(DISTINCT DATE(climb_date)) AS total_sessions, COALESCE(SUM(attempts), 0) AS total_attempts, COALESCE(SUM(falls), 0) AS total_falls").
+		Where("project_id IN ?", ids).
+		Group("project_id").
+		Scan(&stats).Error; err != nil {
+		return err
+	}
+
+	byProject := make(map[uint]projectStats, len(stats))
+	for _, stat := range stats {
+		byProject[stat.ProjectID] = stat
+	}
+
+	for _, response := range responses {
+		stat := byProject[response.ID]
+		response.TotalSessions = stat.TotalSessions
+		response.TotalAttempts = stat.TotalAttempts
+		response.TotalFalls = stat.TotalFalls
+	}
+
+	return nil
+}
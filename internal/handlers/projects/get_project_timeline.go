@@ -0,0 +1,136 @@
+package projects
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	"github.com/jwallace145/crux-backend/internal/db"
+	"github.com/jwallace145/crux-backend/internal/handlers"
+	"github.com/jwallace145/crux-backend/internal/utils"
+	"github.com/jwallace145/crux-backend/models"
+)
+
+// GetProjectTimeline handles GET /projects/:id/timeline requests to show every attempt session on a project
+// Linked climbs are grouped by day with attempt and fall totals, along with the number of days it took to send
+// Requires AuthMiddleware to be applied - reads user_id from context
+func GetProjectTimeline(c *fiber.Ctx) error {
+	apiName := "get_project_timeline"
+	log := utils.GetLoggerFromContext(c)
+
+	log.Info("Starting get project timeline process",
+		zap.String("api", apiName),
+	)
+
+	// Get user ID from context (set by AuthMiddleware)
+	userID, ok := c.Locals("user_id").(uint)
+	if !ok {
+		log.Error("User ID not found in context",
+			zap.String("api", apiName),
+		)
+		return handlers.InternalErrorResponse(c, apiName, "Authentication context missing", nil)
+	}
+
+	projectID, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return handlers.BadRequestResponse(c, apiName, "id must be a valid number", nil)
+	}
+
+	project, err := loadProject(uint(projectID), userID)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			log.Warn("Project not found",
+				zap.String("api", apiName),
+				zap.Uint64("project_id", projectID),
+			)
+			return handlers.NotFoundResponse(c, apiName, "Project not found")
+		}
+		log.Error("Database error while looking up project",
+			zap.Error(err),
+			zap.String("api", apiName),
+		)
+		return handlers.InternalErrorResponse(c, apiName, "Failed to retrieve project timeline", nil)
+	}
+
+	var climbs []models.Climb
+	if err := db.DB.
+		Where("project_id = ?", project.ID).
+		Order("climb_date ASC, id ASC").
+		Find(&climbs).Error; err != nil {
+		log.Error("Database error while querying project climbs",
+			zap.Error(err),
+			zap.String("api", apiName),
+			zap.Uint("project_id", project.ID),
+		)
+		return handlers.InternalErrorResponse(c, apiName, "Failed to retrieve project timeline", nil)
+	}
+
+	timeline := buildProjectTimeline(project, climbs)
+
+	log.Info("Project timeline retrieved successfully",
+		zap.String("api", apiName),
+		zap.Uint("project_id", project.ID),
+		zap.Int("sessions", len(timeline.Sessions)),
+		zap.Int("climbs", len(climbs)),
+	)
+
+	return handlers.SuccessResponse(c, apiName, timeline, "Project timeline retrieved successfully")
+}
+
+// buildProjectTimeline groups the climbs of a project, ordered by climb date, into daily sessions
+func buildProjectTimeline(project *models.Project, climbs []models.Climb) *models.ProjectTimelineResponse {
+	response := project.ToProjectResponse()
+	timeline := &models.ProjectTimelineResponse{
+		Project:  response,
+		Sessions: []models.ProjectSessionResponse{},
+		SentAt:   project.SentAt,
+	}
+
+	for i := range climbs {
+		climb := &climbs[i]
+		date := climb.ClimbDate.UTC().Format(time.DateOnly)
+
+		if len(timeline.Sessions) == 0 || timeline.Sessions[len(timeline.Sessions)-1].Date != date {
+			timeline.Sessions = append(timeline.Sessions, models.ProjectSessionResponse{
+				Date:   date,
+				Climbs: []*models.ClimbResponse{},
+			})
+		}
+
+		session := &timeline.Sessions[len(timeline.Sessions)-1]
+		session.Attempts += climb.Attempts
+		session.Falls += climb.Falls
+		session.Sent = session.Sent || climb.Completed
+		session.Climbs = append(session.Climbs, climb.ToClimbResponse())
+
+		response.TotalAttempts += climb.Attempts
+		response.TotalFalls += climb.Falls
+	}
+	response.TotalSessions = len(timeline.Sessions)
+
+	if len(climbs) > 0 {
+		firstAttemptAt := climbs[0].ClimbDate
+		timeline.FirstAttemptAt = &firstAttemptAt
+
+		if project.SentAt != nil {
+			first := truncateToDay(firstAttemptAt)
+			sent := truncateToDay(*project.SentAt)
+			days := int(sent.Sub(first).Hours() / 24)
+			if days < 0 {
+				days = 0
+			}
+			timeline.DaysToSend = &days
+		}
+	}
+
+	return timeline
+}
+
+// truncateToDay returns midnight UTC of the given time's calendar day
+func truncateToDay(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,129 @@
+package projects
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+
+	"github.com/jwallace145/crux-backend/internal/db"
+	"github.com/jwallace145/crux-backend/internal/handlers"
+	"github.com/jwallace145/crux-backend/internal/query"
+	"github.com/jwallace145/crux-backend/internal/utils"
+	"github.com/jwallace145/crux-backend/models"
+)
+
+// projectListSpec defines the whitelisted sort fields and filters for GET /projects
+var projectListSpec = &query.Spec{
+	SortFields: map[string]query.SortField{
+		"created_at": {Column: "created_at", Type: query.TypeTime},
+		"updated_at": {Column: "updated_at", Type: query.TypeTime},
+		"id":         {Column: "id", Type: query.TypeUint},
+	},
+	DefaultSort: "-updated_at",
+	Filters: []query.Filter{
+		{Param: "status", Column: "status", Type: query.TypeString, Kind: query.FilterEquals},
+	},
+}
+
+// GetProjects handles GET /projects requests to list the authenticated user's projects
+// Query parameters:
+//   - status (optional): Only projects with this status ("active", "sent" or "abandoned")
+//   - sort (optional): Comma separated sort fields, prefix with "-" for descending (default "-updated_at")
+//   - limit (optional): Page size (default 50, max 200)
+//   - cursor (optional): The next_cursor value returned by the previous page
+//
+// Requires AuthMiddleware to be applied - reads user_id from context
+func GetProjects(c *fiber.Ctx) error {
+	apiName := "get_projects"
+	log := utils.GetLoggerFromContext(c)
+
+	log.Info("Starting get projects process",
+		zap.String("api", apiName),
+	)
+
+	// Get user ID from context (set by AuthMiddleware)
+	userID, ok := c.Locals("user_id").(uint)
+	if !ok {
+		log.Error("User ID not found in context",
+			zap.String("api", apiName),
+		)
+		return handlers.InternalErrorResponse(c, apiName, "Authentication context missing", nil)
+	}
+
+	// Parse pagination, filtering and sorting query parameters
+	params, err := query.Parse(c, projectListSpec)
+	if err != nil {
+		log.Warn("Invalid list query parameters",
+			zap.Error(err),
+			zap.String("api", apiName),
+		)
+		return handlers.BadRequestResponse(c, apiName, err.Error(), nil)
+	}
+
+	log.Info("Querying projects for user",
+		zap.String("api", apiName),
+		zap.Uint("user_id", userID),
+		zap.Int("limit", params.Limit),
+		zap.Bool("has_cursor", params.Cursor != nil),
+		zap.Any("filters", params.Applied),
+	)
+
+	var projects []models.Project
+	if err := params.Apply(db.DB.Where("user_id = ?", userID)).
+		Preload("Route").
+		Preload("OutdoorBoulder").
+		Find(&projects).Error; err != nil {
+		log.Error("Database error while querying projects",
+			zap.Error(err),
+			zap.String("api", apiName),
+			zap.Uint("user_id", userID),
+		)
+		return handlers.InternalErrorResponse(c, apiName, "Failed to retrieve projects", nil)
+	}
+
+	projects, nextCursor, err := query.Paginate(params, projects, projectCursorKey)
+	if err != nil {
+		log.Error("Failed to encode next page cursor",
+			zap.Error(err),
+			zap.String("api", apiName),
+		)
+		return handlers.InternalErrorResponse(c, apiName, "Failed to paginate projects", nil)
+	}
+
+	projectResponses := make([]*models.ProjectResponse, len(projects))
+	for i := range projects {
+		projectResponses[i] = projects[i].ToProjectResponse()
+	}
+
+	if err := applyProjectStats(projectResponses); err != nil {
+		log.Error("Failed to compute project stats",
+			zap.Error(err),
+			zap.String("api", apiName),
+		)
+		return handlers.InternalErrorResponse(c, apiName, "Failed to retrieve projects", nil)
+	}
+
+	log.Info("Projects retrieved successfully",
+		zap.String("api", apiName),
+		zap.Uint("user_id", userID),
+		zap.Int("count", len(projectResponses)),
+		zap.Bool("has_more", nextCursor != ""),
+	)
+
+	responseData := map[string]interface{}{
+		"projects":    projectResponses,
+		"count":       len(projectResponses),
+		"limit":       params.Limit,
+		"next_cursor": nextCursor,
+	}
+
+	return handlers.SuccessResponse(c, apiName, responseData, "Projects retrieved successfully")
+}
+
+// projectCursorKey returns the sort field values of a project used to build the next page cursor
+func projectCursorKey(project models.Project) query.Key {
+	return query.Key{
+		"id":         project.ID,
+		"created_at": project.CreatedAt,
+		"updated_at": project.UpdatedAt,
+	}
+}
@@ -0,0 +1,184 @@
+package projects
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	"github.com/jwallace145/crux-backend/internal/db"
+	"github.com/jwallace145/crux-backend/internal/handlers"
+	"github.com/jwallace145/crux-backend/internal/utils"
+	"github.com/jwallace145/crux-backend/models"
+)
+
+// UpdateProject handles PATCH /projects/:id requests to update the status, high point or beta of a project
+// Marking a project sent records the send time; reactivating it clears the send time
+// Requires AuthMiddleware to be applied - reads user_id from context
+func UpdateProject(c *fiber.Ctx) error {
+	apiName := "update_project"
+	log := utils.GetLoggerFromContext(c)
+
+	log.Info("Starting update project process",
+		zap.String("api", apiName),
+	)
+
+	// Validate Content-Type header
+	if err := handlers.ValidateJSONContentType(c, apiName); err != nil {
+		return err
+	}
+
+	// Get user ID from context (set by AuthMiddleware)
+	userID, ok := c.Locals("user_id").(uint)
+	if !ok {
+		log.Error("User ID not found in context",
+			zap.String("api", apiName),
+		)
+		return handlers.InternalErrorResponse(c, apiName, "Authentication context missing", nil)
+	}
+
+	projectID, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return handlers.BadRequestResponse(c, apiName, "id must be a valid number", nil)
+	}
+
+	var req models.UpdateProjectRequest
+	if err := c.BodyParser(&req); err != nil {
+		log.Error("Failed to parse request body",
+			zap.Error(err),
+			zap.String("api", apiName),
+		)
+		return handlers.BadRequestResponse(c, apiName, "Invalid request body", err.Error())
+	}
+
+	if err := validateUpdateProjectRequest(&req); err != nil {
+		log.Warn("Request validation failed",
+			zap.Error(err),
+			zap.String("api", apiName),
+		)
+		return handlers.ValidationErrorResponse(c, apiName, err.Error(), nil)
+	}
+
+	project, err := loadProject(uint(projectID), userID)
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			log.Warn("Project not found",
+				zap.String("api", apiName),
+				zap.Uint64("project_id", projectID),
+			)
+			return handlers.NotFoundResponse(c, apiName, "Project not found")
+		}
+		log.Error("Database error while looking up project",
+			zap.Error(err),
+			zap.String("api", apiName),
+		)
+		return handlers.InternalErrorResponse(c, apiName, "Failed to update project", nil)
+	}
+
+	// Track what fields are being updated
+	updates := make(map[string]interface{})
+
+	if req.Status != nil && *req.Status != project.Status {
+		updates["status"] = *req.Status
+		switch *req.Status {
+		case models.ProjectStatusSent:
+			updates["sent_at"] = time.Now()
+		case models.ProjectStatusActive:
+			updates["sent_at"] = nil
+		}
+	}
+	if req.HighPoint != nil {
+		updates["high_point"] = *req.HighPoint
+	}
+	if req.BetaNotes != nil {
+		updates["beta_notes"] = *req.BetaNotes
+	}
+
+	if len(updates) == 0 {
+		log.Warn("No fields provided for update",
+			zap.String("api", apiName),
+		)
+		return handlers.BadRequestResponse(c, apiName, "No fields provided for update", nil)
+	}
+
+	// Only one active project per route or boulder
+	if updates["status"] == models.ProjectStatusActive {
+		conflict := db.DB.Model(&models.Project{}).
+			Where("user_id = ? AND status = ? AND id <> ?", userID, models.ProjectStatusActive, project.ID)
+		if project.RouteID != nil {
+			conflict = conflict.Where("route_id = ?", *project.RouteID)
+		} else {
+			conflict = conflict.Where("outdoor_boulder_id = ?", *project.OutdoorBoulderID)
+		}
+
+		var count int64
+		if err := conflict.Count(&count).Error; err != nil {
+			log.Error("Database error while checking existing projects",
+				zap.Error(err),
+				zap.String("api", apiName),
+			)
+			return handlers.InternalErrorResponse(c, apiName, "Failed to update project", nil)
+		}
+		if count > 0 {
+			return handlers.ConflictResponse(c, apiName, "An active project already exists for this climb", nil)
+		}
+	}
+
+	if err := db.DB.Model(project).Updates(updates).Error; err != nil {
+		log.Error("Failed to update project in db",
+			zap.Error(err),
+			zap.String("api", apiName),
+			zap.Uint64("project_id", projectID),
+		)
+		return handlers.InternalErrorResponse(c, apiName, "Failed to update project", nil)
+	}
+
+	project, err = loadProject(project.ID, userID)
+	if err != nil {
+		log.Error("Failed to reload project",
+			zap.Error(err),
+			zap.String("api", apiName),
+		)
+		return handlers.InternalErrorResponse(c, apiName, "Failed to update project", nil)
+	}
+
+	response := project.ToProjectResponse()
+	if err := applyProjectStats([]*models.ProjectResponse{response}); err != nil {
+		log.Error("Failed to compute project stats",
+			zap.Error(err),
+			zap.String("api", apiName),
+		)
+		return handlers.InternalErrorResponse(c, apiName, "Failed to update project", nil)
+	}
+
+	log.Info("Project updated successfully",
+		zap.String("api", apiName),
+		zap.Uint("project_id", project.ID),
+		zap.String("status", project.Status),
+	)
+
+	return handlers.SuccessResponse(c, apiName, response, "Project updated successfully")
+}
+
+// validateUpdateProjectRequest validates the update project request
+func validateUpdateProjectRequest(req *models.UpdateProjectRequest) error {
+	if req.Status != nil {
+		switch *req.Status {
+		case models.ProjectStatusActive, models.ProjectStatusSent, models.ProjectStatusAbandoned:
+		default:
+			return fiber.NewError(fiber.StatusBadRequest, "Status must be 'active', 'sent' or 'abandoned'")
+		}
+	}
+
+	highPoint, betaNotes := "", ""
+	if req.HighPoint != nil {
+		highPoint = *req.HighPoint
+	}
+	if req.BetaNotes != nil {
+		betaNotes = *req.BetaNotes
+	}
+
+	return validateProjectDetails(highPoint, betaNotes)
+}
@@ -166,11 +166,21 @@ func ForbiddenResponse(c *fiber.Ctx, apiName string, message string) error {
 	return ErrorResponse(c, apiName, fiber.StatusForbidden, models.ErrorCodeForbidden, message, nil)
 }
 
+// ConflictResponse creates a 409 Conflict response
+func ConflictResponse(c *fiber.Ctx, apiName string, message string, details interface{}) error {
+	return ErrorResponse(c, apiName, fiber.StatusConflict, models.ErrorCodeConflict, message, details)
+}
+
 // InternalErrorResponse creates a 500 Internal Server Error response
 func InternalErrorResponse(c *fiber.Ctx, apiName string, message string, details interface{}) error {
 	return ErrorResponse(c, apiName, fiber.StatusInternalServerError, models.ErrorCodeInternalError, message, details)
 }
 
+// PayloadTooLargeResponse creates a 413 Payload Too Large response
+func PayloadTooLargeResponse(c *fiber.Ctx, apiName string, message string) error {
+	return ErrorResponse(c, apiName, fiber.StatusRequestEntityTooLarge, models.ErrorCodePayloadTooBig, message, nil)
+}
+
 // ValidationErrorResponse creates a 422 Unprocessable Entity response for validation errors
 func ValidationErrorResponse(c *fiber.Ctx, apiName string, message string, details interface{}) error {
 	return ErrorResponse(c, apiName, fiber.StatusUnprocessableEntity, models.ErrorCodeValidationFail, message, details)
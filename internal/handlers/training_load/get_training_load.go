@@ -0,0 +1,115 @@
+package training_load
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+
+	"github.com/jwallace145/crux-backend/internal/db"
+	"github.com/jwallace145/crux-backend/internal/handlers"
+	"github.com/jwallace145/crux-backend/internal/utils"
+	"github.com/jwallace145/crux-backend/models"
+)
+
+const (
+	defaultTrainingLoadWeeks = 8
+	maxTrainingLoadWeeks     = 52
+)
+
+// GetTrainingLoad returns a handler for GET /training-load requests that computes weekly session load,
+// monotony, strain and acute:chronic workload ratio from the RPE and duration of the user's training
+// sessions, and flags weeks past the warning thresholds
+// Query parameters:
+//   - weeks (optional): Number of weeks to return, ending with the week of end_date (default 8, max 52)
+//   - end_date (optional): RFC3339 date of the last day to include (default now)
+//   - acwr_threshold, weekly_increase_threshold, monotony_threshold (optional): Override the configured thresholds
+//
+// Requires AuthMiddleware to be applied - reads user_id from context
+func GetTrainingLoad(thresholds models.TrainingLoadThresholds) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		apiName := "get_training_load"
+		log := utils.GetLoggerFromContext(c)
+
+		log.Info("Starting get training load process",
+			zap.String("api", apiName),
+		)
+
+		// Get user ID from context (set by AuthMiddleware)
+		userID, ok := c.Locals("user_id").(uint)
+		if !ok {
+			log.Error("User ID not found in context",
+				zap.String("api", apiName),
+			)
+			return handlers.InternalErrorResponse(c, apiName, "Authentication context missing", nil)
+		}
+
+		weeks := defaultTrainingLoadWeeks
+		if raw := c.Query("weeks"); raw != "" {
+			parsed, err := strconv.Atoi(raw)
+			if err != nil || parsed < 1 || parsed > maxTrainingLoadWeeks {
+				return handlers.BadRequestResponse(c, apiName, "weeks must be a number between 1 and 52", nil)
+			}
+			weeks = parsed
+		}
+
+		end := time.Now().UTC()
+		if raw := c.Query("end_date"); raw != "" {
+			parsed, err := time.Parse(time.RFC3339, raw)
+			if err != nil {
+				return handlers.BadRequestResponse(c, apiName, "end_date must be in RFC3339 format (e.g., 2024-01-01T00:00:00Z)", nil)
+			}
+			end = parsed
+		}
+
+		// Allow coaches to tighten or relax the configured thresholds per request
+		for _, override := range []struct {
+			param string
+			value *float64
+		}{
+			{"acwr_threshold", &thresholds.ACWR},
+			{"weekly_increase_threshold", &thresholds.WeeklyIncrease},
+			{"monotony_threshold", &thresholds.Monotony},
+		} {
+			raw := c.Query(override.param)
+			if raw == "" {
+				continue
+			}
+			parsed, err := strconv.ParseFloat(raw, 64)
+			if err != nil || parsed <= 0 {
+				return handlers.BadRequestResponse(c, apiName, override.param+" must be a positive number", nil)
+			}
+			*override.value = parsed
+		}
+
+		// Load the sessions needed for the requested weeks and their chronic load history
+		lastDay := time.Date(end.Year(), end.Month(), end.Day(), 0, 0, 0, 0, end.Location())
+		firstDay := lastDay.AddDate(0, 0, -(models.TrainingLoadHistoryDays(weeks) - 1))
+
+		var sessions []models.TrainingSession
+		if err := db.DB.
+			Select("id", "session_date", "started_at", "ended_at", "rpe", "duration_minutes", "pain_flags").
+			Where("user_id = ? AND session_date >= ? AND session_date < ?", userID, firstDay, lastDay.AddDate(0, 0, 1)).
+			Find(&sessions).Error; err != nil {
+			log.Error("Database error while querying training sessions",
+				zap.Error(err),
+				zap.String("api", apiName),
+				zap.Uint("user_id", userID),
+			)
+			return handlers.InternalErrorResponse(c, apiName, "Failed to retrieve training load", nil)
+		}
+
+		response := models.ComputeTrainingLoad(sessions, end, weeks, thresholds)
+
+		log.Info("Training load retrieved successfully",
+			zap.String("api", apiName),
+			zap.Uint("user_id", userID),
+			zap.Int("weeks", weeks),
+			zap.Int("sessions", len(sessions)),
+			zap.Int("flagged_weeks", response.FlaggedWeeks),
+		)
+
+		return handlers.SuccessResponse(c, apiName, response, "Training load retrieved successfully")
+	}
+}
@@ -0,0 +1,149 @@
+package training_sessions
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	"github.com/jwallace145/crux-backend/internal/db"
+	"github.com/jwallace145/crux-backend/internal/handlers"
+	"github.com/jwallace145/crux-backend/internal/utils"
+	"github.com/jwallace145/crux-backend/models"
+)
+
+// AcceptPartnerInvitation handles POST /training-sessions/:id/invitation/accept requests to accept
+// being tagged as a partner on another user's session
+// With "mirror": true the session's gym, date and description are copied into the partner's own
+// log, linked to the original session. A declined invitation can still be accepted
+// Requires AuthMiddleware to be applied - reads user_id from context
+func AcceptPartnerInvitation(c *fiber.Ctx) error {
+	apiName := "accept_partner_invitation"
+	log := utils.GetLoggerFromContext(c)
+
+	log.Info("Starting accept partner invitation process",
+		zap.String("api", apiName),
+	)
+
+	// Get user ID from context (set by AuthMiddleware)
+	userID, ok := c.Locals("user_id").(uint)
+	if !ok {
+		log.Error("User ID not found in context",
+			zap.String("api", apiName),
+		)
+		return handlers.InternalErrorResponse(c, apiName, "Authentication context missing", nil)
+	}
+
+	// The request body is optional
+	var req models.AcceptPartnerInvitationRequest
+	if len(c.Body()) > 0 {
+		if err := handlers.ValidateJSONContentType(c, apiName); err != nil {
+			return err
+		}
+		if err := c.BodyParser(&req); err != nil {
+			log.Error("Failed to parse request body",
+				zap.Error(err),
+				zap.String("api", apiName),
+			)
+			return handlers.BadRequestResponse(c, apiName, "Invalid request body", err.Error())
+		}
+	}
+
+	invitation, trainingSession, err := findPartnerInvitation(c, apiName, userID)
+	if err != nil {
+		return err
+	}
+
+	if invitation.IsAccepted() && (!req.Mirror || invitation.MirroredSessionID != nil) {
+		log.Warn("Invitation already accepted",
+			zap.String("api", apiName),
+			zap.Uint("training_session_id", invitation.TrainingSessionID),
+		)
+		return handlers.ConflictResponse(c, apiName, "Invitation has already been accepted", nil)
+	}
+
+	if err := db.DB.Transaction(func(tx *gorm.DB) error {
+		updates := map[string]interface{}{
+			"status":       models.PartnerInvitationStatusAccepted,
+			"responded_at": time.Now(),
+		}
+
+		// Copy the session shell into the partner's own log
+		if req.Mirror && invitation.MirroredSessionID == nil {
+			mirror := &models.TrainingSession{
+				UserID:         userID,
+				GymID:          trainingSession.GymID,
+				SessionDate:    trainingSession.SessionDate,
+				Description:    trainingSession.Description,
+				MirroredFromID: &trainingSession.ID,
+			}
+			if err := tx.Create(mirror).Error; err != nil {
+				return err
+			}
+			updates["mirrored_session_id"] = mirror.ID
+		}
+
+		return tx.Model(&models.TrainingSessionPartner{}).
+			Where("training_session_id = ? AND user_id = ?", invitation.TrainingSessionID, userID).
+			Updates(updates).Error
+	}); err != nil {
+		log.Error("Failed to accept partner invitation in db",
+			zap.Error(err),
+			zap.String("api", apiName),
+			zap.Uint("training_session_id", invitation.TrainingSessionID),
+		)
+		return handlers.InternalErrorResponse(c, apiName, "Failed to accept invitation", nil)
+	}
+
+	invitation, trainingSession, err = findPartnerInvitation(c, apiName, userID)
+	if err != nil {
+		return err
+	}
+
+	log.Info("Partner invitation accepted successfully",
+		zap.String("api", apiName),
+		zap.Uint("training_session_id", invitation.TrainingSessionID),
+		zap.Uint("user_id", userID),
+		zap.Bool("mirrored", invitation.MirroredSessionID != nil),
+	)
+
+	return handlers.SuccessResponse(c, apiName, invitation.ToPartnerInvitationResponse(trainingSession), "Invitation accepted successfully")
+}
+
+// findPartnerInvitation looks up the user's invitation on the training session in the :id path
+// parameter along with the session's owner and gym
+func findPartnerInvitation(c *fiber.Ctx, apiName string, userID uint) (*models.TrainingSessionPartner, *models.TrainingSession, error) {
+	log := utils.GetLoggerFromContext(c)
+
+	sessionID, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return nil, nil, handlers.BadRequestResponse(c, apiName, "id must be a valid number", nil)
+	}
+
+	var trainingSession models.TrainingSession
+	if err := db.DB.
+		Preload("User").
+		Preload("Gym").
+		Preload("PartnerInvitations", "user_id = ?", userID).
+		First(&trainingSession, sessionID).Error; err != nil && err != gorm.ErrRecordNotFound {
+		log.Error("Database error while looking up training session",
+			zap.Error(err),
+			zap.String("api", apiName),
+		)
+		return nil, nil, handlers.InternalErrorResponse(c, apiName, "Failed to retrieve invitation", nil)
+	}
+
+	invitation := trainingSession.GetPartnerInvitation(userID)
+	if trainingSession.ID == 0 || invitation == nil {
+		log.Warn("Partner invitation not found",
+			zap.String("api", apiName),
+			zap.Uint("user_id", userID),
+			zap.Uint64("training_session_id", sessionID),
+		)
+		return nil, nil, handlers.NotFoundResponse(c, apiName, "Invitation not found")
+	}
+
+	return invitation, &trainingSession, nil
+}
@@ -0,0 +1,128 @@
+package training_sessions
+
+import (
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	"github.com/jwallace145/crux-backend/internal/db"
+	"github.com/jwallace145/crux-backend/internal/handlers"
+	"github.com/jwallace145/crux-backend/internal/utils"
+	"github.com/jwallace145/crux-backend/models"
+)
+
+// AppendSessionClimb handles POST /training-sessions/:id/climbs requests to log one climb in a live session
+// The request contains either an indoor boulder or a rope climb, validated like the create request.
+// The time the climb is logged is used for the session's rest intervals
+// Requires AuthMiddleware to be applied - reads user_id from context
+func AppendSessionClimb(c *fiber.Ctx) error {
+	apiName := "append_session_climb"
+	log := utils.GetLoggerFromContext(c)
+
+	log.Info("Starting append session climb process",
+		zap.String("api", apiName),
+	)
+
+	// Validate Content-Type header
+	if err := handlers.ValidateJSONContentType(c, apiName); err != nil {
+		return err
+	}
+
+	// Get user ID from context (set by AuthMiddleware)
+	userID, ok := c.Locals("user_id").(uint)
+	if !ok {
+		log.Error("User ID not found in context",
+			zap.String("api", apiName),
+		)
+		return handlers.InternalErrorResponse(c, apiName, "Authentication context missing", nil)
+	}
+
+	// Parse request body
+	var req models.AppendSessionClimbRequest
+	if err := c.BodyParser(&req); err != nil {
+		log.Error("Failed to parse request body",
+			zap.Error(err),
+			zap.String("api", apiName),
+		)
+		return handlers.BadRequestResponse(c, apiName, "Invalid request body", err.Error())
+	}
+
+	if err := validateAppendSessionClimbRequest(&req); err != nil {
+		log.Warn("Request validation failed",
+			zap.Error(err),
+			zap.String("api", apiName),
+		)
+		return handlers.ValidationErrorResponse(c, apiName, err.Error(), nil)
+	}
+
+	liveSession, err := findLiveTrainingSession(c, apiName, userID)
+	if err != nil {
+		return err
+	}
+
+	// Verify the referenced gym catalog climb is set at the session's gym
+	var boulders []models.IndoorBoulderRequest
+	var ropeClimbs []models.RopeClimbRequest
+	if req.IndoorBoulder != nil {
+		boulders = append(boulders, *req.IndoorBoulder)
+	} else {
+		ropeClimbs = append(ropeClimbs, *req.RopeClimb)
+	}
+	if err := verifyGymClimbs(c, apiName, liveSession.GymID, boulders, ropeClimbs); err != nil {
+		return err
+	}
+
+	// Create the climb and bump the session so the change is picked up by sync
+	if err := db.DB.Transaction(func(tx *gorm.DB) error {
+		if req.IndoorBoulder != nil {
+			boulder := req.IndoorBoulder.ToIndoorBoulder()
+			boulder.TrainingSessionID = liveSession.ID
+			if err := tx.Create(boulder).Error; err != nil {
+				return err
+			}
+		} else {
+			ropeClimb := req.RopeClimb.ToRopeClimb()
+			ropeClimb.TrainingSessionID = liveSession.ID
+			if err := tx.Create(ropeClimb).Error; err != nil {
+				return err
+			}
+		}
+		return tx.Model(liveSession).Update("updated_at", time.Now()).Error
+	}); err != nil {
+		log.Error("Failed to append climb to training session in db",
+			zap.Error(err),
+			zap.String("api", apiName),
+			zap.Uint("training_session_id", liveSession.ID),
+		)
+		return handlers.InternalErrorResponse(c, apiName, "Failed to append climb", nil)
+	}
+
+	updated, err := loadTrainingSession(c, apiName, liveSession.ID)
+	if err != nil {
+		return err
+	}
+
+	response := updated.ToTrainingSessionResponse()
+
+	log.Info("Climb appended to training session successfully",
+		zap.String("api", apiName),
+		zap.Uint("training_session_id", updated.ID),
+		zap.Uint("user_id", userID),
+		zap.Int("total_climbs", response.TotalClimbs),
+	)
+
+	return handlers.CreatedResponse(c, apiName, response, "Climb appended successfully")
+}
+
+// validateAppendSessionClimbRequest ensures exactly one climb is provided and validates it
+func validateAppendSessionClimbRequest(req *models.AppendSessionClimbRequest) error {
+	if (req.IndoorBoulder == nil) == (req.RopeClimb == nil) {
+		return fiber.NewError(fiber.StatusBadRequest, "Exactly one of indoor_boulder or rope_climb is required")
+	}
+	if req.IndoorBoulder != nil {
+		return validateIndoorBoulder(*req.IndoorBoulder)
+	}
+	return validateRopeClimb(*req.RopeClimb)
+}
@@ -1,6 +1,7 @@
 package training_sessions
 
 import (
+	"errors"
 	"time"
 
 	"github.com/gofiber/fiber/v2"
@@ -9,6 +10,7 @@ import (
 
 	"github.com/jwallace145/crux-backend/internal/db"
 	"github.com/jwallace145/crux-backend/internal/handlers"
+	"github.com/jwallace145/crux-backend/internal/services"
 	"github.com/jwallace145/crux-backend/internal/utils"
 	"github.com/jwallace145/crux-backend/models"
 )
@@ -46,7 +48,7 @@ func CreateTrainingSession(c *fiber.Ctx) error {
 	)
 
 	// Validate request
-	if err := validateCreateTrainingSessionRequest(&req); err != nil {
+	if err := ValidateCreateTrainingSessionRequest(&req); err != nil {
 		log.Warn("Request validation failed",
 			zap.Error(err),
 			zap.String("api", apiName),
@@ -77,12 +79,24 @@ func CreateTrainingSession(c *fiber.Ctx) error {
 		return err
 	}
 
+	// Verify the gym catalog climbs referenced by the logged climbs are set at the gym
+	if err := verifyGymClimbs(c, apiName, req.GymID, req.IndoorBoulders, req.RopeClimbs); err != nil {
+		return err
+	}
+
 	// Verify all partners exist if PartnerIDs are provided
-	partners, err := verifyPartnersExist(c, apiName, req.PartnerIDs)
+	partners, err := verifyPartnersExist(c, apiName, userID, req.PartnerIDs)
 	if err != nil {
 		return err
 	}
 
+	// Verify the planned session can be completed if provided
+	if req.PlannedSessionID != nil {
+		if err := verifyPlannedSessionOpen(c, apiName, userID, *req.PlannedSessionID); err != nil {
+			return err
+		}
+	}
+
 	// Create training session
 	log.Info("Creating training session in db",
 		zap.String("api", apiName),
@@ -91,14 +105,24 @@ func CreateTrainingSession(c *fiber.Ctx) error {
 	)
 
 	trainingSession := &models.TrainingSession{
-		UserID:      userID,
-		GymID:       req.GymID,
-		SessionDate: req.SessionDate,
-		Description: req.Description,
+		UserID:          userID,
+		GymID:           req.GymID,
+		SessionDate:     req.SessionDate,
+		Description:     req.Description,
+		RPE:             req.RPE,
+		DurationMinutes: req.DurationMinutes,
+		PainFlags:       req.PainFlags,
 	}
 
 	// Create training session with all related entities in a transaction
-	err = createTrainingSessionWithRelations(trainingSession, partners, &req)
+	err = CreateTrainingSessionWithRelations(trainingSession, partners, &req)
+	if errors.Is(err, services.ErrPlannedSessionUnavailable) {
+		log.Warn("Planned session was completed concurrently",
+			zap.String("api", apiName),
+			zap.Uint("planned_session_id", *req.PlannedSessionID),
+		)
+		return handlers.ConflictResponse(c, apiName, "Planned session is already completed", nil)
+	}
 	if err != nil {
 		log.Error("Failed to create training session in db",
 			zap.Error(err),
@@ -119,8 +143,12 @@ func CreateTrainingSession(c *fiber.Ctx) error {
 	if err := db.DB.
 		Preload("Gym").
 		Preload("Partners").
+		Preload("PartnerInvitations").
 		Preload("IndoorBoulders").
 		Preload("RopeClimbs").
+		Preload("IndoorBoulders.Attempts").
+		Preload("RopeClimbs.Attempts").
+		Preload("Exercises").
 		First(trainingSession, trainingSession.ID).Error; err != nil {
 		log.Error("Failed to load training session relationships",
 			zap.Error(err),
@@ -143,8 +171,8 @@ func CreateTrainingSession(c *fiber.Ctx) error {
 	return handlers.CreatedResponse(c, apiName, response, "Training session created successfully")
 }
 
-// validateCreateTrainingSessionRequest validates the create training session request
-func validateCreateTrainingSessionRequest(req *models.CreateTrainingSessionRequest) error {
+// ValidateCreateTrainingSessionRequest validates the create training session request
+func ValidateCreateTrainingSessionRequest(req *models.CreateTrainingSessionRequest) error {
 	if err := validateSessionBasicInfo(req); err != nil {
 		return err
 	}
@@ -154,6 +182,9 @@ func validateCreateTrainingSessionRequest(req *models.CreateTrainingSessionReque
 	if err := validateRopeClimbs(req.RopeClimbs); err != nil {
 		return err
 	}
+	if err := validateExercises(req.Exercises); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -171,6 +202,27 @@ func validateSessionBasicInfo(req *models.CreateTrainingSessionRequest) error {
 	if len(req.Description) > 1000 {
 		return fiber.NewError(fiber.StatusBadRequest, "Description must not exceed 1000 characters")
 	}
+	if req.DurationMinutes != nil && (*req.DurationMinutes < 1 || *req.DurationMinutes > 1440) {
+		return fiber.NewError(fiber.StatusBadRequest, "Duration must be between 1 and 1440 minutes")
+	}
+	return validateSessionEffort(req.RPE, req.PainFlags)
+}
+
+// validateSessionEffort validates the session RPE and pain flags
+func validateSessionEffort(rpe *int, painFlags []string) error {
+	if rpe != nil && (*rpe < 1 || *rpe > 10) {
+		return fiber.NewError(fiber.StatusBadRequest, "RPE must be between 1 and 10")
+	}
+	seen := make(map[string]bool, len(painFlags))
+	for _, flag := range painFlags {
+		if !models.IsValidPainFlag(flag) {
+			return fiber.NewError(fiber.StatusBadRequest, "Pain flags must be one of: fingers, wrist, elbow, shoulder, neck, back, hip, knee, ankle, skin, other")
+		}
+		if seen[flag] {
+			return fiber.NewError(fiber.StatusBadRequest, "Pain flags must not contain duplicates")
+		}
+		seen[flag] = true
+	}
 	return nil
 }
 
@@ -192,12 +244,17 @@ func validateIndoorBoulder(boulder models.IndoorBoulderRequest) error {
 	if len(boulder.Grade) > 20 {
 		return fiber.NewError(fiber.StatusBadRequest, "Indoor boulder grade must not exceed 20 characters")
 	}
-	if boulder.Outcome != models.IndoorBoulderOutcomeFell &&
+	// Outcome is derived from the attempts when any are logged
+	if (len(boulder.Attempts) == 0 || boulder.Outcome != "") &&
+		boulder.Outcome != models.IndoorBoulderOutcomeFell &&
 		boulder.Outcome != models.IndoorBoulderOutcomeFlash &&
 		boulder.Outcome != models.IndoorBoulderOutcomeOnSight &&
 		boulder.Outcome != models.IndoorBoulderOutcomeRedpoint {
 		return fiber.NewError(fiber.StatusBadRequest, "Indoor boulder outcome must be 'Fell', 'Flash', 'Onsite', or 'Redpoint'")
 	}
+	if err := validateClimbAttempts(boulder.Attempts, false, "Indoor boulder"); err != nil {
+		return err
+	}
 	if boulder.ColorTag != nil && len(*boulder.ColorTag) > 50 {
 		return fiber.NewError(fiber.StatusBadRequest, "Indoor boulder color tag must not exceed 50 characters")
 	}
@@ -228,19 +285,116 @@ func validateRopeClimb(ropeClimb models.RopeClimbRequest) error {
 	if len(ropeClimb.Grade) > 20 {
 		return fiber.NewError(fiber.StatusBadRequest, "Rope climb grade must not exceed 20 characters")
 	}
-	if ropeClimb.Outcome != models.RopeClimbOutcomeFell &&
+	// Outcome is derived from the attempts when any are logged
+	if (len(ropeClimb.Attempts) == 0 || ropeClimb.Outcome != "") &&
+		ropeClimb.Outcome != models.RopeClimbOutcomeFell &&
 		ropeClimb.Outcome != models.RopeClimbOutcomeHung &&
 		ropeClimb.Outcome != models.RopeClimbOutcomeFlash &&
 		ropeClimb.Outcome != models.RopeClimbOutcomeOnSight &&
 		ropeClimb.Outcome != models.RopeClimbOutcomeRedpoint {
 		return fiber.NewError(fiber.StatusBadRequest, "Rope climb outcome must be 'Fell', 'Hung', 'Flash', 'Onsite', or 'Redpoint'")
 	}
+	if err := validateClimbAttempts(ropeClimb.Attempts, true, "Rope climb"); err != nil {
+		return err
+	}
 	if len(ropeClimb.Notes) > 1000 {
 		return fiber.NewError(fiber.StatusBadRequest, "Rope climb notes must not exceed 1000 characters")
 	}
 	return nil
 }
 
+// validateClimbAttempts validates the ordered attempts of a climb. Hung attempts are only allowed
+// on rope climbs and attempt times must not go backwards or be in the future
+func validateClimbAttempts(attempts []models.ClimbAttemptRequest, allowHung bool, label string) error {
+	if len(attempts) > models.MaxClimbAttempts {
+		return fiber.NewError(fiber.StatusBadRequest, label+" must not have more than 100 attempts")
+	}
+	var previous *time.Time
+	for _, attempt := range attempts {
+		switch attempt.Result {
+		case models.AttemptResultFell, models.AttemptResultTopped:
+		case models.AttemptResultHung:
+			if !allowHung {
+				return fiber.NewError(fiber.StatusBadRequest, label+" attempt result must be 'fell' or 'topped'")
+			}
+		default:
+			if allowHung {
+				return fiber.NewError(fiber.StatusBadRequest, label+" attempt result must be 'fell', 'hung', or 'topped'")
+			}
+			return fiber.NewError(fiber.StatusBadRequest, label+" attempt result must be 'fell' or 'topped'")
+		}
+		if attempt.HighPointPercent != nil && (*attempt.HighPointPercent < 0 || *attempt.HighPointPercent > 100) {
+			return fiber.NewError(fiber.StatusBadRequest, label+" attempt high point must be between 0 and 100 percent")
+		}
+		if attempt.AttemptedAt != nil {
+			if attempt.AttemptedAt.After(time.Now()) {
+				return fiber.NewError(fiber.StatusBadRequest, label+" attempt time cannot be in the future")
+			}
+			if previous != nil && attempt.AttemptedAt.Before(*previous) {
+				return fiber.NewError(fiber.StatusBadRequest, label+" attempts must be in chronological order")
+			}
+			previous = attempt.AttemptedAt
+		}
+	}
+	return nil
+}
+
+// validateExercises validates all exercises in the request
+func validateExercises(exercises []models.ExerciseRequest) error {
+	for _, exercise := range exercises {
+		if err := ValidateExercise(exercise); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ValidateExercise validates a single off-the-wall exercise
+func ValidateExercise(exercise models.ExerciseRequest) error {
+	if exercise.Type != models.ExerciseTypeHangboard &&
+		exercise.Type != models.ExerciseTypeCampus &&
+		exercise.Type != models.ExerciseTypeStrength {
+		return fiber.NewError(fiber.StatusBadRequest, "Exercise type must be 'hangboard', 'campus', or 'strength'")
+	}
+	if len(exercise.Name) > 100 {
+		return fiber.NewError(fiber.StatusBadRequest, "Exercise name must not exceed 100 characters")
+	}
+	if exercise.Sets < 1 || exercise.Sets > 100 {
+		return fiber.NewError(fiber.StatusBadRequest, "Exercise sets must be between 1 and 100")
+	}
+	if exercise.Reps < 1 || exercise.Reps > 1000 {
+		return fiber.NewError(fiber.StatusBadRequest, "Exercise reps must be between 1 and 1000")
+	}
+	if exercise.HangSeconds != nil && (*exercise.HangSeconds < 1 || *exercise.HangSeconds > 600) {
+		return fiber.NewError(fiber.StatusBadRequest, "Exercise hang seconds must be between 1 and 600")
+	}
+	if exercise.RestSeconds != nil && (*exercise.RestSeconds < 0 || *exercise.RestSeconds > 3600) {
+		return fiber.NewError(fiber.StatusBadRequest, "Exercise rest seconds must be between 0 and 3600")
+	}
+	if exercise.AddedWeightKg != nil && (*exercise.AddedWeightKg < -200 || *exercise.AddedWeightKg > 500) {
+		return fiber.NewError(fiber.StatusBadRequest, "Exercise added weight must be between -200 and 500 kg")
+	}
+	if exercise.Type == models.ExerciseTypeStrength && (exercise.EdgeSizeMM != nil || exercise.Grip != nil) {
+		return fiber.NewError(fiber.StatusBadRequest, "Edge size and grip only apply to hangboard and campus exercises")
+	}
+	if exercise.EdgeSizeMM != nil && (*exercise.EdgeSizeMM < 1 || *exercise.EdgeSizeMM > 100) {
+		return fiber.NewError(fiber.StatusBadRequest, "Exercise edge size must be between 1 and 100 mm")
+	}
+	if exercise.Grip != nil &&
+		*exercise.Grip != models.GripOpenHand &&
+		*exercise.Grip != models.GripHalfCrimp &&
+		*exercise.Grip != models.GripFullCrimp &&
+		*exercise.Grip != models.GripThreeDrag &&
+		*exercise.Grip != models.GripPinch &&
+		*exercise.Grip != models.GripSloper {
+		return fiber.NewError(fiber.StatusBadRequest, "Exercise grip must be 'open_hand', 'half_crimp', 'full_crimp', 'three_finger_drag', 'pinch', or 'sloper'")
+	}
+	if len(exercise.Notes) > 1000 {
+		return fiber.NewError(fiber.StatusBadRequest, "Exercise notes must not exceed 1000 characters")
+	}
+	return nil
+}
+
 // verifyGymExists verifies that the gym with the given ID exists
 func verifyGymExists(c *fiber.Ctx, apiName string, gymID uint) error {
 	log := utils.GetLoggerFromContext(c)
@@ -276,38 +430,107 @@ func verifyGymExists(c *fiber.Ctx, apiName string, gymID uint) error {
 	return nil
 }
 
-// verifyPartnersExist verifies that all partners with the given IDs exist
-func verifyPartnersExist(c *fiber.Ctx, apiName string, partnerIDs []uint) ([]models.User, error) {
+// verifyGymClimbs verifies that the catalog climbs referenced by logged indoor boulders and rope
+// climbs are set at the gym, boulder problems for indoor boulders and routes for rope climbs
+func verifyGymClimbs(c *fiber.Ctx, apiName string, gymID uint, boulders []models.IndoorBoulderRequest, ropeClimbs []models.RopeClimbRequest) error {
 	log := utils.GetLoggerFromContext(c)
 
-	var partners []models.User
-	if len(partnerIDs) == 0 {
-		return partners, nil
+	ok, err := services.GymClimbsBelongToGym(db.DB, gymID, boulders, ropeClimbs)
+	if err != nil {
+		log.Error("Database error while checking gym climbs",
+			zap.Error(err),
+			zap.String("api", apiName),
+		)
+		return handlers.InternalErrorResponse(c, apiName, "Failed to verify gym climbs", nil)
+	}
+	if !ok {
+		log.Warn("One or more gym climbs not found at the gym",
+			zap.String("api", apiName),
+			zap.Uint("gym_id", gymID),
+		)
+		return handlers.BadRequestResponse(c, apiName, "One or more gym climbs not found at this gym", map[string]interface{}{
+			"gym_id": gymID,
+		})
 	}
 
-	log.Info("Verifying training partners exist",
-		zap.String("api", apiName),
-		zap.Int("partner_count", len(partnerIDs)),
-	)
+	return nil
+}
 
-	if err := db.DB.Where("id IN ?", partnerIDs).Find(&partners).Error; err != nil {
-		log.Error("Database error while checking partners",
+// verifyPlannedSessionOpen verifies that the planned session belongs to the user and has not
+// already been completed by another training session
+func verifyPlannedSessionOpen(c *fiber.Ctx, apiName string, userID, plannedSessionID uint) error {
+	log := utils.GetLoggerFromContext(c)
+
+	var plannedSession models.PlannedSession
+	if err := db.DB.Where("id = ? AND user_id = ?", plannedSessionID, userID).First(&plannedSession).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			log.Warn("Planned session not found",
+				zap.String("api", apiName),
+				zap.Uint("planned_session_id", plannedSessionID),
+			)
+			return handlers.BadRequestResponse(c, apiName, "Planned session not found", map[string]interface{}{
+				"planned_session_id": plannedSessionID,
+			})
+		}
+		log.Error("Database error while checking planned session",
 			zap.Error(err),
 			zap.String("api", apiName),
 		)
-		return nil, handlers.InternalErrorResponse(c, apiName, "Failed to verify partners", nil)
+		return handlers.InternalErrorResponse(c, apiName, "Failed to verify planned session", nil)
+	}
+
+	if plannedSession.IsCompleted() {
+		log.Warn("Planned session is already completed",
+			zap.String("api", apiName),
+			zap.Uint("planned_session_id", plannedSessionID),
+			zap.Uint("training_session_id", *plannedSession.TrainingSessionID),
+		)
+		return handlers.ConflictResponse(c, apiName, "Planned session is already completed", map[string]interface{}{
+			"training_session_id": *plannedSession.TrainingSessionID,
+		})
+	}
+
+	return nil
+}
+
+// verifyPartnersExist verifies that all partners with the given IDs exist and that none of them
+// have blocked the user from tagging them
+func verifyPartnersExist(c *fiber.Ctx, apiName string, userID uint, partnerIDs []uint) ([]models.User, error) {
+	log := utils.GetLoggerFromContext(c)
+
+	if len(partnerIDs) == 0 {
+		return []models.User{}, nil
 	}
 
-	if len(partners) != len(partnerIDs) {
+	log.Info("Verifying training partners exist",
+		zap.String("api", apiName),
+		zap.Int("partner_count", len(partnerIDs)),
+	)
+
+	partners, err := services.VerifySessionPartners(db.DB, userID, partnerIDs)
+	if errors.Is(err, services.ErrPartnersNotFound) {
 		log.Warn("One or more partners not found",
 			zap.String("api", apiName),
 			zap.Int("requested", len(partnerIDs)),
-			zap.Int("found", len(partners)),
 		)
 		return nil, handlers.BadRequestResponse(c, apiName, "One or more partners not found", map[string]interface{}{
 			"partner_ids": partnerIDs,
 		})
 	}
+	if errors.Is(err, services.ErrPartnersBlocked) {
+		log.Warn("One or more partners have blocked the user",
+			zap.String("api", apiName),
+			zap.Uint("user_id", userID),
+		)
+		return nil, handlers.ForbiddenResponse(c, apiName, "One or more partners cannot be tagged")
+	}
+	if err != nil {
+		log.Error("Database error while checking partners",
+			zap.Error(err),
+			zap.String("api", apiName),
+		)
+		return nil, handlers.InternalErrorResponse(c, apiName, "Failed to verify partners", nil)
+	}
 
 	log.Info("All partners verified",
 		zap.String("api", apiName),
@@ -317,8 +540,8 @@ func verifyPartnersExist(c *fiber.Ctx, apiName string, partnerIDs []uint) ([]mod
 	return partners, nil
 }
 
-// createTrainingSessionWithRelations creates a training session with all related entities in a transaction
-func createTrainingSessionWithRelations(trainingSession *models.TrainingSession, partners []models.User, req *models.CreateTrainingSessionRequest) error {
+// CreateTrainingSessionWithRelations creates a training session with all related entities in a transaction
+func CreateTrainingSessionWithRelations(trainingSession *models.TrainingSession, partners []models.User, req *models.CreateTrainingSessionRequest) error {
 	return db.DB.Transaction(func(tx *gorm.DB) error {
 		// Create the training session
 		if err := tx.Create(trainingSession).Error; err != nil {
@@ -360,6 +583,27 @@ func createTrainingSessionWithRelations(trainingSession *models.TrainingSession,
 			trainingSession.RopeClimbs = ropeClimbs
 		}
 
+		// Create exercises if any
+		if len(req.Exercises) > 0 {
+			exercises := make([]models.Exercise, len(req.Exercises))
+			for i, exerciseReq := range req.Exercises {
+				exercise := exerciseReq.ToExercise()
+				exercise.TrainingSessionID = trainingSession.ID
+				exercises[i] = *exercise
+			}
+			if err := tx.Create(&exercises).Error; err != nil {
+				return err
+			}
+			trainingSession.Exercises = exercises
+		}
+
+		// Complete the planned session if any
+		if req.PlannedSessionID != nil {
+			if err := services.LinkPlannedSession(tx, trainingSession.UserID, *req.PlannedSessionID, trainingSession.ID); err != nil {
+				return err
+			}
+		}
+
 		return nil
 	})
 }
@@ -0,0 +1,74 @@
+package training_sessions
+
+import (
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+
+	"github.com/jwallace145/crux-backend/internal/db"
+	"github.com/jwallace145/crux-backend/internal/handlers"
+	"github.com/jwallace145/crux-backend/internal/utils"
+	"github.com/jwallace145/crux-backend/models"
+)
+
+// DeclinePartnerInvitation handles POST /training-sessions/:id/invitation/decline requests to decline
+// being tagged as a partner on another user's session
+// Declined sessions are no longer visible to the partner. A mirrored copy in the partner's own log is kept
+// Requires AuthMiddleware to be applied - reads user_id from context
+func DeclinePartnerInvitation(c *fiber.Ctx) error {
+	apiName := "decline_partner_invitation"
+	log := utils.GetLoggerFromContext(c)
+
+	log.Info("Starting decline partner invitation process",
+		zap.String("api", apiName),
+	)
+
+	// Get user ID from context (set by AuthMiddleware)
+	userID, ok := c.Locals("user_id").(uint)
+	if !ok {
+		log.Error("User ID not found in context",
+			zap.String("api", apiName),
+		)
+		return handlers.InternalErrorResponse(c, apiName, "Authentication context missing", nil)
+	}
+
+	invitation, trainingSession, err := findPartnerInvitation(c, apiName, userID)
+	if err != nil {
+		return err
+	}
+
+	if invitation.IsDeclined() {
+		log.Warn("Invitation already declined",
+			zap.String("api", apiName),
+			zap.Uint("training_session_id", invitation.TrainingSessionID),
+		)
+		return handlers.ConflictResponse(c, apiName, "Invitation has already been declined", nil)
+	}
+
+	now := time.Now()
+	if err := db.DB.Model(&models.TrainingSessionPartner{}).
+		Where("training_session_id = ? AND user_id = ?", invitation.TrainingSessionID, userID).
+		Updates(map[string]interface{}{
+			"status":       models.PartnerInvitationStatusDeclined,
+			"responded_at": now,
+		}).Error; err != nil {
+		log.Error("Failed to decline partner invitation in db",
+			zap.Error(err),
+			zap.String("api", apiName),
+			zap.Uint("training_session_id", invitation.TrainingSessionID),
+		)
+		return handlers.InternalErrorResponse(c, apiName, "Failed to decline invitation", nil)
+	}
+
+	invitation.Status = models.PartnerInvitationStatusDeclined
+	invitation.RespondedAt = &now
+
+	log.Info("Partner invitation declined successfully",
+		zap.String("api", apiName),
+		zap.Uint("training_session_id", invitation.TrainingSessionID),
+		zap.Uint("user_id", userID),
+	)
+
+	return handlers.SuccessResponse(c, apiName, invitation.ToPartnerInvitationResponse(trainingSession), "Invitation declined successfully")
+}
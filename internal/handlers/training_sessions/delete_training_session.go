@@ -0,0 +1,83 @@
+package training_sessions
+
+import (
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	"github.com/jwallace145/crux-backend/internal/db"
+	"github.com/jwallace145/crux-backend/internal/handlers"
+	"github.com/jwallace145/crux-backend/internal/services"
+	"github.com/jwallace145/crux-backend/internal/utils"
+	"github.com/jwallace145/crux-backend/models"
+)
+
+// DeleteTrainingSession handles DELETE /training-sessions/:id requests to delete a training session
+// Rope climbs, indoor boulders and media attachments of the session are removed with it
+// Requires AuthMiddleware to be applied - reads user_id from context
+func DeleteTrainingSession(c *fiber.Ctx) error {
+	apiName := "delete_training_session"
+	log := utils.GetLoggerFromContext(c)
+
+	log.Info("Starting delete training session process",
+		zap.String("api", apiName),
+	)
+
+	// Get user ID from context (set by AuthMiddleware)
+	userID, ok := c.Locals("user_id").(uint)
+	if !ok {
+		log.Error("User ID not found in context",
+			zap.String("api", apiName),
+		)
+		return handlers.InternalErrorResponse(c, apiName, "Authentication context missing", nil)
+	}
+
+	sessionID, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return handlers.BadRequestResponse(c, apiName, "id must be a valid number", nil)
+	}
+
+	var trainingSession models.TrainingSession
+	if err := db.DB.Where("id = ? AND user_id = ?", sessionID, userID).First(&trainingSession).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			log.Warn("Training session not found",
+				zap.String("api", apiName),
+				zap.Uint64("training_session_id", sessionID),
+			)
+			return handlers.NotFoundResponse(c, apiName, "Training session not found")
+		}
+		log.Error("Database error while looking up training session",
+			zap.Error(err),
+			zap.String("api", apiName),
+		)
+		return handlers.InternalErrorResponse(c, apiName, "Failed to delete training session", nil)
+	}
+
+	// Delete the session, its climbs and its media attachments in a transaction
+	var mediaKeys []string
+	if err := db.DB.Transaction(func(tx *gorm.DB) error {
+		keys, err := services.DeleteTrainingSession(tx, &trainingSession)
+		mediaKeys = keys
+		return err
+	}); err != nil {
+		log.Error("Failed to delete training session in db",
+			zap.Error(err),
+			zap.String("api", apiName),
+			zap.Uint64("training_session_id", sessionID),
+		)
+		return handlers.InternalErrorResponse(c, apiName, "Failed to delete training session", nil)
+	}
+
+	// Remove media objects from S3 once the rows are gone
+	services.DeleteMediaObjects(c.Context(), mediaKeys)
+
+	log.Info("Training session deleted successfully",
+		zap.String("api", apiName),
+		zap.Uint64("training_session_id", sessionID),
+		zap.Int("media_deleted", len(mediaKeys)),
+	)
+
+	return handlers.SuccessResponse(c, apiName, map[string]interface{}{"id": trainingSession.ID}, "Training session deleted successfully")
+}
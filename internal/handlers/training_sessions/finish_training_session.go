@@ -0,0 +1,144 @@
+package training_sessions
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	"github.com/jwallace145/crux-backend/internal/db"
+	"github.com/jwallace145/crux-backend/internal/handlers"
+	"github.com/jwallace145/crux-backend/internal/services"
+	"github.com/jwallace145/crux-backend/internal/utils"
+	"github.com/jwallace145/crux-backend/models"
+)
+
+// FinishTrainingSession handles POST /training-sessions/:id/finish requests to end a live training session
+// An optional body records the session RPE and pain flags while they are fresh, and the user is
+// checked out of the gym
+// Returns the session with its duration, climbs per hour and rest intervals
+// Requires AuthMiddleware to be applied - reads user_id from context
+func FinishTrainingSession(c *fiber.Ctx) error {
+	apiName := "finish_training_session"
+	log := utils.GetLoggerFromContext(c)
+
+	log.Info("Starting finish training session process",
+		zap.String("api", apiName),
+	)
+
+	// Get user ID from context (set by AuthMiddleware)
+	userID, ok := c.Locals("user_id").(uint)
+	if !ok {
+		log.Error("User ID not found in context",
+			zap.String("api", apiName),
+		)
+		return handlers.InternalErrorResponse(c, apiName, "Authentication context missing", nil)
+	}
+
+	// Parse the optional request body
+	var req models.FinishTrainingSessionRequest
+	if len(c.Body()) > 0 {
+		if err := handlers.ValidateJSONContentType(c, apiName); err != nil {
+			return err
+		}
+		if err := c.BodyParser(&req); err != nil {
+			log.Error("Failed to parse request body",
+				zap.Error(err),
+				zap.String("api", apiName),
+			)
+			return handlers.BadRequestResponse(c, apiName, "Invalid request body", err.Error())
+		}
+		if err := validateSessionEffort(req.RPE, req.PainFlags); err != nil {
+			log.Warn("Request validation failed",
+				zap.Error(err),
+				zap.String("api", apiName),
+			)
+			return handlers.ValidationErrorResponse(c, apiName, err.Error(), nil)
+		}
+	}
+
+	liveSession, err := findLiveTrainingSession(c, apiName, userID)
+	if err != nil {
+		return err
+	}
+
+	endedAt := time.Now()
+	updates := map[string]interface{}{"ended_at": endedAt}
+	if req.RPE != nil {
+		updates["rpe"] = *req.RPE
+	}
+	if req.PainFlags != nil {
+		updates["pain_flags"] = models.PainFlags(req.PainFlags)
+	}
+
+	if err := db.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(liveSession).Updates(updates).Error; err != nil {
+			return err
+		}
+		return services.CheckOutTrainingSession(tx, liveSession.ID, endedAt)
+	}); err != nil {
+		log.Error("Failed to finish training session in db",
+			zap.Error(err),
+			zap.String("api", apiName),
+			zap.Uint("training_session_id", liveSession.ID),
+		)
+		return handlers.InternalErrorResponse(c, apiName, "Failed to finish training session", nil)
+	}
+
+	finished, err := loadTrainingSession(c, apiName, liveSession.ID)
+	if err != nil {
+		return err
+	}
+
+	response := finished.ToTrainingSessionResponse()
+	response.Media = services.PresignMediaAttachments(c.Context(), finished.Media)
+
+	log.Info("Training session finished successfully",
+		zap.String("api", apiName),
+		zap.Uint("training_session_id", finished.ID),
+		zap.Uint("user_id", userID),
+		zap.Int("total_climbs", response.TotalClimbs),
+		zap.Int64("duration_seconds", response.Timing.DurationSeconds),
+	)
+
+	return handlers.SuccessResponse(c, apiName, response, "Training session finished successfully")
+}
+
+// findLiveTrainingSession looks up the training session in the :id path parameter and ensures it
+// belongs to the user and is still in progress
+func findLiveTrainingSession(c *fiber.Ctx, apiName string, userID uint) (*models.TrainingSession, error) {
+	log := utils.GetLoggerFromContext(c)
+
+	sessionID, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return nil, handlers.BadRequestResponse(c, apiName, "id must be a valid number", nil)
+	}
+
+	var trainingSession models.TrainingSession
+	if err := db.DB.Where("id = ? AND user_id = ?", sessionID, userID).First(&trainingSession).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			log.Warn("Training session not found",
+				zap.String("api", apiName),
+				zap.Uint64("training_session_id", sessionID),
+			)
+			return nil, handlers.NotFoundResponse(c, apiName, "Training session not found")
+		}
+		log.Error("Database error while looking up training session",
+			zap.Error(err),
+			zap.String("api", apiName),
+		)
+		return nil, handlers.InternalErrorResponse(c, apiName, "Failed to retrieve training session", nil)
+	}
+
+	if !trainingSession.IsLive() {
+		log.Warn("Training session is not in progress",
+			zap.String("api", apiName),
+			zap.Uint64("training_session_id", sessionID),
+		)
+		return nil, handlers.ConflictResponse(c, apiName, "Training session is not in progress", nil)
+	}
+
+	return &trainingSession, nil
+}
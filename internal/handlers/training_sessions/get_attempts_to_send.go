@@ -0,0 +1,167 @@
+package training_sessions
+
+import (
+	"math"
+	"sort"
+
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+
+	"github.com/jwallace145/crux-backend/internal/db"
+	"github.com/jwallace145/crux-backend/internal/handlers"
+	"github.com/jwallace145/crux-backend/internal/query"
+	"github.com/jwallace145/crux-backend/internal/utils"
+	"github.com/jwallace145/crux-backend/models"
+)
+
+// attemptsToSendSpec defines the whitelisted date range for GET /training-sessions/attempts-to-send
+var attemptsToSendSpec = &query.Spec{
+	DateRange: &query.DateRange{
+		Column:     "ts.session_date",
+		StartParam: "start_date",
+		EndParam:   "end_date",
+	},
+}
+
+// attemptsToSendTables maps the climb types to their table
+var attemptsToSendTables = map[string]string{
+	models.AttemptParentTypeIndoorBoulder: "indoor_boulders",
+	models.AttemptParentTypeRopeClimb:     "rope_climbs",
+}
+
+// attemptsToSendRow is a row of the grouped attempts query
+type attemptsToSendRow struct {
+	Grade                 string
+	Climbs                int
+	Sends                 int
+	FirstAttemptSends     int
+	TotalAttempts         int
+	AverageAttemptsToSend *float64
+	MaxAttemptsToSend     *int
+}
+
+// GetAttemptsToSend handles GET /training-sessions/attempts-to-send requests to summarize how many
+// attempts the user needs to send each grade. Only climbs with attempts logged are counted
+// Query parameters:
+//   - type (optional): "indoor_boulder" or "rope_climb" (default both)
+//   - start_date, end_date (optional): RFC3339 session date range
+//
+// Requires AuthMiddleware to be applied - reads user_id from context
+func GetAttemptsToSend(c *fiber.Ctx) error {
+	apiName := "get_attempts_to_send"
+	log := utils.GetLoggerFromContext(c)
+
+	log.Info("Starting get attempts to send process",
+		zap.String("api", apiName),
+	)
+
+	// Get user ID from context (set by AuthMiddleware)
+	userID, ok := c.Locals("user_id").(uint)
+	if !ok {
+		log.Error("User ID not found in context",
+			zap.String("api", apiName),
+		)
+		return handlers.InternalErrorResponse(c, apiName, "Authentication context missing", nil)
+	}
+
+	types := []string{models.AttemptParentTypeIndoorBoulder, models.AttemptParentTypeRopeClimb}
+	if climbType := c.Query("type"); climbType != "" {
+		if _, ok := attemptsToSendTables[climbType]; !ok {
+			return handlers.BadRequestResponse(c, apiName, "type must be 'indoor_boulder' or 'rope_climb'", nil)
+		}
+		types = []string{climbType}
+	}
+
+	// Parse date range query parameters
+	params, err := query.Parse(c, attemptsToSendSpec)
+	if err != nil {
+		log.Warn("Invalid query parameters",
+			zap.Error(err),
+			zap.String("api", apiName),
+		)
+		return handlers.BadRequestResponse(c, apiName, err.Error(), nil)
+	}
+
+	response := &models.AttemptsToSendResponse{
+		StartDate: params.StartDate,
+		EndDate:   params.EndDate,
+		Grades:    []models.AttemptsToSendGradeResponse{},
+	}
+
+	for _, climbType := range types {
+		rows, err := queryAttemptsToSend(params, userID, climbType)
+		if err != nil {
+			log.Error("Database error while querying attempts to send",
+				zap.Error(err),
+				zap.String("api", apiName),
+				zap.Uint("user_id", userID),
+				zap.String("type", climbType),
+			)
+			return handlers.InternalErrorResponse(c, apiName, "Failed to retrieve attempts to send", nil)
+		}
+		sortAttemptsToSendRows(rows)
+
+		for _, row := range rows {
+			grade := models.AttemptsToSendGradeResponse{
+				Type:              climbType,
+				Grade:             row.Grade,
+				Climbs:            row.Climbs,
+				Sends:             row.Sends,
+				FirstAttemptSends: row.FirstAttemptSends,
+				TotalAttempts:     row.TotalAttempts,
+				MaxAttemptsToSend: row.MaxAttemptsToSend,
+			}
+			if row.Climbs > 0 {
+				grade.SendRate = math.Round(float64(row.Sends)/float64(row.Climbs)*10000) / 100
+			}
+			if row.AverageAttemptsToSend != nil {
+				average := math.Round(*row.AverageAttemptsToSend*100) / 100
+				grade.AverageAttemptsToSend = &average
+			}
+			response.Grades = append(response.Grades, grade)
+		}
+	}
+
+	log.Info("Attempts to send retrieved successfully",
+		zap.String("api", apiName),
+		zap.Uint("user_id", userID),
+		zap.Int("grades", len(response.Grades)),
+	)
+
+	return handlers.SuccessResponse(c, apiName, response, "Attempts to send retrieved successfully")
+}
+
+// queryAttemptsToSend groups the user's climbs of one type by grade. The inner query finds the
+// first topped attempt of each climb and the outer query aggregates them per grade
+func queryAttemptsToSend(params *query.Params, userID uint, climbType string) ([]attemptsToSendRow, error) {
+	perClimb := params.ApplyFilters(db.DB.Table(attemptsToSendTables[climbType]+" x").
+		Joins("JOIN training_sessions ts ON ts.id = x.training_session_id").
+		Joins("JOIN climb_attempts a ON a.parent_type = ? AND a.parent_id = x.id AND a.deleted_at IS NULL", climbType).
+		Where("ts.user_id = ? AND x.deleted_at IS NULL AND ts.deleted_at IS NULL", userID)).
+		Select("x.id, x.grade, COUNT(a.id) AS attempts, MIN(a.attempt_number) FILTER (WHERE a.result = ?) AS send_attempt", models.AttemptResultTopped).
+		Group("x.id, x.grade")
+
+	rows := []attemptsToSendRow{}
+	err := db.DB.Table("(?) AS c", perClimb).
+		Select("c.grade, COUNT(*) AS climbs, COUNT(c.send_attempt) AS sends, " +
+			"COUNT(*) FILTER (WHERE c.send_attempt = 1) AS first_attempt_sends, SUM(c.attempts)::int AS total_attempts, " +
+			"AVG(c.send_attempt)::float8 AS average_attempts_to_send, MAX(c.send_attempt) AS max_attempts_to_send").
+		Group("c.grade").
+		Scan(&rows).Error
+	return rows, err
+}
+
+// sortAttemptsToSendRows orders rows from the easiest to the hardest grade, with unknown grades last
+func sortAttemptsToSendRows(rows []attemptsToSendRow) {
+	sort.SliceStable(rows, func(i, j int) bool {
+		_, rankI, okI := models.GradeRank(rows[i].Grade)
+		_, rankJ, okJ := models.GradeRank(rows[j].Grade)
+		if okI != okJ {
+			return okI
+		}
+		if !okI || rankI == rankJ {
+			return rows[i].Grade < rows[j].Grade
+		}
+		return rankI < rankJ
+	})
+}
@@ -0,0 +1,123 @@
+package training_sessions
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+
+	"github.com/jwallace145/crux-backend/internal/db"
+	"github.com/jwallace145/crux-backend/internal/handlers"
+	"github.com/jwallace145/crux-backend/internal/query"
+	"github.com/jwallace145/crux-backend/internal/utils"
+	"github.com/jwallace145/crux-backend/models"
+)
+
+// partnerInvitationListSpec defines the whitelisted sort fields for GET /training-sessions/invitations
+var partnerInvitationListSpec = &query.Spec{
+	SortFields: map[string]query.SortField{
+		"session_date": {Column: "session_date", Type: query.TypeTime},
+		"id":           {Column: "id", Type: query.TypeUint},
+	},
+	DefaultSort: "-session_date",
+}
+
+// GetPartnerInvitations handles GET /training-sessions/invitations requests to list the sessions
+// the authenticated user has been tagged on as a partner
+// Query parameters:
+//   - status (optional): Invitation status to list ("pending", "accepted" or "declined", default "pending")
+//   - sort (optional): Comma separated sort fields, prefix with "-" for descending (default "-session_date")
+//   - limit (optional): Page size (default 50, max 200)
+//   - cursor (optional): The next_cursor value returned by the previous page
+//
+// Requires AuthMiddleware to be applied - reads user_id from context
+func GetPartnerInvitations(c *fiber.Ctx) error {
+	apiName := "get_partner_invitations"
+	log := utils.GetLoggerFromContext(c)
+
+	log.Info("Starting get partner invitations process",
+		zap.String("api", apiName),
+	)
+
+	// Get user ID from context (set by AuthMiddleware)
+	userID, ok := c.Locals("user_id").(uint)
+	if !ok {
+		log.Error("User ID not found in context",
+			zap.String("api", apiName),
+		)
+		return handlers.InternalErrorResponse(c, apiName, "Authentication context missing", nil)
+	}
+
+	status := c.Query("status", models.PartnerInvitationStatusPending)
+	if status != models.PartnerInvitationStatusPending &&
+		status != models.PartnerInvitationStatusAccepted &&
+		status != models.PartnerInvitationStatusDeclined {
+		return handlers.BadRequestResponse(c, apiName, "status must be 'pending', 'accepted' or 'declined'", nil)
+	}
+
+	// Parse pagination and sorting query parameters
+	params, err := query.Parse(c, partnerInvitationListSpec)
+	if err != nil {
+		log.Warn("Invalid list query parameters",
+			zap.Error(err),
+			zap.String("api", apiName),
+		)
+		return handlers.BadRequestResponse(c, apiName, err.Error(), nil)
+	}
+
+	invitedSessions := db.DB.Model(&models.TrainingSessionPartner{}).
+		Select("training_session_id").
+		Where("user_id = ? AND status = ?", userID, status)
+
+	var sessions []models.TrainingSession
+	if err := params.Apply(db.DB.Where("id IN (?)", invitedSessions)).
+		Preload("User").
+		Preload("Gym").
+		Preload("PartnerInvitations", "user_id = ?", userID).
+		Find(&sessions).Error; err != nil {
+		log.Error("Database error while querying partner invitations",
+			zap.Error(err),
+			zap.String("api", apiName),
+			zap.Uint("user_id", userID),
+		)
+		return handlers.InternalErrorResponse(c, apiName, "Failed to retrieve invitations", nil)
+	}
+
+	sessions, nextCursor, err := query.Paginate(params, sessions, partnerInvitationCursorKey)
+	if err != nil {
+		log.Error("Failed to encode next page cursor",
+			zap.Error(err),
+			zap.String("api", apiName),
+		)
+		return handlers.InternalErrorResponse(c, apiName, "Failed to paginate invitations", nil)
+	}
+
+	invitations := make([]*models.PartnerInvitationResponse, 0, len(sessions))
+	for i := range sessions {
+		if invitation := sessions[i].GetPartnerInvitation(userID); invitation != nil {
+			invitations = append(invitations, invitation.ToPartnerInvitationResponse(&sessions[i]))
+		}
+	}
+
+	log.Info("Partner invitations retrieved successfully",
+		zap.String("api", apiName),
+		zap.Uint("user_id", userID),
+		zap.String("status", status),
+		zap.Int("count", len(invitations)),
+	)
+
+	responseData := map[string]interface{}{
+		"invitations": invitations,
+		"count":       len(invitations),
+		"limit":       params.Limit,
+		"next_cursor": nextCursor,
+	}
+
+	return handlers.SuccessResponse(c, apiName, responseData, "Invitations retrieved successfully")
+}
+
+// partnerInvitationCursorKey returns the sort field values of an invited session used to build the next page cursor
+func partnerInvitationCursorKey(session models.TrainingSession) query.Key {
+	return query.Key{
+		"id":           session.ID,
+		"session_date": session.SessionDate,
+	}
+}
@@ -0,0 +1,107 @@
+package training_sessions
+
+import (
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	"github.com/jwallace145/crux-backend/internal/db"
+	"github.com/jwallace145/crux-backend/internal/handlers"
+	"github.com/jwallace145/crux-backend/internal/services"
+	"github.com/jwallace145/crux-backend/internal/utils"
+	"github.com/jwallace145/crux-backend/models"
+)
+
+// GetTrainingSession handles GET /training-sessions/:id requests to retrieve a single training session
+// The session is visible to its owner and to tagged partners who have not declined the invitation
+// Requires AuthMiddleware to be applied - reads user_id from context
+func GetTrainingSession(c *fiber.Ctx) error {
+	apiName := "get_training_session"
+	log := utils.GetLoggerFromContext(c)
+
+	log.Info("Starting get training session process",
+		zap.String("api", apiName),
+	)
+
+	// Get user ID from context (set by AuthMiddleware)
+	userID, ok := c.Locals("user_id").(uint)
+	if !ok {
+		log.Error("User ID not found in context",
+			zap.String("api", apiName),
+		)
+		return handlers.InternalErrorResponse(c, apiName, "Authentication context missing", nil)
+	}
+
+	sessionID, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return handlers.BadRequestResponse(c, apiName, "id must be a valid number", nil)
+	}
+
+	trainingSession, err := loadTrainingSession(c, apiName, uint(sessionID))
+	if err != nil {
+		return err
+	}
+
+	// Only the owner and tagged partners can view the session
+	if trainingSession.UserID != userID && !isTaggedPartner(trainingSession, userID) {
+		log.Warn("User is not allowed to view training session",
+			zap.String("api", apiName),
+			zap.Uint("user_id", userID),
+			zap.Uint64("training_session_id", sessionID),
+		)
+		return handlers.NotFoundResponse(c, apiName, "Training session not found")
+	}
+
+	response := trainingSession.ToTrainingSessionResponse()
+	response.Media = services.PresignMediaAttachments(c.Context(), trainingSession.Media)
+
+	log.Info("Training session retrieved successfully",
+		zap.String("api", apiName),
+		zap.Uint("training_session_id", trainingSession.ID),
+		zap.Uint("user_id", userID),
+	)
+
+	return handlers.SuccessResponse(c, apiName, response, "Training session retrieved successfully")
+}
+
+// loadTrainingSession fetches a training session with all of its relationships
+func loadTrainingSession(c *fiber.Ctx, apiName string, sessionID uint) (*models.TrainingSession, error) {
+	log := utils.GetLoggerFromContext(c)
+
+	var trainingSession models.TrainingSession
+	if err := db.DB.
+		Preload("Gym").
+		Preload("Partners").
+		Preload("PartnerInvitations").
+		Preload("IndoorBoulders").
+		Preload("RopeClimbs").
+		Preload("IndoorBoulders.Attempts").
+		Preload("RopeClimbs.Attempts").
+		Preload("Exercises").
+		Preload("Media").
+		First(&trainingSession, sessionID).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			log.Warn("Training session not found",
+				zap.String("api", apiName),
+				zap.Uint("training_session_id", sessionID),
+			)
+			return nil, handlers.NotFoundResponse(c, apiName, "Training session not found")
+		}
+		log.Error("Database error while looking up training session",
+			zap.Error(err),
+			zap.String("api", apiName),
+		)
+		return nil, handlers.InternalErrorResponse(c, apiName, "Failed to retrieve training session", nil)
+	}
+
+	return &trainingSession, nil
+}
+
+// isTaggedPartner returns true if the user is tagged as a partner on the loaded session
+// and has not declined the invitation
+func isTaggedPartner(trainingSession *models.TrainingSession, userID uint) bool {
+	invitation := trainingSession.GetPartnerInvitation(userID)
+	return invitation != nil && !invitation.IsDeclined()
+}
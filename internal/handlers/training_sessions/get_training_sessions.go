@@ -8,14 +8,34 @@ import (
 
 	"github.com/jwallace145/crux-backend/internal/db"
 	"github.com/jwallace145/crux-backend/internal/handlers"
+	"github.com/jwallace145/crux-backend/internal/query"
+	"github.com/jwallace145/crux-backend/internal/services"
 	"github.com/jwallace145/crux-backend/internal/utils"
 	"github.com/jwallace145/crux-backend/models"
 )
 
+// trainingSessionListSpec defines the whitelisted sort fields and filters for GET /training-sessions
+var trainingSessionListSpec = &query.Spec{
+	SortFields: map[string]query.SortField{
+		"session_date": {Column: "session_date", Type: query.TypeTime},
+		"created_at":   {Column: "created_at", Type: query.TypeTime},
+		"id":           {Column: "id", Type: query.TypeUint},
+	},
+	DefaultSort: "-session_date",
+	Filters: []query.Filter{
+		{Param: "gym_id", Column: "gym_id", Type: query.TypeUint, Kind: query.FilterEquals},
+	},
+	DateRange: &query.DateRange{Column: "session_date", StartParam: "start_date", EndParam: "end_date"},
+}
+
 // GetTrainingSessions handles GET /training-sessions requests to retrieve training sessions for a user
 // Query parameters:
 //   - start_date (optional): The start date for filtering training sessions (RFC3339 format, e.g., "2024-01-01T00:00:00Z")
 //   - end_date (optional): The end date for filtering training sessions (RFC3339 format, e.g., "2024-12-31T23:59:59Z")
+//   - gym_id (optional): Only sessions at this gym
+//   - sort (optional): Comma separated sort fields, prefix with "-" for descending (default "-session_date")
+//   - limit (optional): Page size (default 50, max 200)
+//   - cursor (optional): The next_cursor value returned by the previous page
 //
 // If start_date is not provided, queries from the beginning of time
 // If end_date is not provided, queries until now
@@ -42,118 +62,80 @@ func GetTrainingSessions(c *fiber.Ctx) error {
 		zap.Uint("user_id", userID),
 	)
 
-	// Parse optional query parameters for date range
-	startDateStr := c.Query("start_date")
-	endDateStr := c.Query("end_date")
-
-	// Default to min time if start_date not provided
-	var startDate time.Time
-	if startDateStr != "" {
-		parsedStartDate, err := time.Parse(time.RFC3339, startDateStr)
-		if err != nil {
-			log.Warn("Invalid start_date format",
-				zap.Error(err),
-				zap.String("api", apiName),
-				zap.String("start_date", startDateStr),
-			)
-			return handlers.BadRequestResponse(c, apiName, "start_date must be in RFC3339 format (e.g., 2024-01-01T00:00:00Z)", nil)
-		}
-		startDate = parsedStartDate
-		log.Info("Start date parsed from query parameter",
-			zap.String("api", apiName),
-			zap.Time("start_date", startDate),
-		)
-	} else {
-		// Use minimum time value (beginning of time)
-		startDate = time.Time{}
-		log.Info("No start_date provided, using minimum time",
+	// Parse pagination, filtering, sorting and date range query parameters
+	params, err := query.Parse(c, trainingSessionListSpec)
+	if err != nil {
+		log.Warn("Invalid list query parameters",
+			zap.Error(err),
 			zap.String("api", apiName),
 		)
-	}
-
-	// Default to current time if end_date not provided
-	var endDate time.Time
-	if endDateStr != "" {
-		parsedEndDate, err := time.Parse(time.RFC3339, endDateStr)
-		if err != nil {
-			log.Warn("Invalid end_date format",
-				zap.Error(err),
-				zap.String("api", apiName),
-				zap.String("end_date", endDateStr),
-			)
-			return handlers.BadRequestResponse(c, apiName, "end_date must be in RFC3339 format (e.g., 2024-12-31T23:59:59Z)", nil)
-		}
-		endDate = parsedEndDate
-		log.Info("End date parsed from query parameter",
-			zap.String("api", apiName),
-			zap.Time("end_date", endDate),
-		)
-	} else {
-		// Use current time as default
-		endDate = time.Now()
-		log.Info("No end_date provided, using current time",
-			zap.String("api", apiName),
-			zap.Time("end_date", endDate),
-		)
-	}
-
-	// Validate that start_date is before end_date
-	if !startDate.IsZero() && startDate.After(endDate) {
-		log.Warn("start_date is after end_date",
-			zap.String("api", apiName),
-			zap.Time("start_date", startDate),
-			zap.Time("end_date", endDate),
-		)
-		return handlers.BadRequestResponse(c, apiName, "start_date must be before or equal to end_date", nil)
+		return handlers.BadRequestResponse(c, apiName, err.Error(), nil)
 	}
 
 	log.Info("Querying training sessions for user within date range",
 		zap.String("api", apiName),
 		zap.Uint("user_id", userID),
-		zap.Time("start_date", startDate),
-		zap.Time("end_date", endDate),
+		zap.Time("start_date", params.StartDate),
+		zap.Time("end_date", params.EndDate),
+		zap.Int("limit", params.Limit),
+		zap.Bool("has_cursor", params.Cursor != nil),
+		zap.Any("filters", params.Applied),
 	)
 
 	// Query training sessions for the user within the date range
 	var trainingSessions []models.TrainingSession
-	query := db.DB.
-		Where("user_id = ?", userID).
-		Where("session_date >= ?", startDate).
-		Where("session_date <= ?", endDate).
-		Order("session_date DESC").
+	result := params.Apply(db.DB.Where("user_id = ?", userID)).
 		Preload("Gym").
 		Preload("Partners").
+		Preload("PartnerInvitations").
 		Preload("IndoorBoulders").
 		Preload("RopeClimbs").
+		Preload("IndoorBoulders.Attempts").
+		Preload("RopeClimbs.Attempts").
+		Preload("Exercises").
+		Preload("Media").
 		Find(&trainingSessions)
 
-	if query.Error != nil {
+	if result.Error != nil {
 		log.Error("Database error while querying training sessions",
-			zap.Error(query.Error),
+			zap.Error(result.Error),
 			zap.String("api", apiName),
 			zap.Uint("user_id", userID),
 		)
 		return handlers.InternalErrorResponse(c, apiName, "Failed to retrieve training sessions", nil)
 	}
 
+	trainingSessions, nextCursor, err := query.Paginate(params, trainingSessions, trainingSessionCursorKey)
+	if err != nil {
+		log.Error("Failed to encode next page cursor",
+			zap.Error(err),
+			zap.String("api", apiName),
+		)
+		return handlers.InternalErrorResponse(c, apiName, "Failed to paginate training sessions", nil)
+	}
+
 	log.Info("Training sessions retrieved successfully",
 		zap.String("api", apiName),
 		zap.Uint("user_id", userID),
 		zap.Int("count", len(trainingSessions)),
+		zap.Bool("has_more", nextCursor != ""),
 	)
 
 	// Convert training sessions to response DTOs
 	sessionResponses := make([]*models.TrainingSessionResponse, len(trainingSessions))
 	for i, session := range trainingSessions {
 		sessionResponses[i] = session.ToTrainingSessionResponse()
+		sessionResponses[i].Media = services.PresignMediaAttachments(c.Context(), session.Media)
 	}
 
 	// Prepare response
 	responseData := map[string]interface{}{
 		"training_sessions": sessionResponses,
 		"count":             len(sessionResponses),
-		"start_date":        startDate.Format(time.RFC3339),
-		"end_date":          endDate.Format(time.RFC3339),
+		"start_date":        params.StartDate.Format(time.RFC3339),
+		"end_date":          params.EndDate.Format(time.RFC3339),
+		"limit":             params.Limit,
+		"next_cursor":       nextCursor,
 	}
 
 	log.Info("Get training sessions completed successfully",
@@ -164,3 +146,12 @@ func GetTrainingSessions(c *fiber.Ctx) error {
 
 	return handlers.SuccessResponse(c, apiName, responseData, "Training sessions retrieved successfully")
 }
+
+// trainingSessionCursorKey returns the sort field values of a session used to build the next page cursor
+func trainingSessionCursorKey(session models.TrainingSession) query.Key {
+	return query.Key{
+		"id":           session.ID,
+		"session_date": session.SessionDate,
+		"created_at":   session.CreatedAt,
+	}
+}
@@ -0,0 +1,142 @@
+package training_sessions
+
+import (
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	"github.com/jwallace145/crux-backend/internal/db"
+	"github.com/jwallace145/crux-backend/internal/handlers"
+	"github.com/jwallace145/crux-backend/internal/services"
+	"github.com/jwallace145/crux-backend/internal/utils"
+	"github.com/jwallace145/crux-backend/models"
+)
+
+// StartTrainingSession handles POST /training-sessions/start requests to begin a live training session
+// The session date and start time are set to now. Climbs are appended with POST /training-sessions/:id/climbs
+// and the session is ended with POST /training-sessions/:id/finish. A user can only have one live session at a time
+// Starting a session checks the user in at the gym until the session is finished
+// Requires AuthMiddleware to be applied - reads user_id from context
+func StartTrainingSession(c *fiber.Ctx) error {
+	apiName := "start_training_session"
+	log := utils.GetLoggerFromContext(c)
+
+	log.Info("Starting live training session process",
+		zap.String("api", apiName),
+	)
+
+	// Validate Content-Type header
+	if err := handlers.ValidateJSONContentType(c, apiName); err != nil {
+		return err
+	}
+
+	// Parse request body
+	var req models.StartTrainingSessionRequest
+	if err := c.BodyParser(&req); err != nil {
+		log.Error("Failed to parse request body",
+			zap.Error(err),
+			zap.String("api", apiName),
+		)
+		return handlers.BadRequestResponse(c, apiName, "Invalid request body", err.Error())
+	}
+
+	// Validate request using the same rules as a session logged after the fact
+	now := time.Now()
+	if err := validateSessionBasicInfo(&models.CreateTrainingSessionRequest{
+		GymID:       req.GymID,
+		SessionDate: now,
+		Description: req.Description,
+	}); err != nil {
+		log.Warn("Request validation failed",
+			zap.Error(err),
+			zap.String("api", apiName),
+		)
+		return handlers.ValidationErrorResponse(c, apiName, err.Error(), nil)
+	}
+
+	// Get user ID from context (set by AuthMiddleware)
+	userID, ok := c.Locals("user_id").(uint)
+	if !ok {
+		log.Error("User ID not found in context",
+			zap.String("api", apiName),
+		)
+		return handlers.InternalErrorResponse(c, apiName, "Authentication context missing", nil)
+	}
+
+	// Only one live session can be in progress at a time
+	var liveSession models.TrainingSession
+	err := db.DB.Where("user_id = ? AND started_at IS NOT NULL AND ended_at IS NULL", userID).First(&liveSession).Error
+	if err == nil {
+		log.Warn("Live training session already in progress",
+			zap.String("api", apiName),
+			zap.Uint("user_id", userID),
+			zap.Uint("training_session_id", liveSession.ID),
+		)
+		return handlers.ConflictResponse(c, apiName, "A live training session is already in progress", map[string]interface{}{
+			"training_session_id": liveSession.ID,
+		})
+	}
+	if err != gorm.ErrRecordNotFound {
+		log.Error("Database error while checking for live training session",
+			zap.Error(err),
+			zap.String("api", apiName),
+		)
+		return handlers.InternalErrorResponse(c, apiName, "Failed to start training session", nil)
+	}
+
+	// Verify gym and partners exist
+	if err := verifyGymExists(c, apiName, req.GymID); err != nil {
+		return err
+	}
+
+	partners, err := verifyPartnersExist(c, apiName, userID, req.PartnerIDs)
+	if err != nil {
+		return err
+	}
+
+	trainingSession := &models.TrainingSession{
+		UserID:      userID,
+		GymID:       req.GymID,
+		SessionDate: now,
+		Description: req.Description,
+		StartedAt:   &now,
+	}
+
+	if err := CreateTrainingSessionWithRelations(trainingSession, partners, &models.CreateTrainingSessionRequest{}); err != nil {
+		log.Error("Failed to create live training session in db",
+			zap.Error(err),
+			zap.String("api", apiName),
+			zap.Uint("user_id", userID),
+		)
+		return handlers.InternalErrorResponse(c, apiName, "Failed to start training session", nil)
+	}
+
+	// Starting a session checks the user in at the gym. The session is already saved, so a failed
+	// check-in only affects the gym's busyness estimate
+	if err := db.DB.Transaction(func(tx *gorm.DB) error {
+		_, _, err := services.CheckInAtGym(tx, userID, req.GymID, &trainingSession.ID, now)
+		return err
+	}); err != nil {
+		log.Warn("Failed to check in at gym for live training session",
+			zap.Error(err),
+			zap.String("api", apiName),
+			zap.Uint("training_session_id", trainingSession.ID),
+			zap.Uint("gym_id", req.GymID),
+		)
+	}
+
+	started, err := loadTrainingSession(c, apiName, trainingSession.ID)
+	if err != nil {
+		return err
+	}
+
+	log.Info("Live training session started successfully",
+		zap.String("api", apiName),
+		zap.Uint("training_session_id", started.ID),
+		zap.Uint("user_id", userID),
+	)
+
+	return handlers.CreatedResponse(c, apiName, started.ToTrainingSessionResponse(), "Training session started successfully")
+}
@@ -0,0 +1,514 @@
+package training_sessions
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	"github.com/jwallace145/crux-backend/internal/db"
+	"github.com/jwallace145/crux-backend/internal/handlers"
+	"github.com/jwallace145/crux-backend/internal/services"
+	"github.com/jwallace145/crux-backend/internal/utils"
+	"github.com/jwallace145/crux-backend/models"
+)
+
+// UpdateTrainingSession handles PATCH /training-sessions/:id requests to update a training session
+// Supports editing the description, date, gym, partners, RPE, duration and pain flags, and adding, editing or removing
+// individual rope climbs, indoor boulders and exercises. All changes are applied in a single transaction.
+// Only the owner of the session can update it
+// Requires AuthMiddleware to be applied - reads user_id from context
+func UpdateTrainingSession(c *fiber.Ctx) error {
+	apiName := "update_training_session"
+	log := utils.GetLoggerFromContext(c)
+
+	log.Info("Starting update training session process",
+		zap.String("api", apiName),
+	)
+
+	// Validate Content-Type header
+	if err := handlers.ValidateJSONContentType(c, apiName); err != nil {
+		return err
+	}
+
+	// Get user ID from context (set by AuthMiddleware)
+	userID, ok := c.Locals("user_id").(uint)
+	if !ok {
+		log.Error("User ID not found in context",
+			zap.String("api", apiName),
+		)
+		return handlers.InternalErrorResponse(c, apiName, "Authentication context missing", nil)
+	}
+
+	sessionID, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return handlers.BadRequestResponse(c, apiName, "id must be a valid number", nil)
+	}
+
+	// Parse request body
+	var req models.UpdateTrainingSessionRequest
+	if err := c.BodyParser(&req); err != nil {
+		log.Error("Failed to parse request body",
+			zap.Error(err),
+			zap.String("api", apiName),
+		)
+		return handlers.BadRequestResponse(c, apiName, "Invalid request body", err.Error())
+	}
+
+	if req.GymID == nil && req.SessionDate == nil && req.Description == nil && req.PartnerIDs == nil &&
+		req.IndoorBoulders == nil && req.RopeClimbs == nil && req.Exercises == nil &&
+		req.RPE == nil && req.DurationMinutes == nil && req.PainFlags == nil {
+		log.Warn("No fields provided for update",
+			zap.String("api", apiName),
+		)
+		return handlers.BadRequestResponse(c, apiName, "No fields provided for update", nil)
+	}
+
+	// Only the owner can update the session
+	var trainingSession models.TrainingSession
+	if err := db.DB.Where("id = ? AND user_id = ?", sessionID, userID).First(&trainingSession).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			log.Warn("Training session not found",
+				zap.String("api", apiName),
+				zap.Uint64("training_session_id", sessionID),
+			)
+			return handlers.NotFoundResponse(c, apiName, "Training session not found")
+		}
+		log.Error("Database error while looking up training session",
+			zap.Error(err),
+			zap.String("api", apiName),
+		)
+		return handlers.InternalErrorResponse(c, apiName, "Failed to update training session", nil)
+	}
+
+	// Validate the session as it will look after the update, using the create validations
+	updates := buildTrainingSessionUpdates(&trainingSession, &req)
+	merged := models.CreateTrainingSessionRequest{
+		GymID:           trainingSession.GymID,
+		SessionDate:     trainingSession.SessionDate,
+		Description:     trainingSession.Description,
+		RPE:             req.RPE,
+		DurationMinutes: req.DurationMinutes,
+	}
+	if req.GymID != nil {
+		merged.GymID = *req.GymID
+	}
+	if req.SessionDate != nil {
+		merged.SessionDate = *req.SessionDate
+	}
+	if req.Description != nil {
+		merged.Description = *req.Description
+	}
+	if req.PainFlags != nil {
+		merged.PainFlags = *req.PainFlags
+	}
+
+	if err := validateUpdateTrainingSessionRequest(&merged, &req); err != nil {
+		log.Warn("Request validation failed",
+			zap.Error(err),
+			zap.String("api", apiName),
+		)
+		return handlers.ValidationErrorResponse(c, apiName, err.Error(), nil)
+	}
+
+	// Verify the new gym and partners exist
+	if req.GymID != nil && *req.GymID != trainingSession.GymID {
+		if err := verifyGymExists(c, apiName, *req.GymID); err != nil {
+			return err
+		}
+	}
+
+	var partners []models.User
+	if req.PartnerIDs != nil {
+		partners, err = verifyPartnersExist(c, apiName, userID, *req.PartnerIDs)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Verify edited and removed climbs belong to this session
+	if err := verifySessionClimbs(c, apiName, trainingSession.ID, &req); err != nil {
+		return err
+	}
+
+	// Verify the gym catalog climbs referenced by added and edited climbs are set at the session's gym
+	var boulders []models.IndoorBoulderRequest
+	var ropeClimbs []models.RopeClimbRequest
+	if changes := req.IndoorBoulders; changes != nil {
+		boulders = append(boulders, changes.Add...)
+		for _, update := range changes.Update {
+			boulders = append(boulders, update.IndoorBoulderRequest)
+		}
+	}
+	if changes := req.RopeClimbs; changes != nil {
+		ropeClimbs = append(ropeClimbs, changes.Add...)
+		for _, update := range changes.Update {
+			ropeClimbs = append(ropeClimbs, update.RopeClimbRequest)
+		}
+	}
+	if err := verifyGymClimbs(c, apiName, merged.GymID, boulders, ropeClimbs); err != nil {
+		return err
+	}
+
+	if err := db.DB.Transaction(func(tx *gorm.DB) error {
+		return applyTrainingSessionUpdates(tx, &trainingSession, updates, partners, &req)
+	}); err != nil {
+		log.Error("Failed to update training session in db",
+			zap.Error(err),
+			zap.String("api", apiName),
+			zap.Uint64("training_session_id", sessionID),
+		)
+		return handlers.InternalErrorResponse(c, apiName, "Failed to update training session", nil)
+	}
+
+	updated, err := loadTrainingSession(c, apiName, trainingSession.ID)
+	if err != nil {
+		return err
+	}
+
+	response := updated.ToTrainingSessionResponse()
+	response.Media = services.PresignMediaAttachments(c.Context(), updated.Media)
+
+	log.Info("Training session updated successfully",
+		zap.String("api", apiName),
+		zap.Uint("training_session_id", updated.ID),
+		zap.Uint("user_id", userID),
+		zap.Int("total_climbs", response.TotalClimbs),
+	)
+
+	return handlers.SuccessResponse(c, apiName, response, "Training session updated successfully")
+}
+
+// buildTrainingSessionUpdates collects the session columns changed by the request
+func buildTrainingSessionUpdates(trainingSession *models.TrainingSession, req *models.UpdateTrainingSessionRequest) map[string]interface{} {
+	updates := make(map[string]interface{})
+	if req.GymID != nil && *req.GymID != trainingSession.GymID {
+		updates["gym_id"] = *req.GymID
+	}
+	if req.SessionDate != nil {
+		updates["session_date"] = *req.SessionDate
+	}
+	if req.Description != nil {
+		updates["description"] = *req.Description
+	}
+	if req.RPE != nil {
+		updates["rpe"] = *req.RPE
+	}
+	if req.DurationMinutes != nil {
+		updates["duration_minutes"] = *req.DurationMinutes
+	}
+	if req.PainFlags != nil {
+		updates["pain_flags"] = models.PainFlags(*req.PainFlags)
+	}
+	return updates
+}
+
+// validateUpdateTrainingSessionRequest validates the merged session and every added or edited climb
+func validateUpdateTrainingSessionRequest(merged *models.CreateTrainingSessionRequest, req *models.UpdateTrainingSessionRequest) error {
+	if err := validateSessionBasicInfo(merged); err != nil {
+		return err
+	}
+
+	if changes := req.IndoorBoulders; changes != nil {
+		if err := validateIndoorBoulders(changes.Add); err != nil {
+			return err
+		}
+		ids := append([]uint{}, changes.Remove...)
+		for _, update := range changes.Update {
+			if err := validateIndoorBoulder(update.IndoorBoulderRequest); err != nil {
+				return err
+			}
+			ids = append(ids, update.ID)
+		}
+		if err := validateClimbIDs(ids, "Indoor boulder"); err != nil {
+			return err
+		}
+	}
+
+	if changes := req.RopeClimbs; changes != nil {
+		if err := validateRopeClimbs(changes.Add); err != nil {
+			return err
+		}
+		ids := append([]uint{}, changes.Remove...)
+		for _, update := range changes.Update {
+			if err := validateRopeClimb(update.RopeClimbRequest); err != nil {
+				return err
+			}
+			ids = append(ids, update.ID)
+		}
+		if err := validateClimbIDs(ids, "Rope climb"); err != nil {
+			return err
+		}
+	}
+
+	if changes := req.Exercises; changes != nil {
+		if err := validateExercises(changes.Add); err != nil {
+			return err
+		}
+		ids := append([]uint{}, changes.Remove...)
+		for _, update := range changes.Update {
+			if err := ValidateExercise(update.ExerciseRequest); err != nil {
+				return err
+			}
+			ids = append(ids, update.ID)
+		}
+		if err := validateClimbIDs(ids, "Exercise"); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// validateClimbIDs ensures edited and removed climb or exercise IDs are set and each appears only once
+func validateClimbIDs(ids []uint, label string) error {
+	seen := make(map[uint]bool, len(ids))
+	for _, id := range ids {
+		if id == 0 {
+			return fiber.NewError(fiber.StatusBadRequest, label+" id is required")
+		}
+		if seen[id] {
+			return fiber.NewError(fiber.StatusBadRequest, label+" "+strconv.FormatUint(uint64(id), 10)+" can only be edited or removed once")
+		}
+		seen[id] = true
+	}
+	return nil
+}
+
+// verifySessionClimbs verifies that every edited or removed climb and exercise belongs to the session
+func verifySessionClimbs(c *fiber.Ctx, apiName string, sessionID uint, req *models.UpdateTrainingSessionRequest) error {
+	if changes := req.IndoorBoulders; changes != nil {
+		ids := append([]uint{}, changes.Remove...)
+		for _, update := range changes.Update {
+			ids = append(ids, update.ID)
+		}
+		if err := verifyClimbsInSession(c, apiName, &models.IndoorBoulder{}, sessionID, ids,
+			"One or more indoor boulders not found in this training session"); err != nil {
+			return err
+		}
+	}
+
+	if changes := req.RopeClimbs; changes != nil {
+		ids := append([]uint{}, changes.Remove...)
+		for _, update := range changes.Update {
+			ids = append(ids, update.ID)
+		}
+		if err := verifyClimbsInSession(c, apiName, &models.RopeClimb{}, sessionID, ids,
+			"One or more rope climbs not found in this training session"); err != nil {
+			return err
+		}
+	}
+
+	if changes := req.Exercises; changes != nil {
+		ids := append([]uint{}, changes.Remove...)
+		for _, update := range changes.Update {
+			ids = append(ids, update.ID)
+		}
+		if err := verifyClimbsInSession(c, apiName, &models.Exercise{}, sessionID, ids,
+			"One or more exercises not found in this training session"); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// verifyClimbsInSession checks that all ids exist for the given climb model within the session
+func verifyClimbsInSession(c *fiber.Ctx, apiName string, model interface{}, sessionID uint, ids []uint, message string) error {
+	log := utils.GetLoggerFromContext(c)
+
+	if len(ids) == 0 {
+		return nil
+	}
+
+	var count int64
+	if err := db.DB.Model(model).
+		Where("training_session_id = ? AND id IN ?", sessionID, ids).
+		Count(&count).Error; err != nil {
+		log.Error("Database error while checking session climbs",
+			zap.Error(err),
+			zap.String("api", apiName),
+		)
+		return handlers.InternalErrorResponse(c, apiName, "Failed to verify session climbs", nil)
+	}
+
+	if int(count) != len(ids) {
+		log.Warn("Session climbs not found",
+			zap.String("api", apiName),
+			zap.Uint("training_session_id", sessionID),
+			zap.Int("requested", len(ids)),
+			zap.Int64("found", count),
+		)
+		return handlers.BadRequestResponse(c, apiName, message, map[string]interface{}{
+			"ids": ids,
+		})
+	}
+
+	return nil
+}
+
+// applyTrainingSessionUpdates writes the session, partner, climb and exercise changes using the given transaction
+func applyTrainingSessionUpdates(tx *gorm.DB, trainingSession *models.TrainingSession, updates map[string]interface{}, partners []models.User, req *models.UpdateTrainingSessionRequest) error {
+	// Always bump updated_at so that climb-only changes are picked up by sync
+	if len(updates) == 0 {
+		updates["updated_at"] = time.Now()
+	}
+	if err := tx.Model(trainingSession).Updates(updates).Error; err != nil {
+		return err
+	}
+
+	// Catalog climbs belong to the old gym, so moving the session to another gym unlinks its climbs
+	if _, ok := updates["gym_id"]; ok {
+		if err := tx.Model(&models.IndoorBoulder{}).
+			Where("training_session_id = ? AND gym_climb_id IS NOT NULL", trainingSession.ID).
+			Update("gym_climb_id", nil).Error; err != nil {
+			return err
+		}
+		if err := tx.Model(&models.RopeClimb{}).
+			Where("training_session_id = ? AND gym_climb_id IS NOT NULL", trainingSession.ID).
+			Update("gym_climb_id", nil).Error; err != nil {
+			return err
+		}
+	}
+
+	if req.PartnerIDs != nil {
+		if err := services.ReplaceSessionPartners(tx, trainingSession.ID, partners); err != nil {
+			return err
+		}
+	}
+
+	if changes := req.IndoorBoulders; changes != nil {
+		if len(changes.Remove) > 0 {
+			if err := tx.Where("training_session_id = ? AND id IN ?", trainingSession.ID, changes.Remove).
+				Delete(&models.IndoorBoulder{}).Error; err != nil {
+				return err
+			}
+			if err := services.DeleteClimbAttempts(tx, models.AttemptParentTypeIndoorBoulder, changes.Remove); err != nil {
+				return err
+			}
+		}
+		for _, update := range changes.Update {
+			boulder := update.ToIndoorBoulder()
+			if err := tx.Model(&models.IndoorBoulder{}).
+				Where("training_session_id = ? AND id = ?", trainingSession.ID, update.ID).
+				Updates(map[string]interface{}{
+					"grade":        boulder.Grade,
+					"color_tag":    boulder.ColorTag,
+					"outcome":      boulder.Outcome,
+					"gym_climb_id": boulder.GymClimbID,
+					"notes":        boulder.Notes,
+				}).Error; err != nil {
+				return err
+			}
+			if err := replaceClimbAttempts(tx, models.AttemptParentTypeIndoorBoulder, update.ID, boulder.Attempts); err != nil {
+				return err
+			}
+		}
+		if len(changes.Add) > 0 {
+			boulders := make([]models.IndoorBoulder, len(changes.Add))
+			for i := range changes.Add {
+				boulders[i] = *changes.Add[i].ToIndoorBoulder()
+				boulders[i].TrainingSessionID = trainingSession.ID
+			}
+			if err := tx.Create(&boulders).Error; err != nil {
+				return err
+			}
+		}
+	}
+
+	if changes := req.RopeClimbs; changes != nil {
+		if len(changes.Remove) > 0 {
+			if err := tx.Where("training_session_id = ? AND id IN ?", trainingSession.ID, changes.Remove).
+				Delete(&models.RopeClimb{}).Error; err != nil {
+				return err
+			}
+			if err := services.DeleteClimbAttempts(tx, models.AttemptParentTypeRopeClimb, changes.Remove); err != nil {
+				return err
+			}
+		}
+		for _, update := range changes.Update {
+			ropeClimb := update.ToRopeClimb()
+			if err := tx.Model(&models.RopeClimb{}).
+				Where("training_session_id = ? AND id = ?", trainingSession.ID, update.ID).
+				Updates(map[string]interface{}{
+					"climb_type":   ropeClimb.ClimbType,
+					"grade":        ropeClimb.Grade,
+					"outcome":      ropeClimb.Outcome,
+					"gym_climb_id": ropeClimb.GymClimbID,
+					"notes":        ropeClimb.Notes,
+				}).Error; err != nil {
+				return err
+			}
+			if err := replaceClimbAttempts(tx, models.AttemptParentTypeRopeClimb, update.ID, ropeClimb.Attempts); err != nil {
+				return err
+			}
+		}
+		if len(changes.Add) > 0 {
+			ropeClimbs := make([]models.RopeClimb, len(changes.Add))
+			for i := range changes.Add {
+				ropeClimbs[i] = *changes.Add[i].ToRopeClimb()
+				ropeClimbs[i].TrainingSessionID = trainingSession.ID
+			}
+			if err := tx.Create(&ropeClimbs).Error; err != nil {
+				return err
+			}
+		}
+	}
+
+	if changes := req.Exercises; changes != nil {
+		if len(changes.Remove) > 0 {
+			if err := tx.Where("training_session_id = ? AND id IN ?", trainingSession.ID, changes.Remove).
+				Delete(&models.Exercise{}).Error; err != nil {
+				return err
+			}
+		}
+		for _, update := range changes.Update {
+			if err := tx.Model(&models.Exercise{}).
+				Where("training_session_id = ? AND id = ?", trainingSession.ID, update.ID).
+				Updates(map[string]interface{}{
+					"type":            update.Type,
+					"name":            update.Name,
+					"sets":            update.Sets,
+					"reps":            update.Reps,
+					"hang_seconds":    update.HangSeconds,
+					"rest_seconds":    update.RestSeconds,
+					"added_weight_kg": update.AddedWeightKg,
+					"edge_size_mm":    update.EdgeSizeMM,
+					"grip":            update.Grip,
+					"notes":           update.Notes,
+				}).Error; err != nil {
+				return err
+			}
+		}
+		if len(changes.Add) > 0 {
+			exercises := make([]models.Exercise, len(changes.Add))
+			for i := range changes.Add {
+				exercises[i] = *changes.Add[i].ToExercise()
+				exercises[i].TrainingSessionID = trainingSession.ID
+			}
+			if err := tx.Create(&exercises).Error; err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// replaceClimbAttempts deletes the attempts of an edited climb and creates the attempts in the request
+func replaceClimbAttempts(tx *gorm.DB, parentType string, parentID uint, attempts []models.ClimbAttempt) error {
+	if err := tx.Unscoped().Where("parent_type = ? AND parent_id = ?", parentType, parentID).
+		Delete(&models.ClimbAttempt{}).Error; err != nil {
+		return err
+	}
+	if len(attempts) == 0 {
+		return nil
+	}
+	for i := range attempts {
+		attempts[i].ParentType = parentType
+		attempts[i].ParentID = parentID
+	}
+	return tx.Create(&attempts).Error
+}
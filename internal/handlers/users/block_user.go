@@ -0,0 +1,101 @@
+package users
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/jwallace145/crux-backend/internal/db"
+	"github.com/jwallace145/crux-backend/internal/handlers"
+	"github.com/jwallace145/crux-backend/internal/services"
+	"github.com/jwallace145/crux-backend/internal/utils"
+	"github.com/jwallace145/crux-backend/models"
+)
+
+// BlockUser handles POST /users/blocks requests to stop another user from tagging the authenticated
+// user as a training partner. Pending invitations from the blocked user are declined
+// Blocking an already blocked user succeeds without changes
+// Requires AuthMiddleware to be applied - reads user_id from context
+func BlockUser(c *fiber.Ctx) error {
+	apiName := "block_user"
+	log := utils.GetLoggerFromContext(c)
+
+	log.Info("Starting block user process",
+		zap.String("api", apiName),
+	)
+
+	// Validate Content-Type header
+	if err := handlers.ValidateJSONContentType(c, apiName); err != nil {
+		return err
+	}
+
+	// Get user ID from context (set by AuthMiddleware)
+	userID, ok := c.Locals("user_id").(uint)
+	if !ok {
+		log.Error("User ID not found in context",
+			zap.String("api", apiName),
+		)
+		return handlers.InternalErrorResponse(c, apiName, "Authentication context missing", nil)
+	}
+
+	// Parse request body
+	var req models.BlockUserRequest
+	if err := c.BodyParser(&req); err != nil {
+		log.Error("Failed to parse request body",
+			zap.Error(err),
+			zap.String("api", apiName),
+		)
+		return handlers.BadRequestResponse(c, apiName, "Invalid request body", err.Error())
+	}
+
+	if req.UserID == 0 {
+		return handlers.ValidationErrorResponse(c, apiName, "User ID is required", nil)
+	}
+	if req.UserID == userID {
+		return handlers.ValidationErrorResponse(c, apiName, "You cannot block yourself", nil)
+	}
+
+	var blocked models.User
+	if err := db.DB.First(&blocked, req.UserID).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			log.Warn("User to block not found",
+				zap.String("api", apiName),
+				zap.Uint("blocked_id", req.UserID),
+			)
+			return handlers.NotFoundResponse(c, apiName, "User not found")
+		}
+		log.Error("Database error while looking up user",
+			zap.Error(err),
+			zap.String("api", apiName),
+		)
+		return handlers.InternalErrorResponse(c, apiName, "Failed to block user", nil)
+	}
+
+	block := models.UserBlock{BlockerID: userID, BlockedID: blocked.ID}
+	if err := db.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&block).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("blocker_id = ? AND blocked_id = ?", userID, blocked.ID).First(&block).Error; err != nil {
+			return err
+		}
+		return services.DeclineInvitationsFromUser(tx, userID, blocked.ID)
+	}); err != nil {
+		log.Error("Failed to block user in db",
+			zap.Error(err),
+			zap.String("api", apiName),
+			zap.Uint("blocked_id", blocked.ID),
+		)
+		return handlers.InternalErrorResponse(c, apiName, "Failed to block user", nil)
+	}
+	block.Blocked = blocked
+
+	log.Info("User blocked successfully",
+		zap.String("api", apiName),
+		zap.Uint("user_id", userID),
+		zap.Uint("blocked_id", blocked.ID),
+	)
+
+	return handlers.CreatedResponse(c, apiName, block.ToUserBlockResponse(), "User blocked successfully")
+}
@@ -0,0 +1,62 @@
+package users
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+
+	"github.com/jwallace145/crux-backend/internal/db"
+	"github.com/jwallace145/crux-backend/internal/handlers"
+	"github.com/jwallace145/crux-backend/internal/utils"
+	"github.com/jwallace145/crux-backend/models"
+)
+
+// GetBlockedUsers handles GET /users/blocks requests to list the users blocked by the authenticated user
+// Requires AuthMiddleware to be applied - reads user_id from context
+func GetBlockedUsers(c *fiber.Ctx) error {
+	apiName := "get_blocked_users"
+	log := utils.GetLoggerFromContext(c)
+
+	log.Info("Starting get blocked users process",
+		zap.String("api", apiName),
+	)
+
+	// Get user ID from context (set by AuthMiddleware)
+	userID, ok := c.Locals("user_id").(uint)
+	if !ok {
+		log.Error("User ID not found in context",
+			zap.String("api", apiName),
+		)
+		return handlers.InternalErrorResponse(c, apiName, "Authentication context missing", nil)
+	}
+
+	var blocks []models.UserBlock
+	if err := db.DB.Where("blocker_id = ?", userID).
+		Preload("Blocked").
+		Order("created_at DESC").
+		Find(&blocks).Error; err != nil {
+		log.Error("Database error while querying blocked users",
+			zap.Error(err),
+			zap.String("api", apiName),
+			zap.Uint("user_id", userID),
+		)
+		return handlers.InternalErrorResponse(c, apiName, "Failed to retrieve blocked users", nil)
+	}
+
+	blockResponses := make([]*models.UserBlockResponse, len(blocks))
+	for i := range blocks {
+		blockResponses[i] = blocks[i].ToUserBlockResponse()
+	}
+
+	log.Info("Blocked users retrieved successfully",
+		zap.String("api", apiName),
+		zap.Uint("user_id", userID),
+		zap.Int("count", len(blockResponses)),
+	)
+
+	responseData := map[string]interface{}{
+		"blocks": blockResponses,
+		"count":  len(blockResponses),
+	}
+
+	return handlers.SuccessResponse(c, apiName, responseData, "Blocked users retrieved successfully")
+}
@@ -0,0 +1,69 @@
+package users
+
+import (
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+
+	"github.com/jwallace145/crux-backend/internal/db"
+	"github.com/jwallace145/crux-backend/internal/handlers"
+	"github.com/jwallace145/crux-backend/internal/utils"
+	"github.com/jwallace145/crux-backend/models"
+)
+
+// UnblockUser handles DELETE /users/blocks/:id requests to allow a blocked user to tag the
+// authenticated user as a training partner again. The :id parameter is the blocked user's ID
+// Requires AuthMiddleware to be applied - reads user_id from context
+func UnblockUser(c *fiber.Ctx) error {
+	apiName := "unblock_user"
+	log := utils.GetLoggerFromContext(c)
+
+	log.Info("Starting unblock user process",
+		zap.String("api", apiName),
+	)
+
+	// Get user ID from context (set by AuthMiddleware)
+	userID, ok := c.Locals("user_id").(uint)
+	if !ok {
+		log.Error("User ID not found in context",
+			zap.String("api", apiName),
+		)
+		return handlers.InternalErrorResponse(c, apiName, "Authentication context missing", nil)
+	}
+
+	blockedID, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return handlers.BadRequestResponse(c, apiName, "id must be a valid number", nil)
+	}
+
+	// Hard delete so the same user can be blocked again later
+	result := db.DB.Unscoped().
+		Where("blocker_id = ? AND blocked_id = ?", userID, blockedID).
+		Delete(&models.UserBlock{})
+	if result.Error != nil {
+		log.Error("Failed to unblock user in db",
+			zap.Error(result.Error),
+			zap.String("api", apiName),
+			zap.Uint64("blocked_id", blockedID),
+		)
+		return handlers.InternalErrorResponse(c, apiName, "Failed to unblock user", nil)
+	}
+
+	if result.RowsAffected == 0 {
+		log.Warn("Block not found",
+			zap.String("api", apiName),
+			zap.Uint("user_id", userID),
+			zap.Uint64("blocked_id", blockedID),
+		)
+		return handlers.NotFoundResponse(c, apiName, "Block not found")
+	}
+
+	log.Info("User unblocked successfully",
+		zap.String("api", apiName),
+		zap.Uint("user_id", userID),
+		zap.Uint64("blocked_id", blockedID),
+	)
+
+	return handlers.SuccessResponse(c, apiName, map[string]interface{}{"user_id": blockedID}, "User unblocked successfully")
+}
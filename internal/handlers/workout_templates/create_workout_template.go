@@ -0,0 +1,164 @@
+package workout_templates
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	"github.com/jwallace145/crux-backend/internal/db"
+	"github.com/jwallace145/crux-backend/internal/handlers"
+	"github.com/jwallace145/crux-backend/internal/handlers/training_sessions"
+	"github.com/jwallace145/crux-backend/internal/utils"
+	"github.com/jwallace145/crux-backend/models"
+)
+
+// maxWorkoutTemplateItems is the maximum number of items in a workout template
+const maxWorkoutTemplateItems = 50
+
+// CreateWorkoutTemplate handles POST /workout-templates requests to create a reusable workout
+// Climb items target a number of boulders or rope climbs at or above an optional grade, and
+// exercise items target sets and reps of a hangboard, campus or strength exercise
+// Requires AuthMiddleware to be applied - reads user_id from context
+func CreateWorkoutTemplate(c *fiber.Ctx) error {
+	apiName := "create_workout_template"
+	log := utils.GetLoggerFromContext(c)
+
+	log.Info("Starting workout template creation process",
+		zap.String("api", apiName),
+	)
+
+	// Validate Content-Type header
+	if err := handlers.ValidateJSONContentType(c, apiName); err != nil {
+		return err
+	}
+
+	// Get user ID from context (set by AuthMiddleware)
+	userID, ok := c.Locals("user_id").(uint)
+	if !ok {
+		log.Error("User ID not found in context",
+			zap.String("api", apiName),
+		)
+		return handlers.InternalErrorResponse(c, apiName, "Authentication context missing", nil)
+	}
+
+	// Parse request body
+	var req models.CreateWorkoutTemplateRequest
+	if err := c.BodyParser(&req); err != nil {
+		log.Error("Failed to parse request body",
+			zap.Error(err),
+			zap.String("api", apiName),
+		)
+		return handlers.BadRequestResponse(c, apiName, "Invalid request body", err.Error())
+	}
+
+	// Validate request
+	if err := validateCreateWorkoutTemplateRequest(&req); err != nil {
+		log.Warn("Request validation failed",
+			zap.Error(err),
+			zap.String("api", apiName),
+		)
+		return handlers.ValidationErrorResponse(c, apiName, err.Error(), nil)
+	}
+
+	template := &models.WorkoutTemplate{
+		UserID:      userID,
+		Name:        strings.TrimSpace(req.Name),
+		Description: req.Description,
+		IsPublic:    req.IsPublic,
+		Items:       make([]models.WorkoutTemplateItem, len(req.Items)),
+	}
+	for i := range req.Items {
+		template.Items[i] = *req.Items[i].ToWorkoutTemplateItem(i)
+	}
+
+	if err := db.DB.Transaction(func(tx *gorm.DB) error {
+		return tx.Create(template).Error
+	}); err != nil {
+		log.Error("Failed to create workout template in db",
+			zap.Error(err),
+			zap.String("api", apiName),
+			zap.Uint("user_id", userID),
+		)
+		return handlers.InternalErrorResponse(c, apiName, "Failed to create workout template", nil)
+	}
+
+	log.Info("Workout template created successfully",
+		zap.String("api", apiName),
+		zap.Uint("workout_template_id", template.ID),
+		zap.Uint("user_id", userID),
+		zap.Int("items", len(template.Items)),
+	)
+
+	return handlers.CreatedResponse(c, apiName, template.ToWorkoutTemplateResponse(), "Workout template created successfully")
+}
+
+// validateCreateWorkoutTemplateRequest validates the create workout template request
+func validateCreateWorkoutTemplateRequest(req *models.CreateWorkoutTemplateRequest) error {
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return fiber.NewError(fiber.StatusBadRequest, "Name is required")
+	}
+	if len(name) > 100 {
+		return fiber.NewError(fiber.StatusBadRequest, "Name must not exceed 100 characters")
+	}
+	if len(req.Description) > 1000 {
+		return fiber.NewError(fiber.StatusBadRequest, "Description must not exceed 1000 characters")
+	}
+	if len(req.Items) == 0 {
+		return fiber.NewError(fiber.StatusBadRequest, "At least one item is required")
+	}
+	if len(req.Items) > maxWorkoutTemplateItems {
+		return fiber.NewError(fiber.StatusBadRequest, "A workout template can have at most "+strconv.Itoa(maxWorkoutTemplateItems)+" items")
+	}
+	for _, item := range req.Items {
+		if err := validateWorkoutTemplateItem(item); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// validateWorkoutTemplateItem validates a single workout template item
+// Exercise targets reuse the training session exercise validation
+func validateWorkoutTemplateItem(item models.WorkoutTemplateItemRequest) error {
+	if len(item.Notes) > 1000 {
+		return fiber.NewError(fiber.StatusBadRequest, "Item notes must not exceed 1000 characters")
+	}
+
+	switch item.Kind {
+	case models.WorkoutItemKindIndoorBoulder, models.WorkoutItemKindRopeClimb:
+		if item.Exercise != nil {
+			return fiber.NewError(fiber.StatusBadRequest, "Exercise can only be set on exercise items")
+		}
+		if item.Count < 1 || item.Count > 200 {
+			return fiber.NewError(fiber.StatusBadRequest, "Item count must be between 1 and 200")
+		}
+		if item.Grade == "" {
+			return nil
+		}
+		scale, _, ok := models.GradeRank(item.Grade)
+		if !ok {
+			return fiber.NewError(fiber.StatusBadRequest, "Item grade is not a recognized grade")
+		}
+		if item.Kind == models.WorkoutItemKindIndoorBoulder && scale != models.GradeScaleVScale {
+			return fiber.NewError(fiber.StatusBadRequest, "Indoor boulder items must use a V-scale grade")
+		}
+		if item.Kind == models.WorkoutItemKindRopeClimb && scale != models.GradeScaleYDS {
+			return fiber.NewError(fiber.StatusBadRequest, "Rope climb items must use a YDS grade")
+		}
+		return nil
+	case models.WorkoutItemKindExercise:
+		if item.Exercise == nil {
+			return fiber.NewError(fiber.StatusBadRequest, "Exercise is required on exercise items")
+		}
+		if item.Count != 0 || item.Grade != "" {
+			return fiber.NewError(fiber.StatusBadRequest, "Count and grade can only be set on climb items")
+		}
+		return training_sessions.ValidateExercise(*item.Exercise)
+	default:
+		return fiber.NewError(fiber.StatusBadRequest, "Item kind must be 'indoor_boulder', 'rope_climb', or 'exercise'")
+	}
+}
@@ -0,0 +1,66 @@
+package workout_templates
+
+import (
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+
+	"github.com/jwallace145/crux-backend/internal/db"
+	"github.com/jwallace145/crux-backend/internal/handlers"
+	"github.com/jwallace145/crux-backend/internal/utils"
+	"github.com/jwallace145/crux-backend/models"
+)
+
+// DeleteWorkoutTemplate handles DELETE /workout-templates/:id requests to delete a workout template
+// Only the owner can delete a template. Sessions already planned from it keep their schedule and
+// compliance scores
+// Requires AuthMiddleware to be applied - reads user_id from context
+func DeleteWorkoutTemplate(c *fiber.Ctx) error {
+	apiName := "delete_workout_template"
+	log := utils.GetLoggerFromContext(c)
+
+	log.Info("Starting delete workout template process",
+		zap.String("api", apiName),
+	)
+
+	// Get user ID from context (set by AuthMiddleware)
+	userID, ok := c.Locals("user_id").(uint)
+	if !ok {
+		log.Error("User ID not found in context",
+			zap.String("api", apiName),
+		)
+		return handlers.InternalErrorResponse(c, apiName, "Authentication context missing", nil)
+	}
+
+	templateID, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return handlers.BadRequestResponse(c, apiName, "id must be a valid number", nil)
+	}
+
+	result := db.DB.Where("id = ? AND user_id = ?", templateID, userID).Delete(&models.WorkoutTemplate{})
+	if result.Error != nil {
+		log.Error("Failed to delete workout template in db",
+			zap.Error(result.Error),
+			zap.String("api", apiName),
+			zap.Uint64("workout_template_id", templateID),
+		)
+		return handlers.InternalErrorResponse(c, apiName, "Failed to delete workout template", nil)
+	}
+
+	if result.RowsAffected == 0 {
+		log.Warn("Workout template not found",
+			zap.String("api", apiName),
+			zap.Uint64("workout_template_id", templateID),
+		)
+		return handlers.NotFoundResponse(c, apiName, "Workout template not found")
+	}
+
+	log.Info("Workout template deleted successfully",
+		zap.String("api", apiName),
+		zap.Uint64("workout_template_id", templateID),
+		zap.Uint("user_id", userID),
+	)
+
+	return handlers.SuccessResponse(c, apiName, map[string]interface{}{"id": templateID}, "Workout template deleted successfully")
+}
@@ -0,0 +1,68 @@
+package workout_templates
+
+import (
+	"strconv"
+
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	"github.com/jwallace145/crux-backend/internal/db"
+	"github.com/jwallace145/crux-backend/internal/handlers"
+	"github.com/jwallace145/crux-backend/internal/utils"
+	"github.com/jwallace145/crux-backend/models"
+)
+
+// GetWorkoutTemplate handles GET /workout-templates/:id requests to retrieve a workout template
+// The template is visible to its owner, or to anyone if it is public
+// Requires AuthMiddleware to be applied - reads user_id from context
+func GetWorkoutTemplate(c *fiber.Ctx) error {
+	apiName := "get_workout_template"
+	log := utils.GetLoggerFromContext(c)
+
+	log.Info("Starting get workout template process",
+		zap.String("api", apiName),
+	)
+
+	// Get user ID from context (set by AuthMiddleware)
+	userID, ok := c.Locals("user_id").(uint)
+	if !ok {
+		log.Error("User ID not found in context",
+			zap.String("api", apiName),
+		)
+		return handlers.InternalErrorResponse(c, apiName, "Authentication context missing", nil)
+	}
+
+	templateID, err := strconv.ParseUint(c.Params("id"), 10, 32)
+	if err != nil {
+		return handlers.BadRequestResponse(c, apiName, "id must be a valid number", nil)
+	}
+
+	var template models.WorkoutTemplate
+	if err := db.DB.Where("id = ? AND (user_id = ? OR is_public = ?)", templateID, userID, true).
+		Preload("Items", func(tx *gorm.DB) *gorm.DB {
+			return tx.Order("position ASC")
+		}).
+		First(&template).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			log.Warn("Workout template not found",
+				zap.String("api", apiName),
+				zap.Uint64("workout_template_id", templateID),
+			)
+			return handlers.NotFoundResponse(c, apiName, "Workout template not found")
+		}
+		log.Error("Database error while looking up workout template",
+			zap.Error(err),
+			zap.String("api", apiName),
+		)
+		return handlers.InternalErrorResponse(c, apiName, "Failed to retrieve workout template", nil)
+	}
+
+	log.Info("Workout template retrieved successfully",
+		zap.String("api", apiName),
+		zap.Uint("workout_template_id", template.ID),
+		zap.Uint("user_id", userID),
+	)
+
+	return handlers.SuccessResponse(c, apiName, template.ToWorkoutTemplateResponse(), "Workout template retrieved successfully")
+}
@@ -0,0 +1,117 @@
+package workout_templates
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	"github.com/jwallace145/crux-backend/internal/db"
+	"github.com/jwallace145/crux-backend/internal/handlers"
+	"github.com/jwallace145/crux-backend/internal/query"
+	"github.com/jwallace145/crux-backend/internal/utils"
+	"github.com/jwallace145/crux-backend/models"
+)
+
+// workoutTemplateListSpec defines the whitelisted sort fields for GET /workout-templates
+var workoutTemplateListSpec = &query.Spec{
+	SortFields: map[string]query.SortField{
+		"name":       {Column: "name", Type: query.TypeString},
+		"created_at": {Column: "created_at", Type: query.TypeTime},
+		"id":         {Column: "id", Type: query.TypeUint},
+	},
+	DefaultSort: "name",
+}
+
+// GetWorkoutTemplates handles GET /workout-templates requests to list the workout templates
+// the authenticated user can schedule: their own templates and public templates of other users
+// Query parameters:
+//   - mine (optional): "true" to only list the user's own templates
+//   - sort (optional): Comma separated sort fields, prefix with "-" for descending (default "name")
+//   - limit (optional): Page size (default 50, max 200)
+//   - cursor (optional): The next_cursor value returned by the previous page
+//
+// Requires AuthMiddleware to be applied - reads user_id from context
+func GetWorkoutTemplates(c *fiber.Ctx) error {
+	apiName := "get_workout_templates"
+	log := utils.GetLoggerFromContext(c)
+
+	log.Info("Starting get workout templates process",
+		zap.String("api", apiName),
+	)
+
+	// Get user ID from context (set by AuthMiddleware)
+	userID, ok := c.Locals("user_id").(uint)
+	if !ok {
+		log.Error("User ID not found in context",
+			zap.String("api", apiName),
+		)
+		return handlers.InternalErrorResponse(c, apiName, "Authentication context missing", nil)
+	}
+
+	// Parse pagination and sorting query parameters
+	params, err := query.Parse(c, workoutTemplateListSpec)
+	if err != nil {
+		log.Warn("Invalid list query parameters",
+			zap.Error(err),
+			zap.String("api", apiName),
+		)
+		return handlers.BadRequestResponse(c, apiName, err.Error(), nil)
+	}
+
+	templateQuery := db.DB.Where("(user_id = ? OR is_public = ?)", userID, true)
+	if c.QueryBool("mine") {
+		templateQuery = db.DB.Where("user_id = ?", userID)
+	}
+
+	var templates []models.WorkoutTemplate
+	if err := params.Apply(templateQuery).
+		Preload("Items", func(tx *gorm.DB) *gorm.DB {
+			return tx.Order("position ASC")
+		}).
+		Find(&templates).Error; err != nil {
+		log.Error("Database error while querying workout templates",
+			zap.Error(err),
+			zap.String("api", apiName),
+			zap.Uint("user_id", userID),
+		)
+		return handlers.InternalErrorResponse(c, apiName, "Failed to retrieve workout templates", nil)
+	}
+
+	templates, nextCursor, err := query.Paginate(params, templates, workoutTemplateCursorKey)
+	if err != nil {
+		log.Error("Failed to encode next page cursor",
+			zap.Error(err),
+			zap.String("api", apiName),
+		)
+		return handlers.InternalErrorResponse(c, apiName, "Failed to paginate workout templates", nil)
+	}
+
+	templateResponses := make([]*models.WorkoutTemplateResponse, len(templates))
+	for i := range templates {
+		templateResponses[i] = templates[i].ToWorkoutTemplateResponse()
+	}
+
+	log.Info("Workout templates retrieved successfully",
+		zap.String("api", apiName),
+		zap.Uint("user_id", userID),
+		zap.Int("count", len(templateResponses)),
+	)
+
+	responseData := map[string]interface{}{
+		"workout_templates": templateResponses,
+		"count":             len(templateResponses),
+		"limit":             params.Limit,
+		"next_cursor":       nextCursor,
+	}
+
+	return handlers.SuccessResponse(c, apiName, responseData, "Workout templates retrieved successfully")
+}
+
+// workoutTemplateCursorKey returns the sort field values of a template used to build the next page cursor
+func workoutTemplateCursorKey(template models.WorkoutTemplate) query.Key {
+	return query.Key{
+		"id":         template.ID,
+		"name":       template.Name,
+		"created_at": template.CreatedAt,
+	}
+}
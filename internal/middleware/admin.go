@@ -0,0 +1,50 @@
+package middleware
+
+import (
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+
+	"github.com/jwallace145/crux-backend/internal/db"
+	"github.com/jwallace145/crux-backend/internal/handlers"
+	"github.com/jwallace145/crux-backend/internal/services"
+	"github.com/jwallace145/crux-backend/internal/utils"
+)
+
+// AdminMiddleware only lets requests from users with the admin role through and returns
+// 403 Forbidden for everyone else.
+// Must be applied after AuthMiddleware - reads user_id from context
+func AdminMiddleware() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		apiName := "admin_middleware"
+		log := utils.GetLoggerFromContext(c)
+
+		userID, ok := c.Locals("user_id").(uint)
+		if !ok {
+			log.Error("User ID not found in context",
+				zap.String("api", apiName),
+			)
+			return handlers.InternalErrorResponse(c, apiName, "Authentication context missing", nil)
+		}
+
+		isAdmin, err := services.IsAdmin(db.DB, userID)
+		if err != nil {
+			log.Error("Database error while checking user role",
+				zap.Error(err),
+				zap.String("api", apiName),
+				zap.Uint("user_id", userID),
+			)
+			return handlers.InternalErrorResponse(c, apiName, "Failed to verify user role", nil)
+		}
+
+		if !isAdmin {
+			log.Warn("Non-admin user attempted to access admin route",
+				zap.String("api", apiName),
+				zap.Uint("user_id", userID),
+				zap.String("path", c.Path()),
+			)
+			return handlers.ForbiddenResponse(c, apiName, "Admin access required")
+		}
+
+		return c.Next()
+	}
+}
@@ -0,0 +1,81 @@
+package middleware
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+
+	"github.com/jwallace145/crux-backend/internal/handlers"
+	"github.com/jwallace145/crux-backend/internal/utils"
+)
+
+// BodyLimitMiddleware rejects requests with a body larger than limit bytes with 413 Payload Too
+// Large. The app streams bodies over its own BodyLimit rather than rejecting them, so this
+// middleware enforces the limit before a handler reads the body into memory.
+//
+// Upload routes that accept larger files are listed in skipPaths and apply their own
+// BodyLimitMiddleware with the upload limit. A "*" segment in a skip path matches any one
+// path segment, such as a route parameter
+func BodyLimitMiddleware(limit int, skipPaths ...string) fiber.Handler {
+	skip := make([][]string, len(skipPaths))
+	for i, path := range skipPaths {
+		skip[i] = strings.Split(strings.TrimSuffix(path, "/"), "/")
+	}
+
+	return func(c *fiber.Ctx) error {
+		apiName := "body_limit_middleware"
+		log := utils.GetLoggerFromContext(c)
+
+		path := strings.Split(strings.TrimSuffix(c.Path(), "/"), "/")
+		for _, skipPath := range skip {
+			if matchesPathSegments(path, skipPath) {
+				return c.Next()
+			}
+		}
+
+		req := c.Request()
+		size := req.Header.ContentLength()
+		if size < 0 && req.IsBodyStream() {
+			// Chunked bodies have no declared length, so read at most one byte past the limit
+			body, err := io.ReadAll(io.LimitReader(req.BodyStream(), int64(limit)+1))
+			if err != nil {
+				return handlers.BadRequestResponse(c, apiName, "Failed to read request body", nil)
+			}
+			size = len(body)
+			if size <= limit {
+				req.SetBodyRaw(body)
+			}
+		}
+
+		if size > limit {
+			log.Warn("Request body too large",
+				zap.String("api", apiName),
+				zap.String("path", c.Path()),
+				zap.Int("size", size),
+				zap.Int("limit", limit),
+			)
+			// The rest of the body is left unread, so the connection cannot be reused
+			c.Context().SetConnectionClose()
+			return handlers.PayloadTooLargeResponse(c, apiName, fmt.Sprintf("Request body must not exceed %d bytes", limit))
+		}
+
+		return c.Next()
+	}
+}
+
+// matchesPathSegments returns true if a request path matches a pattern segment by segment, with
+// "*" matching any one segment
+func matchesPathSegments(path, pattern []string) bool {
+	if len(path) != len(pattern) {
+		return false
+	}
+	for i := range pattern {
+		if pattern[i] != "*" && pattern[i] != path[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,201 @@
+package middleware
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/jwallace145/crux-backend/internal/db"
+	"github.com/jwallace145/crux-backend/internal/handlers"
+	"github.com/jwallace145/crux-backend/internal/utils"
+	"github.com/jwallace145/crux-backend/models"
+)
+
+// IdempotencyKeyHeader is the request header clients use to make POST requests safe to retry
+const IdempotencyKeyHeader = "Idempotency-Key"
+
+// IdempotencyMiddleware honours the Idempotency-Key header on POST routes.
+//
+// The middleware follows this flow:
+// 1. Requests without the header, or that are not POST, proceed unchanged
+// 2. The request is fingerprinted by method, path and body
+// 3. If the user already used the key within the TTL:
+//   - A different fingerprint returns 409 Conflict
+//   - A completed request replays the stored status and APIResponse
+//   - A request still in progress returns 409 Conflict
+//
+// 4. Otherwise the key is reserved, the handler runs and its response is stored
+//
+// Responses with a 5xx status are not stored so the client can retry with the same key. Expired
+// keys are purged in the background by services.StartIdempotencyKeyPurger
+// Must be applied after AuthMiddleware - reads user_id from context
+func IdempotencyMiddleware(ttl time.Duration) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		apiName := "idempotency_middleware"
+		log := utils.GetLoggerFromContext(c)
+
+		key := c.Get(IdempotencyKeyHeader)
+		if key == "" || c.Method() != fiber.MethodPost {
+			return c.Next()
+		}
+
+		if len(key) > 255 {
+			return handlers.BadRequestResponse(c, apiName, "Idempotency-Key must not exceed 255 characters", nil)
+		}
+
+		userID, ok := c.Locals("user_id").(uint)
+		if !ok {
+			log.Error("User ID not found in context",
+				zap.String("api", apiName),
+			)
+			return handlers.InternalErrorResponse(c, apiName, "Authentication context missing", nil)
+		}
+
+		fingerprint := requestFingerprint(c)
+
+		// Check for an earlier request with this key
+		var existing models.IdempotencyKey
+		err := db.DB.Where("user_id = ? AND key = ?", userID, key).First(&existing).Error
+		if err != nil && err != gorm.ErrRecordNotFound {
+			log.Error("Database error while looking up idempotency key",
+				zap.Error(err),
+				zap.String("api", apiName),
+			)
+			return handlers.InternalErrorResponse(c, apiName, "Failed to process Idempotency-Key", nil)
+		}
+
+		if err == nil {
+			if !existing.IsExpired() {
+				return replayIdempotentRequest(c, apiName, &existing, fingerprint)
+			}
+
+			// Expired keys can be reused for a new request
+			if err := db.DB.Unscoped().Delete(&existing).Error; err != nil {
+				log.Error("Failed to delete expired idempotency key",
+					zap.Error(err),
+					zap.String("api", apiName),
+				)
+				return handlers.InternalErrorResponse(c, apiName, "Failed to process Idempotency-Key", nil)
+			}
+		}
+
+		// Reserve the key so concurrent retries are rejected while this request runs
+		record := &models.IdempotencyKey{
+			UserID:      userID,
+			Key:         key,
+			Method:      c.Method(),
+			Path:        c.Path(),
+			Fingerprint: fingerprint,
+			ExpiresAt:   time.Now().Add(ttl),
+		}
+		result := db.DB.Clauses(clause.OnConflict{DoNothing: true}).Create(record)
+		if result.Error != nil {
+			log.Error("Failed to reserve idempotency key",
+				zap.Error(result.Error),
+				zap.String("api", apiName),
+			)
+			return handlers.InternalErrorResponse(c, apiName, "Failed to process Idempotency-Key", nil)
+		}
+		if result.RowsAffected == 0 {
+			log.Warn("Idempotency key reserved by a concurrent request",
+				zap.String("api", apiName),
+				zap.Uint("user_id", userID),
+			)
+			return handlers.ConflictResponse(c, apiName, "A request with this Idempotency-Key is still being processed", nil)
+		}
+
+		log.Info("Idempotency key reserved",
+			zap.String("api", apiName),
+			zap.Uint("user_id", userID),
+			zap.Uint("idempotency_key_id", record.ID),
+		)
+
+		// Release the key if the handler fails so the client can retry
+		if err := c.Next(); err != nil {
+			releaseIdempotencyKey(c, apiName, record)
+			return err
+		}
+
+		statusCode := c.Response().StatusCode()
+		if statusCode >= fiber.StatusInternalServerError {
+			releaseIdempotencyKey(c, apiName, record)
+			return nil
+		}
+
+		body := append([]byte(nil), c.Response().Body()...)
+		if err := db.DB.Model(record).Updates(map[string]interface{}{
+			"completed":     true,
+			"status_code":   statusCode,
+			"response_body": body,
+		}).Error; err != nil {
+			// The response has already been produced - log and release rather than fail the request
+			log.Error("Failed to store idempotent response",
+				zap.Error(err),
+				zap.String("api", apiName),
+				zap.Uint("idempotency_key_id", record.ID),
+			)
+			releaseIdempotencyKey(c, apiName, record)
+		}
+
+		return nil
+	}
+}
+
+// replayIdempotentRequest responds to a retry of a request made with the same key
+func replayIdempotentRequest(c *fiber.Ctx, apiName string, existing *models.IdempotencyKey, fingerprint string) error {
+	log := utils.GetLoggerFromContext(c)
+
+	if existing.Fingerprint != fingerprint {
+		log.Warn("Idempotency key reused with a different request",
+			zap.String("api", apiName),
+			zap.Uint("idempotency_key_id", existing.ID),
+		)
+		return handlers.ConflictResponse(c, apiName, "Idempotency-Key has already been used with a different request", nil)
+	}
+
+	if !existing.Completed {
+		log.Warn("Idempotency key request still in progress",
+			zap.String("api", apiName),
+			zap.Uint("idempotency_key_id", existing.ID),
+		)
+		return handlers.ConflictResponse(c, apiName, "A request with this Idempotency-Key is still being processed", nil)
+	}
+
+	log.Info("Replaying stored idempotent response",
+		zap.String("api", apiName),
+		zap.Uint("idempotency_key_id", existing.ID),
+		zap.Int("status_code", existing.StatusCode),
+	)
+
+	c.Set("Idempotent-Replayed", "true")
+	c.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+	return c.Status(existing.StatusCode).Send(existing.ResponseBody)
+}
+
+// releaseIdempotencyKey removes a reserved key so the request can be retried
+func releaseIdempotencyKey(c *fiber.Ctx, apiName string, record *models.IdempotencyKey) {
+	log := utils.GetLoggerFromContext(c)
+	if err := db.DB.Unscoped().Delete(record).Error; err != nil {
+		log.Error("Failed to release idempotency key",
+			zap.Error(err),
+			zap.String("api", apiName),
+			zap.Uint("idempotency_key_id", record.ID),
+		)
+	}
+}
+
+// requestFingerprint hashes the method, path and body of a request
+func requestFingerprint(c *fiber.Ctx) string {
+	hash := sha256.New()
+	hash.Write([]byte(c.Method()))
+	hash.Write([]byte(" "))
+	hash.Write([]byte(c.Path()))
+	hash.Write([]byte("\n"))
+	hash.Write(c.Body())
+	return hex.EncodeToString(hash.Sum(nil))
+}
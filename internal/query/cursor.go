@@ -0,0 +1,114 @@
+package query
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Cursor is the decoded position of the last row returned on the previous page
+type Cursor struct {
+	Values []interface{}
+}
+
+// cursorPayload is the JSON structure encoded inside the opaque cursor string
+type cursorPayload struct {
+	Sort   string   `json:"s"`
+	Values []string `json:"v"`
+}
+
+// EncodeCursor builds an opaque, URL-safe cursor from the sort fields and the
+// values of the last row on the current page
+func EncodeCursor(sorts []Sort, values []interface{}) (string, error) {
+	if len(sorts) != len(values) {
+		return "", errors.New("cursor values do not match sort fields")
+	}
+
+	payload := cursorPayload{
+		Sort:   sortSignature(sorts),
+		Values: make([]string, len(values)),
+	}
+	for i, v := range values {
+		if t, ok := v.(time.Time); ok {
+			payload.Values[i] = t.UTC().Format(time.RFC3339Nano)
+		} else {
+			payload.Values[i] = fmt.Sprint(v)
+		}
+	}
+
+	raw, err := json.Marshal(payload)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(raw), nil
+}
+
+// DecodeCursor parses an opaque cursor string. The cursor must have been issued
+// for the same sort order, otherwise an error is returned.
+func DecodeCursor(encoded string, sorts []Sort) (*Cursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, err
+	}
+
+	var payload cursorPayload
+	if err := json.Unmarshal(raw, &payload); err != nil {
+		return nil, err
+	}
+
+	if payload.Sort != sortSignature(sorts) || len(payload.Values) != len(sorts) {
+		return nil, errors.New("cursor does not match sort order")
+	}
+
+	cursor := &Cursor{Values: make([]interface{}, len(sorts))}
+	for i, s := range sorts {
+		v, err := parseValue(payload.Values[i], s.Type)
+		if err != nil {
+			return nil, err
+		}
+		cursor.Values[i] = v
+	}
+	return cursor, nil
+}
+
+// condition builds the keyset where clause selecting rows after the cursor.
+// For sorts (a DESC, b ASC) it produces: (a < ?) OR (a = ? AND b > ?)
+func (cur *Cursor) condition(sorts []Sort) (string, []interface{}) {
+	var clauses []string
+	var args []interface{}
+
+	for i, s := range sorts {
+		var parts []string
+		for j := 0; j < i; j++ {
+			parts = append(parts, sorts[j].Column+" = ?")
+			args = append(args, cur.Values[j])
+		}
+
+		op := ">"
+		if s.Desc {
+			op = "<"
+		}
+		parts = append(parts, s.Column+" "+op+" ?")
+		args = append(args, cur.Values[i])
+
+		clauses = append(clauses, "("+strings.Join(parts, " AND ")+")")
+	}
+
+	return "(" + strings.Join(clauses, " OR ") + ")", args
+}
+
+// sortSignature returns a compact representation of a sort order (e.g., "-climb_date,-id")
+func sortSignature(sorts []Sort) string {
+	terms := make([]string, len(sorts))
+	for i, s := range sorts {
+		if s.Desc {
+			terms[i] = "-" + s.Field
+		} else {
+			terms[i] = s.Field
+		}
+	}
+	return strings.Join(terms, ",")
+}
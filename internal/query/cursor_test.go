@@ -0,0 +1,148 @@
+package query
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestCursorRoundTrip(t *testing.T) {
+	climbDate := time.Date(2026, 3, 4, 18, 30, 15, 123456789, time.UTC)
+
+	tests := []struct {
+		name   string
+		sort   string
+		values []interface{}
+	}{
+		{name: "time and id", sort: "-climb_date", values: []interface{}{climbDate, uint(42)}},
+		{name: "string with separators", sort: "name", values: []interface{}{"Crux, \"The\" Gym", uint(7)}},
+		{name: "float", sort: "-rating,name", values: []interface{}{4.5, "Boulder Barn", uint(3)}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sorts, err := parseSorts(tt.sort, testSpec)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			encoded, err := EncodeCursor(sorts, tt.values)
+			if err != nil {
+				t.Fatalf("encode failed: %v", err)
+			}
+			cursor, err := DecodeCursor(encoded, sorts)
+			if err != nil {
+				t.Fatalf("decode failed: %v", err)
+			}
+			if !reflect.DeepEqual(cursor.Values, tt.values) {
+				t.Errorf("got values %#v, want %#v", cursor.Values, tt.values)
+			}
+		})
+	}
+}
+
+func TestDecodeCursorRejectsMismatch(t *testing.T) {
+	issued, _ := parseSorts("-climb_date", testSpec)
+	encoded, err := EncodeCursor(issued, []interface{}{time.Now(), uint(1)})
+	if err != nil {
+		t.Fatalf("encode failed: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		sort    string
+		encoded string
+	}{
+		{name: "different field", sort: "name", encoded: encoded},
+		{name: "different direction", sort: "climb_date", encoded: encoded},
+		{name: "extra field", sort: "-climb_date,name", encoded: encoded},
+		{name: "not base64", sort: "-climb_date", encoded: "not a cursor!"},
+		{name: "not json", sort: "-climb_date", encoded: "bm90IGpzb24"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sorts, err := parseSorts(tt.sort, testSpec)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if _, err := DecodeCursor(tt.encoded, sorts); err == nil {
+				t.Error("expected the cursor to be rejected")
+			}
+		})
+	}
+}
+
+func TestDecodeCursorRejectsInvalidValue(t *testing.T) {
+	sorts, _ := parseSorts("rating", testSpec)
+	encoded, err := EncodeCursor(sorts, []interface{}{"high", uint(1)})
+	if err != nil {
+		t.Fatalf("encode failed: %v", err)
+	}
+	if _, err := DecodeCursor(encoded, sorts); err == nil {
+		t.Error("expected a non-numeric rating to be rejected")
+	}
+}
+
+func TestParseRejectsCursorFromAnotherSort(t *testing.T) {
+	issued, _ := parseSorts("name", testSpec)
+	encoded, err := EncodeCursor(issued, []interface{}{"Boulder Barn", uint(3)})
+	if err != nil {
+		t.Fatalf("encode failed: %v", err)
+	}
+
+	if _, err := parseQuery(t, testSpec, "sort=name&cursor="+encoded); err != nil {
+		t.Errorf("expected the cursor to match its own sort: %v", err)
+	}
+	if _, err := parseQuery(t, testSpec, "sort=-name&cursor="+encoded); err == nil {
+		t.Error("expected the cursor to be rejected for a different sort")
+	}
+}
+
+func TestCursorCondition(t *testing.T) {
+	tests := []struct {
+		name     string
+		sort     string
+		values   []interface{}
+		wantSQL  string
+		wantArgs []interface{}
+	}{
+		{
+			name:     "single descending field",
+			sort:     "-id",
+			values:   []interface{}{uint(9)},
+			wantSQL:  "((id < ?))",
+			wantArgs: []interface{}{uint(9)},
+		},
+		{
+			name:     "descending field with ascending tiebreaker",
+			sort:     "-rating,id",
+			values:   []interface{}{4.5, uint(9)},
+			wantSQL:  "((rating < ?) OR (rating = ? AND id > ?))",
+			wantArgs: []interface{}{4.5, 4.5, uint(9)},
+		},
+		{
+			name:     "mixed directions",
+			sort:     "name,-rating",
+			values:   []interface{}{"Barn", 4.5, uint(9)},
+			wantSQL:  "((name > ?) OR (name = ? AND rating < ?) OR (name = ? AND rating = ? AND id < ?))",
+			wantArgs: []interface{}{"Barn", "Barn", 4.5, "Barn", 4.5, uint(9)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sorts, err := parseSorts(tt.sort, testSpec)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			sql, args := (&Cursor{Values: tt.values}).condition(sorts)
+			if sql != tt.wantSQL {
+				t.Errorf("got sql %q, want %q", sql, tt.wantSQL)
+			}
+			if !reflect.DeepEqual(args, tt.wantArgs) {
+				t.Errorf("got args %#v, want %#v", args, tt.wantArgs)
+			}
+		})
+	}
+}
@@ -0,0 +1,156 @@
+package query
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/jwallace145/crux-backend/models"
+)
+
+// FilterKind determines how a filter query parameter is interpreted
+type FilterKind int
+
+const (
+	// FilterEquals matches rows where the column equals the parameter value
+	FilterEquals FilterKind = iota
+
+	// FilterIn matches rows where the column is any of a comma separated list of values
+	FilterIn
+
+	// FilterGradeRange reads <param>_min and <param>_max and matches every grade in between
+	FilterGradeRange
+
+	// FilterAllOf reads a comma separated list of options and requires every
+	// selected option's boolean column to be true (AND semantics)
+	FilterAllOf
+)
+
+// Filter is a whitelisted filter for a list endpoint
+type Filter struct {
+	Param  string
+	Column string
+	Type   FieldType
+	Kind   FilterKind
+
+	// Options maps accepted values to boolean columns for FilterAllOf
+	Options map[string]string
+}
+
+// parse reads the filter's query parameters and returns the resulting condition,
+// or nil if the filter was not provided
+func (f Filter) parse(c *fiber.Ctx) (*condition, interface{}, error) {
+	switch f.Kind {
+	case FilterGradeRange:
+		return f.parseGradeRange(c)
+	case FilterAllOf:
+		return f.parseAllOf(c)
+	case FilterIn:
+		return f.parseIn(c)
+	default:
+		return f.parseEquals(c)
+	}
+}
+
+// parseEquals parses a single value equality filter
+func (f Filter) parseEquals(c *fiber.Ctx) (*condition, interface{}, error) {
+	raw := c.Query(f.Param)
+	if raw == "" {
+		return nil, nil, nil
+	}
+
+	value, err := parseValue(raw, f.Type)
+	if err != nil {
+		return nil, nil, fiber.NewError(fiber.StatusBadRequest, f.Param+" has an invalid value")
+	}
+
+	return &condition{sql: f.Column + " = ?", args: []interface{}{value}}, value, nil
+}
+
+// parseIn parses a comma separated list of values
+func (f Filter) parseIn(c *fiber.Ctx) (*condition, interface{}, error) {
+	raw := c.Query(f.Param)
+	if raw == "" {
+		return nil, nil, nil
+	}
+
+	var values []interface{}
+	for _, part := range strings.Split(raw, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		value, err := parseValue(part, f.Type)
+		if err != nil {
+			return nil, nil, fiber.NewError(fiber.StatusBadRequest, f.Param+" has an invalid value")
+		}
+		values = append(values, value)
+	}
+	if len(values) == 0 {
+		return nil, nil, nil
+	}
+
+	return &condition{sql: f.Column + " IN ?", args: []interface{}{values}}, values, nil
+}
+
+// parseGradeRange expands <param>_min and <param>_max into the list of grades between them
+func (f Filter) parseGradeRange(c *fiber.Ctx) (*condition, interface{}, error) {
+	minGrade := c.Query(f.Param + "_min")
+	maxGrade := c.Query(f.Param + "_max")
+	if minGrade == "" && maxGrade == "" {
+		return nil, nil, nil
+	}
+
+	grades, err := models.GradesBetween(minGrade, maxGrade)
+	if err != nil {
+		return nil, nil, fiber.NewError(fiber.StatusBadRequest, f.Param+" range is invalid: "+err.Error())
+	}
+
+	// Grades are free-form text in the db, so compare case-insensitively
+	upper := make([]string, len(grades))
+	for i, g := range grades {
+		upper[i] = strings.ToUpper(g)
+	}
+
+	applied := map[string]string{"min": minGrade, "max": maxGrade}
+	return &condition{sql: "UPPER(" + f.Column + ") IN ?", args: []interface{}{upper}}, applied, nil
+}
+
+// parseAllOf requires every selected option's boolean column to be true
+func (f Filter) parseAllOf(c *fiber.Ctx) (*condition, interface{}, error) {
+	raw := c.Query(f.Param)
+	if raw == "" {
+		return nil, nil, nil
+	}
+
+	var clauses []string
+	var selected []string
+	for _, part := range strings.Split(raw, ",") {
+		option := strings.ToLower(strings.TrimSpace(part))
+		if option == "" {
+			continue
+		}
+		column, ok := f.Options[option]
+		if !ok {
+			return nil, nil, fiber.NewError(fiber.StatusBadRequest, f.Param+" value '"+option+"' is not supported (supported: "+strings.Join(f.optionNames(), ", ")+")")
+		}
+		clauses = append(clauses, column+" = true")
+		selected = append(selected, option)
+	}
+	if len(clauses) == 0 {
+		return nil, nil, nil
+	}
+
+	return &condition{sql: strings.Join(clauses, " AND ")}, selected, nil
+}
+
+// optionNames returns the sorted list of accepted FilterAllOf options
+func (f Filter) optionNames() []string {
+	names := make([]string, 0, len(f.Options))
+	for name := range f.Options {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
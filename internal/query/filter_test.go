@@ -0,0 +1,60 @@
+package query
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestGradeRangeFilter(t *testing.T) {
+	tests := []struct {
+		name     string
+		query    string
+		want     []string
+		wantNone bool
+		wantErr  bool
+	}{
+		{name: "not provided", query: "", wantNone: true},
+		{name: "v scale range", query: "grade_min=v3&grade_max=V5", want: []string{"V3", "V4", "V5"}},
+		{name: "open maximum", query: "grade_min=V15", want: []string{"V15", "V16", "V17"}},
+		{name: "open minimum", query: "grade_max=V0", want: []string{"VB", "V0"}},
+		{name: "yds range", query: "grade_min=5.11c&grade_max=5.12a", want: []string{"5.11C", "5.11D", "5.12A"}},
+		{name: "different scales", query: "grade_min=V3&grade_max=5.10a", wantErr: true},
+		{name: "min harder than max", query: "grade_min=V6&grade_max=V2", wantErr: true},
+		{name: "unknown grade", query: "grade_min=V20", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			params, err := parseQuery(t, testSpec, tt.query)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected the range to be rejected")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if tt.wantNone {
+				if len(params.conditions) != 0 {
+					t.Errorf("got conditions %+v, want none", params.conditions)
+				}
+				return
+			}
+			if len(params.conditions) != 1 {
+				t.Fatalf("got %d conditions, want 1", len(params.conditions))
+			}
+			cond := params.conditions[0]
+			if cond.sql != "UPPER(grade) IN ?" {
+				t.Errorf("got sql %q", cond.sql)
+			}
+			if !reflect.DeepEqual(cond.args, []interface{}{tt.want}) {
+				t.Errorf("got grades %v, want %v", cond.args, tt.want)
+			}
+			if _, ok := params.Applied["grade"]; !ok {
+				t.Error("expected the grade filter to be recorded as applied")
+			}
+		})
+	}
+}
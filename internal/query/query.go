@@ -0,0 +1,315 @@
+package query
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"gorm.io/gorm"
+)
+
+// FieldType describes how a query parameter or cursor value is parsed
+type FieldType int
+
+const (
+	TypeString FieldType = iota
+	TypeInt
+	TypeUint
+	TypeFloat
+	TypeBool
+	TypeTime
+)
+
+// Default pagination limits used when a Spec does not set its own
+const (
+	DefaultLimit = 50
+	MaxLimit     = 200
+)
+
+// SortField is a whitelisted field that list endpoints can be sorted by
+type SortField struct {
+	Column string
+	Type   FieldType
+}
+
+// DateRange describes an optional start_date/end_date window on a time column.
+// Missing bounds default to the Unix epoch and the current time respectively.
+type DateRange struct {
+	Column     string
+	StartParam string
+	EndParam   string
+}
+
+// Spec declares the pagination, filtering and sorting rules for a list endpoint.
+// Only the sort fields and filters listed here are accepted from query parameters.
+type Spec struct {
+	DefaultLimit int
+	MaxLimit     int
+
+	// SortFields maps the public sort field name to its column
+	SortFields map[string]SortField
+
+	// DefaultSort is used when no sort parameter is provided (e.g., "-climb_date,name")
+	DefaultSort string
+
+	Filters   []Filter
+	DateRange *DateRange
+}
+
+// Sort is a single parsed sort term
+type Sort struct {
+	Field  string
+	Column string
+	Type   FieldType
+	Desc   bool
+}
+
+// Params holds the parsed pagination, filtering and sorting options for a request
+type Params struct {
+	Limit  int
+	Sorts  []Sort
+	Cursor *Cursor
+
+	// StartDate and EndDate are populated when the Spec has a DateRange
+	StartDate time.Time
+	EndDate   time.Time
+
+	// Applied records the filter values that were provided, keyed by parameter name
+	Applied map[string]interface{}
+
+	conditions []condition
+	dateRange  *DateRange
+}
+
+// condition is a single SQL where clause with its arguments
+type condition struct {
+	sql  string
+	args []interface{}
+}
+
+// Parse reads limit, cursor, sort, filter and date range query parameters according to spec.
+// It returns a fiber error with status 400 describing the first invalid parameter.
+func Parse(c *fiber.Ctx, spec *Spec) (*Params, error) {
+	params := &Params{
+		Applied:   map[string]interface{}{},
+		dateRange: spec.DateRange,
+	}
+
+	limit, err := parseLimit(c.Query("limit"), spec)
+	if err != nil {
+		return nil, err
+	}
+	params.Limit = limit
+
+	sortStr := c.Query("sort")
+	if sortStr == "" {
+		sortStr = spec.DefaultSort
+	}
+	sorts, err := parseSorts(sortStr, spec)
+	if err != nil {
+		return nil, err
+	}
+	params.Sorts = sorts
+
+	if cursorStr := c.Query("cursor"); cursorStr != "" {
+		cursor, err := DecodeCursor(cursorStr, params.Sorts)
+		if err != nil {
+			return nil, fiber.NewError(fiber.StatusBadRequest, "cursor is invalid or does not match the requested sort")
+		}
+		params.Cursor = cursor
+	}
+
+	for _, filter := range spec.Filters {
+		cond, applied, err := filter.parse(c)
+		if err != nil {
+			return nil, err
+		}
+		if cond != nil {
+			params.conditions = append(params.conditions, *cond)
+			params.Applied[filter.Param] = applied
+		}
+	}
+
+	if spec.DateRange != nil {
+		if err := params.parseDateRange(c, spec.DateRange); err != nil {
+			return nil, err
+		}
+	}
+
+	return params, nil
+}
+
+// Apply adds the filters, cursor condition, ordering and limit to a query.
+// One extra row is requested so Paginate can tell whether another page exists.
+func (p *Params) Apply(db *gorm.DB) *gorm.DB {
+	query := p.ApplyFilters(db)
+
+	if p.Cursor != nil {
+		sql, args := p.Cursor.condition(p.Sorts)
+		query = query.Where(sql, args...)
+	}
+
+	for _, s := range p.Sorts {
+		direction := "ASC"
+		if s.Desc {
+			direction = "DESC"
+		}
+		query = query.Order(s.Column + " " + direction)
+	}
+
+	return query.Limit(p.Limit + 1)
+}
+
+// ApplyFilters adds only the filter and date range conditions to a query
+func (p *Params) ApplyFilters(db *gorm.DB) *gorm.DB {
+	query := db
+	for _, cond := range p.conditions {
+		query = query.Where(cond.sql, cond.args...)
+	}
+	if p.dateRange != nil {
+		query = query.Where(p.dateRange.Column+" >= ? AND "+p.dateRange.Column+" <= ?", p.StartDate, p.EndDate)
+	}
+	return query
+}
+
+// Key holds the sort field values of a row, keyed by sort field name plus "id"
+type Key map[string]interface{}
+
+// Paginate trims rows fetched with Apply down to the requested limit and returns the
+// opaque cursor for the next page, or an empty string if this is the last page
+func Paginate[T any](p *Params, rows []T, key func(T) Key) ([]T, string, error) {
+	if len(rows) <= p.Limit {
+		return rows, "", nil
+	}
+
+	rows = rows[:p.Limit]
+	last := key(rows[len(rows)-1])
+
+	values := make([]interface{}, len(p.Sorts))
+	for i, s := range p.Sorts {
+		values[i] = last[s.Field]
+	}
+
+	next, err := EncodeCursor(p.Sorts, values)
+	if err != nil {
+		return nil, "", err
+	}
+	return rows, next, nil
+}
+
+// parseLimit parses the limit query parameter, applying the spec defaults and maximum
+func parseLimit(limitStr string, spec *Spec) (int, error) {
+	defaultLimit := spec.DefaultLimit
+	if defaultLimit == 0 {
+		defaultLimit = DefaultLimit
+	}
+	maxLimit := spec.MaxLimit
+	if maxLimit == 0 {
+		maxLimit = MaxLimit
+	}
+
+	if limitStr == "" {
+		return defaultLimit, nil
+	}
+
+	limit, err := strconv.Atoi(limitStr)
+	if err != nil || limit < 1 {
+		return 0, fiber.NewError(fiber.StatusBadRequest, "limit must be a positive number")
+	}
+	if limit > maxLimit {
+		return 0, fiber.NewError(fiber.StatusBadRequest, "limit must not exceed "+strconv.Itoa(maxLimit))
+	}
+	return limit, nil
+}
+
+// parseSorts parses a comma separated sort string (e.g., "-climb_date,rating").
+// A leading "-" sorts descending. An "id" tiebreaker is always appended so that
+// keyset pagination is stable across rows with equal sort values. The tiebreaker
+// uses the spec's "id" sort field when defined, otherwise the uint "id" column.
+func parseSorts(sortStr string, spec *Spec) ([]Sort, error) {
+	var sorts []Sort
+	seen := map[string]bool{}
+
+	for _, term := range strings.Split(sortStr, ",") {
+		term = strings.TrimSpace(term)
+		if term == "" {
+			continue
+		}
+
+		desc := strings.HasPrefix(term, "-")
+		name := strings.TrimPrefix(term, "-")
+
+		field, ok := spec.SortFields[name]
+		if !ok {
+			return nil, fiber.NewError(fiber.StatusBadRequest, "sort field '"+name+"' is not supported")
+		}
+		if seen[name] {
+			return nil, fiber.NewError(fiber.StatusBadRequest, "sort field '"+name+"' is specified more than once")
+		}
+		seen[name] = true
+
+		sorts = append(sorts, Sort{Field: name, Column: field.Column, Type: field.Type, Desc: desc})
+	}
+
+	if !seen["id"] {
+		desc := false
+		if len(sorts) > 0 {
+			desc = sorts[len(sorts)-1].Desc
+		}
+		idField, ok := spec.SortFields["id"]
+		if !ok {
+			idField = SortField{Column: "id", Type: TypeUint}
+		}
+		sorts = append(sorts, Sort{Field: "id", Column: idField.Column, Type: idField.Type, Desc: desc})
+	}
+
+	return sorts, nil
+}
+
+// parseDateRange parses the start and end date parameters in RFC3339 format
+func (p *Params) parseDateRange(c *fiber.Ctx, dr *DateRange) error {
+	p.StartDate = time.Unix(0, 0).UTC()
+	p.EndDate = time.Now().UTC()
+
+	if startStr := c.Query(dr.StartParam); startStr != "" {
+		start, err := time.Parse(time.RFC3339, startStr)
+		if err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, dr.StartParam+" must be in RFC3339 format (e.g., 2024-01-01T00:00:00Z)")
+		}
+		p.StartDate = start.UTC()
+	}
+
+	if endStr := c.Query(dr.EndParam); endStr != "" {
+		end, err := time.Parse(time.RFC3339, endStr)
+		if err != nil {
+			return fiber.NewError(fiber.StatusBadRequest, dr.EndParam+" must be in RFC3339 format (e.g., 2024-12-31T23:59:59Z)")
+		}
+		p.EndDate = end.UTC()
+	}
+
+	if p.StartDate.After(p.EndDate) {
+		return fiber.NewError(fiber.StatusBadRequest, dr.StartParam+" must be before or equal to "+dr.EndParam)
+	}
+
+	return nil
+}
+
+// parseValue converts a raw query parameter or cursor string into the given type
+func parseValue(raw string, fieldType FieldType) (interface{}, error) {
+	switch fieldType {
+	case TypeInt:
+		return strconv.ParseInt(raw, 10, 64)
+	case TypeUint:
+		v, err := strconv.ParseUint(raw, 10, 32)
+		return uint(v), err
+	case TypeFloat:
+		return strconv.ParseFloat(raw, 64)
+	case TypeBool:
+		return strconv.ParseBool(raw)
+	case TypeTime:
+		return time.Parse(time.RFC3339Nano, raw)
+	default:
+		return raw, nil
+	}
+}
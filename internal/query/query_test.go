@@ -0,0 +1,122 @@
+package query
+
+import (
+	"errors"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// testSpec sorts by a time, a string and a float column, and by id
+var testSpec = &Spec{
+	SortFields: map[string]SortField{
+		"id":         {Column: "id", Type: TypeUint},
+		"climb_date": {Column: "climb_date", Type: TypeTime},
+		"name":       {Column: "name", Type: TypeString},
+		"rating":     {Column: "rating", Type: TypeFloat},
+	},
+	DefaultSort: "-climb_date",
+	Filters: []Filter{
+		{Param: "grade", Column: "grade", Kind: FilterGradeRange},
+	},
+}
+
+// parseQuery runs Parse against a request with the given raw query string
+func parseQuery(t *testing.T, spec *Spec, rawQuery string) (*Params, error) {
+	t.Helper()
+
+	var params *Params
+	var parseErr error
+	app := fiber.New()
+	app.Get("/", func(c *fiber.Ctx) error {
+		params, parseErr = Parse(c, spec)
+		return nil
+	})
+	if _, err := app.Test(httptest.NewRequest("GET", "/?"+rawQuery, nil)); err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	return params, parseErr
+}
+
+func TestParseSorts(t *testing.T) {
+	tests := []struct {
+		name    string
+		sort    string
+		want    string
+		wantErr bool
+	}{
+		{name: "default sort", sort: "", want: "-climb_date,-id"},
+		{name: "ascending", sort: "name", want: "name,id"},
+		{name: "mixed directions", sort: "-rating,name", want: "-rating,name,id"},
+		{name: "explicit id", sort: "-id", want: "-id"},
+		{name: "whitespace and empty terms", sort: " rating ,,-name", want: "rating,-name,-id"},
+		{name: "unknown field", sort: "password", wantErr: true},
+		{name: "unknown descending field", sort: "-user_id", wantErr: true},
+		{name: "duplicate field", sort: "name,-name", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sortStr := tt.sort
+			if sortStr == "" {
+				sortStr = testSpec.DefaultSort
+			}
+			sorts, err := parseSorts(sortStr, testSpec)
+			if tt.wantErr {
+				var fiberErr *fiber.Error
+				if !errors.As(err, &fiberErr) || fiberErr.Code != fiber.StatusBadRequest {
+					t.Fatalf("got error %v, want a 400", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := sortSignature(sorts); got != tt.want {
+				t.Errorf("got sort %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseLimit(t *testing.T) {
+	tests := []struct {
+		name    string
+		limit   string
+		want    int
+		wantErr bool
+	}{
+		{name: "default", limit: "", want: DefaultLimit},
+		{name: "valid", limit: "10", want: 10},
+		{name: "maximum", limit: "200", want: MaxLimit},
+		{name: "above maximum", limit: "201", wantErr: true},
+		{name: "zero", limit: "0", wantErr: true},
+		{name: "not a number", limit: "ten", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseLimit(tt.limit, testSpec)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("got error %v, want error %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && got != tt.want {
+				t.Errorf("got limit %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseRejectsUnknownSortParameter(t *testing.T) {
+	if _, err := parseQuery(t, testSpec, "sort=-password"); err == nil {
+		t.Fatal("expected an unknown sort field to be rejected")
+	}
+	params, err := parseQuery(t, testSpec, "sort=-rating&limit=5")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if params.Limit != 5 || sortSignature(params.Sorts) != "-rating,-id" {
+		t.Errorf("got limit %d and sort %q", params.Limit, sortSignature(params.Sorts))
+	}
+}
@@ -0,0 +1,14 @@
+package routes
+
+import (
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/jwallace145/crux-backend/internal/handlers/activity"
+)
+
+func SetupActivityRoutes(app *fiber.App, authMiddleware fiber.Handler) {
+	activityRoutes := app.Group("/activity")
+
+	// Protected routes (authentication required)
+	activityRoutes.Get("/", authMiddleware, activity.GetActivity)
+}
@@ -6,10 +6,12 @@ import (
 	"github.com/jwallace145/crux-backend/internal/handlers/climbs"
 )
 
-func SetupClimbRoutes(app *fiber.App, authMiddleware fiber.Handler) {
+func SetupClimbRoutes(app *fiber.App, authMiddleware fiber.Handler, idempotencyMiddleware fiber.Handler) {
 	climbRoutes := app.Group("/climbs")
 
 	// Protected routes (authentication required)
 	climbRoutes.Get("/", authMiddleware, climbs.GetClimbs)
-	climbRoutes.Post("/", authMiddleware, climbs.CreateClimb)
+	climbRoutes.Post("/", authMiddleware, idempotencyMiddleware, climbs.CreateClimb)
+	climbRoutes.Post("/batch", authMiddleware, idempotencyMiddleware, climbs.BatchCreateClimbs)
+	climbRoutes.Delete("/:id", authMiddleware, climbs.DeleteClimb)
 }
@@ -0,0 +1,14 @@
+package routes
+
+import (
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/jwallace145/crux-backend/internal/handlers/exercises"
+)
+
+func SetupExerciseRoutes(app *fiber.App, authMiddleware fiber.Handler) {
+	exerciseRoutes := app.Group("/exercises")
+
+	// Protected routes (authentication required)
+	exerciseRoutes.Get("/progression", authMiddleware, exercises.GetExerciseProgression)
+}
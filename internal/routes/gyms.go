@@ -6,10 +6,64 @@ import (
 	"github.com/jwallace145/crux-backend/internal/handlers/gyms"
 )
 
-func SetupGymRoutes(app *fiber.App, authMiddleware fiber.Handler) {
+func SetupGymRoutes(app *fiber.App, authMiddleware, adminMiddleware, uploadLimitMiddleware fiber.Handler) {
 	gymRoutes := app.Group("/gyms")
 
 	// Protected routes (authentication required)
 	gymRoutes.Get("/", authMiddleware, gyms.GetGyms)
 	gymRoutes.Post("/", authMiddleware, gyms.CreateGym)
+
+	// Text search (registered before /:id so "search" is not parsed as a gym ID)
+	gymRoutes.Get("/search", authMiddleware, gyms.SearchGyms)
+
+	// Bulk import (registered before /:id so "import" is not parsed as a gym ID). Import files
+	// skip the app's default body limit and apply the larger upload limit instead
+	gymRoutes.Post("/import", authMiddleware, adminMiddleware, uploadLimitMiddleware, gyms.ImportGyms)
+
+	// Amenity catalog (registered before /:id so "amenities" is not parsed as a gym ID)
+	gymRoutes.Get("/amenities", authMiddleware, gyms.GetAmenities)
+	gymRoutes.Post("/amenities", authMiddleware, adminMiddleware, gyms.CreateAmenity)
+
+	// Ownership claims (registered before /:id so "claims" is not parsed as a gym ID)
+	gymRoutes.Get("/claims", authMiddleware, gyms.GetGymClaims)
+	gymRoutes.Post("/claims/:id/approve", authMiddleware, adminMiddleware, gyms.ApproveGymClaim)
+	gymRoutes.Post("/claims/:id/reject", authMiddleware, adminMiddleware, gyms.RejectGymClaim)
+
+	// Review moderation (registered before /:id so "reviews" is not parsed as a gym ID)
+	gymRoutes.Get("/reviews/reports", authMiddleware, adminMiddleware, gyms.GetGymReviewReports)
+	gymRoutes.Post("/reviews/:id/hide", authMiddleware, adminMiddleware, gyms.HideGymReview)
+	gymRoutes.Post("/reviews/:id/restore", authMiddleware, adminMiddleware, gyms.RestoreGymReview)
+
+	// Owner or admin only (checked by the handlers)
+	gymRoutes.Patch("/:id", authMiddleware, gyms.UpdateGym)
+	gymRoutes.Delete("/:id", authMiddleware, gyms.DeleteGym)
+	gymRoutes.Post("/:id/claims", authMiddleware, gyms.ClaimGym)
+
+	// Admin only: merge a duplicate gym into this one
+	gymRoutes.Post("/:id/merge", authMiddleware, adminMiddleware, gyms.MergeGym)
+
+	// Route-setting catalog, readable by any user and managed by the gym's staff (checked by the handlers).
+	// Climb photos skip the app's default body limit and apply the upload limit, and the handler
+	// enforces the smaller photo size
+	gymRoutes.Get("/:id/walls", authMiddleware, gyms.GetGymWalls)
+	gymRoutes.Post("/:id/walls", authMiddleware, gyms.CreateGymWall)
+	gymRoutes.Patch("/:id/walls/:wall_id", authMiddleware, gyms.UpdateGymWall)
+	gymRoutes.Delete("/:id/walls/:wall_id", authMiddleware, gyms.DeleteGymWall)
+	gymRoutes.Get("/:id/climbs", authMiddleware, gyms.GetGymClimbs)
+	gymRoutes.Post("/:id/climbs", authMiddleware, gyms.CreateGymClimb)
+	gymRoutes.Get("/:id/climbs/:climb_id", authMiddleware, gyms.GetGymClimb)
+	gymRoutes.Patch("/:id/climbs/:climb_id", authMiddleware, gyms.UpdateGymClimb)
+	gymRoutes.Delete("/:id/climbs/:climb_id", authMiddleware, gyms.DeleteGymClimb)
+	gymRoutes.Put("/:id/climbs/:climb_id/photo", authMiddleware, uploadLimitMiddleware, gyms.UploadGymClimbPhoto)
+
+	// Reviews, one per user per gym. Only the author can delete their review (checked by the handler)
+	gymRoutes.Get("/:id/reviews", authMiddleware, gyms.GetGymReviews)
+	gymRoutes.Post("/:id/reviews", authMiddleware, gyms.CreateGymReview)
+	gymRoutes.Delete("/:id/reviews/:review_id", authMiddleware, gyms.DeleteGymReview)
+	gymRoutes.Post("/:id/reviews/:review_id/reports", authMiddleware, gyms.ReportGymReview)
+
+	// Check-ins and anonymous busyness estimates
+	gymRoutes.Post("/:id/check-in", authMiddleware, gyms.CheckInGym)
+	gymRoutes.Post("/:id/check-out", authMiddleware, gyms.CheckOutGym)
+	gymRoutes.Get("/:id/busyness", authMiddleware, gyms.GetGymBusyness)
 }
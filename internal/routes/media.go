@@ -0,0 +1,17 @@
+package routes
+
+import (
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/jwallace145/crux-backend/internal/handlers/media"
+)
+
+func SetupMediaRoutes(app *fiber.App, authMiddleware, uploadLimitMiddleware fiber.Handler) {
+	mediaRoutes := app.Group("/media")
+
+	// Protected routes (authentication required)
+	mediaRoutes.Get("/", authMiddleware, media.GetMedia)
+	// Uploads skip the app's default body limit and apply the larger upload limit instead
+	mediaRoutes.Post("/", authMiddleware, uploadLimitMiddleware, media.UploadMedia)
+	mediaRoutes.Delete("/:id", authMiddleware, media.DeleteMedia)
+}
@@ -0,0 +1,17 @@
+package routes
+
+import (
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/jwallace145/crux-backend/internal/handlers/planned_sessions"
+)
+
+func SetupPlannedSessionRoutes(app *fiber.App, authMiddleware fiber.Handler) {
+	plannedSessionRoutes := app.Group("/planned-sessions")
+
+	// Protected routes (authentication required)
+	plannedSessionRoutes.Get("/", authMiddleware, planned_sessions.GetPlannedSessions)
+	plannedSessionRoutes.Post("/", authMiddleware, planned_sessions.CreatePlannedSession)
+	plannedSessionRoutes.Get("/:id", authMiddleware, planned_sessions.GetPlannedSession)
+	plannedSessionRoutes.Delete("/:id", authMiddleware, planned_sessions.DeletePlannedSession)
+}
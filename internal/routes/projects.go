@@ -0,0 +1,17 @@
+package routes
+
+import (
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/jwallace145/crux-backend/internal/handlers/projects"
+)
+
+func SetupProjectRoutes(app *fiber.App, authMiddleware fiber.Handler) {
+	projectRoutes := app.Group("/projects")
+
+	// Protected routes (authentication required)
+	projectRoutes.Get("/", authMiddleware, projects.GetProjects)
+	projectRoutes.Post("/", authMiddleware, projects.CreateProject)
+	projectRoutes.Patch("/:id", authMiddleware, projects.UpdateProject)
+	projectRoutes.Get("/:id/timeline", authMiddleware, projects.GetProjectTimeline)
+}
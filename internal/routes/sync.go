@@ -0,0 +1,15 @@
+package routes
+
+import (
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/jwallace145/crux-backend/internal/handlers/offline_sync"
+)
+
+func SetupSyncRoutes(app *fiber.App, authMiddleware fiber.Handler, idempotencyMiddleware fiber.Handler) {
+	syncRoutes := app.Group("/sync")
+
+	// Protected routes (authentication required)
+	syncRoutes.Get("/", authMiddleware, offline_sync.PullChanges)
+	syncRoutes.Post("/", authMiddleware, idempotencyMiddleware, offline_sync.PushChanges)
+}
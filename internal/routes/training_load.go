@@ -0,0 +1,13 @@
+package routes
+
+import (
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/jwallace145/crux-backend/internal/handlers/training_load"
+	"github.com/jwallace145/crux-backend/models"
+)
+
+func SetupTrainingLoadRoutes(app *fiber.App, authMiddleware fiber.Handler, thresholds models.TrainingLoadThresholds) {
+	// Protected routes (authentication required)
+	app.Get("/training-load", authMiddleware, training_load.GetTrainingLoad(thresholds))
+}
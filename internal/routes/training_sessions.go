@@ -6,10 +6,20 @@ import (
 	"github.com/jwallace145/crux-backend/internal/handlers/training_sessions"
 )
 
-func SetupTrainingSessionRoutes(app *fiber.App, authMiddleware fiber.Handler) {
+func SetupTrainingSessionRoutes(app *fiber.App, authMiddleware fiber.Handler, idempotencyMiddleware fiber.Handler) {
 	trainingSessionRoutes := app.Group("/training-sessions")
 
 	// Protected routes (authentication required)
 	trainingSessionRoutes.Get("/", authMiddleware, training_sessions.GetTrainingSessions)
-	trainingSessionRoutes.Post("/", authMiddleware, training_sessions.CreateTrainingSession)
+	trainingSessionRoutes.Post("/", authMiddleware, idempotencyMiddleware, training_sessions.CreateTrainingSession)
+	trainingSessionRoutes.Post("/start", authMiddleware, idempotencyMiddleware, training_sessions.StartTrainingSession)
+	trainingSessionRoutes.Get("/invitations", authMiddleware, training_sessions.GetPartnerInvitations)
+	trainingSessionRoutes.Get("/attempts-to-send", authMiddleware, training_sessions.GetAttemptsToSend)
+	trainingSessionRoutes.Get("/:id", authMiddleware, training_sessions.GetTrainingSession)
+	trainingSessionRoutes.Patch("/:id", authMiddleware, training_sessions.UpdateTrainingSession)
+	trainingSessionRoutes.Delete("/:id", authMiddleware, training_sessions.DeleteTrainingSession)
+	trainingSessionRoutes.Post("/:id/climbs", authMiddleware, idempotencyMiddleware, training_sessions.AppendSessionClimb)
+	trainingSessionRoutes.Post("/:id/finish", authMiddleware, training_sessions.FinishTrainingSession)
+	trainingSessionRoutes.Post("/:id/invitation/accept", authMiddleware, training_sessions.AcceptPartnerInvitation)
+	trainingSessionRoutes.Post("/:id/invitation/decline", authMiddleware, training_sessions.DeclinePartnerInvitation)
 }
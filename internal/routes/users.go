@@ -15,4 +15,7 @@ func SetupUserRoutes(app *fiber.App, authMiddleware fiber.Handler) {
 	// Protected routes (authentication required)
 	userRoutes.Get("/", authMiddleware, users.GetUser)
 	userRoutes.Put("/", authMiddleware, users.UpdateUser)
+	userRoutes.Get("/blocks", authMiddleware, users.GetBlockedUsers)
+	userRoutes.Post("/blocks", authMiddleware, users.BlockUser)
+	userRoutes.Delete("/blocks/:id", authMiddleware, users.UnblockUser)
 }
@@ -0,0 +1,17 @@
+package routes
+
+import (
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/jwallace145/crux-backend/internal/handlers/workout_templates"
+)
+
+func SetupWorkoutTemplateRoutes(app *fiber.App, authMiddleware fiber.Handler) {
+	workoutTemplateRoutes := app.Group("/workout-templates")
+
+	// Protected routes (authentication required)
+	workoutTemplateRoutes.Get("/", authMiddleware, workout_templates.GetWorkoutTemplates)
+	workoutTemplateRoutes.Post("/", authMiddleware, workout_templates.CreateWorkoutTemplate)
+	workoutTemplateRoutes.Get("/:id", authMiddleware, workout_templates.GetWorkoutTemplate)
+	workoutTemplateRoutes.Delete("/:id", authMiddleware, workout_templates.DeleteWorkoutTemplate)
+}
@@ -0,0 +1,53 @@
+package services
+
+import (
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/jwallace145/crux-backend/models"
+)
+
+// FindAmenities looks up the catalog entries for the given slugs. The slugs with no catalog entry
+// are returned separately so they can be reported back to the caller
+func FindAmenities(tx *gorm.DB, slugs []string) ([]models.Amenity, []string, error) {
+	amenities := []models.Amenity{}
+	unknown := []string{}
+	if len(slugs) == 0 {
+		return amenities, unknown, nil
+	}
+
+	if err := tx.Where("slug IN ?", slugs).Find(&amenities).Error; err != nil {
+		return nil, nil, err
+	}
+
+	found := map[string]bool{}
+	for _, amenity := range amenities {
+		found[amenity.Slug] = true
+	}
+	for _, slug := range slugs {
+		if !found[slug] {
+			unknown = append(unknown, slug)
+		}
+	}
+	return amenities, unknown, nil
+}
+
+// SetGymAmenities links the amenities to the gym in place of its current ones
+func SetGymAmenities(tx *gorm.DB, gymID uint, amenities []models.Amenity) error {
+	if err := tx.Where("gym_id = ?", gymID).Delete(&models.GymAmenity{}).Error; err != nil {
+		return err
+	}
+	return AddGymAmenities(tx, gymID, amenities)
+}
+
+// AddGymAmenities links the amenities to the gym, skipping the ones it already has
+func AddGymAmenities(tx *gorm.DB, gymID uint, amenities []models.Amenity) error {
+	if len(amenities) == 0 {
+		return nil
+	}
+	links := make([]models.GymAmenity, len(amenities))
+	for i, amenity := range amenities {
+		links[i] = models.GymAmenity{GymID: gymID, AmenityID: amenity.ID}
+	}
+	return tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&links).Error
+}
@@ -0,0 +1,36 @@
+package services
+
+import (
+	"gorm.io/gorm"
+
+	"github.com/jwallace145/crux-backend/models"
+)
+
+// DeleteClimbAttempts soft deletes the attempts logged on the given indoor boulders or rope climbs.
+// Call it alongside deleting the climbs themselves so that no attempts are left without a climb
+func DeleteClimbAttempts(tx *gorm.DB, parentType string, parentIDs []uint) error {
+	if len(parentIDs) == 0 {
+		return nil
+	}
+	return tx.Where("parent_type = ? AND parent_id IN ?", parentType, parentIDs).
+		Delete(&models.ClimbAttempt{}).Error
+}
+
+// DeleteSessionClimbAttempts soft deletes the attempts logged on every indoor boulder and rope
+// climb of a training session. Call it before the climbs are deleted, since only the climbs that
+// still exist are looked up
+func DeleteSessionClimbAttempts(tx *gorm.DB, sessionID uint) error {
+	var boulderIDs []uint
+	if err := tx.Model(&models.IndoorBoulder{}).Where("training_session_id = ?", sessionID).Pluck("id", &boulderIDs).Error; err != nil {
+		return err
+	}
+	if err := DeleteClimbAttempts(tx, models.AttemptParentTypeIndoorBoulder, boulderIDs); err != nil {
+		return err
+	}
+
+	var ropeClimbIDs []uint
+	if err := tx.Model(&models.RopeClimb{}).Where("training_session_id = ?", sessionID).Pluck("id", &ropeClimbIDs).Error; err != nil {
+		return err
+	}
+	return DeleteClimbAttempts(tx, models.AttemptParentTypeRopeClimb, ropeClimbIDs)
+}
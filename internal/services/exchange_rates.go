@@ -0,0 +1,71 @@
+package services
+
+// ExchangeRates converts amounts between currencies for cross-currency price comparisons
+type ExchangeRates interface {
+	// Rate returns the value of one unit of from in units of to, and false when either currency
+	// has no rate
+	Rate(from, to string) (float64, bool)
+}
+
+// StaticExchangeRates is a fixed table of the value of one unit of each currency in a base currency
+type StaticExchangeRates struct {
+	Base  string
+	Rates map[string]float64
+}
+
+// Rate returns the value of one unit of from in units of to, converting through the base currency
+func (r *StaticExchangeRates) Rate(from, to string) (float64, bool) {
+	if from == to {
+		return 1, true
+	}
+	fromRate, ok := r.baseRate(from)
+	if !ok {
+		return 0, false
+	}
+	toRate, ok := r.baseRate(to)
+	if !ok || toRate == 0 {
+		return 0, false
+	}
+	return fromRate / toRate, true
+}
+
+// baseRate returns the value of one unit of a currency in the base currency
+func (r *StaticExchangeRates) baseRate(currency string) (float64, bool) {
+	if currency == r.Base {
+		return 1, true
+	}
+	rate, ok := r.Rates[currency]
+	return rate, ok
+}
+
+// DefaultExchangeRates are approximate rates against USD. They only need to be close enough to
+// compare gym prices, not to charge anyone
+var DefaultExchangeRates = &StaticExchangeRates{
+	Base: "USD",
+	Rates: map[string]float64{
+		"AUD": 0.66,
+		"BRL": 0.18,
+		"CAD": 0.73,
+		"CHF": 1.12,
+		"CNY": 0.14,
+		"CZK": 0.043,
+		"DKK": 0.145,
+		"EUR": 1.08,
+		"GBP": 1.27,
+		"HKD": 0.128,
+		"INR": 0.012,
+		"JPY": 0.0067,
+		"KRW": 0.00074,
+		"MXN": 0.055,
+		"NOK": 0.093,
+		"NZD": 0.60,
+		"PLN": 0.25,
+		"SEK": 0.095,
+		"SGD": 0.74,
+		"ZAR": 0.054,
+	},
+}
+
+// FXRates is the exchange rate source used by price filters. Replace it at startup to plug in
+// another rate table or a live provider
+var FXRates ExchangeRates = DefaultExchangeRates
@@ -0,0 +1,146 @@
+package services
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/jwallace145/crux-backend/models"
+)
+
+// gymVisitsSQL selects the visits to a gym that started after @from: every check-in, plus the
+// training sessions that did not check the user in. Sessions logged after the fact with a date but
+// no time of day are skipped since they would all land at midnight
+const gymVisitsSQL = `
+	SELECT ci.user_id, ci.checked_in_at AS started_at, ci.checked_out_at AS ended_at,
+		NULL::int AS duration_minutes, true AS live
+	FROM gym_check_ins ci
+	WHERE ci.gym_id = @gym_id AND ci.deleted_at IS NULL AND ci.checked_in_at >= @from
+	UNION ALL
+	SELECT ts.user_id, COALESCE(ts.started_at, ts.session_date) AS started_at, ts.ended_at,
+		ts.duration_minutes, ts.started_at IS NOT NULL AS live
+	FROM training_sessions ts
+	WHERE ts.gym_id = @gym_id AND ts.deleted_at IS NULL
+		AND COALESCE(ts.started_at, ts.session_date) >= @from
+		AND (ts.started_at IS NOT NULL OR ts.session_date::time <> '00:00:00')
+		AND NOT EXISTS (
+			SELECT 1 FROM gym_check_ins ci
+			WHERE ci.training_session_id = ts.id AND ci.deleted_at IS NULL
+		)`
+
+// gymVisitRow is a raw visit selected by gymVisitsSQL
+type gymVisitRow struct {
+	UserID          uint
+	StartedAt       time.Time
+	EndedAt         *time.Time
+	DurationMinutes *int
+	Live            bool // Check-ins and live sessions run until now when they have not ended
+}
+
+// CheckInAtGym checks the user in at a gym, checking them out of any other gym first. An open
+// check-in at the same gym is reused and linked to the training session when one is given.
+// The second value is false when the user was already checked in at the gym
+func CheckInAtGym(tx *gorm.DB, userID, gymID uint, trainingSessionID *uint, now time.Time) (*models.GymCheckIn, bool, error) {
+	if err := tx.Model(&models.GymCheckIn{}).
+		Where("user_id = ? AND gym_id <> ? AND checked_out_at IS NULL AND checked_in_at > ?", userID, gymID, now.Add(-models.MaxGymVisitDuration)).
+		Update("checked_out_at", now).Error; err != nil {
+		return nil, false, err
+	}
+
+	var open models.GymCheckIn
+	err := tx.Where("user_id = ? AND gym_id = ? AND checked_out_at IS NULL AND checked_in_at > ?", userID, gymID, now.Add(-models.MaxGymVisitDuration)).
+		Order("checked_in_at DESC").
+		First(&open).Error
+	if err == nil {
+		if trainingSessionID != nil && open.TrainingSessionID == nil {
+			if err := tx.Model(&open).Update("training_session_id", *trainingSessionID).Error; err != nil {
+				return nil, false, err
+			}
+		}
+		return &open, false, nil
+	}
+	if err != gorm.ErrRecordNotFound {
+		return nil, false, err
+	}
+
+	source := models.GymCheckInSourceManual
+	if trainingSessionID != nil {
+		source = models.GymCheckInSourceTrainingSession
+	}
+	checkIn := &models.GymCheckIn{
+		GymID:             gymID,
+		UserID:            userID,
+		TrainingSessionID: trainingSessionID,
+		Source:            source,
+		CheckedInAt:       now,
+	}
+	if err := tx.Create(checkIn).Error; err != nil {
+		return nil, false, err
+	}
+	return checkIn, true, nil
+}
+
+// CheckOutOfGym checks the user out of their open check-in at a gym. Returns nil without an error
+// when the user is not checked in there
+func CheckOutOfGym(tx *gorm.DB, userID, gymID uint, now time.Time) (*models.GymCheckIn, error) {
+	var open models.GymCheckIn
+	err := tx.Where("user_id = ? AND gym_id = ? AND checked_out_at IS NULL AND checked_in_at > ?", userID, gymID, now.Add(-models.MaxGymVisitDuration)).
+		Order("checked_in_at DESC").
+		First(&open).Error
+	if err == gorm.ErrRecordNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Model(&open).Update("checked_out_at", now).Error; err != nil {
+		return nil, err
+	}
+	return &open, nil
+}
+
+// CheckOutTrainingSession checks out the check-in created by a live training session when the
+// session ends
+func CheckOutTrainingSession(tx *gorm.DB, trainingSessionID uint, endedAt time.Time) error {
+	return tx.Model(&models.GymCheckIn{}).
+		Where("training_session_id = ? AND checked_out_at IS NULL", trainingSessionID).
+		Update("checked_out_at", endedAt).Error
+}
+
+// GymVisits returns the anonymous visits to a gym over the busyness history window. Visits without
+// an end are capped at MaxGymVisitDuration, and sessions logged without a duration are assumed to
+// last DefaultGymVisitDuration
+func GymVisits(tx *gorm.DB, gymID uint, now time.Time) ([]models.GymVisit, error) {
+	var rows []gymVisitRow
+	if err := tx.Raw(gymVisitsSQL, map[string]interface{}{
+		"gym_id": gymID,
+		"from":   now.AddDate(0, 0, -7*models.GymBusynessHistoryWeeks),
+	}).Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	visits := make([]models.GymVisit, 0, len(rows))
+	for _, row := range rows {
+		var end time.Time
+		switch {
+		case row.EndedAt != nil:
+			end = *row.EndedAt
+		case row.DurationMinutes != nil:
+			end = row.StartedAt.Add(time.Duration(*row.DurationMinutes) * time.Minute)
+		case row.Live:
+			end = now
+		default:
+			end = row.StartedAt.Add(models.DefaultGymVisitDuration)
+		}
+		limit := row.StartedAt.Add(models.MaxGymVisitDuration)
+		if end.After(limit) {
+			end = limit
+		}
+		if end.After(now) {
+			end = now
+		}
+		visits = append(visits, models.GymVisit{UserID: row.UserID, Start: row.StartedAt, End: end})
+	}
+	return visits, nil
+}
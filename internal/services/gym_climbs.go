@@ -0,0 +1,120 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	awsClient "github.com/jwallace145/crux-backend/internal/aws"
+	"github.com/jwallace145/crux-backend/internal/utils"
+
+	"github.com/jwallace145/crux-backend/models"
+)
+
+// gymClimbLogsSQL lists the logged indoor boulders and rope climbs that reference the given gym climbs
+const gymClimbLogsSQL = `
+	SELECT ib.gym_climb_id, ts.user_id, ib.grade, ib.outcome, ts.session_date FROM indoor_boulders ib
+	JOIN training_sessions ts ON ts.id = ib.training_session_id AND ts.deleted_at IS NULL
+	WHERE ib.gym_climb_id IN @ids AND ib.deleted_at IS NULL
+	UNION ALL
+	SELECT rc.gym_climb_id, ts.user_id, rc.grade, rc.outcome, ts.session_date FROM rope_climbs rc
+	JOIN training_sessions ts ON ts.id = rc.training_session_id AND ts.deleted_at IS NULL
+	WHERE rc.gym_climb_id IN @ids AND rc.deleted_at IS NULL`
+
+// GymClimbsBelongToGym returns true if every catalog climb referenced by the logged climbs is set at
+// the gym, with boulder problems logged as indoor boulders and routes as rope climbs
+func GymClimbsBelongToGym(tx *gorm.DB, gymID uint, boulders []models.IndoorBoulderRequest, ropeClimbs []models.RopeClimbRequest) (bool, error) {
+	boulderIDs := map[uint]bool{}
+	for _, boulder := range boulders {
+		if boulder.GymClimbID != nil {
+			boulderIDs[*boulder.GymClimbID] = true
+		}
+	}
+	routeIDs := map[uint]bool{}
+	for _, ropeClimb := range ropeClimbs {
+		if ropeClimb.GymClimbID != nil {
+			routeIDs[*ropeClimb.GymClimbID] = true
+		}
+	}
+
+	for discipline, ids := range map[string]map[uint]bool{
+		models.GymClimbDisciplineBoulder: boulderIDs,
+		models.GymClimbDisciplineRope:    routeIDs,
+	} {
+		if len(ids) == 0 {
+			continue
+		}
+		idList := make([]uint, 0, len(ids))
+		for id := range ids {
+			idList = append(idList, id)
+		}
+
+		var count int64
+		if err := tx.Model(&models.GymClimb{}).
+			Where("gym_id = ? AND discipline = ? AND id IN ?", gymID, discipline, idList).
+			Count(&count).Error; err != nil {
+			return false, err
+		}
+		if int(count) != len(idList) {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+// GymClimbLogs returns the logged indoor boulders and rope climbs that reference the gym climbs
+func GymClimbLogs(tx *gorm.DB, gymClimbIDs []uint) ([]models.GymClimbLog, error) {
+	logs := []models.GymClimbLog{}
+	if len(gymClimbIDs) == 0 {
+		return logs, nil
+	}
+	if err := tx.Raw(gymClimbLogsSQL, map[string]interface{}{"ids": gymClimbIDs}).Scan(&logs).Error; err != nil {
+		return nil, err
+	}
+	return logs, nil
+}
+
+// GymClimbStats aggregates the logs of each gym climb, keyed by gym climb ID
+func GymClimbStats(tx *gorm.DB, climbs []models.GymClimb) (map[uint]*models.GymClimbStats, error) {
+	ids := make([]uint, len(climbs))
+	for i := range climbs {
+		ids[i] = climbs[i].ID
+	}
+
+	logs, err := GymClimbLogs(tx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	byClimb := map[uint][]models.GymClimbLog{}
+	for _, log := range logs {
+		byClimb[log.GymClimbID] = append(byClimb[log.GymClimbID], log)
+	}
+
+	stats := make(map[uint]*models.GymClimbStats, len(climbs))
+	for i := range climbs {
+		stats[climbs[i].ID] = models.AggregateGymClimbLogs(&climbs[i], byClimb[climbs[i].ID])
+	}
+	return stats, nil
+}
+
+// PresignGymClimbPhoto adds a presigned photo URL to a gym climb response when the climb has a photo.
+// If the URL cannot be generated the response is left without one
+func PresignGymClimbPhoto(ctx context.Context, climb *models.GymClimb, response *models.GymClimbResponse) {
+	if !climb.HasPhoto() {
+		return
+	}
+	url, err := awsClient.GeneratePresignedURL(ctx, awsClient.MediaBucket, climb.PhotoS3Key, int(MediaURLExpiry.Minutes()))
+	if err != nil {
+		utils.Log.Warn("Failed to generate presigned URL for gym climb photo",
+			zap.Error(err),
+			zap.Uint("gym_climb_id", climb.ID),
+		)
+		return
+	}
+	response.PhotoURL = url
+	response.PhotoURLExpiresAt = time.Now().Add(MediaURLExpiry).Format(time.RFC3339)
+}
@@ -0,0 +1,271 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/jwallace145/crux-backend/models"
+)
+
+// gymImportCreateBatchSize bounds the rows per insert statement so gyms and their opening hours
+// stay below the Postgres parameter limit
+const gymImportCreateBatchSize = 200
+
+// ImportGyms reads gyms from a CSV or GeoJSON file and creates them, or updates the gym with
+// the same external ID. Every row is validated like a gym created through POST /gyms, and rows are
+// written in batches of opts.BatchSize, each in its own transaction. Rows that fail are listed in
+// the report and do not stop the import. An error is returned with the report so far when the file
+// itself cannot be read
+func ImportGyms(tx *gorm.DB, source io.Reader, opts models.GymImportOptions) (*models.GymImportReport, error) {
+	if opts.BatchSize <= 0 {
+		opts.BatchSize = models.DefaultGymImportBatchSize
+	}
+	if opts.BatchSize > models.MaxGymImportBatchSize {
+		opts.BatchSize = models.MaxGymImportBatchSize
+	}
+
+	reader, err := models.NewGymImportReader(source, opts.Format, opts.Mapping)
+	if err != nil {
+		return nil, err
+	}
+
+	// The whole catalog is small, so amenities are resolved in memory rather than per row
+	var amenities []models.Amenity
+	if err := tx.Find(&amenities).Error; err != nil {
+		return nil, err
+	}
+	catalog := make(map[string]models.Amenity, len(amenities))
+	for _, amenity := range amenities {
+		catalog[amenity.Slug] = amenity
+	}
+
+	report := &models.GymImportReport{
+		Format:    opts.Format,
+		DryRun:    opts.DryRun,
+		BatchSize: opts.BatchSize,
+		Errors:    []models.GymImportRowError{},
+	}
+	importer := &gymImporter{tx: tx, opts: opts, report: report, catalog: catalog, seen: map[string]int{}}
+
+	batch := make([]*gymImportItem, 0, opts.BatchSize)
+	for {
+		row, err := reader.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			importer.importBatch(batch)
+			return report, err
+		}
+
+		report.Rows++
+		if item := importer.prepare(row); item != nil {
+			batch = append(batch, item)
+		}
+		if len(batch) == opts.BatchSize {
+			importer.importBatch(batch)
+			batch = batch[:0]
+		}
+	}
+	importer.importBatch(batch)
+
+	return report, nil
+}
+
+// gymImporter holds the state shared by the batches of an import
+type gymImporter struct {
+	tx      *gorm.DB
+	opts    models.GymImportOptions
+	report  *models.GymImportReport
+	catalog map[string]models.Amenity
+	seen    map[string]int // Row of each external ID already read, to catch repeats in the file
+}
+
+// gymImportItem is a validated row ready to be written
+type gymImportItem struct {
+	row       *models.GymImportRow
+	gym       *models.Gym
+	amenities bool // The row lists amenities, replacing those of an existing gym
+}
+
+// prepare validates a row and builds its gym. Invalid rows are added to the report and nil is returned
+func (i *gymImporter) prepare(row *models.GymImportRow) *gymImportItem {
+	if row.Err != nil {
+		i.report.AddError(row, row.Err)
+		return nil
+	}
+
+	if row.ExternalID != "" {
+		if len(row.ExternalID) > 200 {
+			i.report.AddError(row, errors.New("external_id must not exceed 200 characters"))
+			return nil
+		}
+		if first, ok := i.seen[row.ExternalID]; ok {
+			i.report.AddError(row, fmt.Errorf("external_id is repeated from row %d", first))
+			return nil
+		}
+		i.seen[row.ExternalID] = row.Row
+	}
+
+	req := &row.Request
+	if req.Type == "" {
+		req.Type = i.opts.DefaultType
+	}
+	if req.Country == "" {
+		req.Country = i.opts.DefaultCountry
+	}
+	NormalizeGymRequest(req)
+	if err := ValidateCreateGymRequest(req); err != nil {
+		i.report.AddError(row, err)
+		return nil
+	}
+
+	slugs, _ := req.AmenitySlugs()
+	amenities := make([]models.Amenity, 0, len(slugs))
+	unknown := []string{}
+	for _, slug := range slugs {
+		amenity, ok := i.catalog[slug]
+		if !ok {
+			unknown = append(unknown, slug)
+			continue
+		}
+		amenities = append(amenities, amenity)
+	}
+	if len(unknown) > 0 {
+		i.report.AddError(row, errors.New("Unknown amenities: "+strings.Join(unknown, ", ")))
+		return nil
+	}
+
+	gym := NewGymFromRequest(req)
+	gym.Amenities = amenities
+	gym.CreatedByID = i.opts.CreatedByID
+	if row.ExternalID != "" {
+		externalID := row.ExternalID
+		gym.ExternalID = &externalID
+	}
+
+	item := &gymImportItem{row: row, gym: gym}
+	for _, field := range row.Fields {
+		if field == "amenities" {
+			item.amenities = true
+		}
+	}
+	return item
+}
+
+// importBatch creates the new gyms of a batch and updates the ones whose external ID already
+// exists, in one transaction. When the transaction fails every row of the batch is reported
+func (i *gymImporter) importBatch(batch []*gymImportItem) {
+	if len(batch) == 0 {
+		return
+	}
+	i.report.Batches++
+
+	externalIDs := []string{}
+	for _, item := range batch {
+		if item.gym.ExternalID != nil {
+			externalIDs = append(externalIDs, *item.gym.ExternalID)
+		}
+	}
+	existing := map[string]uint{}
+	if len(externalIDs) > 0 {
+		var gyms []models.Gym
+		if err := i.tx.Select("id", "external_id").Where("external_id IN ?", externalIDs).Find(&gyms).Error; err != nil {
+			i.failBatch(batch, err)
+			return
+		}
+		for _, gym := range gyms {
+			existing[*gym.ExternalID] = gym.ID
+		}
+	}
+
+	creates := []*models.Gym{}
+	updates := []*gymImportItem{}
+	for _, item := range batch {
+		if item.gym.ExternalID != nil {
+			if id, ok := existing[*item.gym.ExternalID]; ok {
+				item.gym.ID = id
+				updates = append(updates, item)
+				continue
+			}
+		}
+		creates = append(creates, item.gym)
+	}
+
+	if !i.opts.DryRun {
+		err := i.tx.Transaction(func(tx *gorm.DB) error {
+			if len(creates) > 0 {
+				if err := tx.Session(&gorm.Session{CreateBatchSize: gymImportCreateBatchSize}).Create(&creates).Error; err != nil {
+					return err
+				}
+				// The active column defaults to true, so inserts skip a false value
+				inactiveIDs := []uint{}
+				for _, gym := range creates {
+					if !gym.Active {
+						inactiveIDs = append(inactiveIDs, gym.ID)
+					}
+				}
+				if len(inactiveIDs) > 0 {
+					if err := tx.Model(&models.Gym{}).Where("id IN ?", inactiveIDs).Update("active", false).Error; err != nil {
+						return err
+					}
+				}
+			}
+			for _, item := range updates {
+				if err := updateImportedGym(tx, item); err != nil {
+					return fmt.Errorf("row %d: %w", item.row.Row, err)
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			i.failBatch(batch, err)
+			return
+		}
+	}
+
+	i.report.Created += len(creates)
+	i.report.Updated += len(updates)
+}
+
+// updateImportedGym writes the fields present in an import row onto the existing gym. The weekly
+// schedule is replaced when the free-form hours can be parsed into one
+func updateImportedGym(tx *gorm.DB, item *gymImportItem) error {
+	gym := item.gym
+	columns := []string{}
+	for _, field := range item.row.Fields {
+		if field != "amenities" {
+			columns = append(columns, field)
+		}
+	}
+
+	if len(columns) > 0 {
+		if err := tx.Model(&models.Gym{Model: gorm.Model{ID: gym.ID}}).Select(columns).Omit(clause.Associations).Updates(gym).Error; err != nil {
+			return err
+		}
+	} else if err := tx.Model(&models.Gym{Model: gorm.Model{ID: gym.ID}}).Update("updated_at", time.Now()).Error; err != nil {
+		return err
+	}
+	if len(gym.OpeningHours) > 0 {
+		if err := ReplaceGymOpeningHours(tx, gym.ID, gym.OpeningHours); err != nil {
+			return err
+		}
+	}
+	if item.amenities {
+		return SetGymAmenities(tx, gym.ID, gym.Amenities)
+	}
+	return nil
+}
+
+// failBatch reports every row of a batch that could not be written
+func (i *gymImporter) failBatch(batch []*gymImportItem, err error) {
+	for _, item := range batch {
+		i.report.AddError(item.row, fmt.Errorf("batch %d was not imported: %w", i.report.Batches, err))
+	}
+}
@@ -0,0 +1,184 @@
+package services
+
+import (
+	"math"
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/jwallace145/crux-backend/internal/db"
+	"github.com/jwallace145/crux-backend/models"
+)
+
+// maxGymDuplicateScan bounds the number of nearby gyms compared when looking for duplicates
+const maxGymDuplicateScan = 200
+
+// FindDuplicateGyms returns the existing gyms that a new or edited gym is likely a duplicate of.
+// Gyms in the same city and, when the gym has coordinates, gyms within models.GymDuplicateNearbyKm
+// are compared by normalized name. Only the maxGymDuplicateScan nearest gyms are compared, then
+// those with the most similar names, so a large city cannot push the duplicate out of the scan
+func FindDuplicateGyms(tx *gorm.DB, gym *models.Gym) ([]models.GymDuplicateCandidate, error) {
+	area := "(lower(city) = lower(?) AND lower(country) = lower(?))"
+	args := []interface{}{gym.City, gym.Country}
+	if gym.Latitude != nil && gym.Longitude != nil {
+		box := models.BoundingBoxAround(*gym.Latitude, *gym.Longitude, models.GymDuplicateNearbyKm)
+		area += " OR (latitude BETWEEN ? AND ? AND "
+		args = append(args, box.MinLat, box.MaxLat)
+		if box.CrossesAntimeridian() {
+			area += "(longitude >= ? OR longitude <= ?))"
+		} else {
+			area += "longitude BETWEEN ? AND ?)"
+		}
+		args = append(args, box.MinLng, box.MaxLng)
+	}
+
+	// Nearest first on a flat projection around the gym, with longitude differences wrapped at the
+	// antimeridian, leaving gyms without coordinates to be ordered by name
+	var order []string
+	var orderArgs []interface{}
+	if gym.Latitude != nil && gym.Longitude != nil {
+		lngScale := math.Cos(*gym.Latitude * math.Pi / 180)
+		order = append(order, "power(latitude - ?, 2) + power(least(abs(longitude - ?), 360 - abs(longitude - ?)) * ?, 2) ASC NULLS LAST")
+		orderArgs = append(orderArgs, *gym.Latitude, *gym.Longitude, *gym.Longitude, lngScale)
+	}
+	name := models.NormalizeGymName(gym.Name)
+	if db.TrigramSearchEnabled {
+		order = append(order, "similarity(lower(name), ?) DESC")
+		orderArgs = append(orderArgs, name)
+	} else if words := strings.Fields(name); len(words) > 0 {
+		order = append(order, "strpos(lower(name), ?) > 0 DESC")
+		orderArgs = append(orderArgs, words[0])
+	}
+	order = append(order, "id ASC")
+
+	var existing []models.Gym
+	if err := tx.Select("id", "name", "address", "city", "state", "country", "latitude", "longitude", "active").
+		Where(area, args...).
+		Where("id <> ?", gym.ID).
+		Clauses(clause.OrderBy{Expression: clause.Expr{SQL: strings.Join(order, ", "), Vars: orderArgs}}).
+		Limit(maxGymDuplicateScan).
+		Find(&existing).Error; err != nil {
+		return nil, err
+	}
+	return models.FindGymDuplicates(gym, existing), nil
+}
+
+// MergeGyms moves every reference to the duplicate gym onto the survivor, deletes the duplicate and
+// records the merge. Reviews by users who also reviewed the survivor are dropped since a user can
+// only review a gym once. Amenities are combined, the duplicate's opening hours and prices are only
+// moved when the survivor has none, and its owner is kept when the survivor is unclaimed. It must be
+// called in a transaction
+func MergeGyms(tx *gorm.DB, survivor, duplicate *models.Gym, mergedByID uint, reason string) (*models.GymMerge, error) {
+	merge := &models.GymMerge{
+		SurvivorGymID:  survivor.ID,
+		DuplicateGymID: duplicate.ID,
+		MergedByID:     mergedByID,
+		DuplicateName:  duplicate.Name,
+		Reason:         reason,
+	}
+
+	// Drop the duplicate's reviews by users who already reviewed the survivor, with their reports
+	overlapping := "user_id IN (SELECT user_id FROM gym_reviews WHERE gym_id = ?)"
+	if err := tx.Unscoped().
+		Where("gym_review_id IN (SELECT id FROM gym_reviews WHERE gym_id = ? AND "+overlapping+")", duplicate.ID, survivor.ID).
+		Delete(&models.GymReviewReport{}).Error; err != nil {
+		return nil, err
+	}
+	result := tx.Unscoped().Where("gym_id = ? AND "+overlapping, duplicate.ID, survivor.ID).Delete(&models.GymReview{})
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	merge.Moved.ReviewsDropped = int(result.RowsAffected)
+
+	// Re-point every record at the survivor, including soft deleted ones so nothing is left
+	// referencing the deleted gym
+	moves := []struct {
+		model interface{}
+		count *int
+	}{
+		{&models.TrainingSession{}, &merge.Moved.TrainingSessions},
+		{&models.PlannedSession{}, &merge.Moved.PlannedSessions},
+		{&models.Climb{}, &merge.Moved.Climbs},
+		{&models.GymCheckIn{}, &merge.Moved.CheckIns},
+		{&models.GymReview{}, &merge.Moved.Reviews},
+		{&models.GymWall{}, &merge.Moved.Walls},
+		{&models.GymClimb{}, &merge.Moved.GymClimbs},
+		{&models.GymClaim{}, &merge.Moved.Claims},
+	}
+	for _, move := range moves {
+		result := tx.Unscoped().Model(move.model).Where("gym_id = ?", duplicate.ID).Update("gym_id", survivor.ID)
+		if result.Error != nil {
+			return nil, result.Error
+		}
+		*move.count = int(result.RowsAffected)
+	}
+
+	// Add the duplicate's amenities that the survivor does not list yet
+	result = tx.Exec(`INSERT INTO gym_amenities (gym_id, amenity_id, created_at)
+		SELECT ?, amenity_id, created_at FROM gym_amenities WHERE gym_id = ?
+		ON CONFLICT DO NOTHING`, survivor.ID, duplicate.ID)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	merge.Moved.Amenities = int(result.RowsAffected)
+	if err := tx.Where("gym_id = ?", duplicate.ID).Delete(&models.GymAmenity{}).Error; err != nil {
+		return nil, err
+	}
+
+	// Keep the duplicate's schedule and prices when the survivor has none
+	var err error
+	if merge.Moved.Schedule, err = moveGymChildrenIfMissing(tx, survivor.ID, duplicate.ID, &models.GymOpeningHours{}, &models.GymHoursException{}); err != nil {
+		return nil, err
+	}
+	if merge.Moved.Prices, err = moveGymChildrenIfMissing(tx, survivor.ID, duplicate.ID, &models.GymPrice{}); err != nil {
+		return nil, err
+	}
+
+	updates := map[string]interface{}{}
+	if !survivor.IsClaimed() && duplicate.IsClaimed() {
+		updates["owner_id"] = *duplicate.OwnerID
+	}
+	if len(updates) > 0 {
+		if err := tx.Model(survivor).Updates(updates).Error; err != nil {
+			return nil, err
+		}
+	}
+	if merge.Moved.Reviews > 0 || merge.Moved.ReviewsDropped > 0 {
+		if err := RefreshGymRatings(tx, survivor.ID); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.Delete(duplicate).Error; err != nil {
+		return nil, err
+	}
+	if err := tx.Create(merge).Error; err != nil {
+		return nil, err
+	}
+	return merge, nil
+}
+
+// moveGymChildrenIfMissing moves the rows of the given child models from one gym to another when
+// the target gym has no rows of any of them. Returns true if rows were moved
+func moveGymChildrenIfMissing(tx *gorm.DB, toGymID, fromGymID uint, childModels ...interface{}) (bool, error) {
+	for _, model := range childModels {
+		var count int64
+		if err := tx.Model(model).Where("gym_id = ?", toGymID).Count(&count).Error; err != nil {
+			return false, err
+		}
+		if count > 0 {
+			return false, nil
+		}
+	}
+
+	moved := false
+	for _, model := range childModels {
+		result := tx.Model(model).Where("gym_id = ?", fromGymID).Update("gym_id", toGymID)
+		if result.Error != nil {
+			return false, result.Error
+		}
+		moved = moved || result.RowsAffected > 0
+	}
+	return moved, nil
+}
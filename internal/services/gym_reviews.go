@@ -0,0 +1,55 @@
+package services
+
+import (
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/jwallace145/crux-backend/models"
+)
+
+// gymRatingAggregate holds the ratings aggregated from a gym's visible reviews
+type gymRatingAggregate struct {
+	RatingCount       int
+	RatingAverage     float64
+	SettingRating     *float64
+	CrowdingRating    *float64
+	CleanlinessRating *float64
+	ValueRating       *float64
+}
+
+// LockGymRatings locks the gym row so that concurrent review changes refresh its ratings one at a
+// time. It must be called at the start of the transaction, before the gym's reviews are changed,
+// or each transaction can aggregate without the other's review and leave stale ratings
+func LockGymRatings(tx *gorm.DB, gymID uint) error {
+	var gym models.Gym
+	return tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Select("id").
+		Where("id = ?", gymID).
+		Take(&gym).Error
+}
+
+// RefreshGymRatings recomputes the aggregated rating columns of a gym from its visible reviews.
+// It must be called in the same transaction as any change to the gym's reviews, after LockGymRatings
+func RefreshGymRatings(tx *gorm.DB, gymID uint) error {
+	var aggregate gymRatingAggregate
+	if err := tx.Model(&models.GymReview{}).
+		Select(`COUNT(*) AS rating_count,
+			COALESCE(AVG(overall_rating), 0) AS rating_average,
+			AVG(setting_rating) AS setting_rating,
+			AVG(crowding_rating) AS crowding_rating,
+			AVG(cleanliness_rating) AS cleanliness_rating,
+			AVG(value_rating) AS value_rating`).
+		Where("gym_id = ? AND hidden = ?", gymID, false).
+		Scan(&aggregate).Error; err != nil {
+		return err
+	}
+
+	return tx.Model(&models.Gym{}).Where("id = ?", gymID).Updates(map[string]interface{}{
+		"rating_count":       aggregate.RatingCount,
+		"rating_average":     aggregate.RatingAverage,
+		"setting_rating":     aggregate.SettingRating,
+		"crowding_rating":    aggregate.CrowdingRating,
+		"cleanliness_rating": aggregate.CleanlinessRating,
+		"value_rating":       aggregate.ValueRating,
+	}).Error
+}
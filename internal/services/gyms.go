@@ -0,0 +1,204 @@
+package services
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/jwallace145/crux-backend/models"
+)
+
+// NewGymFromRequest builds a gym model from a validated and normalized create gym request
+// When no weekly schedule is given the free-form hours text is parsed into one where possible
+func NewGymFromRequest(req *models.CreateGymRequest) *models.Gym {
+	openingHours, _ := models.ToGymOpeningHours(req.OpeningHours)
+	if len(openingHours) == 0 && req.Hours != "" {
+		openingHours, _ = models.ParseOpeningHoursText(req.Hours)
+	}
+	exceptions, _ := models.ToGymHoursExceptions(req.HoursExceptions)
+	prices, _ := models.ToGymPrices(req.Prices)
+
+	return &models.Gym{
+		Name:            req.Name,
+		Description:     req.Description,
+		Type:            req.Type,
+		Address:         req.Address,
+		City:            req.City,
+		State:           req.State,
+		Province:        req.Province,
+		Country:         req.Country,
+		PostalCode:      req.PostalCode,
+		Latitude:        req.Latitude,
+		Longitude:       req.Longitude,
+		Phone:           req.Phone,
+		Email:           req.Email,
+		Website:         req.Website,
+		Hours:           req.Hours,
+		Timezone:        req.Timezone,
+		OpeningHours:    openingHours,
+		HoursExceptions: exceptions,
+		WallHeight:      req.WallHeight,
+		SquareFeet:      req.SquareFeet,
+		Prices:          prices,
+		Notes:           req.Notes,
+		Active:          req.Active,
+	}
+}
+
+// NormalizeGymRequest trims the required text fields and lowercases the email
+func NormalizeGymRequest(req *models.CreateGymRequest) {
+	req.Name = strings.TrimSpace(req.Name)
+	req.City = strings.TrimSpace(req.City)
+	req.Country = strings.TrimSpace(req.Country)
+	req.Timezone = strings.TrimSpace(req.Timezone)
+	if req.Email != "" {
+		req.Email = strings.ToLower(strings.TrimSpace(req.Email))
+	}
+}
+
+// ValidateCreateGymRequest validates a new gym. Updates and imported rows are validated the same way
+func ValidateCreateGymRequest(req *models.CreateGymRequest) error {
+	if err := validateGymName(req.Name); err != nil {
+		return err
+	}
+	if err := ValidateGymType(req.Type); err != nil {
+		return err
+	}
+	if err := validateGymLocation(req.City, req.Country); err != nil {
+		return err
+	}
+	if err := validateGymContactInfo(req.Email); err != nil {
+		return err
+	}
+	if err := ValidateGymCoordinates(req.Latitude, req.Longitude); err != nil {
+		return err
+	}
+	if err := validateGymSchedule(req.Timezone, req.OpeningHours, req.HoursExceptions); err != nil {
+		return err
+	}
+	if _, err := models.ToGymPrices(req.Prices); err != nil {
+		return errors.New("Invalid prices: " + err.Error())
+	}
+	if _, err := req.AmenitySlugs(); err != nil {
+		return errors.New("Invalid amenities: " + err.Error())
+	}
+	return nil
+}
+
+// validateGymName validates the gym name
+func validateGymName(name string) error {
+	if name == "" {
+		return errors.New("Name is required")
+	}
+	if len(name) < 1 || len(name) > 200 {
+		return errors.New("Name must be between 1 and 200 characters")
+	}
+	return nil
+}
+
+// ValidateGymType validates the gym type
+func ValidateGymType(gymType string) error {
+	if gymType == "" {
+		return errors.New("Type is required")
+	}
+	if gymType != models.GymTypeBouldering && gymType != models.GymTypeRoped && gymType != models.GymTypeFull {
+		return errors.New("Type must be one of: bouldering, roped, full")
+	}
+	return nil
+}
+
+// validateGymLocation validates the city and country
+func validateGymLocation(city, country string) error {
+	if city == "" {
+		return errors.New("City is required")
+	}
+	if len(city) > 100 {
+		return errors.New("City must not exceed 100 characters")
+	}
+	if country == "" {
+		return errors.New("Country is required")
+	}
+	if len(country) > 100 {
+		return errors.New("Country must not exceed 100 characters")
+	}
+	return nil
+}
+
+// validateGymContactInfo validates email format
+func validateGymContactInfo(email string) error {
+	if email != "" && !strings.Contains(email, "@") {
+		return errors.New("Invalid email format")
+	}
+	return nil
+}
+
+// ValidateGymCoordinates validates latitude and longitude
+func ValidateGymCoordinates(latitude, longitude *float64) error {
+	if latitude != nil && (*latitude < -90 || *latitude > 90) {
+		return errors.New("Latitude must be between -90 and 90")
+	}
+	if longitude != nil && (*longitude < -180 || *longitude > 180) {
+		return errors.New("Longitude must be between -180 and 180")
+	}
+	return nil
+}
+
+// validateGymSchedule validates the timezone, weekly opening hours and dated exceptions
+func validateGymSchedule(timezone string, openingHours []models.GymOpeningHoursRequest, exceptions []models.GymHoursExceptionRequest) error {
+	if timezone != "" {
+		if _, err := time.LoadLocation(timezone); err != nil || timezone == "Local" || len(timezone) > 64 {
+			return errors.New("Timezone must be a valid IANA timezone (e.g., America/New_York)")
+		}
+	}
+	if _, err := models.ToGymOpeningHours(openingHours); err != nil {
+		return errors.New("Invalid opening hours: " + err.Error())
+	}
+	if _, err := models.ToGymHoursExceptions(exceptions); err != nil {
+		return errors.New("Invalid hours exceptions: " + err.Error())
+	}
+	return nil
+}
+
+// ReplaceGymOpeningHours replaces the weekly schedule of a gym
+func ReplaceGymOpeningHours(tx *gorm.DB, gymID uint, hours []models.GymOpeningHours) error {
+	if err := tx.Unscoped().Where("gym_id = ?", gymID).Delete(&models.GymOpeningHours{}).Error; err != nil {
+		return err
+	}
+	if len(hours) == 0 {
+		return nil
+	}
+	for i := range hours {
+		hours[i].GymID = gymID
+	}
+	return tx.Create(&hours).Error
+}
+
+// ReplaceGymHoursExceptions replaces the dated exceptions of a gym
+func ReplaceGymHoursExceptions(tx *gorm.DB, gymID uint, exceptions []models.GymHoursException) error {
+	if err := tx.Unscoped().Where("gym_id = ?", gymID).Delete(&models.GymHoursException{}).Error; err != nil {
+		return err
+	}
+	if len(exceptions) == 0 {
+		return nil
+	}
+	for i := range exceptions {
+		exceptions[i].GymID = gymID
+	}
+	return tx.Create(&exceptions).Error
+}
+
+// ReplaceGymPrices replaces the pricing tiers of a gym
+func ReplaceGymPrices(tx *gorm.DB, gymID uint, prices []models.GymPrice) error {
+	if err := tx.Unscoped().Where("gym_id = ?", gymID).Delete(&models.GymPrice{}).Error; err != nil {
+		return err
+	}
+	if len(prices) == 0 {
+		return nil
+	}
+	for i := range prices {
+		prices[i].GymID = gymID
+	}
+	return tx.Create(&prices).Error
+}
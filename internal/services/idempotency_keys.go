@@ -0,0 +1,53 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	"github.com/jwallace145/crux-backend/internal/db"
+	"github.com/jwallace145/crux-backend/internal/utils"
+	"github.com/jwallace145/crux-backend/models"
+)
+
+// idempotencyKeySweepInterval is how often expired idempotency keys are purged
+const idempotencyKeySweepInterval = time.Hour
+
+// PurgeExpiredIdempotencyKeys permanently deletes the idempotency keys whose stored response can no
+// longer be replayed. It returns the number of keys deleted
+func PurgeExpiredIdempotencyKeys(tx *gorm.DB) (int64, error) {
+	result := tx.Unscoped().Where("expires_at < ?", time.Now()).Delete(&models.IdempotencyKey{})
+	if result.Error != nil {
+		return 0, result.Error
+	}
+	return result.RowsAffected, nil
+}
+
+// StartIdempotencyKeyPurger periodically purges expired idempotency keys in the background until ctx is done
+func StartIdempotencyKeyPurger(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(idempotencyKeySweepInterval)
+		defer ticker.Stop()
+
+		for {
+			purged, err := PurgeExpiredIdempotencyKeys(db.DB)
+			if err != nil {
+				utils.Log.Error("Failed to purge expired idempotency keys",
+					zap.Error(err),
+				)
+			} else if purged > 0 {
+				utils.Log.Info("Purged expired idempotency keys",
+					zap.Int64("purged", purged),
+				)
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
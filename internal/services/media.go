@@ -0,0 +1,79 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	awsClient "github.com/jwallace145/crux-backend/internal/aws"
+	"github.com/jwallace145/crux-backend/internal/utils"
+	"github.com/jwallace145/crux-backend/models"
+)
+
+// MediaURLExpiry is how long presigned media URLs remain valid
+const MediaURLExpiry = 60 * time.Minute
+
+// PresignMediaAttachments converts attachments to response DTOs with presigned download URLs.
+// If a URL cannot be generated the attachment is still returned without one.
+func PresignMediaAttachments(ctx context.Context, attachments []models.MediaAttachment) []models.MediaAttachmentResponse {
+	if len(attachments) == 0 {
+		return nil
+	}
+
+	expiresAt := time.Now().Add(MediaURLExpiry).Format(time.RFC3339)
+	responses := make([]models.MediaAttachmentResponse, len(attachments))
+
+	for i := range attachments {
+		attachment := &attachments[i]
+		url, err := awsClient.GeneratePresignedURL(ctx, awsClient.MediaBucket, attachment.S3Key, int(MediaURLExpiry.Minutes()))
+		if err != nil {
+			utils.Log.Warn("Failed to generate presigned URL for media attachment",
+				zap.Error(err),
+				zap.Uint("media_id", attachment.ID),
+			)
+			responses[i] = *attachment.ToMediaAttachmentResponse()
+			continue
+		}
+		responses[i] = *attachment.ToMediaAttachmentResponseWithPresignedURL(url, expiresAt)
+	}
+
+	return responses
+}
+
+// DeleteParentMedia soft deletes every attachment of a parent record using the given
+// transaction and returns their S3 keys. Call DeleteMediaObjects with the keys once
+// the transaction has committed.
+func DeleteParentMedia(tx *gorm.DB, parentType string, parentID uint) ([]string, error) {
+	var attachments []models.MediaAttachment
+	if err := tx.Where("parent_type = ? AND parent_id = ?", parentType, parentID).Find(&attachments).Error; err != nil {
+		return nil, err
+	}
+	if len(attachments) == 0 {
+		return nil, nil
+	}
+
+	if err := tx.Delete(&attachments).Error; err != nil {
+		return nil, err
+	}
+
+	keys := make([]string, len(attachments))
+	for i, attachment := range attachments {
+		keys[i] = attachment.S3Key
+	}
+	return keys, nil
+}
+
+// DeleteMediaObjects removes media objects from S3. Failures are logged and skipped
+// so that one missing object does not prevent cleanup of the rest.
+func DeleteMediaObjects(ctx context.Context, keys []string) {
+	for _, key := range keys {
+		if err := awsClient.DeleteFile(ctx, awsClient.MediaBucket, key); err != nil {
+			utils.Log.Warn("Failed to delete media object from S3",
+				zap.Error(err),
+				zap.String("s3_key", key),
+			)
+		}
+	}
+}
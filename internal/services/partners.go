@@ -0,0 +1,98 @@
+package services
+
+import (
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/jwallace145/crux-backend/models"
+)
+
+// ErrPartnersNotFound is returned when one or more tagged partners do not exist
+var ErrPartnersNotFound = errors.New("one or more partners not found")
+
+// ErrPartnersBlocked is returned when one or more tagged partners have blocked the user
+var ErrPartnersBlocked = errors.New("one or more partners cannot be tagged")
+
+// VerifySessionPartners loads the users tagged as partners on a session, checking that every one
+// exists and that none of them has blocked the user
+func VerifySessionPartners(tx *gorm.DB, userID uint, partnerIDs []uint) ([]models.User, error) {
+	partners := []models.User{}
+	if len(partnerIDs) == 0 {
+		return partners, nil
+	}
+
+	if err := tx.Where("id IN ?", partnerIDs).Find(&partners).Error; err != nil {
+		return nil, err
+	}
+	if len(partners) != len(partnerIDs) {
+		return nil, ErrPartnersNotFound
+	}
+
+	blockerIDs, err := FindBlockingUsers(tx, userID, partnerIDs)
+	if err != nil {
+		return nil, err
+	}
+	if len(blockerIDs) > 0 {
+		return nil, ErrPartnersBlocked
+	}
+	return partners, nil
+}
+
+// ReplaceSessionPartners sets the partners tagged on a session. Newly tagged partners get a pending
+// invitation, untagged partners lose theirs, and partners who stay tagged keep their invitation
+// status and mirrored session
+func ReplaceSessionPartners(tx *gorm.DB, sessionID uint, partners []models.User) error {
+	partnerIDs := make([]uint, len(partners))
+	for i := range partners {
+		partnerIDs[i] = partners[i].ID
+	}
+
+	removed := tx.Where("training_session_id = ?", sessionID)
+	if len(partnerIDs) > 0 {
+		removed = removed.Where("user_id NOT IN ?", partnerIDs)
+	}
+	if err := removed.Delete(&models.TrainingSessionPartner{}).Error; err != nil {
+		return err
+	}
+	if len(partnerIDs) == 0 {
+		return nil
+	}
+
+	invitations := make([]models.TrainingSessionPartner, len(partnerIDs))
+	for i, partnerID := range partnerIDs {
+		invitations[i] = models.TrainingSessionPartner{
+			TrainingSessionID: sessionID,
+			UserID:            partnerID,
+			Status:            models.PartnerInvitationStatusPending,
+		}
+	}
+	return tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&invitations).Error
+}
+
+// FindBlockingUsers returns the IDs of the candidate partners who have blocked the user
+func FindBlockingUsers(tx *gorm.DB, userID uint, candidateIDs []uint) ([]uint, error) {
+	blockerIDs := []uint{}
+	if len(candidateIDs) == 0 {
+		return blockerIDs, nil
+	}
+
+	err := tx.Model(&models.UserBlock{}).
+		Where("blocked_id = ? AND blocker_id IN ?", userID, candidateIDs).
+		Pluck("blocker_id", &blockerIDs).Error
+	return blockerIDs, err
+}
+
+// DeclineInvitationsFromUser declines the pending partner invitations a user has received on
+// sessions owned by another user. Used when the invited user blocks the session owner.
+func DeclineInvitationsFromUser(tx *gorm.DB, invitedUserID, ownerID uint) error {
+	ownerSessions := tx.Model(&models.TrainingSession{}).Select("id").Where("user_id = ?", ownerID)
+	return tx.Model(&models.TrainingSessionPartner{}).
+		Where("user_id = ? AND status = ? AND training_session_id IN (?)", invitedUserID, models.PartnerInvitationStatusPending, ownerSessions).
+		Updates(map[string]interface{}{
+			"status":       models.PartnerInvitationStatusDeclined,
+			"responded_at": time.Now(),
+		}).Error
+}
@@ -0,0 +1,59 @@
+package services
+
+import (
+	"errors"
+
+	"gorm.io/gorm"
+
+	"github.com/jwallace145/crux-backend/models"
+)
+
+// ErrPlannedSessionUnavailable is returned when a planned session does not belong to the user
+// or has already been completed by another training session
+var ErrPlannedSessionUnavailable = errors.New("planned session not found or already completed")
+
+// LinkPlannedSession completes one of the user's planned sessions with a logged training session.
+// The update is guarded so two sessions can never complete the same plan.
+func LinkPlannedSession(tx *gorm.DB, userID, plannedSessionID, trainingSessionID uint) error {
+	result := tx.Model(&models.PlannedSession{}).
+		Where("id = ? AND user_id = ? AND training_session_id IS NULL", plannedSessionID, userID).
+		Update("training_session_id", trainingSessionID)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrPlannedSessionUnavailable
+	}
+	return nil
+}
+
+// SetPlannedSession links a training session to one of the user's open planned sessions, or
+// unlinks it when plannedSessionID is nil. Plans previously completed by the session are reopened
+func SetPlannedSession(tx *gorm.DB, userID, trainingSessionID uint, plannedSessionID *uint) error {
+	if plannedSessionID != nil {
+		var count int64
+		if err := tx.Model(&models.PlannedSession{}).
+			Where("id = ? AND user_id = ? AND training_session_id = ?", *plannedSessionID, userID, trainingSessionID).
+			Count(&count).Error; err != nil {
+			return err
+		}
+		if count > 0 {
+			return nil
+		}
+	}
+
+	if err := unlinkPlannedSessions(tx, trainingSessionID); err != nil {
+		return err
+	}
+	if plannedSessionID == nil {
+		return nil
+	}
+	return LinkPlannedSession(tx, userID, *plannedSessionID, trainingSessionID)
+}
+
+// unlinkPlannedSessions reopens plans completed by a training session that is being deleted
+func unlinkPlannedSessions(tx *gorm.DB, trainingSessionID uint) error {
+	return tx.Model(&models.PlannedSession{}).
+		Where("training_session_id = ?", trainingSessionID).
+		Update("training_session_id", nil).Error
+}
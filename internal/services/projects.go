@@ -0,0 +1,93 @@
+package services
+
+import (
+	"gorm.io/gorm"
+
+	"github.com/jwallace145/crux-backend/models"
+)
+
+// LinkClimbsToProjects sets ProjectID on climbs that reference a route or outdoor boulder the user
+// is projecting. Active projects are preferred over sent ones and abandoned projects are never linked.
+// An active project is marked sent when one of its linked climbs was completed.
+// Must be called before the climbs are created so the link is persisted with them.
+func LinkClimbsToProjects(tx *gorm.DB, userID uint, climbs []*models.Climb) error {
+	routeIDs := []uint{}
+	boulderIDs := []uint{}
+	for _, climb := range climbs {
+		if climb.RouteID != nil {
+			routeIDs = append(routeIDs, *climb.RouteID)
+		}
+		if climb.OutdoorBoulderID != nil {
+			boulderIDs = append(boulderIDs, *climb.OutdoorBoulderID)
+		}
+	}
+
+	if len(routeIDs) == 0 && len(boulderIDs) == 0 {
+		return nil
+	}
+
+	var projects []models.Project
+	if err := tx.
+		Where("user_id = ? AND status <> ?", userID, models.ProjectStatusAbandoned).
+		Where(tx.Where("route_id IN ?", routeIDs).Or("outdoor_boulder_id IN ?", boulderIDs)).
+		Order("created_at DESC").
+		Find(&projects).Error; err != nil {
+		return err
+	}
+
+	if len(projects) == 0 {
+		return nil
+	}
+
+	// Index the best project for each target, preferring active over sent
+	byRoute := map[uint]*models.Project{}
+	byBoulder := map[uint]*models.Project{}
+	for i := range projects {
+		project := &projects[i]
+		if project.RouteID != nil {
+			if existing, ok := byRoute[*project.RouteID]; !ok || (!existing.IsActive() && project.IsActive()) {
+				byRoute[*project.RouteID] = project
+			}
+		}
+		if project.OutdoorBoulderID != nil {
+			if existing, ok := byBoulder[*project.OutdoorBoulderID]; !ok || (!existing.IsActive() && project.IsActive()) {
+				byBoulder[*project.OutdoorBoulderID] = project
+			}
+		}
+	}
+
+	sent := map[uint]*models.Project{}
+	for _, climb := range climbs {
+		var project *models.Project
+		if climb.RouteID != nil {
+			project = byRoute[*climb.RouteID]
+		} else if climb.OutdoorBoulderID != nil {
+			project = byBoulder[*climb.OutdoorBoulderID]
+		}
+		if project == nil {
+			continue
+		}
+
+		climb.ProjectID = &project.ID
+
+		// Record the earliest completed climb as the send
+		if climb.Completed && project.IsActive() {
+			if project.SentAt == nil || climb.ClimbDate.Before(*project.SentAt) {
+				sentAt := climb.ClimbDate
+				project.SentAt = &sentAt
+			}
+			sent[project.ID] = project
+		}
+	}
+
+	for _, project := range sent {
+		if err := tx.Model(project).Updates(map[string]interface{}{
+			"status":  models.ProjectStatusSent,
+			"sent_at": project.SentAt,
+		}).Error; err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,121 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+	"gorm.io/gorm"
+
+	"github.com/jwallace145/crux-backend/internal/db"
+	"github.com/jwallace145/crux-backend/internal/utils"
+	"github.com/jwallace145/crux-backend/models"
+)
+
+// idleSessionSweepInterval is how often open live sessions are checked for the idle timeout
+const idleSessionSweepInterval = 5 * time.Minute
+
+// closeIdleSessionsSQL ends live sessions whose last climb (or start, if no climbs were logged)
+// is older than the cutoff. The end time is set to the last activity rather than the sweep time
+// so the duration is not inflated by the idle period.
+const closeIdleSessionsSQL = `
+	UPDATE training_sessions ts
+	SET ended_at = activity.last_activity_at, auto_closed = true, updated_at = @now
+	FROM (
+		SELECT s.id, GREATEST(s.started_at, MAX(rc.created_at), MAX(ib.created_at)) AS last_activity_at
+		FROM training_sessions s
+		LEFT JOIN rope_climbs rc ON rc.training_session_id = s.id AND rc.deleted_at IS NULL
+		LEFT JOIN indoor_boulders ib ON ib.training_session_id = s.id AND ib.deleted_at IS NULL
+		WHERE s.started_at IS NOT NULL AND s.ended_at IS NULL AND s.deleted_at IS NULL
+		GROUP BY s.id, s.started_at
+	) activity
+	WHERE ts.id = activity.id AND activity.last_activity_at < @cutoff`
+
+// checkOutClosedSessionsSQL checks out the check-ins of live sessions that have ended
+const checkOutClosedSessionsSQL = `
+	UPDATE gym_check_ins ci
+	SET checked_out_at = ts.ended_at, updated_at = @now
+	FROM training_sessions ts
+	WHERE ci.training_session_id = ts.id AND ci.checked_out_at IS NULL AND ts.ended_at IS NOT NULL`
+
+// DeleteTrainingSession soft deletes a training session along with its rope climbs, indoor boulders,
+// their attempts, exercises and media attachments using the given transaction. Planned sessions it completed are reopened.
+// It returns the S3 keys of the deleted media; call DeleteMediaObjects with the keys once the transaction has committed.
+func DeleteTrainingSession(tx *gorm.DB, session *models.TrainingSession) ([]string, error) {
+	keys, err := DeleteParentMedia(tx, models.MediaParentTypeTrainingSession, session.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := DeleteSessionClimbAttempts(tx, session.ID); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Where("training_session_id = ?", session.ID).Delete(&models.RopeClimb{}).Error; err != nil {
+		return nil, err
+	}
+
+	if err := tx.Where("training_session_id = ?", session.ID).Delete(&models.IndoorBoulder{}).Error; err != nil {
+		return nil, err
+	}
+
+	if err := tx.Where("training_session_id = ?", session.ID).Delete(&models.Exercise{}).Error; err != nil {
+		return nil, err
+	}
+
+	if err := unlinkPlannedSessions(tx, session.ID); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Delete(session).Error; err != nil {
+		return nil, err
+	}
+
+	return keys, nil
+}
+
+// CloseIdleTrainingSessions automatically finishes live sessions that have had no activity for
+// longer than the idle timeout, checks their users out of the gym and returns the number of
+// sessions closed
+func CloseIdleTrainingSessions(tx *gorm.DB, idleTimeout time.Duration) (int64, error) {
+	now := time.Now()
+	result := tx.Exec(closeIdleSessionsSQL, map[string]interface{}{
+		"now":    now,
+		"cutoff": now.Add(-idleTimeout),
+	})
+	if result.Error != nil {
+		return 0, result.Error
+	}
+	if err := tx.Exec(checkOutClosedSessionsSQL, map[string]interface{}{"now": now}).Error; err != nil {
+		return result.RowsAffected, err
+	}
+	return result.RowsAffected, nil
+}
+
+// StartIdleSessionCloser periodically closes idle live sessions in the background until ctx is done
+func StartIdleSessionCloser(ctx context.Context, idleTimeout time.Duration) {
+	go func() {
+		ticker := time.NewTicker(idleSessionSweepInterval)
+		defer ticker.Stop()
+
+		for {
+			closed, err := CloseIdleTrainingSessions(db.DB, idleTimeout)
+			if err != nil {
+				utils.Log.Error("Failed to close idle training sessions",
+					zap.Error(err),
+				)
+			} else if closed > 0 {
+				utils.Log.Info("Closed idle training sessions",
+					zap.Int64("closed", closed),
+					zap.Duration("idle_timeout", idleTimeout),
+				)
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
@@ -0,0 +1,17 @@
+package services
+
+import (
+	"gorm.io/gorm"
+
+	"github.com/jwallace145/crux-backend/models"
+)
+
+// IsAdmin returns true if the user has the admin role. The role is read from the db rather than
+// the access token so that promoting or demoting a user takes effect immediately
+func IsAdmin(tx *gorm.DB, userID uint) (bool, error) {
+	var count int64
+	err := tx.Model(&models.User{}).
+		Where("id = ? AND role = ?", userID, models.UserRoleAdmin).
+		Count(&count).Error
+	return count > 0, err
+}
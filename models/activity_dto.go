@@ -0,0 +1,34 @@
+package models
+
+import (
+	"time"
+)
+
+// ActivityType constants for the source of an activity feed item
+const (
+	ActivityTypeClimb         = "climb"          // Free-standing Climb log entry
+	ActivityTypeRopeClimb     = "rope_climb"     // RopeClimb logged in a training session
+	ActivityTypeIndoorBoulder = "indoor_boulder" // IndoorBoulder logged in a training session
+)
+
+// ActivityItemResponse represents a single climb in the unified activity feed,
+// normalized across Climb, RopeClimb and IndoorBoulder
+type ActivityItemResponse struct {
+	ID       string    `json:"id"`        // Unique across sources (e.g., "climb:12", "rope_climb:40")
+	Type     string    `json:"type"`      // climb, rope_climb, indoor_boulder
+	SourceID uint      `json:"source_id"` // ID of the underlying Climb, RopeClimb or IndoorBoulder
+	Date     time.Time `json:"date"`      // Climb date, or session date for training session climbs
+
+	Grade     string `json:"grade"`
+	Style     string `json:"style,omitempty"`   // Climb style, TR/Lead for rope climbs, boulder for indoor boulders
+	Outcome   string `json:"outcome,omitempty"` // Fell, Hung, Flash, Onsite, Redpoint - training session climbs only
+	Completed bool   `json:"completed"`         // Climb completed, or outcome was a send
+
+	// Where the climb happened
+	Location          string `json:"location,omitempty"` // Gym or crag name
+	GymID             *uint  `json:"gym_id,omitempty"`
+	RouteID           *uint  `json:"route_id,omitempty"`
+	TrainingSessionID *uint  `json:"training_session_id,omitempty"`
+
+	Notes string `json:"notes,omitempty"`
+}
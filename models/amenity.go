@@ -0,0 +1,118 @@
+package models
+
+import (
+	"regexp"
+	"sort"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Amenity category constants, in display order
+const (
+	AmenityCategoryClimbing   = "climbing"
+	AmenityCategoryTraining   = "training"
+	AmenityCategoryFacilities = "facilities"
+	AmenityCategoryServices   = "services"
+)
+
+// MaxGymAmenities is the number of amenities a gym can list
+const MaxGymAmenities = 50
+
+// amenityCategories lists the accepted categories in display order
+var amenityCategories = []string{
+	AmenityCategoryClimbing,
+	AmenityCategoryTraining,
+	AmenityCategoryFacilities,
+	AmenityCategoryServices,
+}
+
+// amenitySlugPattern matches lowercase snake_case slugs such as "spray_wall"
+var amenitySlugPattern = regexp.MustCompile(`^[a-z0-9]+(_[a-z0-9]+)*$`)
+
+// Amenity is an entry in the catalog of features a gym can offer, e.g. a spray wall or a sauna.
+// Admins extend the catalog without a schema change and gyms link to it through GymAmenity
+type Amenity struct {
+	gorm.Model
+
+	Slug     string `gorm:"size:50;not null;uniqueIndex" json:"slug"` // Used in requests and filters, e.g. "spray_wall"
+	Name     string `gorm:"size:100;not null" json:"name"`
+	Category string `gorm:"size:30;not null;index" json:"category"`
+}
+
+// GymAmenity is the join table between gyms and the amenities they offer
+type GymAmenity struct {
+	GymID     uint      `gorm:"primaryKey" json:"gym_id"`
+	AmenityID uint      `gorm:"primaryKey;index" json:"amenity_id"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// LegacyAmenitySlugs are the amenities that replaced the Gym Has* columns, in column order
+var LegacyAmenitySlugs = []string{
+	"bouldering",
+	"top_rope",
+	"lead",
+	"auto_belay",
+	"kids_area",
+	"training_area",
+	"yoga",
+	"shower",
+	"parking",
+	"gear_rental",
+	"pro_shop",
+	"cafe",
+}
+
+// DefaultAmenities is the built-in catalog seeded on startup. It includes every legacy amenity
+var DefaultAmenities = []Amenity{
+	{Slug: "bouldering", Name: "Bouldering", Category: AmenityCategoryClimbing},
+	{Slug: "top_rope", Name: "Top Rope", Category: AmenityCategoryClimbing},
+	{Slug: "lead", Name: "Lead Climbing", Category: AmenityCategoryClimbing},
+	{Slug: "auto_belay", Name: "Auto Belay", Category: AmenityCategoryClimbing},
+	{Slug: "spray_wall", Name: "Spray Wall", Category: AmenityCategoryClimbing},
+	{Slug: "system_board", Name: "System Board", Category: AmenityCategoryClimbing}, // Moon, Kilter, Tension
+	{Slug: "speed_wall", Name: "Speed Wall", Category: AmenityCategoryClimbing},
+	{Slug: "training_area", Name: "Training Area", Category: AmenityCategoryTraining},
+	{Slug: "fitness", Name: "Fitness Area", Category: AmenityCategoryTraining},
+	{Slug: "yoga", Name: "Yoga Classes", Category: AmenityCategoryTraining},
+	{Slug: "kids_area", Name: "Kids Area", Category: AmenityCategoryFacilities},
+	{Slug: "shower", Name: "Showers", Category: AmenityCategoryFacilities},
+	{Slug: "sauna", Name: "Sauna", Category: AmenityCategoryFacilities},
+	{Slug: "lockers", Name: "Lockers", Category: AmenityCategoryFacilities},
+	{Slug: "parking", Name: "Parking", Category: AmenityCategoryFacilities},
+	{Slug: "wifi", Name: "Wi-Fi", Category: AmenityCategoryFacilities},
+	{Slug: "gear_rental", Name: "Gear Rental", Category: AmenityCategoryServices},
+	{Slug: "pro_shop", Name: "Pro Shop", Category: AmenityCategoryServices},
+	{Slug: "cafe", Name: "Cafe", Category: AmenityCategoryServices},
+}
+
+// IsValidAmenitySlug returns true if the slug is lowercase snake_case and at most 50 characters
+func IsValidAmenitySlug(slug string) bool {
+	return len(slug) <= 50 && amenitySlugPattern.MatchString(slug)
+}
+
+// IsValidAmenityCategory returns true if the category is a known amenity category
+func IsValidAmenityCategory(category string) bool {
+	return amenityCategoryOrder(category) >= 0
+}
+
+// SortAmenities orders amenities by category and then name
+func SortAmenities(amenities []Amenity) {
+	sort.SliceStable(amenities, func(i, j int) bool {
+		ci, cj := amenityCategoryOrder(amenities[i].Category), amenityCategoryOrder(amenities[j].Category)
+		if ci != cj {
+			return ci < cj
+		}
+		return amenities[i].Name < amenities[j].Name
+	})
+}
+
+// amenityCategoryOrder returns the display position of a category, or -1 if it is unknown
+func amenityCategoryOrder(category string) int {
+	for i, c := range amenityCategories {
+		if c == category {
+			return i
+		}
+	}
+	return -1
+}
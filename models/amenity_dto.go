@@ -0,0 +1,132 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+)
+
+// CreateAmenityRequest represents the request body for adding an amenity to the catalog
+type CreateAmenityRequest struct {
+	Slug     string `json:"slug"` // Lowercase snake_case, e.g. "spray_wall"
+	Name     string `json:"name"`
+	Category string `json:"category"` // climbing, training, facilities, services
+}
+
+// AmenityResponse represents an amenity returned in API responses
+type AmenityResponse struct {
+	ID       uint   `json:"id"`
+	Slug     string `json:"slug"`
+	Name     string `json:"name"`
+	Category string `json:"category"`
+}
+
+// ToAmenityResponse converts an Amenity model to an AmenityResponse DTO
+func (a *Amenity) ToAmenityResponse() AmenityResponse {
+	return AmenityResponse{
+		ID:       a.ID,
+		Slug:     a.Slug,
+		Name:     a.Name,
+		Category: a.Category,
+	}
+}
+
+// ToAmenityResponses converts amenities into responses ordered by category and name
+func ToAmenityResponses(amenities []Amenity) []AmenityResponse {
+	sorted := append([]Amenity{}, amenities...)
+	SortAmenities(sorted)
+
+	responses := make([]AmenityResponse, len(sorted))
+	for i := range sorted {
+		responses[i] = sorted[i].ToAmenityResponse()
+	}
+	return responses
+}
+
+// NormalizeAmenitySlugs trims, lowercases and deduplicates amenity slugs, checking their format.
+// Whether the amenities exist in the catalog is checked separately
+func NormalizeAmenitySlugs(slugs []string) ([]string, error) {
+	normalized := make([]string, 0, len(slugs))
+	seen := map[string]bool{}
+	for _, slug := range slugs {
+		slug = strings.ToLower(strings.TrimSpace(slug))
+		if slug == "" || seen[slug] {
+			continue
+		}
+		if !IsValidAmenitySlug(slug) {
+			return nil, fmt.Errorf("amenity %q must be a lowercase snake_case slug (e.g., spray_wall)", slug)
+		}
+		seen[slug] = true
+		normalized = append(normalized, slug)
+	}
+	if len(normalized) > MaxGymAmenities {
+		return nil, fmt.Errorf("a gym can have at most %d amenities", MaxGymAmenities)
+	}
+	return normalized, nil
+}
+
+// AmenitySlugs returns the requested amenities together with the ones set by the legacy has_*
+// flags, normalized and deduplicated
+func (r *CreateGymRequest) AmenitySlugs() ([]string, error) {
+	legacy := legacyAmenitySlugs([]bool{
+		r.HasBouldering, r.HasTopRope, r.HasLeadClimbing, r.HasAutoBelay, r.HasKidsArea, r.HasTrainingArea,
+		r.HasYogaClasses, r.HasShower, r.HasParking, r.HasGearRental, r.HasProShop, r.HasCafe,
+	})
+	return NormalizeAmenitySlugs(append(append([]string{}, r.Amenities...), legacy...))
+}
+
+// ChangesAmenities returns true if the update sets the amenities or any legacy has_* flag
+func (r *UpdateGymRequest) ChangesAmenities() bool {
+	if r.Amenities != nil {
+		return true
+	}
+	for _, flag := range r.legacyAmenityFlags() {
+		if flag != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// ApplyAmenities returns the gym's amenity slugs after the update. The amenities list replaces the
+// current ones when present, then each legacy has_* flag present adds or removes its amenity
+func (r *UpdateGymRequest) ApplyAmenities(current []string) []string {
+	slugs := current
+	if r.Amenities != nil {
+		slugs = *r.Amenities
+	}
+
+	for i, flag := range r.legacyAmenityFlags() {
+		if flag == nil {
+			continue
+		}
+		slug := LegacyAmenitySlugs[i]
+		kept := make([]string, 0, len(slugs)+1)
+		for _, s := range slugs {
+			if strings.ToLower(strings.TrimSpace(s)) != slug {
+				kept = append(kept, s)
+			}
+		}
+		if *flag {
+			kept = append(kept, slug)
+		}
+		slugs = kept
+	}
+	return slugs
+}
+
+// legacyAmenityFlags returns the legacy has_* flags of the update in LegacyAmenitySlugs order
+func (r *UpdateGymRequest) legacyAmenityFlags() []*bool {
+	return []*bool{
+		r.HasBouldering, r.HasTopRope, r.HasLeadClimbing, r.HasAutoBelay, r.HasKidsArea, r.HasTrainingArea,
+		r.HasYogaClasses, r.HasShower, r.HasParking, r.HasGearRental, r.HasProShop, r.HasCafe,
+	}
+}
+
+// AmenitySlugs returns the slugs of the gym's amenities. Amenities must be preloaded
+func (g *Gym) AmenitySlugs() []string {
+	slugs := make([]string, len(g.Amenities))
+	for i, amenity := range g.Amenities {
+		slugs[i] = amenity.Slug
+	}
+	return slugs
+}
@@ -63,7 +63,9 @@ const (
 	ErrorCodeNotFound       = "NOT_FOUND"
 	ErrorCodeUnauthorized   = "UNAUTHORIZED"
 	ErrorCodeForbidden      = "FORBIDDEN"
+	ErrorCodeConflict       = "CONFLICT"
 	ErrorCodeInternalError  = "INTERNAL_ERROR"
 	ErrorCodeDatabaseError  = "DATABASE_ERROR"
 	ErrorCodeValidationFail = "VALIDATION_FAILED"
+	ErrorCodePayloadTooBig  = "PAYLOAD_TOO_LARGE"
 )
@@ -35,10 +35,18 @@ type Climb struct {
 	RouteID *uint  `gorm:"index" json:"route_id,omitempty"`
 	Route   *Route `gorm:"constraint:OnUpdate:CASCADE,OnDelete:SET NULL;" json:"route,omitempty"`
 
+	// Outdoor boulder relationship - optional, only for outdoor climbs linked to a specific boulder problem
+	OutdoorBoulderID *uint           `gorm:"index" json:"outdoor_boulder_id,omitempty"`
+	OutdoorBoulder   *OutdoorBoulder `gorm:"constraint:OnUpdate:CASCADE,OnDelete:SET NULL;" json:"outdoor_boulder,omitempty"`
+
 	// Gym relationship - optional, only for indoor climbs linked to a specific gym
 	GymID *uint `gorm:"index" json:"gym_id,omitempty"`
 	Gym   *Gym  `gorm:"constraint:OnUpdate:CASCADE,OnDelete:SET NULL;" json:"gym,omitempty"`
 
+	// Project relationship - set automatically when the user is projecting the route or boulder
+	ProjectID *uint    `gorm:"index" json:"project_id,omitempty"`
+	Project   *Project `gorm:"constraint:OnUpdate:CASCADE,OnDelete:SET NULL;" json:"-"`
+
 	// Climb type - indoor or outdoor
 	ClimbType string `gorm:"size:20;not null;index" json:"climb_type"` // "indoor" or "outdoor"
 
@@ -55,6 +63,9 @@ type Climb struct {
 	// Personal rating and notes
 	Rating int    `gorm:"default:0" json:"rating,omitempty"` // Personal rating 1-5 stars
 	Notes  string `gorm:"type:text" json:"notes,omitempty"`  // Personal notes about the climb
+
+	// Photo and video attachments
+	Media []MediaAttachment `gorm:"polymorphic:Parent;polymorphicValue:climb" json:"media,omitempty"`
 }
 
 // IsIndoor returns true if this is an indoor gym climb
@@ -72,6 +83,11 @@ func (c *Climb) HasRoute() bool {
 	return c.RouteID != nil
 }
 
+// HasOutdoorBoulder returns true if this climb is linked to a specific outdoor boulder
+func (c *Climb) HasOutdoorBoulder() bool {
+	return c.OutdoorBoulderID != nil
+}
+
 // HasGym returns true if this climb is linked to a specific gym
 func (c *Climb) HasGym() bool {
 	return c.GymID != nil
@@ -0,0 +1,72 @@
+package models
+
+import (
+	"sort"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Climb attempt result constants
+const (
+	AttemptResultFell   = "fell"   // Came off before the top
+	AttemptResultHung   = "hung"   // Weighted the rope, rope climbs only
+	AttemptResultTopped = "topped" // Reached the top without falling or hanging
+)
+
+// Climb attempt parent type constants
+const (
+	AttemptParentTypeIndoorBoulder = "indoor_boulder"
+	AttemptParentTypeRopeClimb     = "rope_climb"
+)
+
+// MaxClimbAttempts is the maximum number of attempts that can be logged on a single climb
+const MaxClimbAttempts = 100
+
+// ClimbAttempt represents a single go on an indoor boulder or rope climb. Attempts are numbered in
+// the order they were made and the outcome of the climb is derived from them
+type ClimbAttempt struct {
+	gorm.Model
+
+	// Climb the attempt belongs to (indoor_boulder or rope_climb)
+	ParentType string `gorm:"size:20;not null;index:idx_climb_attempt_parent" json:"parent_type"`
+	ParentID   uint   `gorm:"not null;index:idx_climb_attempt_parent" json:"parent_id"`
+
+	// Attempt details
+	AttemptNumber    int        `gorm:"not null" json:"attempt_number"` // 1 for the first attempt
+	AttemptedAt      *time.Time `json:"attempted_at,omitempty"`
+	Result           string     `gorm:"size:20;not null" json:"result"` // fell, hung or topped
+	HighPointPercent *int       `json:"high_point_percent,omitempty"`   // How far up the climb the attempt got, 0-100
+}
+
+// IsTopped returns true if the attempt reached the top cleanly
+func (ca *ClimbAttempt) IsTopped() bool {
+	return ca.Result == AttemptResultTopped
+}
+
+// sortAttempts returns the attempts ordered by attempt number
+func sortAttempts(attempts []ClimbAttempt) []ClimbAttempt {
+	sorted := append([]ClimbAttempt{}, attempts...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].AttemptNumber < sorted[j].AttemptNumber })
+	return sorted
+}
+
+// attemptsToSend returns the number of the first topped attempt, or 0 if the climb was not sent
+func attemptsToSend(attempts []ClimbAttempt) int {
+	for _, attempt := range sortAttempts(attempts) {
+		if attempt.IsTopped() {
+			return attempt.AttemptNumber
+		}
+	}
+	return 0
+}
+
+// hasHungAttempt returns true if any attempt weighted the rope
+func hasHungAttempt(attempts []ClimbAttempt) bool {
+	for _, attempt := range attempts {
+		if attempt.Result == AttemptResultHung {
+			return true
+		}
+	}
+	return false
+}
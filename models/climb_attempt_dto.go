@@ -0,0 +1,26 @@
+package models
+
+import (
+	"time"
+)
+
+// AttemptsToSendGradeResponse summarizes the attempts logged on climbs of one type and grade
+// Only climbs with attempts logged are included
+type AttemptsToSendGradeResponse struct {
+	Type                  string   `json:"type"` // indoor_boulder or rope_climb
+	Grade                 string   `json:"grade"`
+	Climbs                int      `json:"climbs"`
+	Sends                 int      `json:"sends"`
+	FirstAttemptSends     int      `json:"first_attempt_sends"`
+	TotalAttempts         int      `json:"total_attempts"`
+	SendRate              float64  `json:"send_rate"` // Percentage of climbs sent
+	AverageAttemptsToSend *float64 `json:"average_attempts_to_send,omitempty"`
+	MaxAttemptsToSend     *int     `json:"max_attempts_to_send,omitempty"`
+}
+
+// AttemptsToSendResponse represents attempts-to-send analytics by grade for a date range
+type AttemptsToSendResponse struct {
+	StartDate time.Time                     `json:"start_date"`
+	EndDate   time.Time                     `json:"end_date"`
+	Grades    []AttemptsToSendGradeResponse `json:"grades"`
+}
@@ -12,8 +12,9 @@ type CreateClimbRequest struct {
 	Grade     string    `json:"grade" validate:"required,min=1,max=20"`
 
 	// Optional relationship IDs
-	RouteID *uint `json:"route_id,omitempty"` // For outdoor climbs linked to a route
-	GymID   *uint `json:"gym_id,omitempty"`   // For indoor climbs linked to a gym
+	RouteID          *uint `json:"route_id,omitempty"`           // For outdoor climbs linked to a route
+	OutdoorBoulderID *uint `json:"outdoor_boulder_id,omitempty"` // For outdoor climbs linked to a boulder problem
+	GymID            *uint `json:"gym_id,omitempty"`             // For indoor climbs linked to a gym
 
 	// Optional details
 	Style string `json:"style,omitempty" validate:"omitempty,max=50"`
@@ -38,8 +39,10 @@ type ClimbResponse struct {
 	Style     string    `json:"style,omitempty"`
 
 	// Optional relationships
-	RouteID *uint `json:"route_id,omitempty"`
-	GymID   *uint `json:"gym_id,omitempty"`
+	RouteID          *uint `json:"route_id,omitempty"`
+	OutdoorBoulderID *uint `json:"outdoor_boulder_id,omitempty"`
+	GymID            *uint `json:"gym_id,omitempty"`
+	ProjectID        *uint `json:"project_id,omitempty"`
 
 	// Performance
 	Completed bool `json:"completed"`
@@ -50,6 +53,9 @@ type ClimbResponse struct {
 	Rating int    `json:"rating,omitempty"`
 	Notes  string `json:"notes,omitempty"`
 
+	// Photo and video attachments with presigned URLs
+	Media []MediaAttachmentResponse `json:"media,omitempty"`
+
 	// Metadata
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
@@ -58,20 +64,58 @@ type ClimbResponse struct {
 // ToClimbResponse converts a Climb model to a ClimbResponse DTO
 func (c *Climb) ToClimbResponse() *ClimbResponse {
 	return &ClimbResponse{
-		ID:        c.ID,
-		UserID:    c.UserID,
-		ClimbType: c.ClimbType,
-		ClimbDate: c.ClimbDate,
-		Grade:     c.Grade,
-		Style:     c.Style,
-		RouteID:   c.RouteID,
-		GymID:     c.GymID,
-		Completed: c.Completed,
-		Attempts:  c.Attempts,
-		Falls:     c.Falls,
-		Rating:    c.Rating,
-		Notes:     c.Notes,
-		CreatedAt: c.CreatedAt,
-		UpdatedAt: c.UpdatedAt,
+		ID:               c.ID,
+		UserID:           c.UserID,
+		ClimbType:        c.ClimbType,
+		ClimbDate:        c.ClimbDate,
+		Grade:            c.Grade,
+		Style:            c.Style,
+		RouteID:          c.RouteID,
+		OutdoorBoulderID: c.OutdoorBoulderID,
+		GymID:            c.GymID,
+		ProjectID:        c.ProjectID,
+		Completed:        c.Completed,
+		Attempts:         c.Attempts,
+		Falls:            c.Falls,
+		Rating:           c.Rating,
+		Notes:            c.Notes,
+		CreatedAt:        c.CreatedAt,
+		UpdatedAt:        c.UpdatedAt,
 	}
 }
+
+// BatchMode constants for how a batch create request handles failing items
+const (
+	BatchModeAllOrNothing = "all_or_nothing" // Any failing item rejects the whole batch
+	BatchModePartial      = "partial"        // Valid items are created even if others fail
+)
+
+// BatchItemStatus constants for the outcome of a single batch item
+const (
+	BatchItemStatusCreated = "created"
+	BatchItemStatusError   = "error"
+)
+
+// BatchCreateClimbsRequest represents the request body for logging multiple climbs at once
+type BatchCreateClimbsRequest struct {
+	// Mode is "all_or_nothing" (default) or "partial"
+	Mode   string               `json:"mode,omitempty" validate:"omitempty,oneof=all_or_nothing partial"`
+	Climbs []CreateClimbRequest `json:"climbs" validate:"required,min=1"`
+}
+
+// BatchClimbResult represents the outcome of a single item in a batch create request
+type BatchClimbResult struct {
+	Index  int            `json:"index"`
+	Status string         `json:"status"`
+	Climb  *ClimbResponse `json:"climb,omitempty"`
+	Error  *APIError      `json:"error,omitempty"`
+}
+
+// BatchCreateClimbsResponse represents the per-item results of a batch create request
+type BatchCreateClimbsResponse struct {
+	Mode      string             `json:"mode"`
+	Total     int                `json:"total"`
+	Succeeded int                `json:"succeeded"`
+	Failed    int                `json:"failed"`
+	Results   []BatchClimbResult `json:"results"`
+}
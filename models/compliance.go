@@ -0,0 +1,309 @@
+package models
+
+import (
+	"math"
+	"sort"
+	"strings"
+)
+
+// ComplianceItemResponse compares one workout template item with what was logged
+type ComplianceItemResponse struct {
+	ItemID         uint    `json:"item_id"`
+	Kind           string  `json:"kind"`
+	Planned        int     `json:"planned"`      // Planned climbs, or total reps for exercises
+	Actual         int     `json:"actual"`       // Logged climbs or exercise reps assigned to the item
+	AtIntensity    int     `json:"at_intensity"` // Logged climbs or reps meeting the planned grade, weight, edge and hang time
+	VolumeScore    float64 `json:"volume_score"`
+	IntensityScore float64 `json:"intensity_score"`
+}
+
+// ComplianceResponse scores a logged training session against its planned workout.
+// Scores range from 0 to 100; the overall score is the mean of the volume and intensity scores
+type ComplianceResponse struct {
+	Score          float64                  `json:"score"`
+	VolumeScore    float64                  `json:"volume_score"`
+	IntensityScore float64                  `json:"intensity_score"`
+	Items          []ComplianceItemResponse `json:"items"`
+}
+
+// ComputeCompliance scores the climbs and exercises of a training session against the items of
+// a workout template. Each logged climb or exercise counts toward at most one item (see
+// assignClimbs and assignExercises), and actual volume beyond the plan does not raise the score
+// above 100
+func ComputeCompliance(items []WorkoutTemplateItem, session *TrainingSession) *ComplianceResponse {
+	response := &ComplianceResponse{Items: []ComplianceItemResponse{}}
+
+	boulderGrades := make([]string, len(session.IndoorBoulders))
+	for i, boulder := range session.IndoorBoulders {
+		boulderGrades[i] = boulder.Grade
+	}
+	ropeClimbGrades := make([]string, len(session.RopeClimbs))
+	for i, ropeClimb := range session.RopeClimbs {
+		ropeClimbGrades[i] = ropeClimb.Grade
+	}
+	boulders := assignClimbs(items, WorkoutItemKindIndoorBoulder, boulderGrades)
+	ropeClimbs := assignClimbs(items, WorkoutItemKindRopeClimb, ropeClimbGrades)
+	exercises := assignExercises(items, session.Exercises)
+
+	var totalVolume, totalIntensity float64
+	for i := range items {
+		item := &items[i]
+		planned := item.GetPlannedVolume()
+		if planned <= 0 {
+			continue
+		}
+
+		var actual, atIntensity int
+		switch item.Kind {
+		case WorkoutItemKindIndoorBoulder:
+			actual, atIntensity = boulders[i].actual, boulders[i].atIntensity
+		case WorkoutItemKindRopeClimb:
+			actual, atIntensity = ropeClimbs[i].actual, ropeClimbs[i].atIntensity
+		case WorkoutItemKindExercise:
+			actual, atIntensity = exercises[i].actual, exercises[i].atIntensity
+		}
+
+		result := ComplianceItemResponse{
+			ItemID:         item.ID,
+			Kind:           item.Kind,
+			Planned:        planned,
+			Actual:         actual,
+			AtIntensity:    atIntensity,
+			VolumeScore:    complianceRatio(actual, planned),
+			IntensityScore: complianceRatio(atIntensity, planned),
+		}
+		totalVolume += result.VolumeScore
+		totalIntensity += result.IntensityScore
+		response.Items = append(response.Items, result)
+	}
+
+	if len(response.Items) > 0 {
+		count := float64(len(response.Items))
+		response.VolumeScore = roundScore(totalVolume / count)
+		response.IntensityScore = roundScore(totalIntensity / count)
+		response.Score = roundScore((totalVolume + totalIntensity) / (2 * count))
+	}
+
+	return response
+}
+
+// complianceAssignment counts the logged climbs or reps assigned to one workout template item
+type complianceAssignment struct {
+	actual      int
+	atIntensity int
+}
+
+// assignClimbs assigns each logged climb grade to at most one item of the given kind, keyed by
+// item index. Items are filled in order of their target grade, hardest first and ungraded last,
+// taking the easiest climbs that meet their grade first (see assignLogged)
+func assignClimbs(items []WorkoutTemplateItem, kind string, grades []string) map[int]*complianceAssignment {
+	var order []int
+	for i := range items {
+		if items[i].Kind == kind && items[i].GetPlannedVolume() > 0 {
+			order = append(order, i)
+		}
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		return gradeRank(items[order[a]].Grade) > gradeRank(items[order[b]].Grade)
+	})
+
+	// Easiest climbs first, so harder climbs stay available for the items that need them
+	climbs := make([]int, len(grades))
+	for i := range climbs {
+		climbs[i] = i
+	}
+	sort.SliceStable(climbs, func(a, b int) bool {
+		return gradeRank(grades[climbs[a]]) < gradeRank(grades[climbs[b]])
+	})
+
+	return assignLogged(items, order, climbs,
+		func(item *WorkoutTemplateItem, climb int) bool { return true },
+		func(item *WorkoutTemplateItem, climb int) bool { return meetsGrade(grades[climb], item.Grade) },
+		func(climb int) int { return 1 },
+	)
+}
+
+// assignExercises assigns each logged exercise, with all of its reps, to at most one exercise
+// item it matches, keyed by item index. Items naming an exercise are filled before items that
+// only set its type, then the most demanding first: heaviest added weight, longest hang time and
+// smallest edge (see assignLogged)
+func assignExercises(items []WorkoutTemplateItem, logged []Exercise) map[int]*complianceAssignment {
+	var order []int
+	for i := range items {
+		if items[i].Kind == WorkoutItemKindExercise && items[i].GetPlannedVolume() > 0 {
+			order = append(order, i)
+		}
+	}
+	sort.SliceStable(order, func(a, b int) bool {
+		return moreDemandingExerciseItem(&items[order[a]], &items[order[b]])
+	})
+
+	exercises := make([]int, len(logged))
+	for i := range exercises {
+		exercises[i] = i
+	}
+
+	return assignLogged(items, order, exercises,
+		func(item *WorkoutTemplateItem, exercise int) bool { return matchesExercise(&logged[exercise], item) },
+		func(item *WorkoutTemplateItem, exercise int) bool {
+			return meetsExerciseIntensity(&logged[exercise], item)
+		},
+		func(exercise int) int { return logged[exercise].GetTotalReps() },
+	)
+}
+
+// assignLogged assigns each logged climb or exercise to at most one of the items, keyed by item
+// index. Items are visited in the given order, and the logged entries in theirs. Each item first
+// takes the unassigned entries it matches at its planned intensity, then any unassigned entries it
+// matches, until its planned volume is reached. Entries left over once every item is full count
+// toward the first item whose intensity they meet, or else the first item they match. Entries
+// matching no item are not counted
+func assignLogged(items []WorkoutTemplateItem, order, logged []int,
+	matches, meets func(item *WorkoutTemplateItem, entry int) bool, volume func(entry int) int) map[int]*complianceAssignment {
+	assignments := make(map[int]*complianceAssignment, len(order))
+	for _, i := range order {
+		assignments[i] = &complianceAssignment{}
+	}
+	assigned := make(map[int]bool, len(logged))
+
+	assign := func(i, entry int) {
+		assigned[entry] = true
+		assignments[i].actual += volume(entry)
+		if meets(&items[i], entry) {
+			assignments[i].atIntensity += volume(entry)
+		}
+	}
+
+	// Fill each item at its intensity first, then with whatever it matches
+	for _, atIntensity := range []bool{true, false} {
+		for _, i := range order {
+			item := &items[i]
+			for _, entry := range logged {
+				if assignments[i].actual >= item.GetPlannedVolume() {
+					break
+				}
+				if !assigned[entry] && matches(item, entry) && (!atIntensity || meets(item, entry)) {
+					assign(i, entry)
+				}
+			}
+		}
+	}
+
+	for _, entry := range logged {
+		if assigned[entry] {
+			continue
+		}
+		target := -1
+		for _, i := range order {
+			if !matches(&items[i], entry) {
+				continue
+			}
+			if meets(&items[i], entry) {
+				target = i
+				break
+			}
+			if target == -1 {
+				target = i
+			}
+		}
+		if target != -1 {
+			assign(target, entry)
+		}
+	}
+
+	return assignments
+}
+
+// moreDemandingExerciseItem returns true if exercise item a should be filled before item b
+func moreDemandingExerciseItem(a, b *WorkoutTemplateItem) bool {
+	if (a.ExerciseName != "") != (b.ExerciseName != "") {
+		return a.ExerciseName != ""
+	}
+	if weightA, weightB := floatOrDefault(a.AddedWeightKg, math.Inf(-1)), floatOrDefault(b.AddedWeightKg, math.Inf(-1)); weightA != weightB {
+		return weightA > weightB
+	}
+	if hangA, hangB := intOrDefault(a.HangSeconds, -1), intOrDefault(b.HangSeconds, -1); hangA != hangB {
+		return hangA > hangB
+	}
+	return intOrDefault(a.EdgeSizeMM, math.MaxInt) < intOrDefault(b.EdgeSizeMM, math.MaxInt)
+}
+
+// floatOrDefault returns the value of an optional float, or def when it is not set
+func floatOrDefault(value *float64, def float64) float64 {
+	if value == nil {
+		return def
+	}
+	return *value
+}
+
+// intOrDefault returns the value of an optional int, or def when it is not set
+func intOrDefault(value *int, def int) int {
+	if value == nil {
+		return def
+	}
+	return *value
+}
+
+// gradeRank returns the rank of a grade on its scale, or -1 if it is empty or unknown
+func gradeRank(grade string) int {
+	if _, rank, ok := GradeRank(grade); ok {
+		return rank
+	}
+	return -1
+}
+
+// meetsGrade returns true if a logged grade is at or above the target grade on the same scale.
+// Every grade meets an empty target
+func meetsGrade(grade, target string) bool {
+	if target == "" {
+		return true
+	}
+	targetScale, targetRank, ok := GradeRank(target)
+	if !ok {
+		return false
+	}
+	scale, rank, ok := GradeRank(grade)
+	return ok && scale == targetScale && rank >= targetRank
+}
+
+// matchesExercise returns true if a logged exercise is the type (and name, if set) of a template item
+func matchesExercise(exercise *Exercise, item *WorkoutTemplateItem) bool {
+	if exercise.Type != item.ExerciseType {
+		return false
+	}
+	return item.ExerciseName == "" || strings.EqualFold(exercise.Name, item.ExerciseName)
+}
+
+// meetsExerciseIntensity returns true if a logged exercise used at least the planned added weight
+// and hang time on an edge no larger than planned
+func meetsExerciseIntensity(exercise *Exercise, item *WorkoutTemplateItem) bool {
+	if item.AddedWeightKg != nil {
+		weight := 0.0
+		if exercise.AddedWeightKg != nil {
+			weight = *exercise.AddedWeightKg
+		}
+		if weight < *item.AddedWeightKg {
+			return false
+		}
+	}
+	if item.EdgeSizeMM != nil && (exercise.EdgeSizeMM == nil || *exercise.EdgeSizeMM > *item.EdgeSizeMM) {
+		return false
+	}
+	if item.HangSeconds != nil && (exercise.HangSeconds == nil || *exercise.HangSeconds < *item.HangSeconds) {
+		return false
+	}
+	return true
+}
+
+// complianceRatio returns actual over planned as a percentage, capped at 100
+func complianceRatio(actual, planned int) float64 {
+	if actual >= planned {
+		return 100
+	}
+	return roundScore(float64(actual) / float64(planned) * 100)
+}
+
+// roundScore rounds a score to one decimal place
+func roundScore(score float64) float64 {
+	return math.Round(score*10) / 10
+}
@@ -0,0 +1,185 @@
+package models
+
+import "testing"
+
+// boulderSession returns a training session with one indoor boulder per grade
+func boulderSession(grades ...string) *TrainingSession {
+	session := &TrainingSession{}
+	for _, grade := range grades {
+		session.IndoorBoulders = append(session.IndoorBoulders, IndoorBoulder{Grade: grade})
+	}
+	return session
+}
+
+func TestComputeComplianceAssignsEachClimbOnce(t *testing.T) {
+	tests := []struct {
+		name            string
+		items           []WorkoutTemplateItem
+		session         *TrainingSession
+		wantActual      []int
+		wantAtIntensity []int
+	}{
+		{
+			name: "climbs are split between items",
+			items: []WorkoutTemplateItem{
+				{Kind: WorkoutItemKindIndoorBoulder, Count: 2, Grade: "V3"},
+				{Kind: WorkoutItemKindIndoorBoulder, Count: 2, Grade: "V3"},
+			},
+			session:         boulderSession("V3", "V3", "V4", "V5"),
+			wantActual:      []int{2, 2},
+			wantAtIntensity: []int{2, 2},
+		},
+		{
+			name: "hardest target grade is matched first",
+			items: []WorkoutTemplateItem{
+				{Kind: WorkoutItemKindIndoorBoulder, Count: 2, Grade: "V2"},
+				{Kind: WorkoutItemKindIndoorBoulder, Count: 2, Grade: "V6"},
+			},
+			session:         boulderSession("V6", "V7", "V2", "V3"),
+			wantActual:      []int{2, 2},
+			wantAtIntensity: []int{2, 2},
+		},
+		{
+			name: "easier climbs fill volume below the target grade",
+			items: []WorkoutTemplateItem{
+				{Kind: WorkoutItemKindIndoorBoulder, Count: 3, Grade: "V6"},
+				{Kind: WorkoutItemKindIndoorBoulder, Count: 1},
+			},
+			session:         boulderSession("V6", "V1", "V2", "V3"),
+			wantActual:      []int{3, 1},
+			wantAtIntensity: []int{1, 1},
+		},
+		{
+			name: "extra climbs count toward the hardest item they meet",
+			items: []WorkoutTemplateItem{
+				{Kind: WorkoutItemKindIndoorBoulder, Count: 1, Grade: "V2"},
+				{Kind: WorkoutItemKindIndoorBoulder, Count: 1, Grade: "V5"},
+			},
+			session:         boulderSession("V5", "V2", "V3", "V0"),
+			wantActual:      []int{2, 2},
+			wantAtIntensity: []int{2, 1},
+		},
+		{
+			name: "short session leaves the later items unfilled",
+			items: []WorkoutTemplateItem{
+				{Kind: WorkoutItemKindIndoorBoulder, Count: 2, Grade: "V4"},
+				{Kind: WorkoutItemKindIndoorBoulder, Count: 2, Grade: "V2"},
+			},
+			session:         boulderSession("V4", "V5"),
+			wantActual:      []int{2, 0},
+			wantAtIntensity: []int{2, 0},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			compliance := ComputeCompliance(tt.items, tt.session)
+			if len(compliance.Items) != len(tt.wantActual) {
+				t.Fatalf("got %d items, want %d", len(compliance.Items), len(tt.wantActual))
+			}
+			for i, item := range compliance.Items {
+				if item.Actual != tt.wantActual[i] || item.AtIntensity != tt.wantAtIntensity[i] {
+					t.Errorf("item %d: got actual %d at intensity %d, want %d and %d",
+						i, item.Actual, item.AtIntensity, tt.wantActual[i], tt.wantAtIntensity[i])
+				}
+			}
+		})
+	}
+}
+
+func TestComputeComplianceScores(t *testing.T) {
+	items := []WorkoutTemplateItem{
+		{Kind: WorkoutItemKindIndoorBoulder, Count: 2, Grade: "V5"},
+		{Kind: WorkoutItemKindRopeClimb, Count: 2, Grade: "5.10a"},
+	}
+	session := boulderSession("V5", "V5")
+	session.RopeClimbs = []RopeClimb{{Grade: "5.10a"}, {Grade: "5.9"}}
+
+	compliance := ComputeCompliance(items, session)
+	if compliance.VolumeScore != 100 {
+		t.Errorf("got volume score %v, want 100", compliance.VolumeScore)
+	}
+	if compliance.IntensityScore != 75 {
+		t.Errorf("got intensity score %v, want 75", compliance.IntensityScore)
+	}
+	if compliance.Score != 87.5 {
+		t.Errorf("got score %v, want 87.5", compliance.Score)
+	}
+}
+
+func TestComputeComplianceAssignsEachExerciseOnce(t *testing.T) {
+	weight := func(kg float64) *float64 { return &kg }
+
+	tests := []struct {
+		name            string
+		items           []WorkoutTemplateItem
+		exercises       []Exercise
+		wantActual      []int
+		wantAtIntensity []int
+	}{
+		{
+			name: "one hang session fills one of two hangboard items",
+			items: []WorkoutTemplateItem{
+				{Kind: WorkoutItemKindExercise, ExerciseType: ExerciseTypeHangboard, Sets: 6, Reps: 1},
+				{Kind: WorkoutItemKindExercise, ExerciseType: ExerciseTypeHangboard, Sets: 6, Reps: 1},
+			},
+			exercises: []Exercise{
+				{Type: ExerciseTypeHangboard, Sets: 6, Reps: 1},
+			},
+			wantActual:      []int{6, 0},
+			wantAtIntensity: []int{6, 0},
+		},
+		{
+			name: "heaviest planned weight is matched first",
+			items: []WorkoutTemplateItem{
+				{Kind: WorkoutItemKindExercise, ExerciseType: ExerciseTypeHangboard, Sets: 5, Reps: 1},
+				{Kind: WorkoutItemKindExercise, ExerciseType: ExerciseTypeHangboard, Sets: 5, Reps: 1, AddedWeightKg: weight(10)},
+			},
+			exercises: []Exercise{
+				{Type: ExerciseTypeHangboard, Sets: 5, Reps: 1},
+				{Type: ExerciseTypeHangboard, Sets: 5, Reps: 1, AddedWeightKg: weight(12.5)},
+			},
+			wantActual:      []int{5, 5},
+			wantAtIntensity: []int{5, 5},
+		},
+		{
+			name: "named items take their exercise before type-only items",
+			items: []WorkoutTemplateItem{
+				{Kind: WorkoutItemKindExercise, ExerciseType: ExerciseTypeStrength, Sets: 3, Reps: 5},
+				{Kind: WorkoutItemKindExercise, ExerciseType: ExerciseTypeStrength, ExerciseName: "Pull-ups", Sets: 3, Reps: 5},
+			},
+			exercises: []Exercise{
+				{Type: ExerciseTypeStrength, Name: "pull-ups", Sets: 3, Reps: 5},
+				{Type: ExerciseTypeStrength, Name: "Rows", Sets: 3, Reps: 5},
+			},
+			wantActual:      []int{15, 15},
+			wantAtIntensity: []int{15, 15},
+		},
+		{
+			name: "exercises of another type are not counted",
+			items: []WorkoutTemplateItem{
+				{Kind: WorkoutItemKindExercise, ExerciseType: ExerciseTypeCampus, Sets: 4, Reps: 3},
+			},
+			exercises: []Exercise{
+				{Type: ExerciseTypeHangboard, Sets: 6, Reps: 1},
+			},
+			wantActual:      []int{0},
+			wantAtIntensity: []int{0},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			compliance := ComputeCompliance(tt.items, &TrainingSession{Exercises: tt.exercises})
+			if len(compliance.Items) != len(tt.wantActual) {
+				t.Fatalf("got %d items, want %d", len(compliance.Items), len(tt.wantActual))
+			}
+			for i, item := range compliance.Items {
+				if item.Actual != tt.wantActual[i] || item.AtIntensity != tt.wantAtIntensity[i] {
+					t.Errorf("item %d: got actual %d at intensity %d, want %d and %d",
+						i, item.Actual, item.AtIntensity, tt.wantActual[i], tt.wantAtIntensity[i])
+				}
+			}
+		})
+	}
+}
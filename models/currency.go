@@ -0,0 +1,107 @@
+package models
+
+import (
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// DefaultCurrency is assumed for legacy prices of gyms in countries without a known currency
+const DefaultCurrency = "USD"
+
+// CurrencyMinorUnits maps the supported ISO 4217 currency codes to their number of minor unit
+// digits, e.g. 2 for USD (cents) and 0 for JPY
+var CurrencyMinorUnits = map[string]int{
+	"AUD": 2,
+	"BRL": 2,
+	"CAD": 2,
+	"CHF": 2,
+	"CNY": 2,
+	"CZK": 2,
+	"DKK": 2,
+	"EUR": 2,
+	"GBP": 2,
+	"HKD": 2,
+	"INR": 2,
+	"JPY": 0,
+	"KRW": 0,
+	"MXN": 2,
+	"NOK": 2,
+	"NZD": 2,
+	"PLN": 2,
+	"SEK": 2,
+	"SGD": 2,
+	"USD": 2,
+	"ZAR": 2,
+}
+
+// countryCurrencies maps lowercase country names and codes to the currency prices are usually in
+var countryCurrencies = map[string]string{
+	"united states": "USD", "united states of america": "USD", "usa": "USD", "us": "USD",
+	"canada": "CAD", "ca": "CAD",
+	"united kingdom": "GBP", "uk": "GBP", "gb": "GBP", "england": "GBP", "scotland": "GBP", "wales": "GBP",
+	"australia": "AUD", "au": "AUD",
+	"new zealand": "NZD", "nz": "NZD",
+	"switzerland": "CHF", "ch": "CHF",
+	"sweden": "SEK", "se": "SEK",
+	"norway": "NOK", "no": "NOK",
+	"denmark": "DKK", "dk": "DKK",
+	"japan": "JPY", "jp": "JPY",
+	"south korea": "KRW", "korea": "KRW", "kr": "KRW",
+	"china": "CNY", "cn": "CNY",
+	"hong kong": "HKD", "hk": "HKD",
+	"singapore": "SGD", "sg": "SGD",
+	"mexico": "MXN", "mx": "MXN",
+	"brazil": "BRL", "br": "BRL",
+	"south africa": "ZAR", "za": "ZAR",
+	"india": "INR", "in": "INR",
+	"czech republic": "CZK", "czechia": "CZK", "cz": "CZK",
+	"poland": "PLN", "pl": "PLN",
+	"germany": "EUR", "de": "EUR", "france": "EUR", "fr": "EUR", "spain": "EUR", "es": "EUR",
+	"italy": "EUR", "it": "EUR", "netherlands": "EUR", "nl": "EUR", "belgium": "EUR", "be": "EUR",
+	"austria": "EUR", "at": "EUR", "ireland": "EUR", "ie": "EUR", "portugal": "EUR", "pt": "EUR",
+	"finland": "EUR", "fi": "EUR", "greece": "EUR", "gr": "EUR", "slovenia": "EUR", "si": "EUR",
+}
+
+// IsValidCurrency returns true if the code is a supported ISO 4217 currency code
+func IsValidCurrency(code string) bool {
+	_, ok := CurrencyMinorUnits[code]
+	return ok
+}
+
+// SupportedCurrencies returns the supported currency codes in alphabetical order
+func SupportedCurrencies() []string {
+	codes := make([]string, 0, len(CurrencyMinorUnits))
+	for code := range CurrencyMinorUnits {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+	return codes
+}
+
+// CurrencyForCountry returns the currency usually used in a country, or DefaultCurrency when the
+// country is not known
+func CurrencyForCountry(country string) string {
+	if currency, ok := countryCurrencies[strings.ToLower(strings.TrimSpace(country))]; ok {
+		return currency
+	}
+	return DefaultCurrency
+}
+
+// ToMinorUnits converts an amount in major units (e.g. 28.5 USD) into minor units (2850 cents)
+func ToMinorUnits(amount float64, currency string) int64 {
+	return int64(math.Round(amount * math.Pow10(CurrencyMinorUnits[currency])))
+}
+
+// FromMinorUnits converts an amount in minor units (2850 cents) into major units (28.5 USD)
+func FromMinorUnits(amount int64, currency string) float64 {
+	return float64(amount) / math.Pow10(CurrencyMinorUnits[currency])
+}
+
+// FormatMinorUnits formats an amount in minor units as a decimal string in major units, e.g.
+// 2850 USD is "28.50" and 1500 JPY is "1500"
+func FormatMinorUnits(amount int64, currency string) string {
+	digits := CurrencyMinorUnits[currency]
+	return strconv.FormatFloat(float64(amount)/math.Pow10(digits), 'f', digits, 64)
+}
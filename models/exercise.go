@@ -0,0 +1,63 @@
+package models
+
+import (
+	"gorm.io/gorm"
+)
+
+// Exercise type constants
+const (
+	ExerciseTypeHangboard = "hangboard"
+	ExerciseTypeCampus    = "campus"
+	ExerciseTypeStrength  = "strength"
+)
+
+// Grip constants for hangboard and campus exercises
+const (
+	GripOpenHand  = "open_hand"
+	GripHalfCrimp = "half_crimp"
+	GripFullCrimp = "full_crimp"
+	GripThreeDrag = "three_finger_drag"
+	GripPinch     = "pinch"
+	GripSloper    = "sloper"
+)
+
+// Exercise represents an off-the-wall training exercise during a training session,
+// such as hangboard repeaters, campus ladders or weighted pull-ups
+type Exercise struct {
+	gorm.Model
+
+	// Link to training session
+	TrainingSessionID uint            `gorm:"not null;index" json:"training_session_id"`
+	TrainingSession   TrainingSession `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE;" json:"-"`
+
+	// Exercise details
+	Type string `gorm:"size:20;not null;index" json:"type"` // hangboard, campus, strength
+	Name string `gorm:"size:100" json:"name,omitempty"`     // Optional name (e.g., "Repeaters 7/3", "Weighted pull-ups")
+
+	// Volume
+	Sets        int  `gorm:"not null;default:1" json:"sets"`
+	Reps        int  `gorm:"not null;default:1" json:"reps"`
+	HangSeconds *int `json:"hang_seconds,omitempty"` // Time under tension per rep
+	RestSeconds *int `json:"rest_seconds,omitempty"` // Rest between reps
+
+	// Intensity
+	AddedWeightKg *float64 `json:"added_weight_kg,omitempty"` // Negative for assisted (e.g., pulley)
+	EdgeSizeMM    *int     `json:"edge_size_mm,omitempty"`
+	Grip          *string  `gorm:"size:30" json:"grip,omitempty"`
+
+	// Optional notes for this specific exercise
+	Notes string `gorm:"type:text" json:"notes,omitempty"`
+}
+
+// GetTotalReps returns the total number of reps across all sets
+func (e *Exercise) GetTotalReps() int {
+	return e.Sets * e.Reps
+}
+
+// GetTimeUnderTension returns the total hang time in seconds across all sets and reps
+func (e *Exercise) GetTimeUnderTension() int {
+	if e.HangSeconds == nil {
+		return 0
+	}
+	return e.GetTotalReps() * *e.HangSeconds
+}
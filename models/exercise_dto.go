@@ -0,0 +1,110 @@
+package models
+
+import (
+	"time"
+)
+
+// ExerciseRequest represents an off-the-wall exercise in the create training session request
+type ExerciseRequest struct {
+	Type          string   `json:"type" validate:"required,oneof=hangboard campus strength"`
+	Name          string   `json:"name,omitempty" validate:"omitempty,max=100"`
+	Sets          int      `json:"sets" validate:"required,min=1"`
+	Reps          int      `json:"reps" validate:"required,min=1"`
+	HangSeconds   *int     `json:"hang_seconds,omitempty"`
+	RestSeconds   *int     `json:"rest_seconds,omitempty"`
+	AddedWeightKg *float64 `json:"added_weight_kg,omitempty"`
+	EdgeSizeMM    *int     `json:"edge_size_mm,omitempty"`
+	Grip          *string  `json:"grip,omitempty"`
+	Notes         string   `json:"notes,omitempty" validate:"omitempty,max=1000"`
+}
+
+// ExerciseUpdate replaces the details of an existing exercise in the update request
+type ExerciseUpdate struct {
+	ID uint `json:"id" validate:"required"`
+	ExerciseRequest
+}
+
+// ExerciseChanges lists the exercises to add, edit and remove in the update request
+type ExerciseChanges struct {
+	Add    []ExerciseRequest `json:"add,omitempty"`
+	Update []ExerciseUpdate  `json:"update,omitempty"`
+	Remove []uint            `json:"remove,omitempty"`
+}
+
+// ExerciseResponse represents an off-the-wall exercise in the response
+type ExerciseResponse struct {
+	ID                uint      `json:"id"`
+	TrainingSessionID uint      `json:"training_session_id"`
+	Type              string    `json:"type"`
+	Name              string    `json:"name,omitempty"`
+	Sets              int       `json:"sets"`
+	Reps              int       `json:"reps"`
+	HangSeconds       *int      `json:"hang_seconds,omitempty"`
+	RestSeconds       *int      `json:"rest_seconds,omitempty"`
+	AddedWeightKg     *float64  `json:"added_weight_kg,omitempty"`
+	EdgeSizeMM        *int      `json:"edge_size_mm,omitempty"`
+	Grip              *string   `json:"grip,omitempty"`
+	Notes             string    `json:"notes,omitempty"`
+	TotalReps         int       `json:"total_reps"`
+	TimeUnderTension  int       `json:"time_under_tension_seconds"`
+	CreatedAt         time.Time `json:"created_at"`
+	UpdatedAt         time.Time `json:"updated_at"`
+}
+
+// ExerciseProgressionPoint represents one period of an exercise progression series
+type ExerciseProgressionPoint struct {
+	Period   time.Time `json:"period"`
+	Value    float64   `json:"value"`
+	Sessions int       `json:"sessions"`
+}
+
+// ExerciseProgressionResponse represents the progression of an exercise metric over time
+type ExerciseProgressionResponse struct {
+	Type       string                     `json:"type"`
+	Metric     string                     `json:"metric"`
+	Interval   string                     `json:"interval"`
+	Name       string                     `json:"name,omitempty"`
+	EdgeSizeMM *int                       `json:"edge_size_mm,omitempty"`
+	Grip       string                     `json:"grip,omitempty"`
+	StartDate  time.Time                  `json:"start_date"`
+	EndDate    time.Time                  `json:"end_date"`
+	Points     []ExerciseProgressionPoint `json:"points"`
+}
+
+// ToExercise converts an ExerciseRequest to an Exercise model
+func (er *ExerciseRequest) ToExercise() *Exercise {
+	return &Exercise{
+		Type:          er.Type,
+		Name:          er.Name,
+		Sets:          er.Sets,
+		Reps:          er.Reps,
+		HangSeconds:   er.HangSeconds,
+		RestSeconds:   er.RestSeconds,
+		AddedWeightKg: er.AddedWeightKg,
+		EdgeSizeMM:    er.EdgeSizeMM,
+		Grip:          er.Grip,
+		Notes:         er.Notes,
+	}
+}
+
+// ToExerciseResponse converts an Exercise model to an ExerciseResponse DTO
+func (e *Exercise) ToExerciseResponse() ExerciseResponse {
+	return ExerciseResponse{
+		ID:                e.ID,
+		TrainingSessionID: e.TrainingSessionID,
+		Type:              e.Type,
+		Name:              e.Name,
+		Sets:              e.Sets,
+		Reps:              e.Reps,
+		HangSeconds:       e.HangSeconds,
+		RestSeconds:       e.RestSeconds,
+		AddedWeightKg:     e.AddedWeightKg,
+		EdgeSizeMM:        e.EdgeSizeMM,
+		Grip:              e.Grip,
+		Notes:             e.Notes,
+		TotalReps:         e.GetTotalReps(),
+		TimeUnderTension:  e.GetTimeUnderTension(),
+		CreatedAt:         e.CreatedAt,
+		UpdatedAt:         e.UpdatedAt,
+	}
+}
@@ -0,0 +1,129 @@
+package models
+
+import (
+	"math"
+	"sort"
+)
+
+// EarthRadiusKm is the mean radius of the earth used for great-circle distances
+const EarthRadiusKm = 6371.0088
+
+// BoundingBox is a latitude/longitude rectangle. When MinLng is greater than MaxLng the box
+// crosses the antimeridian and covers longitudes >= MinLng or <= MaxLng
+type BoundingBox struct {
+	MinLat float64 `json:"min_lat"`
+	MinLng float64 `json:"min_lng"`
+	MaxLat float64 `json:"max_lat"`
+	MaxLng float64 `json:"max_lng"`
+}
+
+// CrossesAntimeridian returns true if the box wraps around longitude 180
+func (b BoundingBox) CrossesAntimeridian() bool {
+	return b.MinLng > b.MaxLng
+}
+
+// Contains returns true if the point lies inside the box
+func (b BoundingBox) Contains(lat, lng float64) bool {
+	if lat < b.MinLat || lat > b.MaxLat {
+		return false
+	}
+	if b.CrossesAntimeridian() {
+		return lng >= b.MinLng || lng <= b.MaxLng
+	}
+	return lng >= b.MinLng && lng <= b.MaxLng
+}
+
+// Center returns the midpoint of the box, taking a box that crosses the antimeridian into account
+func (b BoundingBox) Center() (float64, float64) {
+	lat := (b.MinLat + b.MaxLat) / 2
+	maxLng := b.MaxLng
+	if b.CrossesAntimeridian() {
+		maxLng += 360
+	}
+	lng := (b.MinLng + maxLng) / 2
+	if lng > 180 {
+		lng -= 360
+	}
+	return lat, lng
+}
+
+// BoundingBoxAround returns the smallest box containing every point within radiusKm of the
+// given point. Near the poles the box covers every longitude
+func BoundingBoxAround(lat, lng, radiusKm float64) BoundingBox {
+	angular := radiusKm / EarthRadiusKm
+	latRad := lat * math.Pi / 180
+
+	minLat := latRad - angular
+	maxLat := latRad + angular
+	if minLat <= -math.Pi/2 || maxLat >= math.Pi/2 {
+		return BoundingBox{
+			MinLat: math.Max(minLat, -math.Pi/2) * 180 / math.Pi,
+			MinLng: -180,
+			MaxLat: math.Min(maxLat, math.Pi/2) * 180 / math.Pi,
+			MaxLng: 180,
+		}
+	}
+
+	deltaLng := math.Asin(math.Sin(angular)/math.Cos(latRad)) * 180 / math.Pi
+	minLng := lng - deltaLng
+	maxLng := lng + deltaLng
+	if minLng < -180 {
+		minLng += 360
+	}
+	if maxLng > 180 {
+		maxLng -= 360
+	}
+
+	return BoundingBox{
+		MinLat: minLat * 180 / math.Pi,
+		MinLng: minLng,
+		MaxLat: maxLat * 180 / math.Pi,
+		MaxLng: maxLng,
+	}
+}
+
+// HaversineKm returns the great-circle distance in kilometers between two points
+func HaversineKm(lat1, lng1, lat2, lng2 float64) float64 {
+	phi1 := lat1 * math.Pi / 180
+	phi2 := lat2 * math.Pi / 180
+	dPhi := (lat2 - lat1) * math.Pi / 180
+	dLambda := (lng2 - lng1) * math.Pi / 180
+
+	a := math.Sin(dPhi/2)*math.Sin(dPhi/2) +
+		math.Cos(phi1)*math.Cos(phi2)*math.Sin(dLambda/2)*math.Sin(dLambda/2)
+	return 2 * EarthRadiusKm * math.Asin(math.Min(1, math.Sqrt(a)))
+}
+
+// GymDistance pairs a gym with its distance from a search point
+type GymDistance struct {
+	Gym        Gym
+	DistanceKm float64
+}
+
+// RankGymsByDistance returns the gyms with coordinates that lie within radiusKm of the point and
+// inside box (when given), nearest first with ties broken by ID. A radiusKm of 0 disables the
+// radius check. This is the pure-Go path used when the db has no spatial index
+func RankGymsByDistance(gyms []Gym, lat, lng, radiusKm float64, box *BoundingBox) []GymDistance {
+	ranked := []GymDistance{}
+	for _, gym := range gyms {
+		if gym.Latitude == nil || gym.Longitude == nil {
+			continue
+		}
+		if box != nil && !box.Contains(*gym.Latitude, *gym.Longitude) {
+			continue
+		}
+		distance := HaversineKm(lat, lng, *gym.Latitude, *gym.Longitude)
+		if radiusKm > 0 && distance > radiusKm {
+			continue
+		}
+		ranked = append(ranked, GymDistance{Gym: gym, DistanceKm: distance})
+	}
+
+	sort.SliceStable(ranked, func(i, j int) bool {
+		if ranked[i].DistanceKm != ranked[j].DistanceKm {
+			return ranked[i].DistanceKm < ranked[j].DistanceKm
+		}
+		return ranked[i].Gym.ID < ranked[j].Gym.ID
+	})
+	return ranked
+}
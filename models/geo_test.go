@@ -0,0 +1,125 @@
+package models
+
+import (
+	"math"
+	"testing"
+)
+
+func TestHaversineKm(t *testing.T) {
+	tests := []struct {
+		name                   string
+		lat1, lng1, lat2, lng2 float64
+		wantKm                 float64
+	}{
+		{name: "same point", lat1: 40.7128, lng1: -74.0060, lat2: 40.7128, lng2: -74.0060, wantKm: 0},
+		{name: "New York to Los Angeles", lat1: 40.7128, lng1: -74.0060, lat2: 34.0522, lng2: -118.2437, wantKm: 3936},
+		{name: "London to Paris", lat1: 51.5074, lng1: -0.1278, lat2: 48.8566, lng2: 2.3522, wantKm: 344},
+		{name: "Sydney to Auckland", lat1: -33.8688, lng1: 151.2093, lat2: -36.8485, lng2: 174.7633, wantKm: 2156},
+		{name: "across the antimeridian", lat1: 0, lng1: 179.5, lat2: 0, lng2: -179.5, wantKm: 111},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := HaversineKm(tt.lat1, tt.lng1, tt.lat2, tt.lng2)
+			// Within 1% of the published great-circle distance
+			if math.Abs(got-tt.wantKm) > math.Max(1, tt.wantKm*0.01) {
+				t.Errorf("HaversineKm() = %.1f, want about %.0f", got, tt.wantKm)
+			}
+		})
+	}
+}
+
+func TestBoundingBoxAround(t *testing.T) {
+	tests := []struct {
+		name        string
+		lat, lng    float64
+		radiusKm    float64
+		wantCrosses bool
+		wantAllLngs bool
+		inside      [][2]float64
+		outside     [][2]float64
+	}{
+		{
+			name: "mid latitude", lat: 40.7128, lng: -74.0060, radiusKm: 25,
+			inside:  [][2]float64{{40.7128, -74.0060}, {40.85, -73.9}},
+			outside: [][2]float64{{41.2, -74.0060}, {40.7128, -73.4}},
+		},
+		{
+			name: "crosses the antimeridian", lat: -17.7134, lng: 179.9, radiusKm: 100, wantCrosses: true,
+			inside:  [][2]float64{{-17.7134, 179.5}, {-17.7134, -179.8}},
+			outside: [][2]float64{{-17.7134, 178}, {-17.7134, -178}, {-17.7134, 0}},
+		},
+		{
+			name: "near the pole", lat: 89.9, lng: 0, radiusKm: 50, wantAllLngs: true,
+			inside: [][2]float64{{89.95, 0}, {89.95, 180}, {89.95, -90}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			box := BoundingBoxAround(tt.lat, tt.lng, tt.radiusKm)
+
+			if box.CrossesAntimeridian() != tt.wantCrosses {
+				t.Errorf("CrossesAntimeridian() = %v, want %v (box %+v)", box.CrossesAntimeridian(), tt.wantCrosses, box)
+			}
+			if tt.wantAllLngs && (box.MinLng != -180 || box.MaxLng != 180) {
+				t.Errorf("box %+v does not cover every longitude", box)
+			}
+			for _, p := range tt.inside {
+				if !box.Contains(p[0], p[1]) {
+					t.Errorf("box %+v does not contain %v", box, p)
+				}
+			}
+			for _, p := range tt.outside {
+				if box.Contains(p[0], p[1]) {
+					t.Errorf("box %+v contains %v", box, p)
+				}
+			}
+		})
+	}
+}
+
+func TestRankGymsByDistance(t *testing.T) {
+	coords := func(id uint, lat, lng float64) Gym {
+		gym := Gym{Latitude: &lat, Longitude: &lng}
+		gym.ID = id
+		return gym
+	}
+	// Distances from the origin in Brooklyn (40.6782, -73.9442)
+	gyms := []Gym{
+		coords(1, 40.7831, -73.9712), // Manhattan, about 12 km
+		coords(2, 40.6782, -73.9442), // Origin
+		coords(3, 42.3601, -71.0589), // Boston, about 300 km
+		coords(4, 40.6782, -73.9442), // Origin, tie broken by ID
+		{Name: "No coordinates"},
+	}
+
+	tests := []struct {
+		name     string
+		radiusKm float64
+		box      *BoundingBox
+		wantIDs  []uint
+	}{
+		{name: "within radius nearest first", radiusKm: 25, wantIDs: []uint{2, 4, 1}},
+		{name: "no radius keeps every gym with coordinates", radiusKm: 0, wantIDs: []uint{2, 4, 1, 3}},
+		{name: "box excludes gyms outside it", radiusKm: 0, box: &BoundingBox{MinLat: 40.7, MinLng: -74.1, MaxLat: 40.9, MaxLng: -73.8}, wantIDs: []uint{1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ranked := RankGymsByDistance(gyms, 40.6782, -73.9442, tt.radiusKm, tt.box)
+
+			if len(ranked) != len(tt.wantIDs) {
+				t.Fatalf("got %d gyms, want %d", len(ranked), len(tt.wantIDs))
+			}
+			for i, want := range tt.wantIDs {
+				if ranked[i].Gym.ID != want {
+					t.Errorf("ranked[%d] = gym %d, want gym %d", i, ranked[i].Gym.ID, want)
+				}
+				if i > 0 && ranked[i].DistanceKm < ranked[i-1].DistanceKm {
+					t.Errorf("ranked[%d] is nearer than ranked[%d]", i, i-1)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,109 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+)
+
+// GradeScale constants for the supported difficulty grading systems
+const (
+	GradeScaleVScale = "v_scale" // Bouldering (VB, V0 - V17)
+	GradeScaleYDS    = "yds"     // Yosemite Decimal System (5.0 - 5.15d)
+)
+
+// vScaleGrades lists the V-scale bouldering grades from easiest to hardest
+var vScaleGrades = []string{
+	"VB", "V0", "V1", "V2", "V3", "V4", "V5", "V6", "V7", "V8",
+	"V9", "V10", "V11", "V12", "V13", "V14", "V15", "V16", "V17",
+}
+
+// ydsGrades lists the Yosemite Decimal System grades from easiest to hardest
+var ydsGrades = []string{
+	"5.0", "5.1", "5.2", "5.3", "5.4", "5.5", "5.6", "5.7", "5.8", "5.9",
+	"5.10a", "5.10b", "5.10c", "5.10d",
+	"5.11a", "5.11b", "5.11c", "5.11d",
+	"5.12a", "5.12b", "5.12c", "5.12d",
+	"5.13a", "5.13b", "5.13c", "5.13d",
+	"5.14a", "5.14b", "5.14c", "5.14d",
+	"5.15a", "5.15b", "5.15c", "5.15d",
+}
+
+// NormalizeGrade trims whitespace and normalizes casing of a grade (e.g., "v3" -> "V3", "5.10A" -> "5.10a")
+func NormalizeGrade(grade string) string {
+	grade = strings.TrimSpace(grade)
+	if strings.HasPrefix(grade, "5.") {
+		return strings.ToLower(grade)
+	}
+	return strings.ToUpper(grade)
+}
+
+// GradeRank returns the grading scale and the position of the grade within that scale.
+// Higher ranks are harder. The ok result is false if the grade is not a recognized grade.
+func GradeRank(grade string) (scale string, rank int, ok bool) {
+	grade = NormalizeGrade(grade)
+	for i, g := range vScaleGrades {
+		if g == grade {
+			return GradeScaleVScale, i, true
+		}
+	}
+	for i, g := range ydsGrades {
+		if g == grade {
+			return GradeScaleYDS, i, true
+		}
+	}
+	return "", 0, false
+}
+
+// GradesBetween returns every grade between min and max (inclusive) on the same scale.
+// Either bound may be empty to leave that end of the range open, but at least one must be provided.
+func GradesBetween(min, max string) ([]string, error) {
+	if min == "" && max == "" {
+		return nil, fmt.Errorf("at least one grade bound is required")
+	}
+
+	var minScale, maxScale string
+	minRank, maxRank := -1, -1
+
+	if min != "" {
+		scale, rank, ok := GradeRank(min)
+		if !ok {
+			return nil, fmt.Errorf("unrecognized grade: %s", min)
+		}
+		minScale, minRank = scale, rank
+	}
+	if max != "" {
+		scale, rank, ok := GradeRank(max)
+		if !ok {
+			return nil, fmt.Errorf("unrecognized grade: %s", max)
+		}
+		maxScale, maxRank = scale, rank
+	}
+
+	if minScale != "" && maxScale != "" && minScale != maxScale {
+		return nil, fmt.Errorf("grades %s and %s are on different scales", min, max)
+	}
+
+	scale := minScale
+	if scale == "" {
+		scale = maxScale
+	}
+
+	grades := vScaleGrades
+	if scale == GradeScaleYDS {
+		grades = ydsGrades
+	}
+
+	if minRank == -1 {
+		minRank = 0
+	}
+	if maxRank == -1 {
+		maxRank = len(grades) - 1
+	}
+	if minRank > maxRank {
+		return nil, fmt.Errorf("minimum grade %s is harder than maximum grade %s", min, max)
+	}
+
+	result := make([]string, maxRank-minRank+1)
+	copy(result, grades[minRank:maxRank+1])
+	return result, nil
+}
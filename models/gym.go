@@ -28,47 +28,94 @@ type Gym struct {
 	Country    string `gorm:"size:100;not null;index" json:"country"`
 	PostalCode string `gorm:"size:20" json:"postal_code,omitempty"`
 
-	// GPS coordinates for mapping
-	Latitude  *float64 `json:"latitude,omitempty"`
-	Longitude *float64 `json:"longitude,omitempty"`
+	// GPS coordinates for mapping, indexed for bounding box searches
+	Latitude  *float64 `gorm:"index:idx_gym_coordinates" json:"latitude,omitempty"`
+	Longitude *float64 `gorm:"index:idx_gym_coordinates" json:"longitude,omitempty"`
 
 	// Contact information
 	Phone   string `gorm:"size:50" json:"phone,omitempty"`
 	Email   string `gorm:"size:200" json:"email,omitempty"`
 	Website string `gorm:"size:300" json:"website,omitempty"`
 
-	// Operating hours (could be enhanced with structured schedule later)
-	Hours string `gorm:"type:text" json:"hours,omitempty"` // Free-form text for now
-
-	// Facilities and features
-	HasBouldering   bool `gorm:"default:false" json:"has_bouldering"`
-	HasTopRope      bool `gorm:"default:false" json:"has_top_rope"`
-	HasLeadClimbing bool `gorm:"default:false" json:"has_lead_climbing"`
-	HasAutoBelay    bool `gorm:"default:false" json:"has_auto_belay"`
-	HasKidsArea     bool `gorm:"default:false" json:"has_kids_area"`
-	HasTrainingArea bool `gorm:"default:false" json:"has_training_area"`
-	HasYogaClasses  bool `gorm:"default:false" json:"has_yoga_classes"`
-	HasShower       bool `gorm:"default:false" json:"has_shower"`
-	HasParking      bool `gorm:"default:false" json:"has_parking"`
-	HasGearRental   bool `gorm:"default:false" json:"has_gear_rental"`
-	HasProShop      bool `gorm:"default:false" json:"has_pro_shop"`
-	HasCafe         bool `gorm:"default:false" json:"has_cafe"`
+	// Operating hours. Hours is the free-form description; the structured weekly schedule and
+	// dated exceptions are in the gym's IANA timezone and drive the open now status
+	Hours           string              `gorm:"type:text" json:"hours,omitempty"`
+	Timezone        string              `gorm:"size:64" json:"timezone,omitempty"` // e.g. "America/New_York"
+	OpeningHours    []GymOpeningHours   `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE;" json:"opening_hours,omitempty"`
+	HoursExceptions []GymHoursException `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE;" json:"hours_exceptions,omitempty"`
+
+	// Amenities offered by the gym, from the amenity catalog
+	Amenities []Amenity `gorm:"many2many:gym_amenities;" json:"amenities,omitempty"`
+
+	// Legacy facility flags, superseded by Amenities. They are only read to backfill the gym's
+	// amenities on startup and are cleared once migrated
+	HasBouldering   bool `gorm:"default:false" json:"-"`
+	HasTopRope      bool `gorm:"default:false" json:"-"`
+	HasLeadClimbing bool `gorm:"default:false" json:"-"`
+	HasAutoBelay    bool `gorm:"default:false" json:"-"`
+	HasKidsArea     bool `gorm:"default:false" json:"-"`
+	HasTrainingArea bool `gorm:"default:false" json:"-"`
+	HasYogaClasses  bool `gorm:"default:false" json:"-"`
+	HasShower       bool `gorm:"default:false" json:"-"`
+	HasParking      bool `gorm:"default:false" json:"-"`
+	HasGearRental   bool `gorm:"default:false" json:"-"`
+	HasProShop      bool `gorm:"default:false" json:"-"`
+	HasCafe         bool `gorm:"default:false" json:"-"`
 
 	// Capacity and size
 	WallHeight int `json:"wall_height,omitempty"` // Max wall height in feet
 	SquareFeet int `json:"square_feet,omitempty"` // Total climbing area
 
-	// Pricing (simplified - could be enhanced with membership tiers later)
-	DayPassPrice    float64 `json:"day_pass_price,omitempty"`
-	MonthlyPrice    float64 `json:"monthly_price,omitempty"`
-	YearlyPrice     float64 `json:"yearly_price,omitempty"`
-	GearRentalPrice float64 `json:"gear_rental_price,omitempty"`
+	// Pricing tiers by product and audience, each in its own currency
+	Prices []GymPrice `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE;" json:"prices,omitempty"`
+
+	// Legacy flat prices without a currency, superseded by Prices. They are only read to backfill
+	// pricing tiers on startup and are cleared once migrated
+	DayPassPrice    float64 `json:"-"`
+	MonthlyPrice    float64 `json:"-"`
+	YearlyPrice     float64 `json:"-"`
+	GearRentalPrice float64 `json:"-"`
 
 	// Additional information
 	Notes string `gorm:"type:text" json:"notes,omitempty"` // Additional notes or comments
 
 	// Status
 	Active bool `gorm:"default:true" json:"active"` // Whether the gym is currently open/operational
+
+	// Ratings aggregated from the gym's visible reviews, refreshed whenever a review changes so gyms
+	// can be sorted by rating. Category averages are null until a review rates the category
+	RatingAverage     float64  `gorm:"not null;default:0;index" json:"rating_average"`
+	RatingCount       int      `gorm:"not null;default:0" json:"rating_count"`
+	SettingRating     *float64 `json:"setting_rating,omitempty"`
+	CrowdingRating    *float64 `json:"crowding_rating,omitempty"`
+	CleanlinessRating *float64 `json:"cleanliness_rating,omitempty"`
+	ValueRating       *float64 `json:"value_rating,omitempty"`
+
+	// Ownership. Unclaimed gyms can be edited by the user who created them, claimed gyms only by
+	// their owner. Admins can edit any gym
+	CreatedByID *uint `gorm:"index" json:"created_by_id,omitempty"`
+	OwnerID     *uint `gorm:"index" json:"owner_id,omitempty"`
+	Owner       *User `gorm:"foreignKey:OwnerID" json:"-"`
+
+	// Identifier of the gym in an external dataset. Bulk imports update the gym with the same
+	// external ID instead of adding it again
+	ExternalID *string `gorm:"size:200;uniqueIndex:idx_gyms_external_id,where:deleted_at IS NULL" json:"external_id,omitempty"`
+}
+
+// IsClaimed returns true if the gym has an approved owner
+func (g *Gym) IsClaimed() bool {
+	return g.OwnerID != nil
+}
+
+// CanBeEditedBy returns true if the user is allowed to update or delete the gym
+func (g *Gym) CanBeEditedBy(userID uint, isAdmin bool) bool {
+	if isAdmin {
+		return true
+	}
+	if g.OwnerID != nil {
+		return *g.OwnerID == userID
+	}
+	return g.CreatedByID != nil && *g.CreatedByID == userID
 }
 
 // GetFullAddress returns a formatted full address string
@@ -123,51 +170,48 @@ func (g *Gym) GetLocation() string {
 	return location
 }
 
+// HasAmenity returns true if the gym offers the amenity. Amenities must be preloaded
+func (g *Gym) HasAmenity(slug string) bool {
+	for _, amenity := range g.Amenities {
+		if amenity.Slug == slug {
+			return true
+		}
+	}
+	return false
+}
+
 // HasRopedClimbing returns true if the gym has any roped climbing facilities
 func (g *Gym) HasRopedClimbing() bool {
-	return g.HasTopRope || g.HasLeadClimbing || g.HasAutoBelay
+	return g.HasAmenity("top_rope") || g.HasAmenity("lead") || g.HasAmenity("auto_belay")
 }
 
-// GetFacilities returns a list of available facilities
+// GetFacilities returns the names of the gym's amenities by category. Amenities must be preloaded
 func (g *Gym) GetFacilities() []string {
-	facilities := []string{}
+	amenities := append([]Amenity{}, g.Amenities...)
+	SortAmenities(amenities)
 
-	if g.HasBouldering {
-		facilities = append(facilities, "Bouldering")
-	}
-	if g.HasTopRope {
-		facilities = append(facilities, "Top Rope")
-	}
-	if g.HasLeadClimbing {
-		facilities = append(facilities, "Lead Climbing")
-	}
-	if g.HasAutoBelay {
-		facilities = append(facilities, "Auto Belay")
+	facilities := make([]string, len(amenities))
+	for i, amenity := range amenities {
+		facilities[i] = amenity.Name
 	}
-	if g.HasKidsArea {
-		facilities = append(facilities, "Kids Area")
-	}
-	if g.HasTrainingArea {
-		facilities = append(facilities, "Training Area")
-	}
-	if g.HasYogaClasses {
-		facilities = append(facilities, "Yoga Classes")
-	}
-	if g.HasShower {
-		facilities = append(facilities, "Showers")
-	}
-	if g.HasParking {
-		facilities = append(facilities, "Parking")
-	}
-	if g.HasGearRental {
-		facilities = append(facilities, "Gear Rental")
-	}
-	if g.HasProShop {
-		facilities = append(facilities, "Pro Shop")
-	}
-	if g.HasCafe {
-		facilities = append(facilities, "Cafe")
-	}
-
 	return facilities
 }
+
+// LegacyAmenities returns the slugs of the amenities set by the legacy Has* flags
+func (g *Gym) LegacyAmenities() []string {
+	return legacyAmenitySlugs([]bool{
+		g.HasBouldering, g.HasTopRope, g.HasLeadClimbing, g.HasAutoBelay, g.HasKidsArea, g.HasTrainingArea,
+		g.HasYogaClasses, g.HasShower, g.HasParking, g.HasGearRental, g.HasProShop, g.HasCafe,
+	})
+}
+
+// legacyAmenitySlugs returns the slugs of the set flags, given in LegacyAmenitySlugs order
+func legacyAmenitySlugs(flags []bool) []string {
+	slugs := []string{}
+	for i, set := range flags {
+		if set {
+			slugs = append(slugs, LegacyAmenitySlugs[i])
+		}
+	}
+	return slugs
+}
@@ -0,0 +1,207 @@
+package models
+
+import (
+	"math"
+	"time"
+)
+
+// Gym busyness constants
+const (
+	// GymBusynessMinCount is the smallest number of people reported. Current occupancy below it and
+	// hours visited by fewer distinct users over the history window are suppressed so that individual
+	// visits cannot be picked out
+	GymBusynessMinCount = 5
+
+	// GymBusynessHistoryWeeks is how many weeks of check-ins and training sessions the typical
+	// busyness histogram is built from
+	GymBusynessHistoryWeeks = 12
+
+	// DefaultGymVisitDuration is the assumed length of a training session logged without a duration
+	DefaultGymVisitDuration = 90 * time.Minute
+)
+
+// Gym busyness level constants, relative to the gym's busiest typical hour
+const (
+	GymBusynessLevelQuiet    = "quiet"
+	GymBusynessLevelModerate = "moderate"
+	GymBusynessLevelBusy     = "busy"
+	GymBusynessLevelVeryBusy = "very_busy"
+	GymBusynessLevelUnknown  = "unknown"
+)
+
+// GymVisit is a single anonymous stay at a gym, from a check-in or a training session
+type GymVisit struct {
+	UserID uint
+	Start  time.Time
+	End    time.Time
+}
+
+// GymBusynessResponse represents the estimated current and typical busyness of a gym
+type GymBusynessResponse struct {
+	GymID    uint   `json:"gym_id"`
+	Timezone string `json:"timezone"` // Timezone of the histogram hours, UTC when the gym has none
+
+	Current GymCurrentBusyness `json:"current"`
+	Typical []GymBusynessHour  `json:"typical"` // 168 entries, Sunday 00:00 first
+
+	WeeksOfData int `json:"weeks_of_data"`
+	MinCount    int `json:"min_count"` // Counts below this are suppressed
+}
+
+// GymCurrentBusyness represents the estimated number of people at a gym right now
+type GymCurrentBusyness struct {
+	EstimatedOccupancy *int      `json:"estimated_occupancy"` // Null when suppressed
+	Suppressed         bool      `json:"suppressed"`
+	Level              string    `json:"level"`            // Unknown when suppressed
+	TypicalBusyness    *int      `json:"typical_busyness"` // Relative busyness usually seen at this hour
+	AsOf               time.Time `json:"as_of"`
+}
+
+// GymBusynessHour represents how busy a gym typically is during one hour of the week
+type GymBusynessHour struct {
+	DayOfWeek        int      `json:"day_of_week"` // 0 = Sunday
+	Hour             int      `json:"hour"`        // 0-23, local to the histogram timezone
+	AverageOccupancy *float64 `json:"average_occupancy"`
+	RelativeBusyness *int     `json:"relative_busyness"` // 0-100, percent of the busiest hour
+	Suppressed       bool     `json:"suppressed"`
+}
+
+// busynessBucket accumulates the visits that overlapped one hour of the week
+type busynessBucket struct {
+	minutes float64
+	users   map[uint]struct{}
+}
+
+// BuildGymBusyness estimates the current occupancy and the typical busyness by day of week and hour
+// from the visits to a gym. Hours are bucketed in loc. Average occupancy is the person-hours spent
+// in the gym during the hour divided by the number of weeks of data
+func BuildGymBusyness(gymID uint, visits []GymVisit, loc *time.Location, now time.Time) *GymBusynessResponse {
+	buckets := make([]busynessBucket, 7*24)
+	current := map[uint]struct{}{}
+	earliest := now
+
+	for _, visit := range visits {
+		if !visit.End.After(visit.Start) {
+			continue
+		}
+		if visit.Start.Before(earliest) {
+			earliest = visit.Start
+		}
+		// Visits still in progress end at now
+		if !visit.Start.After(now) && !visit.End.Before(now) {
+			current[visit.UserID] = struct{}{}
+		}
+
+		local := visit.Start.In(loc)
+		slot := time.Date(local.Year(), local.Month(), local.Day(), local.Hour(), 0, 0, 0, loc)
+		for slot.Before(visit.End) {
+			next := slot.Add(time.Hour)
+			overlap := minTime(next, visit.End).Sub(maxTime(slot, visit.Start))
+			if overlap > 0 {
+				b := &buckets[int(slot.Weekday())*24+slot.Hour()]
+				b.minutes += overlap.Minutes()
+				if b.users == nil {
+					b.users = map[uint]struct{}{}
+				}
+				b.users[visit.UserID] = struct{}{}
+			}
+			slot = next
+		}
+	}
+
+	weeks := 0
+	if len(visits) > 0 {
+		weeks = int(math.Ceil(now.Sub(earliest).Hours() / (24 * 7)))
+		if weeks < 1 {
+			weeks = 1
+		}
+		if weeks > GymBusynessHistoryWeeks {
+			weeks = GymBusynessHistoryWeeks
+		}
+	}
+
+	response := &GymBusynessResponse{
+		GymID:       gymID,
+		Timezone:    loc.String(),
+		Typical:     make([]GymBusynessHour, len(buckets)),
+		WeeksOfData: weeks,
+		MinCount:    GymBusynessMinCount,
+	}
+
+	// Average occupancy of the reportable hours, and the busiest of them
+	peak := 0.0
+	averages := make([]float64, len(buckets))
+	for i, b := range buckets {
+		response.Typical[i] = GymBusynessHour{DayOfWeek: i / 24, Hour: i % 24, Suppressed: true}
+		if len(b.users) < GymBusynessMinCount || weeks == 0 {
+			continue
+		}
+		averages[i] = b.minutes / 60 / float64(weeks)
+		if averages[i] > peak {
+			peak = averages[i]
+		}
+	}
+	for i := range buckets {
+		if len(buckets[i].users) < GymBusynessMinCount || weeks == 0 {
+			continue
+		}
+		average := math.Round(averages[i]*10) / 10
+		relative := 0
+		if peak > 0 {
+			relative = int(math.Round(averages[i] / peak * 100))
+		}
+		response.Typical[i].AverageOccupancy = &average
+		response.Typical[i].RelativeBusyness = &relative
+		response.Typical[i].Suppressed = false
+	}
+
+	occupancy := len(current)
+	localNow := now.In(loc)
+	response.Current = GymCurrentBusyness{
+		Suppressed:      occupancy < GymBusynessMinCount,
+		Level:           GymBusynessLevelUnknown,
+		TypicalBusyness: response.Typical[int(localNow.Weekday())*24+localNow.Hour()].RelativeBusyness,
+		AsOf:            now,
+	}
+	// The level is relative to the published peak, so it would reveal a suppressed count
+	if !response.Current.Suppressed {
+		response.Current.EstimatedOccupancy = &occupancy
+		response.Current.Level = gymBusynessLevel(float64(occupancy), peak)
+	}
+
+	return response
+}
+
+// gymBusynessLevel describes the occupancy relative to the average occupancy of the busiest hour
+func gymBusynessLevel(occupancy, peak float64) string {
+	if peak == 0 {
+		return GymBusynessLevelUnknown
+	}
+	ratio := occupancy / peak
+	switch {
+	case ratio < 0.35:
+		return GymBusynessLevelQuiet
+	case ratio < 0.7:
+		return GymBusynessLevelModerate
+	case ratio < 1:
+		return GymBusynessLevelBusy
+	default:
+		return GymBusynessLevelVeryBusy
+	}
+}
+
+// minTime returns the earlier of two times
+func minTime(a, b time.Time) time.Time {
+	if a.Before(b) {
+		return a
+	}
+	return b
+}
+
+// maxTime returns the later of two times
+func maxTime(a, b time.Time) time.Time {
+	if a.After(b) {
+		return a
+	}
+	return b
+}
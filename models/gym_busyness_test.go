@@ -0,0 +1,76 @@
+package models
+
+import (
+	"testing"
+	"time"
+)
+
+// busyHistory returns one visit per user on each of the past weeks, all in the same hour as now
+func busyHistory(now time.Time, users, weeks int) []GymVisit {
+	visits := []GymVisit{}
+	for week := 1; week <= weeks; week++ {
+		start := now.Add(-time.Duration(week) * 7 * 24 * time.Hour).Truncate(time.Hour)
+		for user := 1; user <= users; user++ {
+			visits = append(visits, GymVisit{UserID: uint(user), Start: start, End: start.Add(time.Hour)})
+		}
+	}
+	return visits
+}
+
+func TestBuildGymBusynessCurrent(t *testing.T) {
+	now := time.Date(2026, 3, 4, 18, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name           string
+		present        int
+		wantSuppressed bool
+		wantLevel      string
+	}{
+		{name: "empty gym is suppressed", present: 0, wantSuppressed: true, wantLevel: GymBusynessLevelUnknown},
+		{name: "single visitor is suppressed", present: 1, wantSuppressed: true, wantLevel: GymBusynessLevelUnknown},
+		{name: "below min count is suppressed", present: GymBusynessMinCount - 1, wantSuppressed: true, wantLevel: GymBusynessLevelUnknown},
+		{name: "min count is reported", present: GymBusynessMinCount, wantSuppressed: false, wantLevel: GymBusynessLevelQuiet},
+		{name: "peak occupancy is very busy", present: 20, wantSuppressed: false, wantLevel: GymBusynessLevelVeryBusy},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// 20 regulars each week make the typical peak 20 people
+			visits := busyHistory(now, 20, 4)
+			for user := 1; user <= tt.present; user++ {
+				visits = append(visits, GymVisit{UserID: uint(100 + user), Start: now.Add(-time.Hour), End: now.Add(time.Hour)})
+			}
+
+			current := BuildGymBusyness(1, visits, time.UTC, now).Current
+
+			if current.Suppressed != tt.wantSuppressed {
+				t.Errorf("Suppressed = %v, want %v", current.Suppressed, tt.wantSuppressed)
+			}
+			if current.Level != tt.wantLevel {
+				t.Errorf("Level = %q, want %q", current.Level, tt.wantLevel)
+			}
+			if tt.wantSuppressed && current.EstimatedOccupancy != nil {
+				t.Errorf("EstimatedOccupancy = %d, want nil", *current.EstimatedOccupancy)
+			}
+			if !tt.wantSuppressed && (current.EstimatedOccupancy == nil || *current.EstimatedOccupancy != tt.present) {
+				t.Errorf("EstimatedOccupancy = %v, want %d", current.EstimatedOccupancy, tt.present)
+			}
+		})
+	}
+}
+
+func TestBuildGymBusynessTypicalSuppressesSmallHours(t *testing.T) {
+	now := time.Date(2026, 3, 4, 18, 30, 0, 0, time.UTC)
+	visits := busyHistory(now, GymBusynessMinCount-1, 4)
+
+	response := BuildGymBusyness(1, visits, time.UTC, now)
+
+	for _, hour := range response.Typical {
+		if !hour.Suppressed || hour.AverageOccupancy != nil || hour.RelativeBusyness != nil {
+			t.Fatalf("hour %d:%02d is reported with fewer than %d distinct users", hour.DayOfWeek, hour.Hour, GymBusynessMinCount)
+		}
+	}
+	if response.Current.Level != GymBusynessLevelUnknown {
+		t.Errorf("Level = %q, want %q", response.Current.Level, GymBusynessLevelUnknown)
+	}
+}
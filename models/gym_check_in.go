@@ -0,0 +1,42 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Gym check-in source constants
+const (
+	GymCheckInSourceManual          = "manual"           // Checked in with POST /gyms/:id/check-ins
+	GymCheckInSourceTrainingSession = "training_session" // Checked in by starting a live training session
+)
+
+// MaxGymVisitDuration is how long a visit is assumed to last at most. Check-ins without a check-out
+// are treated as ended after this long
+const MaxGymVisitDuration = 4 * time.Hour
+
+// GymCheckIn records a user's visit to a gym. Users check in explicitly or by starting a live training
+// session at the gym, and a user has at most one open check-in at a time. Check-ins are only exposed
+// to other users as anonymous aggregates through the gym's busyness
+type GymCheckIn struct {
+	gorm.Model
+
+	GymID  uint `gorm:"not null;index" json:"gym_id"`
+	Gym    Gym  `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE;" json:"-"`
+	UserID uint `gorm:"not null;index" json:"user_id"`
+	User   User `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE;" json:"-"`
+
+	// Live training session that checked the user in, if any
+	TrainingSessionID *uint            `gorm:"uniqueIndex" json:"training_session_id,omitempty"`
+	TrainingSession   *TrainingSession `gorm:"constraint:OnUpdate:CASCADE,OnDelete:SET NULL;" json:"-"`
+
+	Source       string     `gorm:"size:20;not null" json:"source"` // manual or training_session
+	CheckedInAt  time.Time  `gorm:"not null;index" json:"checked_in_at"`
+	CheckedOutAt *time.Time `gorm:"index" json:"checked_out_at,omitempty"`
+}
+
+// IsOpen returns true if the user has not checked out and the visit has not timed out
+func (ci *GymCheckIn) IsOpen(now time.Time) bool {
+	return ci.CheckedOutAt == nil && now.Sub(ci.CheckedInAt) < MaxGymVisitDuration
+}
@@ -0,0 +1,29 @@
+package models
+
+import (
+	"time"
+)
+
+// GymCheckInResponse represents the user's own gym check-in returned in API responses
+type GymCheckInResponse struct {
+	ID                uint       `json:"id"`
+	GymID             uint       `json:"gym_id"`
+	TrainingSessionID *uint      `json:"training_session_id,omitempty"`
+	Source            string     `json:"source"`
+	CheckedInAt       time.Time  `json:"checked_in_at"`
+	CheckedOutAt      *time.Time `json:"checked_out_at,omitempty"`
+	Open              bool       `json:"open"`
+}
+
+// ToGymCheckInResponse converts a GymCheckIn model to a GymCheckInResponse DTO
+func (ci *GymCheckIn) ToGymCheckInResponse() *GymCheckInResponse {
+	return &GymCheckInResponse{
+		ID:                ci.ID,
+		GymID:             ci.GymID,
+		TrainingSessionID: ci.TrainingSessionID,
+		Source:            ci.Source,
+		CheckedInAt:       ci.CheckedInAt,
+		CheckedOutAt:      ci.CheckedOutAt,
+		Open:              ci.IsOpen(time.Now()),
+	}
+}
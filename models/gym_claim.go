@@ -0,0 +1,45 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Gym claim status constants
+const (
+	GymClaimStatusPending  = "pending"
+	GymClaimStatusApproved = "approved"
+	GymClaimStatusRejected = "rejected"
+)
+
+// GymClaim is a request from gym staff to become the owner of a gym. Once an admin approves the
+// claim the user becomes the gym owner and only they (and admins) can edit the gym
+type GymClaim struct {
+	gorm.Model
+
+	GymID  uint `gorm:"not null;index" json:"gym_id"`
+	Gym    Gym  `gorm:"foreignKey:GymID" json:"-"`
+	UserID uint `gorm:"not null;index" json:"user_id"`
+	User   User `gorm:"foreignKey:UserID" json:"-"`
+
+	// Claim details provided by the staff member
+	Role    string `gorm:"size:100" json:"role,omitempty"`     // e.g. "Owner", "General Manager"
+	Message string `gorm:"type:text" json:"message,omitempty"` // Evidence for the reviewer, e.g. a staff email address
+
+	// Review state
+	Status       string     `gorm:"size:20;not null;default:pending;index" json:"status"` // pending, approved, rejected
+	ReviewedByID *uint      `json:"reviewed_by_id,omitempty"`
+	ReviewedAt   *time.Time `json:"reviewed_at,omitempty"`
+	ReviewNote   string     `gorm:"type:text" json:"review_note,omitempty"`
+}
+
+// IsPending returns true if the claim has not been reviewed
+func (gc *GymClaim) IsPending() bool {
+	return gc.Status == GymClaimStatusPending
+}
+
+// IsValidGymClaimStatus returns true if the status is a known gym claim status
+func IsValidGymClaimStatus(status string) bool {
+	return status == GymClaimStatusPending || status == GymClaimStatusApproved || status == GymClaimStatusRejected
+}
@@ -0,0 +1,53 @@
+package models
+
+import (
+	"time"
+)
+
+// CreateGymClaimRequest represents the request body for claiming ownership of a gym
+type CreateGymClaimRequest struct {
+	Role    string `json:"role,omitempty" validate:"omitempty,max=100"`
+	Message string `json:"message" validate:"required,max=2000"`
+}
+
+// ReviewGymClaimRequest represents the optional request body for approving or rejecting a gym claim
+type ReviewGymClaimRequest struct {
+	Note string `json:"note,omitempty" validate:"omitempty,max=2000"`
+}
+
+// GymClaimResponse represents the gym claim data returned in API responses
+type GymClaimResponse struct {
+	ID           uint       `json:"id"`
+	GymID        uint       `json:"gym_id"`
+	GymName      string     `json:"gym_name,omitempty"`
+	UserID       uint       `json:"user_id"`
+	Username     string     `json:"username,omitempty"`
+	Role         string     `json:"role,omitempty"`
+	Message      string     `json:"message,omitempty"`
+	Status       string     `json:"status"`
+	ReviewedByID *uint      `json:"reviewed_by_id,omitempty"`
+	ReviewedAt   *time.Time `json:"reviewed_at,omitempty"`
+	ReviewNote   string     `json:"review_note,omitempty"`
+	CreatedAt    time.Time  `json:"created_at"`
+	UpdatedAt    time.Time  `json:"updated_at"`
+}
+
+// ToGymClaimResponse converts a GymClaim model to a GymClaimResponse DTO
+// Gym and User are included when they have been preloaded
+func (gc *GymClaim) ToGymClaimResponse() *GymClaimResponse {
+	return &GymClaimResponse{
+		ID:           gc.ID,
+		GymID:        gc.GymID,
+		GymName:      gc.Gym.Name,
+		UserID:       gc.UserID,
+		Username:     gc.User.Username,
+		Role:         gc.Role,
+		Message:      gc.Message,
+		Status:       gc.Status,
+		ReviewedByID: gc.ReviewedByID,
+		ReviewedAt:   gc.ReviewedAt,
+		ReviewNote:   gc.ReviewNote,
+		CreatedAt:    gc.CreatedAt,
+		UpdatedAt:    gc.UpdatedAt,
+	}
+}
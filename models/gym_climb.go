@@ -0,0 +1,229 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Gym climb discipline constants. Boulder problems are logged as indoor boulders and routes as rope climbs
+const (
+	GymClimbDisciplineBoulder = "boulder"
+	GymClimbDisciplineRope    = "rope"
+)
+
+// Style tag constants describing the character of a set problem or route
+const (
+	StyleTagCrimpy       = "crimpy"
+	StyleTagSlopey       = "slopey"
+	StyleTagPinchy       = "pinchy"
+	StyleTagJuggy        = "juggy"
+	StyleTagPockets      = "pockets"
+	StyleTagDyno         = "dyno"
+	StyleTagCoordination = "coordination"
+	StyleTagCompression  = "compression"
+	StyleTagBalance      = "balance"
+	StyleTagPowerful     = "powerful"
+	StyleTagEndurance    = "endurance"
+	StyleTagTechnical    = "technical"
+	StyleTagSlab         = "slab"
+	StyleTagVertical     = "vertical"
+	StyleTagOverhang     = "overhang"
+	StyleTagRoof         = "roof"
+)
+
+// MaxGymClimbStyleTags is the most style tags a gym climb can have
+const MaxGymClimbStyleTags = 8
+
+// validStyleTags is the set of accepted style tags
+var validStyleTags = map[string]bool{
+	StyleTagCrimpy: true, StyleTagSlopey: true, StyleTagPinchy: true, StyleTagJuggy: true,
+	StyleTagPockets: true, StyleTagDyno: true, StyleTagCoordination: true, StyleTagCompression: true,
+	StyleTagBalance: true, StyleTagPowerful: true, StyleTagEndurance: true, StyleTagTechnical: true,
+	StyleTagSlab: true, StyleTagVertical: true, StyleTagOverhang: true, StyleTagRoof: true,
+}
+
+// IsValidStyleTag returns true if the tag is a known style tag
+func IsValidStyleTag(tag string) bool {
+	return validStyleTags[tag]
+}
+
+// IsValidGymClimbDiscipline returns true if the discipline is boulder or rope
+func IsValidGymClimbDiscipline(discipline string) bool {
+	return discipline == GymClimbDisciplineBoulder || discipline == GymClimbDisciplineRope
+}
+
+// StyleTags is the list of style tags of a gym climb, stored as a JSON array
+type StyleTags []string
+
+// Value implements driver.Valuer so style tags can be written with both structs and update maps
+func (st StyleTags) Value() (driver.Value, error) {
+	if st == nil {
+		return nil, nil
+	}
+	data, err := json.Marshal([]string(st))
+	if err != nil {
+		return nil, err
+	}
+	return string(data), nil
+}
+
+// Scan implements sql.Scanner for reading style tags from the database
+func (st *StyleTags) Scan(value interface{}) error {
+	switch v := value.(type) {
+	case nil:
+		*st = nil
+		return nil
+	case []byte:
+		return json.Unmarshal(v, (*[]string)(st))
+	case string:
+		return json.Unmarshal([]byte(v), (*[]string)(st))
+	default:
+		return fmt.Errorf("unsupported style tags type %T", value)
+	}
+}
+
+// GymClimb is a boulder problem or route currently or previously set at a gym. Gym staff manage
+// the catalog, and climbers can reference an entry when they log an indoor boulder or rope climb
+// so the ascents and community grade of the problem can be aggregated
+type GymClimb struct {
+	gorm.Model
+
+	GymID uint `gorm:"not null;index" json:"gym_id"`
+	Gym   Gym  `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE;" json:"-"`
+
+	// Optional wall the climb is set on
+	GymWallID *uint    `gorm:"index" json:"gym_wall_id,omitempty"`
+	GymWall   *GymWall `json:"-"`
+
+	// Climb details
+	Discipline string    `gorm:"size:20;not null;index" json:"discipline"` // boulder or rope
+	Name       string    `gorm:"size:150" json:"name,omitempty"`
+	Color      string    `gorm:"size:50;not null" json:"color"`
+	Grade      string    `gorm:"size:20;not null" json:"grade"` // Setter's grade, V scale or YDS
+	Setter     string    `gorm:"size:100" json:"setter,omitempty"`
+	StyleTags  StyleTags `gorm:"type:jsonb" json:"style_tags,omitempty"`
+
+	// When the climb was set and when it was or will be stripped. Climbs without a strip date are still up
+	SetDate   time.Time  `gorm:"not null;index" json:"set_date"`
+	StripDate *time.Time `gorm:"index" json:"strip_date,omitempty"`
+
+	// Photo of the climb stored in S3
+	PhotoS3Key string `gorm:"size:500" json:"-"`
+}
+
+// IsBoulder returns true if the climb is a boulder problem
+func (gc *GymClimb) IsBoulder() bool {
+	return gc.Discipline == GymClimbDisciplineBoulder
+}
+
+// IsCurrent returns true if the climb has not been stripped as of the given time
+func (gc *GymClimb) IsCurrent(now time.Time) bool {
+	return gc.StripDate == nil || gc.StripDate.After(now)
+}
+
+// HasPhoto returns true if a photo has been uploaded for the climb
+func (gc *GymClimb) HasPhoto() bool {
+	return gc.PhotoS3Key != ""
+}
+
+// GymClimbLog is one logged indoor boulder or rope climb that references a gym climb, used to
+// aggregate the climb's ascents and community grade
+type GymClimbLog struct {
+	GymClimbID  uint
+	UserID      uint
+	Grade       string
+	Outcome     string
+	SessionDate time.Time
+}
+
+// IsSent returns true if the logged climb was sent. Indoor boulders and rope climbs share the
+// Flash, Onsite and Redpoint outcomes
+func (l *GymClimbLog) IsSent() bool {
+	return l.Outcome == IndoorBoulderOutcomeFlash || l.Outcome == IndoorBoulderOutcomeOnSight ||
+		l.Outcome == IndoorBoulderOutcomeRedpoint
+}
+
+// IsFlash returns true if the logged climb was sent on the first try
+func (l *GymClimbLog) IsFlash() bool {
+	return l.Outcome == IndoorBoulderOutcomeFlash || l.Outcome == IndoorBoulderOutcomeOnSight
+}
+
+// GymClimbStats aggregates the logs of a gym climb
+type GymClimbStats struct {
+	Logs     int `json:"logs"`     // Logged indoor boulders or rope climbs
+	Climbers int `json:"climbers"` // Distinct users who logged the climb
+	Ascents  int `json:"ascents"`  // Distinct users who sent the climb
+	Flashes  int `json:"flashes"`  // Distinct users who flashed or onsighted the climb
+
+	// Median grade voted by climbers, one vote per climber from their latest log on the
+	// setter's grading scale. Omitted until a climber has logged a recognized grade
+	CommunityGrade string         `json:"community_grade,omitempty"`
+	GradeVotes     map[string]int `json:"grade_votes,omitempty"`
+}
+
+// AggregateGymClimbLogs computes the stats of a gym climb from its logs. Only grades on the same
+// scale as the setter's grade count as votes
+func AggregateGymClimbLogs(climb *GymClimb, logs []GymClimbLog) *GymClimbStats {
+	stats := &GymClimbStats{}
+
+	climbers := map[uint]bool{}
+	senders := map[uint]bool{}
+	flashers := map[uint]bool{}
+	latest := map[uint]GymClimbLog{}
+	for _, log := range logs {
+		stats.Logs++
+		climbers[log.UserID] = true
+		if log.IsSent() {
+			senders[log.UserID] = true
+		}
+		if log.IsFlash() {
+			flashers[log.UserID] = true
+		}
+		if previous, ok := latest[log.UserID]; !ok || !log.SessionDate.Before(previous.SessionDate) {
+			latest[log.UserID] = log
+		}
+	}
+	stats.Climbers = len(climbers)
+	stats.Ascents = len(senders)
+	stats.Flashes = len(flashers)
+
+	scale, grades := climb.gradeScale()
+	var ranks []int
+	votes := map[string]int{}
+	for _, log := range latest {
+		voteScale, rank, ok := GradeRank(log.Grade)
+		if !ok || voteScale != scale {
+			continue
+		}
+		votes[grades[rank]]++
+		ranks = append(ranks, rank)
+	}
+	if len(ranks) > 0 {
+		sort.Ints(ranks)
+		stats.CommunityGrade = grades[ranks[(len(ranks)-1)/2]]
+		stats.GradeVotes = votes
+	}
+
+	return stats
+}
+
+// gradeScale returns the grading scale of the setter's grade and its grades from easiest to
+// hardest. Unrecognized grades fall back to the V scale for boulders and YDS for routes
+func (gc *GymClimb) gradeScale() (string, []string) {
+	scale, _, ok := GradeRank(gc.Grade)
+	if !ok {
+		scale = GradeScaleVScale
+		if !gc.IsBoulder() {
+			scale = GradeScaleYDS
+		}
+	}
+	if scale == GradeScaleYDS {
+		return scale, ydsGrades
+	}
+	return scale, vScaleGrades
+}
@@ -0,0 +1,100 @@
+package models
+
+import (
+	"time"
+)
+
+// CreateGymClimbRequest represents the request body for adding a set problem or route to a gym's catalog
+type CreateGymClimbRequest struct {
+	GymWallID  *uint      `json:"gym_wall_id,omitempty"`
+	Discipline string     `json:"discipline" validate:"required,oneof=boulder rope"`
+	Name       string     `json:"name,omitempty" validate:"omitempty,max=150"`
+	Color      string     `json:"color" validate:"required,min=1,max=50"`
+	Grade      string     `json:"grade" validate:"required,min=1,max=20"`
+	Setter     string     `json:"setter,omitempty" validate:"omitempty,max=100"`
+	StyleTags  []string   `json:"style_tags,omitempty"`
+	SetDate    *time.Time `json:"set_date,omitempty"` // Defaults to now
+	StripDate  *time.Time `json:"strip_date,omitempty"`
+}
+
+// UpdateGymClimbRequest represents the request body for updating a gym catalog climb
+// Only the fields present in the request are changed. Set strip_date to mark the climb as stripped
+type UpdateGymClimbRequest struct {
+	GymWallID  *uint      `json:"gym_wall_id"` // 0 removes the climb from its wall
+	Discipline *string    `json:"discipline" validate:"omitempty,oneof=boulder rope"`
+	Name       *string    `json:"name" validate:"omitempty,max=150"`
+	Color      *string    `json:"color" validate:"omitempty,min=1,max=50"`
+	Grade      *string    `json:"grade" validate:"omitempty,min=1,max=20"`
+	Setter     *string    `json:"setter" validate:"omitempty,max=100"`
+	StyleTags  *[]string  `json:"style_tags"`
+	SetDate    *time.Time `json:"set_date"`
+	StripDate  *time.Time `json:"strip_date"`
+}
+
+// GymClimbResponse represents a gym catalog climb returned in API responses
+type GymClimbResponse struct {
+	ID         uint       `json:"id"`
+	GymID      uint       `json:"gym_id"`
+	GymWallID  *uint      `json:"gym_wall_id,omitempty"`
+	WallName   string     `json:"wall_name,omitempty"`
+	Discipline string     `json:"discipline"`
+	Name       string     `json:"name,omitempty"`
+	Color      string     `json:"color"`
+	Grade      string     `json:"grade"`
+	Setter     string     `json:"setter,omitempty"`
+	StyleTags  []string   `json:"style_tags,omitempty"`
+	SetDate    time.Time  `json:"set_date"`
+	StripDate  *time.Time `json:"strip_date,omitempty"`
+	Current    bool       `json:"current"`
+	CreatedAt  time.Time  `json:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at"`
+
+	// Presigned photo URL, included when a photo has been uploaded
+	PhotoURL          string `json:"photo_url,omitempty"`
+	PhotoURLExpiresAt string `json:"photo_url_expires_at,omitempty"`
+
+	// Ascents and community grade aggregated from the climbs logged against this entry
+	Stats *GymClimbStats `json:"stats,omitempty"`
+}
+
+// ToGymClimbResponse converts a GymClimb model to a GymClimbResponse DTO
+// The wall name is included when the wall has been preloaded
+func (gc *GymClimb) ToGymClimbResponse() *GymClimbResponse {
+	response := &GymClimbResponse{
+		ID:         gc.ID,
+		GymID:      gc.GymID,
+		GymWallID:  gc.GymWallID,
+		Discipline: gc.Discipline,
+		Name:       gc.Name,
+		Color:      gc.Color,
+		Grade:      gc.Grade,
+		Setter:     gc.Setter,
+		StyleTags:  gc.StyleTags,
+		SetDate:    gc.SetDate,
+		StripDate:  gc.StripDate,
+		Current:    gc.IsCurrent(time.Now()),
+		CreatedAt:  gc.CreatedAt,
+		UpdatedAt:  gc.UpdatedAt,
+	}
+	if gc.GymWall != nil {
+		response.WallName = gc.GymWall.Name
+	}
+	return response
+}
+
+// ToCreateGymClimbRequest converts a gym climb back into a create request so that updates can be
+// merged into it and validated with the same rules as a new climb
+func (gc *GymClimb) ToCreateGymClimbRequest() *CreateGymClimbRequest {
+	setDate := gc.SetDate
+	return &CreateGymClimbRequest{
+		GymWallID:  gc.GymWallID,
+		Discipline: gc.Discipline,
+		Name:       gc.Name,
+		Color:      gc.Color,
+		Grade:      gc.Grade,
+		Setter:     gc.Setter,
+		StyleTags:  gc.StyleTags,
+		SetDate:    &setDate,
+		StripDate:  gc.StripDate,
+	}
+}
@@ -30,10 +30,17 @@ type CreateGymRequest struct {
 	Email   string `json:"email,omitempty" validate:"omitempty,email,max=200"`
 	Website string `json:"website,omitempty" validate:"omitempty,url,max=300"`
 
-	// Operating hours
-	Hours string `json:"hours,omitempty" validate:"omitempty,max=1000"`
+	// Operating hours. When opening_hours is omitted the free-form hours text is parsed into a
+	// weekly schedule where possible
+	Hours           string                     `json:"hours,omitempty" validate:"omitempty,max=1000"`
+	Timezone        string                     `json:"timezone,omitempty" validate:"omitempty,max=64"` // IANA name, e.g. "America/New_York"
+	OpeningHours    []GymOpeningHoursRequest   `json:"opening_hours,omitempty"`
+	HoursExceptions []GymHoursExceptionRequest `json:"hours_exceptions,omitempty"`
 
-	// Facilities and features
+	// Amenity slugs from the catalog, e.g. ["lead", "shower"]
+	Amenities []string `json:"amenities,omitempty"`
+
+	// Legacy facility flags, each adds its amenity when true
 	HasBouldering   bool `json:"has_bouldering"`
 	HasTopRope      bool `json:"has_top_rope"`
 	HasLeadClimbing bool `json:"has_lead_climbing"`
@@ -51,17 +58,75 @@ type CreateGymRequest struct {
 	WallHeight int `json:"wall_height,omitempty" validate:"omitempty,min=0"`
 	SquareFeet int `json:"square_feet,omitempty" validate:"omitempty,min=0"`
 
-	// Pricing
-	DayPassPrice    float64 `json:"day_pass_price,omitempty" validate:"omitempty,min=0"`
-	MonthlyPrice    float64 `json:"monthly_price,omitempty" validate:"omitempty,min=0"`
-	YearlyPrice     float64 `json:"yearly_price,omitempty" validate:"omitempty,min=0"`
-	GearRentalPrice float64 `json:"gear_rental_price,omitempty" validate:"omitempty,min=0"`
+	// Pricing tiers
+	Prices []GymPriceRequest `json:"prices,omitempty"`
+	LegacyGymPriceFields
 
 	// Additional information
 	Notes  string `json:"notes,omitempty" validate:"omitempty,max=5000"`
 	Active bool   `json:"active"`
 }
 
+// UpdateGymRequest represents the request body for updating an existing gym
+// Only the fields that are present are updated
+type UpdateGymRequest struct {
+	Name        *string `json:"name" validate:"omitempty,min=1,max=200"`
+	Type        *string `json:"type" validate:"omitempty,oneof=bouldering roped full"`
+	Description *string `json:"description" validate:"omitempty,max=5000"`
+
+	// Location details
+	Address    *string `json:"address" validate:"omitempty,max=300"`
+	City       *string `json:"city" validate:"omitempty,min=1,max=100"`
+	State      *string `json:"state" validate:"omitempty,max=100"`
+	Province   *string `json:"province" validate:"omitempty,max=100"`
+	Country    *string `json:"country" validate:"omitempty,min=1,max=100"`
+	PostalCode *string `json:"postal_code" validate:"omitempty,max=20"`
+
+	// GPS coordinates
+	Latitude  *float64 `json:"latitude"`
+	Longitude *float64 `json:"longitude"`
+
+	// Contact information
+	Phone   *string `json:"phone" validate:"omitempty,max=50"`
+	Email   *string `json:"email" validate:"omitempty,email,max=200"`
+	Website *string `json:"website" validate:"omitempty,url,max=300"`
+
+	// Operating hours. opening_hours and hours_exceptions replace the whole schedule when present
+	Hours           *string                     `json:"hours" validate:"omitempty,max=1000"`
+	Timezone        *string                     `json:"timezone" validate:"omitempty,max=64"`
+	OpeningHours    *[]GymOpeningHoursRequest   `json:"opening_hours"`
+	HoursExceptions *[]GymHoursExceptionRequest `json:"hours_exceptions"`
+
+	// Amenity slugs, replacing all of the gym's amenities when present
+	Amenities *[]string `json:"amenities"`
+
+	// Legacy facility flags, each adds or removes its amenity when present
+	HasBouldering   *bool `json:"has_bouldering"`
+	HasTopRope      *bool `json:"has_top_rope"`
+	HasLeadClimbing *bool `json:"has_lead_climbing"`
+	HasAutoBelay    *bool `json:"has_auto_belay"`
+	HasKidsArea     *bool `json:"has_kids_area"`
+	HasTrainingArea *bool `json:"has_training_area"`
+	HasYogaClasses  *bool `json:"has_yoga_classes"`
+	HasShower       *bool `json:"has_shower"`
+	HasParking      *bool `json:"has_parking"`
+	HasGearRental   *bool `json:"has_gear_rental"`
+	HasProShop      *bool `json:"has_pro_shop"`
+	HasCafe         *bool `json:"has_cafe"`
+
+	// Capacity and size
+	WallHeight *int `json:"wall_height" validate:"omitempty,min=0"`
+	SquareFeet *int `json:"square_feet" validate:"omitempty,min=0"`
+
+	// Pricing tiers, replacing all of the gym's prices when present
+	Prices *[]GymPriceRequest `json:"prices"`
+	LegacyGymPriceFields
+
+	// Additional information
+	Notes  *string `json:"notes" validate:"omitempty,max=5000"`
+	Active *bool   `json:"active"`
+}
+
 // FullGymResponse represents the complete gym data returned in API responses
 type FullGymResponse struct {
 	ID          uint   `json:"id"`
@@ -86,10 +151,19 @@ type FullGymResponse struct {
 	Email   string `json:"email,omitempty"`
 	Website string `json:"website,omitempty"`
 
-	// Operating hours
-	Hours string `json:"hours,omitempty"`
+	// Operating hours. open_now and next_change_at are only set when the gym has a timezone and
+	// a structured schedule
+	Hours           string                      `json:"hours,omitempty"`
+	Timezone        string                      `json:"timezone,omitempty"`
+	OpeningHours    []GymOpeningHoursResponse   `json:"opening_hours"`
+	HoursExceptions []GymHoursExceptionResponse `json:"hours_exceptions"`
+	OpenNow         *bool                       `json:"open_now"`
+	NextChangeAt    *time.Time                  `json:"next_change_at"`
+
+	// Amenities offered by the gym, by category and name
+	Amenities []AmenityResponse `json:"amenities"`
 
-	// Facilities and features
+	// Legacy facility flags, derived from the amenities
 	HasBouldering   bool `json:"has_bouldering"`
 	HasTopRope      bool `json:"has_top_rope"`
 	HasLeadClimbing bool `json:"has_lead_climbing"`
@@ -107,7 +181,11 @@ type FullGymResponse struct {
 	WallHeight int `json:"wall_height,omitempty"`
 	SquareFeet int `json:"square_feet,omitempty"`
 
-	// Pricing
+	// Pricing tiers
+	Prices []GymPriceResponse `json:"prices"`
+
+	// Legacy flat prices in major units, derived from the current adult tiers in the currency of
+	// the gym's country. 0 when the gym has no such tier
 	DayPassPrice    float64 `json:"day_pass_price,omitempty"`
 	MonthlyPrice    float64 `json:"monthly_price,omitempty"`
 	YearlyPrice     float64 `json:"yearly_price,omitempty"`
@@ -117,23 +195,123 @@ type FullGymResponse struct {
 	Notes  string `json:"notes,omitempty"`
 	Active bool   `json:"active"`
 
+	// Ownership
+	CreatedByID *uint `json:"created_by_id,omitempty"`
+	OwnerID     *uint `json:"owner_id,omitempty"`
+
+	// Identifier of the gym in the dataset it was imported from
+	ExternalID *string `json:"external_id,omitempty"`
+
+	// Ratings aggregated from the gym's visible reviews, rounded to two decimals. rating_average is
+	// null until the gym has been reviewed
+	RatingAverage     *float64 `json:"rating_average"`
+	RatingCount       int      `json:"rating_count"`
+	SettingRating     *float64 `json:"setting_rating"`
+	CrowdingRating    *float64 `json:"crowding_rating"`
+	CleanlinessRating *float64 `json:"cleanliness_rating"`
+	ValueRating       *float64 `json:"value_rating"`
+
+	// Great-circle distance from the search point, only set by nearby searches
+	DistanceKm *float64 `json:"distance_km,omitempty"`
+
 	// Metadata
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
 }
 
+// GymSearchResultResponse represents a gym matched by a text search
+type GymSearchResultResponse struct {
+	Gym   *FullGymResponse `json:"gym"`
+	Score float64          `json:"score"` // Relevance, higher is better
+
+	// Matched fragments keyed by field (name, city, state, country, description) with the
+	// matching words wrapped in <mark> tags. Text is HTML escaped
+	Highlights map[string]string `json:"highlights"`
+}
+
 // ToFullGymResponse converts a Gym model to a FullGymResponse DTO
+// Amenities, Prices, OpeningHours and HoursExceptions must be preloaded for the amenities, pricing
+// tiers, schedule and open now status
 func (g *Gym) ToFullGymResponse() *FullGymResponse {
-	return &FullGymResponse{
-		ID:              g.ID,
+	now := time.Now()
+	response := &FullGymResponse{
+		ID:                g.ID,
+		Name:              g.Name,
+		Description:       g.Description,
+		Type:              g.Type,
+		Address:           g.Address,
+		City:              g.City,
+		State:             g.State,
+		Province:          g.Province,
+		Country:           g.Country,
+		PostalCode:        g.PostalCode,
+		Latitude:          g.Latitude,
+		Longitude:         g.Longitude,
+		Phone:             g.Phone,
+		Email:             g.Email,
+		Website:           g.Website,
+		Hours:             g.Hours,
+		Timezone:          g.Timezone,
+		OpeningHours:      toGymOpeningHoursResponses(g.OpeningHours),
+		HoursExceptions:   toGymHoursExceptionResponses(g.HoursExceptions),
+		Amenities:         ToAmenityResponses(g.Amenities),
+		HasBouldering:     g.HasAmenity("bouldering"),
+		HasTopRope:        g.HasAmenity("top_rope"),
+		HasLeadClimbing:   g.HasAmenity("lead"),
+		HasAutoBelay:      g.HasAmenity("auto_belay"),
+		HasKidsArea:       g.HasAmenity("kids_area"),
+		HasTrainingArea:   g.HasAmenity("training_area"),
+		HasYogaClasses:    g.HasAmenity("yoga"),
+		HasShower:         g.HasAmenity("shower"),
+		HasParking:        g.HasAmenity("parking"),
+		HasGearRental:     g.HasAmenity("gear_rental"),
+		HasProShop:        g.HasAmenity("pro_shop"),
+		HasCafe:           g.HasAmenity("cafe"),
+		WallHeight:        g.WallHeight,
+		SquareFeet:        g.SquareFeet,
+		Prices:            toGymPriceResponses(g.Prices),
+		DayPassPrice:      g.LegacyPrice(GymPriceProductDayPass, now),
+		MonthlyPrice:      g.LegacyPrice(GymPriceProductMonthlyMembership, now),
+		YearlyPrice:       g.LegacyPrice(GymPriceProductAnnualMembership, now),
+		GearRentalPrice:   g.LegacyPrice(GymPriceProductGearRental, now),
+		Notes:             g.Notes,
+		Active:            g.Active,
+		CreatedByID:       g.CreatedByID,
+		OwnerID:           g.OwnerID,
+		ExternalID:        g.ExternalID,
+		RatingCount:       g.RatingCount,
+		SettingRating:     roundGymRating(g.SettingRating),
+		CrowdingRating:    roundGymRating(g.CrowdingRating),
+		CleanlinessRating: roundGymRating(g.CleanlinessRating),
+		ValueRating:       roundGymRating(g.ValueRating),
+		CreatedAt:         g.CreatedAt,
+		UpdatedAt:         g.UpdatedAt,
+	}
+
+	if g.RatingCount > 0 {
+		response.RatingAverage = roundGymRating(&g.RatingAverage)
+	}
+
+	if open, nextChange, known := g.OpenStatus(now); known {
+		response.OpenNow = &open
+		response.NextChangeAt = nextChange
+	}
+
+	return response
+}
+
+// ToCreateGymRequest returns the current gym fields as a CreateGymRequest so that partial updates
+// can be merged into it and validated with the same rules as a new gym
+func (g *Gym) ToCreateGymRequest() *CreateGymRequest {
+	return &CreateGymRequest{
 		Name:            g.Name,
-		Description:     g.Description,
 		Type:            g.Type,
-		Address:         g.Address,
 		City:            g.City,
+		Country:         g.Country,
+		Description:     g.Description,
+		Address:         g.Address,
 		State:           g.State,
 		Province:        g.Province,
-		Country:         g.Country,
 		PostalCode:      g.PostalCode,
 		Latitude:        g.Latitude,
 		Longitude:       g.Longitude,
@@ -141,27 +319,14 @@ func (g *Gym) ToFullGymResponse() *FullGymResponse {
 		Email:           g.Email,
 		Website:         g.Website,
 		Hours:           g.Hours,
-		HasBouldering:   g.HasBouldering,
-		HasTopRope:      g.HasTopRope,
-		HasLeadClimbing: g.HasLeadClimbing,
-		HasAutoBelay:    g.HasAutoBelay,
-		HasKidsArea:     g.HasKidsArea,
-		HasTrainingArea: g.HasTrainingArea,
-		HasYogaClasses:  g.HasYogaClasses,
-		HasShower:       g.HasShower,
-		HasParking:      g.HasParking,
-		HasGearRental:   g.HasGearRental,
-		HasProShop:      g.HasProShop,
-		HasCafe:         g.HasCafe,
+		Timezone:        g.Timezone,
+		OpeningHours:    toGymOpeningHoursRequests(g.OpeningHours),
+		HoursExceptions: toGymHoursExceptionRequests(g.HoursExceptions),
+		Amenities:       g.AmenitySlugs(),
 		WallHeight:      g.WallHeight,
 		SquareFeet:      g.SquareFeet,
-		DayPassPrice:    g.DayPassPrice,
-		MonthlyPrice:    g.MonthlyPrice,
-		YearlyPrice:     g.YearlyPrice,
-		GearRentalPrice: g.GearRentalPrice,
+		Prices:          toGymPriceRequests(g.Prices),
 		Notes:           g.Notes,
 		Active:          g.Active,
-		CreatedAt:       g.CreatedAt,
-		UpdatedAt:       g.UpdatedAt,
 	}
 }
@@ -0,0 +1,151 @@
+package models
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// Duplicate gym detection thresholds. A gym is a likely duplicate when its normalized name is
+// similar enough for how close it is: almost any shared name in the same building, a similar name
+// in the same neighbourhood, or a near identical name in the same city when coordinates are missing
+const (
+	GymDuplicateSameSiteKm       = 0.2
+	GymDuplicateSameSiteMinScore = 0.3
+	GymDuplicateNearbyKm         = 5
+	GymDuplicateNearbyMinScore   = 0.6
+	GymDuplicateSameCityMinScore = 0.7
+	MaxGymDuplicateCandidates    = 5
+)
+
+// gymNameStopWords are generic words dropped before comparing gym names
+var gymNameStopWords = map[string]bool{
+	"the": true, "and": true, "climbing": true, "gym": true, "gyms": true, "bouldering": true,
+	"center": true, "centre": true, "club": true, "co": true, "inc": true, "llc": true, "ltd": true,
+}
+
+// GymDuplicateCandidate is an existing gym that a new gym is likely a duplicate of
+type GymDuplicateCandidate struct {
+	ID             uint     `json:"id"`
+	Name           string   `json:"name"`
+	Address        string   `json:"address,omitempty"`
+	City           string   `json:"city"`
+	State          string   `json:"state,omitempty"`
+	Country        string   `json:"country"`
+	Latitude       *float64 `json:"latitude,omitempty"`
+	Longitude      *float64 `json:"longitude,omitempty"`
+	Active         bool     `json:"active"`
+	NameSimilarity float64  `json:"name_similarity"`       // 0-1, trigram similarity of the normalized names
+	DistanceKm     *float64 `json:"distance_km,omitempty"` // Only set when both gyms have coordinates
+}
+
+// NormalizeGymName lowercases a gym name, replaces punctuation with spaces and drops generic words
+// such as "climbing" and "gym" so that "The Movement Climbing Gym - Gowanus" matches "Movement Gowanus"
+func NormalizeGymName(name string) string {
+	words := strings.FieldsFunc(strings.ToLower(name), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	kept := make([]string, 0, len(words))
+	for _, word := range words {
+		if !gymNameStopWords[word] {
+			kept = append(kept, word)
+		}
+	}
+	if len(kept) == 0 {
+		// A name made only of generic words is compared as is
+		return strings.Join(words, " ")
+	}
+	return strings.Join(kept, " ")
+}
+
+// GymNameSimilarity returns the trigram similarity of two normalized gym names, from 0 for no
+// shared trigrams to 1 for identical names. Trigrams are built per word as pg_trgm does
+func GymNameSimilarity(a, b string) float64 {
+	ta, tb := nameTrigrams(a), nameTrigrams(b)
+	if len(ta) == 0 || len(tb) == 0 {
+		return 0
+	}
+	shared := 0
+	for trigram := range ta {
+		if tb[trigram] {
+			shared++
+		}
+	}
+	return float64(shared) / float64(len(ta)+len(tb)-shared)
+}
+
+// nameTrigrams returns the set of trigrams of the words in a name, each padded with two leading
+// spaces and one trailing space
+func nameTrigrams(name string) map[string]bool {
+	trigrams := map[string]bool{}
+	for _, word := range strings.Fields(name) {
+		padded := []rune("  " + word + " ")
+		for i := 0; i+3 <= len(padded); i++ {
+			trigrams[string(padded[i:i+3])] = true
+		}
+	}
+	return trigrams
+}
+
+// FindGymDuplicates returns the existing gyms that the gym is likely a duplicate of, most similar
+// first. The existing gyms should already be narrowed down to the same city or surrounding area
+func FindGymDuplicates(gym *Gym, existing []Gym) []GymDuplicateCandidate {
+	name := NormalizeGymName(gym.Name)
+	candidates := []GymDuplicateCandidate{}
+	for i := range existing {
+		other := &existing[i]
+		if other.ID == gym.ID {
+			continue
+		}
+		score := GymNameSimilarity(name, NormalizeGymName(other.Name))
+
+		var distance *float64
+		if gym.Latitude != nil && gym.Longitude != nil && other.Latitude != nil && other.Longitude != nil {
+			d := HaversineKm(*gym.Latitude, *gym.Longitude, *other.Latitude, *other.Longitude)
+			distance = &d
+		}
+		if !isLikelyGymDuplicate(gym, other, score, distance) {
+			continue
+		}
+
+		candidate := GymDuplicateCandidate{
+			ID:             other.ID,
+			Name:           other.Name,
+			Address:        other.Address,
+			City:           other.City,
+			State:          other.State,
+			Country:        other.Country,
+			Latitude:       other.Latitude,
+			Longitude:      other.Longitude,
+			Active:         other.Active,
+			NameSimilarity: math.Round(score*1000) / 1000,
+		}
+		if distance != nil {
+			rounded := math.Round(*distance*1000) / 1000
+			candidate.DistanceKm = &rounded
+		}
+		candidates = append(candidates, candidate)
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].NameSimilarity > candidates[j].NameSimilarity
+	})
+	if len(candidates) > MaxGymDuplicateCandidates {
+		candidates = candidates[:MaxGymDuplicateCandidates]
+	}
+	return candidates
+}
+
+// isLikelyGymDuplicate applies the duplicate thresholds to a pair of gyms
+func isLikelyGymDuplicate(gym, other *Gym, score float64, distanceKm *float64) bool {
+	if distanceKm != nil {
+		if *distanceKm <= GymDuplicateSameSiteKm && score >= GymDuplicateSameSiteMinScore {
+			return true
+		}
+		return *distanceKm <= GymDuplicateNearbyKm && score >= GymDuplicateNearbyMinScore
+	}
+	return strings.EqualFold(strings.TrimSpace(gym.City), strings.TrimSpace(other.City)) &&
+		strings.EqualFold(strings.TrimSpace(gym.Country), strings.TrimSpace(other.Country)) &&
+		score >= GymDuplicateSameCityMinScore
+}
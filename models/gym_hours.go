@@ -0,0 +1,221 @@
+package models
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Opening hours limits
+const (
+	MinutesPerDay            = 24 * 60
+	MaxGymIntervalsPerDay    = 6   // Opening intervals per day of the week or exception date
+	MaxGymHoursExceptions    = 366 // Dated exceptions per gym
+	gymScheduleLookaheadDays = 8   // Days searched for the next opening or closing time
+)
+
+// GymHoursDateFormat is the format of exception dates in requests and responses
+const GymHoursDateFormat = "2006-01-02"
+
+// GymOpeningHours is one opening interval of a gym's regular weekly schedule. Times are minutes
+// after local midnight in the gym's timezone. An interval that runs past midnight has a ClosesAt
+// greater than 1440 (e.g. 22:00-02:00 is 1320-1560)
+type GymOpeningHours struct {
+	gorm.Model
+
+	GymID     uint `gorm:"not null;index" json:"gym_id"`
+	DayOfWeek int  `gorm:"not null" json:"day_of_week"` // 0 = Sunday, as time.Weekday
+	OpensAt   int  `gorm:"not null" json:"opens_at"`
+	ClosesAt  int  `gorm:"not null" json:"closes_at"`
+}
+
+// GymHoursException overrides the weekly schedule on a specific date, e.g. a holiday closure or
+// an event with extended hours. A date can have several interval rows, or a single closed row
+type GymHoursException struct {
+	gorm.Model
+
+	GymID    uint      `gorm:"not null;index:idx_gym_hours_exception_date" json:"gym_id"`
+	Date     time.Time `gorm:"type:date;not null;index:idx_gym_hours_exception_date" json:"date"`
+	Label    string    `gorm:"size:100" json:"label,omitempty"` // e.g. "Christmas Day"
+	Closed   bool      `gorm:"not null;default:false" json:"closed"`
+	OpensAt  int       `gorm:"not null;default:0" json:"opens_at"` // Ignored when closed
+	ClosesAt int       `gorm:"not null;default:0" json:"closes_at"`
+}
+
+// DateKey returns the exception date formatted as YYYY-MM-DD
+func (e *GymHoursException) DateKey() string {
+	return e.Date.Format(GymHoursDateFormat)
+}
+
+// Location returns the gym's timezone, or false if none is set or it is not a valid IANA name
+func (g *Gym) Location() (*time.Location, bool) {
+	if g.Timezone == "" {
+		return nil, false
+	}
+	loc, err := time.LoadLocation(g.Timezone)
+	if err != nil {
+		return nil, false
+	}
+	return loc, true
+}
+
+// HasSchedule returns true if the gym has a timezone and structured opening hours
+func (g *Gym) HasSchedule() bool {
+	_, ok := g.Location()
+	return ok && (len(g.OpeningHours) > 0 || len(g.HoursExceptions) > 0)
+}
+
+// OpenStatus returns whether the gym is open at now and when it next opens or closes. The last
+// value is false when the gym has no schedule, in which case the status is unknown. The next
+// change is nil if the gym does not open or close again within the next week
+func (g *Gym) OpenStatus(now time.Time) (bool, *time.Time, bool) {
+	loc, ok := g.Location()
+	if !ok || !g.HasSchedule() {
+		return false, nil, false
+	}
+
+	intervals := g.scheduleIntervals(now.In(loc), loc)
+	for _, interval := range intervals {
+		if !now.Before(interval[0]) && now.Before(interval[1]) {
+			end := interval[1]
+			return true, &end, true
+		}
+		if interval[0].After(now) {
+			start := interval[0]
+			return false, &start, true
+		}
+	}
+	return false, nil, true
+}
+
+// scheduleIntervals returns the merged opening intervals that start between the day before local
+// and the end of the lookahead window, in chronological order
+func (g *Gym) scheduleIntervals(local time.Time, loc *time.Location) [][2]time.Time {
+	exceptions := map[string][]GymHoursException{}
+	for _, exception := range g.HoursExceptions {
+		exceptions[exception.DateKey()] = append(exceptions[exception.DateKey()], exception)
+	}
+
+	intervals := [][2]time.Time{}
+	add := func(day time.Time, opensAt, closesAt int) {
+		start := time.Date(day.Year(), day.Month(), day.Day(), 0, opensAt, 0, 0, loc)
+		end := time.Date(day.Year(), day.Month(), day.Day(), 0, closesAt, 0, 0, loc)
+		if end.After(start) {
+			intervals = append(intervals, [2]time.Time{start, end})
+		}
+	}
+
+	for offset := -1; offset <= gymScheduleLookaheadDays; offset++ {
+		day := time.Date(local.Year(), local.Month(), local.Day()+offset, 0, 0, 0, 0, loc)
+		if dayExceptions, ok := exceptions[day.Format(GymHoursDateFormat)]; ok {
+			for _, exception := range dayExceptions {
+				if !exception.Closed {
+					add(day, exception.OpensAt, exception.ClosesAt)
+				}
+			}
+			continue
+		}
+		for _, hours := range g.OpeningHours {
+			if hours.DayOfWeek == int(day.Weekday()) {
+				add(day, hours.OpensAt, hours.ClosesAt)
+			}
+		}
+	}
+
+	sort.Slice(intervals, func(i, j int) bool { return intervals[i][0].Before(intervals[j][0]) })
+
+	// Merge overlapping and back to back intervals so a gym open 22:00-24:00 and 00:00-06:00
+	// only changes state at 06:00
+	merged := [][2]time.Time{}
+	for _, interval := range intervals {
+		last := len(merged) - 1
+		if last >= 0 && !interval[0].After(merged[last][1]) {
+			if interval[1].After(merged[last][1]) {
+				merged[last][1] = interval[1]
+			}
+			continue
+		}
+		merged = append(merged, interval)
+	}
+	return merged
+}
+
+// ParseClockMinutes parses a HH:MM time into minutes after midnight. 24:00 is accepted as the
+// end of the day
+func ParseClockMinutes(clock string) (int, error) {
+	parts := strings.Split(clock, ":")
+	if len(parts) != 2 || len(parts[0]) == 0 || len(parts[0]) > 2 || len(parts[1]) != 2 {
+		return 0, fmt.Errorf("time %q must be in HH:MM format", clock)
+	}
+	hours, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return 0, fmt.Errorf("time %q must be in HH:MM format", clock)
+	}
+	minutes, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return 0, fmt.Errorf("time %q must be in HH:MM format", clock)
+	}
+	if hours < 0 || hours > 24 || minutes < 0 || minutes > 59 || (hours == 24 && minutes != 0) {
+		return 0, fmt.Errorf("time %q is not a valid time of day", clock)
+	}
+	return hours*60 + minutes, nil
+}
+
+// FormatClockMinutes formats minutes after midnight as HH:MM, wrapping times past midnight
+func FormatClockMinutes(minutes int) string {
+	if minutes > MinutesPerDay {
+		minutes -= MinutesPerDay
+	}
+	return fmt.Sprintf("%02d:%02d", minutes/60, minutes%60)
+}
+
+// ParseWeekday parses a day name such as "monday" or "mon" into a time.Weekday
+func ParseWeekday(day string) (time.Weekday, bool) {
+	day = strings.ToLower(strings.TrimSpace(day))
+	if len(day) < 3 {
+		return 0, false
+	}
+	for weekday := time.Sunday; weekday <= time.Saturday; weekday++ {
+		name := strings.ToLower(weekday.String())
+		if strings.HasPrefix(name, day) {
+			return weekday, true
+		}
+	}
+	return 0, false
+}
+
+// intervalMinutes converts opening and closing clock times into an interval, treating a closing
+// time at or before the opening time as the next day
+func intervalMinutes(opensAt, closesAt string) (int, int, error) {
+	opens, err := ParseClockMinutes(opensAt)
+	if err != nil {
+		return 0, 0, err
+	}
+	closes, err := ParseClockMinutes(closesAt)
+	if err != nil {
+		return 0, 0, err
+	}
+	if opens == MinutesPerDay {
+		return 0, 0, fmt.Errorf("opening time cannot be 24:00")
+	}
+	if closes <= opens {
+		closes += MinutesPerDay
+	}
+	return opens, closes, nil
+}
+
+// checkIntervalOverlap returns an error if any two intervals overlap
+func checkIntervalOverlap(intervals [][2]int, label string) error {
+	sorted := append([][2]int{}, intervals...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i][0] < sorted[j][0] })
+	for i := 1; i < len(sorted); i++ {
+		if sorted[i][0] < sorted[i-1][1] {
+			return fmt.Errorf("opening hours on %s overlap", label)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,210 @@
+package models
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// GymOpeningHoursRequest is one opening interval of the weekly schedule in a gym request
+// A closing time at or before the opening time is on the next day (e.g. 22:00-02:00)
+type GymOpeningHoursRequest struct {
+	Day      string `json:"day"`       // monday, tuesday, ... sunday
+	OpensAt  string `json:"opens_at"`  // HH:MM
+	ClosesAt string `json:"closes_at"` // HH:MM, 24:00 for midnight
+}
+
+// GymHoursIntervalRequest is one opening interval on an exception date
+type GymHoursIntervalRequest struct {
+	OpensAt  string `json:"opens_at"`
+	ClosesAt string `json:"closes_at"`
+}
+
+// GymHoursExceptionRequest overrides the weekly schedule on a date. Set closed to close for the
+// whole day, or give the intervals the gym is open instead
+type GymHoursExceptionRequest struct {
+	Date      string                    `json:"date"` // YYYY-MM-DD
+	Label     string                    `json:"label,omitempty"`
+	Closed    bool                      `json:"closed"`
+	Intervals []GymHoursIntervalRequest `json:"intervals,omitempty"`
+}
+
+// GymOpeningHoursResponse is one opening interval of the weekly schedule
+type GymOpeningHoursResponse struct {
+	Day      string `json:"day"`
+	OpensAt  string `json:"opens_at"`
+	ClosesAt string `json:"closes_at"`
+}
+
+// GymHoursIntervalResponse is one opening interval on an exception date
+type GymHoursIntervalResponse struct {
+	OpensAt  string `json:"opens_at"`
+	ClosesAt string `json:"closes_at"`
+}
+
+// GymHoursExceptionResponse is a dated override of the weekly schedule
+type GymHoursExceptionResponse struct {
+	Date      string                     `json:"date"`
+	Label     string                     `json:"label,omitempty"`
+	Closed    bool                       `json:"closed"`
+	Intervals []GymHoursIntervalResponse `json:"intervals,omitempty"`
+}
+
+// ToGymOpeningHours converts weekly schedule requests into models, validating the days and times
+func ToGymOpeningHours(requests []GymOpeningHoursRequest) ([]GymOpeningHours, error) {
+	hours := make([]GymOpeningHours, 0, len(requests))
+	byDay := map[time.Weekday][][2]int{}
+	for _, req := range requests {
+		day, ok := ParseWeekday(req.Day)
+		if !ok {
+			return nil, fmt.Errorf("day %q must be a day of the week (e.g., monday)", req.Day)
+		}
+		opens, closes, err := intervalMinutes(req.OpensAt, req.ClosesAt)
+		if err != nil {
+			return nil, err
+		}
+		byDay[day] = append(byDay[day], [2]int{opens, closes})
+		hours = append(hours, GymOpeningHours{DayOfWeek: int(day), OpensAt: opens, ClosesAt: closes})
+	}
+
+	for day, intervals := range byDay {
+		if len(intervals) > MaxGymIntervalsPerDay {
+			return nil, fmt.Errorf("a day can have at most %d opening intervals", MaxGymIntervalsPerDay)
+		}
+		if err := checkIntervalOverlap(intervals, strings.ToLower(day.String())); err != nil {
+			return nil, err
+		}
+	}
+	return hours, nil
+}
+
+// ToGymHoursExceptions converts exception requests into models, one row per interval or a single
+// closed row per date, validating the dates and times
+func ToGymHoursExceptions(requests []GymHoursExceptionRequest) ([]GymHoursException, error) {
+	if len(requests) > MaxGymHoursExceptions {
+		return nil, fmt.Errorf("a gym can have at most %d hours exceptions", MaxGymHoursExceptions)
+	}
+
+	exceptions := []GymHoursException{}
+	seen := map[string]bool{}
+	for _, req := range requests {
+		date, err := time.Parse(GymHoursDateFormat, req.Date)
+		if err != nil {
+			return nil, fmt.Errorf("exception date %q must be in YYYY-MM-DD format", req.Date)
+		}
+		if seen[req.Date] {
+			return nil, fmt.Errorf("exception date %s is listed more than once", req.Date)
+		}
+		seen[req.Date] = true
+
+		label := strings.TrimSpace(req.Label)
+		if len(label) > 100 {
+			return nil, fmt.Errorf("exception label must not exceed 100 characters")
+		}
+
+		if req.Closed {
+			if len(req.Intervals) > 0 {
+				return nil, fmt.Errorf("exception on %s cannot be closed and have opening intervals", req.Date)
+			}
+			exceptions = append(exceptions, GymHoursException{Date: date, Label: label, Closed: true})
+			continue
+		}
+
+		if len(req.Intervals) == 0 {
+			return nil, fmt.Errorf("exception on %s must be closed or have opening intervals", req.Date)
+		}
+		if len(req.Intervals) > MaxGymIntervalsPerDay {
+			return nil, fmt.Errorf("a day can have at most %d opening intervals", MaxGymIntervalsPerDay)
+		}
+		intervals := [][2]int{}
+		for _, interval := range req.Intervals {
+			opens, closes, err := intervalMinutes(interval.OpensAt, interval.ClosesAt)
+			if err != nil {
+				return nil, err
+			}
+			intervals = append(intervals, [2]int{opens, closes})
+			exceptions = append(exceptions, GymHoursException{Date: date, Label: label, OpensAt: opens, ClosesAt: closes})
+		}
+		if err := checkIntervalOverlap(intervals, req.Date); err != nil {
+			return nil, err
+		}
+	}
+	return exceptions, nil
+}
+
+// toGymOpeningHoursRequests converts the weekly schedule back into requests
+func toGymOpeningHoursRequests(hours []GymOpeningHours) []GymOpeningHoursRequest {
+	requests := make([]GymOpeningHoursRequest, len(hours))
+	for i, response := range toGymOpeningHoursResponses(hours) {
+		requests[i] = GymOpeningHoursRequest(response)
+	}
+	return requests
+}
+
+// toGymHoursExceptionRequests converts exceptions back into requests
+func toGymHoursExceptionRequests(exceptions []GymHoursException) []GymHoursExceptionRequest {
+	responses := toGymHoursExceptionResponses(exceptions)
+	requests := make([]GymHoursExceptionRequest, len(responses))
+	for i, response := range responses {
+		requests[i] = GymHoursExceptionRequest{Date: response.Date, Label: response.Label, Closed: response.Closed}
+		for _, interval := range response.Intervals {
+			requests[i].Intervals = append(requests[i].Intervals, GymHoursIntervalRequest(interval))
+		}
+	}
+	return requests
+}
+
+// toGymOpeningHoursResponses converts the weekly schedule into responses ordered Monday first
+func toGymOpeningHoursResponses(hours []GymOpeningHours) []GymOpeningHoursResponse {
+	sorted := append([]GymOpeningHours{}, hours...)
+	sort.Slice(sorted, func(i, j int) bool {
+		// Monday first, Sunday last
+		di, dj := (sorted[i].DayOfWeek+6)%7, (sorted[j].DayOfWeek+6)%7
+		if di != dj {
+			return di < dj
+		}
+		return sorted[i].OpensAt < sorted[j].OpensAt
+	})
+
+	responses := make([]GymOpeningHoursResponse, len(sorted))
+	for i, h := range sorted {
+		responses[i] = GymOpeningHoursResponse{
+			Day:      strings.ToLower(time.Weekday(h.DayOfWeek).String()),
+			OpensAt:  FormatClockMinutes(h.OpensAt),
+			ClosesAt: FormatClockMinutes(h.ClosesAt),
+		}
+	}
+	return responses
+}
+
+// toGymHoursExceptionResponses groups exception rows by date, in date order
+func toGymHoursExceptionResponses(exceptions []GymHoursException) []GymHoursExceptionResponse {
+	sorted := append([]GymHoursException{}, exceptions...)
+	sort.Slice(sorted, func(i, j int) bool {
+		if !sorted[i].Date.Equal(sorted[j].Date) {
+			return sorted[i].Date.Before(sorted[j].Date)
+		}
+		return sorted[i].OpensAt < sorted[j].OpensAt
+	})
+
+	responses := []GymHoursExceptionResponse{}
+	for _, exception := range sorted {
+		last := len(responses) - 1
+		if last < 0 || responses[last].Date != exception.DateKey() {
+			responses = append(responses, GymHoursExceptionResponse{
+				Date:   exception.DateKey(),
+				Label:  exception.Label,
+				Closed: exception.Closed,
+			})
+			last++
+		}
+		if !exception.Closed {
+			responses[last].Intervals = append(responses[last].Intervals, GymHoursIntervalResponse{
+				OpensAt:  FormatClockMinutes(exception.OpensAt),
+				ClosesAt: FormatClockMinutes(exception.ClosesAt),
+			})
+		}
+	}
+	return responses
+}
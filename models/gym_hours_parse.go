@@ -0,0 +1,279 @@
+package models
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// clockPattern matches a time of day such as "6", "6:30", "18:00", "6am", "6:30 p.m.", "noon"
+const clockPattern = `(?:(noon|midnight)|(\d{1,2})(?:[:.](\d{2}))?\s*(am\b|pm\b|a\.m\.|p\.m\.|a\b|p\b)?)`
+
+// timeRangeRegexp matches an opening interval such as "6am-11pm", "06:00 - 23:00" or "9 to 5pm"
+var timeRangeRegexp = regexp.MustCompile(`(?i)` + clockPattern + `\s*(?:-|–|—|to|until)\s*` + clockPattern)
+
+// dayRangeRegexp matches a range of days such as "mon-fri" or "monday through friday"
+var dayRangeRegexp = regexp.MustCompile(`^([a-z]+)\s*(?:-|–|—|to|through|thru)\s*([a-z]+)$`)
+
+// parsedClock is a time of day parsed from free text, with the meridiem when one was given
+type parsedClock struct {
+	minutes  int
+	meridiem string // "am", "pm" or "" for 24 hour times
+}
+
+// ParseOpeningHoursText parses a free-form hours description such as
+// "Mon-Fri 6am-11pm, Sat-Sun 8am-10pm" or "Daily: 10:00-22:00; Mon closed" into a weekly
+// schedule. The second value is false when any part of the text could not be understood, so
+// callers only replace free text they can represent faithfully
+func ParseOpeningHoursText(text string) ([]GymOpeningHours, bool) {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return nil, false
+	}
+
+	switch strings.ToLower(text) {
+	case "24/7", "open 24/7", "24 hours", "open 24 hours", "24 hours a day":
+		hours := make([]GymOpeningHours, 0, 7)
+		for day := time.Sunday; day <= time.Saturday; day++ {
+			hours = append(hours, GymOpeningHours{DayOfWeek: int(day), OpensAt: 0, ClosesAt: MinutesPerDay})
+		}
+		return hours, true
+	}
+
+	// Later segments override earlier ones for the days they name, so
+	// "Daily 10am-10pm, Mon closed" leaves Monday closed
+	schedule := map[time.Weekday][][2]int{}
+	var days []time.Weekday        // Days of the previous segment, used by segments with only times
+	var pendingDays []time.Weekday // Days listed without times, e.g. "Sat, Sun 8am-10pm"
+
+	for _, segment := range regexp.MustCompile(`[,;\n|]+`).Split(text, -1) {
+		segment = strings.TrimSpace(strings.ToLower(segment))
+		if segment == "" {
+			continue
+		}
+
+		ranges := timeRangeRegexp.FindAllStringSubmatchIndex(segment, -1)
+		daysText := segment
+		if len(ranges) > 0 {
+			daysText = segment[:ranges[0][0]]
+		}
+		daysText = strings.Trim(strings.TrimSpace(daysText), ":")
+
+		closed := false
+		if strings.HasPrefix(daysText, "closed") || strings.HasSuffix(daysText, "closed") {
+			closed = true
+			daysText = strings.TrimSpace(strings.TrimSuffix(strings.TrimPrefix(daysText, "closed"), "closed"))
+			daysText = strings.Trim(strings.TrimSpace(daysText), ":")
+		}
+
+		if daysText != "" {
+			parsed, ok := parseDaysText(daysText)
+			if !ok {
+				return nil, false
+			}
+			days = append(pendingDays, parsed...)
+			pendingDays = nil
+		}
+
+		if closed {
+			if len(ranges) > 0 || len(days) == 0 {
+				return nil, false
+			}
+			for _, day := range days {
+				schedule[day] = [][2]int{}
+			}
+			continue
+		}
+
+		if len(ranges) == 0 {
+			// Days without times belong to the next segment
+			if daysText == "" {
+				return nil, false
+			}
+			pendingDays = days
+			continue
+		}
+		if len(days) == 0 {
+			return nil, false
+		}
+
+		// Everything between and after the time ranges must be separators
+		rest := segment[ranges[0][0]:]
+		for _, match := range timeRangeRegexp.FindAllString(rest, -1) {
+			rest = strings.Replace(rest, match, " ", 1)
+		}
+		if strings.Trim(rest, " &/+and") != "" {
+			return nil, false
+		}
+
+		if daysText != "" {
+			for _, day := range days {
+				schedule[day] = [][2]int{}
+			}
+		}
+		for _, match := range ranges {
+			opens, closes, ok := parseTimeRange(segment, match)
+			if !ok {
+				return nil, false
+			}
+			for _, day := range days {
+				schedule[day] = append(schedule[day], [2]int{opens, closes})
+			}
+		}
+	}
+
+	if len(pendingDays) > 0 {
+		return nil, false
+	}
+
+	// Reject schedules that list the same day twice with overlapping times
+	hours := []GymOpeningHours{}
+	for day := time.Sunday; day <= time.Saturday; day++ {
+		intervals := schedule[day]
+		if len(intervals) > MaxGymIntervalsPerDay || checkIntervalOverlap(intervals, day.String()) != nil {
+			return nil, false
+		}
+		for _, interval := range intervals {
+			hours = append(hours, GymOpeningHours{DayOfWeek: int(day), OpensAt: interval[0], ClosesAt: interval[1]})
+		}
+	}
+	if len(hours) == 0 {
+		return nil, false
+	}
+
+	return hours, true
+}
+
+// parseDaysText parses day names, ranges and lists such as "mon-fri", "sat & sun",
+// "weekdays" or "daily"
+func parseDaysText(text string) ([]time.Weekday, bool) {
+	switch text {
+	case "daily", "every day", "everyday", "7 days", "7 days a week", "all week", "mon-sun", "monday-sunday":
+		return []time.Weekday{time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday, time.Saturday, time.Sunday}, true
+	case "weekdays":
+		return []time.Weekday{time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday}, true
+	case "weekends", "weekend":
+		return []time.Weekday{time.Saturday, time.Sunday}, true
+	}
+
+	days := []time.Weekday{}
+	for _, part := range regexp.MustCompile(`\s*(?:&|/|\+|\band\b)\s*`).Split(text, -1) {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		if match := dayRangeRegexp.FindStringSubmatch(part); match != nil {
+			from, ok := ParseWeekday(match[1])
+			if !ok {
+				return nil, false
+			}
+			to, ok := ParseWeekday(match[2])
+			if !ok {
+				return nil, false
+			}
+			for day := from; ; day = (day + 1) % 7 {
+				days = append(days, day)
+				if day == to {
+					break
+				}
+			}
+			continue
+		}
+
+		day, ok := ParseWeekday(strings.TrimSuffix(part, "s"))
+		if !ok {
+			return nil, false
+		}
+		days = append(days, day)
+	}
+	return days, len(days) > 0
+}
+
+// parseTimeRange converts a timeRangeRegexp match into an interval in minutes
+func parseTimeRange(text string, match []int) (int, int, bool) {
+	group := func(i int) string {
+		if match[2*i] < 0 {
+			return ""
+		}
+		return text[match[2*i]:match[2*i+1]]
+	}
+
+	opens, ok := parseClock(group(1), group(2), group(3), group(4))
+	if !ok {
+		return 0, 0, false
+	}
+	closes, ok := parseClock(group(5), group(6), group(7), group(8))
+	if !ok {
+		return 0, 0, false
+	}
+
+	// "6-11pm" means 6pm and "10-6pm" means 10am: an opening time without a meridiem takes the
+	// closing time's meridiem when that keeps it before the closing time
+	if opens.meridiem == "" && closes.meridiem != "" && opens.minutes < 12*60 {
+		opens.minutes = applyMeridiem(opens.minutes, "am")
+		if candidate := applyMeridiem(opens.minutes, closes.meridiem); candidate < closes.minutes {
+			opens.minutes = candidate
+		}
+	}
+
+	opensAt, closesAt := opens.minutes, closes.minutes
+	if opensAt >= MinutesPerDay {
+		return 0, 0, false
+	}
+	if closesAt <= opensAt {
+		closesAt += MinutesPerDay
+	}
+	return opensAt, closesAt, true
+}
+
+// parseClock converts the groups of a clockPattern match into minutes after midnight
+func parseClock(word, hoursText, minutesText, meridiemText string) (parsedClock, bool) {
+	switch word {
+	case "noon":
+		return parsedClock{minutes: 12 * 60, meridiem: "pm"}, true
+	case "midnight":
+		return parsedClock{minutes: MinutesPerDay, meridiem: "am"}, true
+	}
+
+	hours, err := strconv.Atoi(hoursText)
+	if err != nil {
+		return parsedClock{}, false
+	}
+	minutes := 0
+	if minutesText != "" {
+		if minutes, err = strconv.Atoi(minutesText); err != nil || minutes > 59 {
+			return parsedClock{}, false
+		}
+	}
+
+	meridiem := strings.ReplaceAll(meridiemText, ".", "")
+	switch meridiem {
+	case "a":
+		meridiem = "am"
+	case "p":
+		meridiem = "pm"
+	}
+
+	if meridiem != "" {
+		if hours < 1 || hours > 12 {
+			return parsedClock{}, false
+		}
+		return parsedClock{minutes: applyMeridiem(hours*60+minutes, meridiem), meridiem: meridiem}, true
+	}
+
+	if hours > 24 || (hours == 24 && minutes != 0) {
+		return parsedClock{}, false
+	}
+	return parsedClock{minutes: hours*60 + minutes}, true
+}
+
+// applyMeridiem converts a 12 hour clock time in minutes to a 24 hour clock time
+func applyMeridiem(minutes int, meridiem string) int {
+	hours := (minutes / 60) % 12
+	if meridiem == "pm" {
+		hours += 12
+	}
+	return hours*60 + minutes%60
+}
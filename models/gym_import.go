@@ -0,0 +1,284 @@
+package models
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Gym import format constants
+const (
+	GymImportFormatCSV     = "csv"
+	GymImportFormatGeoJSON = "geojson"
+)
+
+// Gym import limits. Rows are validated, looked up and written one batch at a time so that large
+// files do not hold a single long transaction, and the report lists a bounded number of row errors
+const (
+	DefaultGymImportBatchSize = 500
+	MaxGymImportBatchSize     = 1000
+	MaxGymImportErrors        = 1000
+)
+
+// GymImportOptions controls how an import file is read and applied
+type GymImportOptions struct {
+	Format    string // csv or geojson
+	DryRun    bool   // Validate and report without writing
+	BatchSize int
+
+	// Mapping overrides the source column or property read for a gym field, e.g. {"name": "title"}
+	Mapping map[string]string
+
+	// Defaults for rows that leave the field empty, e.g. a dataset of bouldering gyms
+	DefaultType    string
+	DefaultCountry string
+
+	CreatedByID *uint // Recorded as the creator of new gyms
+}
+
+// GymImportRow is one gym read from an import file
+type GymImportRow struct {
+	Row        int // 1-based position in the file, not counting the CSV header
+	ExternalID string
+	Request    CreateGymRequest
+
+	// Fields are the gym fields present in the source. Only these are written when the row updates
+	// an existing gym
+	Fields []string
+
+	Err error // Set when the row could not be mapped onto a gym
+}
+
+// GymImportRowError describes a row that was not imported
+type GymImportRowError struct {
+	Row        int    `json:"row"`
+	ExternalID string `json:"external_id,omitempty"`
+	Name       string `json:"name,omitempty"`
+	Error      string `json:"error"`
+}
+
+// GymImportReport summarizes an import. In a dry run created and updated are the rows that would
+// have been created and updated
+type GymImportReport struct {
+	Format          string              `json:"format"`
+	DryRun          bool                `json:"dry_run"`
+	BatchSize       int                 `json:"batch_size"`
+	Batches         int                 `json:"batches"`
+	Rows            int                 `json:"rows"`
+	Created         int                 `json:"created"`
+	Updated         int                 `json:"updated"`
+	Failed          int                 `json:"failed"`
+	Errors          []GymImportRowError `json:"errors"`
+	ErrorsTruncated bool                `json:"errors_truncated"` // More rows failed than are listed
+}
+
+// AddError records a row that was not imported
+func (r *GymImportReport) AddError(row *GymImportRow, err error) {
+	r.Failed++
+	if len(r.Errors) >= MaxGymImportErrors {
+		r.ErrorsTruncated = true
+		return
+	}
+	r.Errors = append(r.Errors, GymImportRowError{
+		Row:        row.Row,
+		ExternalID: row.ExternalID,
+		Name:       row.Request.Name,
+		Error:      err.Error(),
+	})
+}
+
+// gymImportField maps source values onto one field of a gym request
+type gymImportField struct {
+	aliases []string // Source keys tried in order when the field is not mapped explicitly
+	set     func(req *CreateGymRequest, value string) error
+}
+
+// gymImportFields are the gym fields an import can set, keyed by their gym column name. The
+// aliases cover common spreadsheet headers and OpenStreetMap tags
+var gymImportFields = map[string]gymImportField{
+	"external_id": {aliases: []string{"external_id", "id", "@id", gymImportFeatureIDKey}},
+	"name":        {aliases: []string{"name", "gym_name", "title"}, set: setGymImportString(func(r *CreateGymRequest) *string { return &r.Name })},
+	"type": {aliases: []string{"type", "gym_type"}, set: func(req *CreateGymRequest, value string) error {
+		req.Type = strings.ToLower(value)
+		return nil
+	}},
+	"description": {aliases: []string{"description"}, set: setGymImportString(func(r *CreateGymRequest) *string { return &r.Description })},
+	"address":     {aliases: []string{"address", "street_address", "addr:full"}, set: setGymImportString(func(r *CreateGymRequest) *string { return &r.Address })},
+	"city":        {aliases: []string{"city", "town", "addr:city"}, set: setGymImportString(func(r *CreateGymRequest) *string { return &r.City })},
+	"state":       {aliases: []string{"state", "region", "addr:state"}, set: setGymImportString(func(r *CreateGymRequest) *string { return &r.State })},
+	"province":    {aliases: []string{"province", "addr:province"}, set: setGymImportString(func(r *CreateGymRequest) *string { return &r.Province })},
+	"country":     {aliases: []string{"country", "country_code", "addr:country"}, set: setGymImportString(func(r *CreateGymRequest) *string { return &r.Country })},
+	"postal_code": {aliases: []string{"postal_code", "postcode", "zip", "zip_code", "addr:postcode"}, set: setGymImportString(func(r *CreateGymRequest) *string { return &r.PostalCode })},
+	"latitude":    {aliases: []string{"latitude", "lat", gymImportLatitudeKey}, set: setGymImportCoordinate(func(r *CreateGymRequest) **float64 { return &r.Latitude })},
+	"longitude":   {aliases: []string{"longitude", "lng", "lon", "long", gymImportLongitudeKey}, set: setGymImportCoordinate(func(r *CreateGymRequest) **float64 { return &r.Longitude })},
+	"phone":       {aliases: []string{"phone", "telephone", "contact:phone"}, set: setGymImportString(func(r *CreateGymRequest) *string { return &r.Phone })},
+	"email":       {aliases: []string{"email", "contact:email"}, set: setGymImportString(func(r *CreateGymRequest) *string { return &r.Email })},
+	"website":     {aliases: []string{"website", "url", "contact:website"}, set: setGymImportString(func(r *CreateGymRequest) *string { return &r.Website })},
+	"hours":       {aliases: []string{"hours", "opening_hours"}, set: setGymImportString(func(r *CreateGymRequest) *string { return &r.Hours })},
+	"timezone":    {aliases: []string{"timezone", "tz"}, set: setGymImportString(func(r *CreateGymRequest) *string { return &r.Timezone })},
+	"amenities": {aliases: []string{"amenities"}, set: func(req *CreateGymRequest, value string) error {
+		req.Amenities = strings.FieldsFunc(value, func(r rune) bool { return r == ',' || r == ';' || r == '|' })
+		return nil
+	}},
+	"wall_height": {aliases: []string{"wall_height"}, set: setGymImportInt(func(r *CreateGymRequest) *int { return &r.WallHeight })},
+	"square_feet": {aliases: []string{"square_feet", "sq_ft"}, set: setGymImportInt(func(r *CreateGymRequest) *int { return &r.SquareFeet })},
+	"notes":       {aliases: []string{"notes"}, set: setGymImportString(func(r *CreateGymRequest) *string { return &r.Notes })},
+	"active": {aliases: []string{"active"}, set: func(req *CreateGymRequest, value string) error {
+		switch strings.ToLower(value) {
+		case "true", "t", "yes", "y", "1":
+			req.Active = true
+		case "false", "f", "no", "n", "0":
+			req.Active = false
+		default:
+			return fmt.Errorf("active %q must be true or false", value)
+		}
+		return nil
+	}},
+}
+
+// Source keys set by the GeoJSON reader from the feature rather than its properties
+const (
+	gymImportFeatureIDKey = "@feature_id"
+	gymImportLatitudeKey  = "@geometry_latitude"
+	gymImportLongitudeKey = "@geometry_longitude"
+)
+
+// GymImportFields returns the names of the gym fields an import can set, sorted
+func GymImportFields() []string {
+	fields := make([]string, 0, len(gymImportFields))
+	for field := range gymImportFields {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+	return fields
+}
+
+// ParseGymImportMapping parses a comma separated list of field=source pairs, e.g.
+// "name=title,city=town", into an import mapping
+func ParseGymImportMapping(raw string) (map[string]string, error) {
+	mapping := map[string]string{}
+	for _, pair := range strings.Split(raw, ",") {
+		if strings.TrimSpace(pair) == "" {
+			continue
+		}
+		field, source, ok := strings.Cut(pair, "=")
+		field = strings.ToLower(strings.TrimSpace(field))
+		source = strings.ToLower(strings.TrimSpace(source))
+		if !ok || field == "" || source == "" {
+			return nil, fmt.Errorf("mapping %q must be in field=source format", pair)
+		}
+		if _, known := gymImportFields[field]; !known {
+			return nil, fmt.Errorf("mapping field %q must be one of: %s", field, strings.Join(GymImportFields(), ", "))
+		}
+		mapping[field] = source
+	}
+	return mapping, nil
+}
+
+// DetectGymImportFormat returns the import format implied by a file name or content type, or an
+// empty string when neither is recognized
+func DetectGymImportFormat(filename, contentType string) string {
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".csv":
+		return GymImportFormatCSV
+	case ".geojson", ".json":
+		return GymImportFormatGeoJSON
+	}
+	contentType = strings.ToLower(contentType)
+	switch {
+	case strings.HasPrefix(contentType, "text/csv"):
+		return GymImportFormatCSV
+	case strings.HasPrefix(contentType, "application/geo+json"), strings.HasPrefix(contentType, "application/json"):
+		return GymImportFormatGeoJSON
+	}
+	return ""
+}
+
+// IsValidGymImportFormat returns true if the format is csv or geojson
+func IsValidGymImportFormat(format string) bool {
+	return format == GymImportFormatCSV || format == GymImportFormatGeoJSON
+}
+
+// newGymImportRow maps a source record onto a gym request. Keys are lowercase column names or
+// property names. New gyms are active unless the source says otherwise
+func newGymImportRow(row int, record map[string]string, mapping map[string]string) *GymImportRow {
+	r := &GymImportRow{Row: row, Request: CreateGymRequest{Active: true}}
+
+	for _, field := range GymImportFields() {
+		value, ok := gymImportValue(record, field, mapping)
+		if !ok {
+			continue
+		}
+		if field == "external_id" {
+			r.ExternalID = value
+			continue
+		}
+		if err := gymImportFields[field].set(&r.Request, value); err != nil {
+			// Keep reading the row so the report can name the gym
+			if r.Err == nil {
+				r.Err = err
+			}
+			continue
+		}
+		r.Fields = append(r.Fields, field)
+	}
+
+	// OpenStreetMap splits the street address into parts
+	if r.Request.Address == "" && mapping["address"] == "" {
+		street := strings.TrimSpace(record["addr:housenumber"] + " " + record["addr:street"])
+		if street != "" {
+			r.Request.Address = street
+			r.Fields = append(r.Fields, "address")
+		}
+	}
+	return r
+}
+
+// gymImportValue returns the non-empty source value for a gym field, from the mapped source key or
+// the first alias present in the record
+func gymImportValue(record map[string]string, field string, mapping map[string]string) (string, bool) {
+	keys := gymImportFields[field].aliases
+	if source, ok := mapping[field]; ok {
+		keys = []string{source}
+	}
+	for _, key := range keys {
+		if value := strings.TrimSpace(record[key]); value != "" {
+			return value, true
+		}
+	}
+	return "", false
+}
+
+// setGymImportString returns a setter for a text field
+func setGymImportString(field func(*CreateGymRequest) *string) func(*CreateGymRequest, string) error {
+	return func(req *CreateGymRequest, value string) error {
+		*field(req) = value
+		return nil
+	}
+}
+
+// setGymImportInt returns a setter for a whole number field
+func setGymImportInt(field func(*CreateGymRequest) *int) func(*CreateGymRequest, string) error {
+	return func(req *CreateGymRequest, value string) error {
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			return fmt.Errorf("%q must be a whole number", value)
+		}
+		*field(req) = n
+		return nil
+	}
+}
+
+// setGymImportCoordinate returns a setter for a latitude or longitude
+func setGymImportCoordinate(field func(*CreateGymRequest) **float64) func(*CreateGymRequest, string) error {
+	return func(req *CreateGymRequest, value string) error {
+		n, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return fmt.Errorf("coordinate %q must be a number", value)
+		}
+		*field(req) = &n
+		return nil
+	}
+}
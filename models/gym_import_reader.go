@@ -0,0 +1,286 @@
+package models
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// GymImportReader streams the gyms of a CSV or GeoJSON import file one row at a time so that large
+// files are never held in memory as a whole
+type GymImportReader struct {
+	source  gymImportSource
+	mapping map[string]string
+	row     int
+}
+
+// gymImportSource yields the records of an import file keyed by lowercase column or property name
+type gymImportSource interface {
+	next() (map[string]string, error)
+}
+
+// NewGymImportReader returns a reader for an import file in the given format. It fails when the
+// file does not start with a CSV header or a GeoJSON FeatureCollection
+func NewGymImportReader(r io.Reader, format string, mapping map[string]string) (*GymImportReader, error) {
+	var source gymImportSource
+	var err error
+	switch format {
+	case GymImportFormatCSV:
+		source, err = newCSVGymImportSource(r)
+	case GymImportFormatGeoJSON:
+		source, err = newGeoJSONGymImportSource(r)
+	default:
+		return nil, fmt.Errorf("format must be one of: %s, %s", GymImportFormatCSV, GymImportFormatGeoJSON)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &GymImportReader{source: source, mapping: mapping}, nil
+}
+
+// Next returns the next row of the file, or io.EOF after the last one. Rows that cannot be mapped
+// onto a gym are returned with Err set. Any other error means the rest of the file is unreadable
+func (r *GymImportReader) Next() (*GymImportRow, error) {
+	record, err := r.source.next()
+	if err == io.EOF {
+		return nil, io.EOF
+	}
+	r.row++
+
+	var rowErr *gymImportRowError
+	if errors.As(err, &rowErr) {
+		return &GymImportRow{Row: r.row, Err: rowErr.err}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("row %d: %w", r.row, err)
+	}
+	return newGymImportRow(r.row, record, r.mapping), nil
+}
+
+// gymImportRowError is a source error that only affects the current row
+type gymImportRowError struct {
+	err error
+}
+
+func (e *gymImportRowError) Error() string {
+	return e.err.Error()
+}
+
+// csvGymImportSource reads records from a CSV file with a header row
+type csvGymImportSource struct {
+	reader *csv.Reader
+	header []string
+}
+
+func newCSVGymImportSource(r io.Reader) (*csvGymImportSource, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, fmt.Errorf("CSV file is empty")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CSV header: %w", err)
+	}
+	for i, column := range header {
+		if i == 0 {
+			column = strings.TrimPrefix(column, "\ufeff") // Byte order mark written by spreadsheet apps
+		}
+		header[i] = strings.ToLower(strings.TrimSpace(column))
+	}
+	return &csvGymImportSource{reader: reader, header: header}, nil
+}
+
+func (s *csvGymImportSource) next() (map[string]string, error) {
+	values, err := s.reader.Read()
+	if err == io.EOF {
+		return nil, io.EOF
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(values) > len(s.header) {
+		return nil, &gymImportRowError{err: fmt.Errorf("row has %d values but the header has %d columns", len(values), len(s.header))}
+	}
+
+	record := make(map[string]string, len(values))
+	for i, value := range values {
+		record[s.header[i]] = value
+	}
+	return record, nil
+}
+
+// geoJSONGymImportSource reads the features of a GeoJSON FeatureCollection one at a time
+type geoJSONGymImportSource struct {
+	decoder *json.Decoder
+	done    bool
+}
+
+// geoJSONFeature is a GeoJSON feature. Properties are decoded as generic values since datasets
+// store numbers and flags as either strings or JSON values
+type geoJSONFeature struct {
+	ID         json.RawMessage        `json:"id"`
+	Geometry   *geoJSONGeometry       `json:"geometry"`
+	Properties map[string]interface{} `json:"properties"`
+}
+
+// geoJSONGeometry is a GeoJSON geometry with its coordinates left undecoded until the type is known
+type geoJSONGeometry struct {
+	Type        string          `json:"type"`
+	Coordinates json.RawMessage `json:"coordinates"`
+}
+
+// newGeoJSONGymImportSource reads up to the start of the features array, skipping any other
+// members of the FeatureCollection that come before it
+func newGeoJSONGymImportSource(r io.Reader) (*geoJSONGymImportSource, error) {
+	decoder := json.NewDecoder(r)
+	decoder.UseNumber()
+
+	notCollection := fmt.Errorf("GeoJSON must be a FeatureCollection with a features array")
+	if token, err := decoder.Token(); err != nil || token != json.Delim('{') {
+		return nil, notCollection
+	}
+	for decoder.More() {
+		token, err := decoder.Token()
+		if err != nil {
+			return nil, fmt.Errorf("invalid GeoJSON: %w", err)
+		}
+		switch token {
+		case "type":
+			var collectionType string
+			if err := decoder.Decode(&collectionType); err != nil || collectionType != "FeatureCollection" {
+				return nil, notCollection
+			}
+		case "features":
+			if token, err := decoder.Token(); err != nil || token != json.Delim('[') {
+				return nil, notCollection
+			}
+			return &geoJSONGymImportSource{decoder: decoder}, nil
+		default:
+			var skipped json.RawMessage
+			if err := decoder.Decode(&skipped); err != nil {
+				return nil, fmt.Errorf("invalid GeoJSON: %w", err)
+			}
+		}
+	}
+	return nil, notCollection
+}
+
+func (s *geoJSONGymImportSource) next() (map[string]string, error) {
+	if s.done || !s.decoder.More() {
+		s.done = true
+		return nil, io.EOF
+	}
+
+	var feature geoJSONFeature
+	err := s.decoder.Decode(&feature)
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &typeErr) {
+		// The decoder has consumed the whole feature, so the next one can still be read
+		return nil, &gymImportRowError{err: fmt.Errorf("feature %s has an invalid value", typeErr.Field)}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid GeoJSON feature: %w", err)
+	}
+
+	record := make(map[string]string, len(feature.Properties)+3)
+	for key, value := range feature.Properties {
+		record[strings.ToLower(key)] = geoJSONPropertyString(value)
+	}
+	if len(feature.ID) > 0 {
+		var id interface{}
+		if err := json.Unmarshal(feature.ID, &id); err == nil {
+			record[gymImportFeatureIDKey] = geoJSONPropertyString(id)
+		}
+	}
+	if feature.Geometry != nil {
+		lat, lng, err := feature.Geometry.position()
+		if err != nil {
+			return nil, &gymImportRowError{err: err}
+		}
+		record[gymImportLatitudeKey] = strconv.FormatFloat(lat, 'f', -1, 64)
+		record[gymImportLongitudeKey] = strconv.FormatFloat(lng, 'f', -1, 64)
+	}
+	return record, nil
+}
+
+// position returns the location of a gym's geometry. Points are used as is, and polygons such as
+// building outlines are reduced to the average of their outer ring
+func (g *geoJSONGeometry) position() (float64, float64, error) {
+	var ring [][]float64
+	switch g.Type {
+	case "Point":
+		var point []float64
+		if err := json.Unmarshal(g.Coordinates, &point); err != nil || len(point) < 2 {
+			return 0, 0, fmt.Errorf("point geometry must have [longitude, latitude] coordinates")
+		}
+		return point[1], point[0], nil
+	case "Polygon":
+		var polygon [][][]float64
+		if err := json.Unmarshal(g.Coordinates, &polygon); err != nil || len(polygon) == 0 {
+			return 0, 0, fmt.Errorf("polygon geometry has invalid coordinates")
+		}
+		ring = polygon[0]
+	case "MultiPolygon":
+		var polygons [][][][]float64
+		if err := json.Unmarshal(g.Coordinates, &polygons); err != nil || len(polygons) == 0 || len(polygons[0]) == 0 {
+			return 0, 0, fmt.Errorf("multipolygon geometry has invalid coordinates")
+		}
+		ring = polygons[0][0]
+	default:
+		return 0, 0, fmt.Errorf("geometry type %q is not supported, use a Point or Polygon", g.Type)
+	}
+
+	// Rings repeat their first position at the end, which would otherwise be counted twice
+	if len(ring) > 1 && len(ring[0]) >= 2 && len(ring[len(ring)-1]) >= 2 &&
+		ring[0][0] == ring[len(ring)-1][0] && ring[0][1] == ring[len(ring)-1][1] {
+		ring = ring[:len(ring)-1]
+	}
+
+	var lat, lng float64
+	count := 0
+	for _, position := range ring {
+		if len(position) < 2 {
+			continue
+		}
+		lng += position[0]
+		lat += position[1]
+		count++
+	}
+	if count == 0 {
+		return 0, 0, fmt.Errorf("%s geometry has no coordinates", strings.ToLower(g.Type))
+	}
+	return lat / float64(count), lng / float64(count), nil
+}
+
+// geoJSONPropertyString converts a property value to the text form used by CSV columns. Lists,
+// such as amenities, are joined with commas
+func geoJSONPropertyString(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case json.Number:
+		return v.String()
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(v)
+	case []interface{}:
+		parts := make([]string, len(v))
+		for i, item := range v {
+			parts[i] = geoJSONPropertyString(item)
+		}
+		return strings.Join(parts, ",")
+	default:
+		encoded, _ := json.Marshal(v)
+		return string(encoded)
+	}
+}
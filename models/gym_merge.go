@@ -0,0 +1,38 @@
+package models
+
+import (
+	"gorm.io/gorm"
+)
+
+// GymMerge records an admin merging a duplicate gym into the gym that survives. Every reference to
+// the duplicate was moved to the survivor and the duplicate was deleted
+type GymMerge struct {
+	gorm.Model
+
+	SurvivorGymID  uint `gorm:"not null;index" json:"survivor_gym_id"`
+	SurvivorGym    Gym  `gorm:"foreignKey:SurvivorGymID" json:"-"`
+	DuplicateGymID uint `gorm:"not null;uniqueIndex" json:"duplicate_gym_id"` // A gym can only be merged away once
+	DuplicateGym   Gym  `gorm:"foreignKey:DuplicateGymID" json:"-"`
+	MergedByID     uint `gorm:"not null;index" json:"merged_by_id"`
+
+	DuplicateName string `gorm:"size:200;not null" json:"duplicate_name"` // Name of the duplicate at merge time
+	Reason        string `gorm:"type:text" json:"reason,omitempty"`
+
+	Moved GymMergeCounts `gorm:"embedded;embeddedPrefix:moved_" json:"moved"`
+}
+
+// GymMergeCounts is the number of records of each kind moved from the duplicate to the survivor
+type GymMergeCounts struct {
+	TrainingSessions int  `gorm:"not null;default:0" json:"training_sessions"`
+	PlannedSessions  int  `gorm:"not null;default:0" json:"planned_sessions"`
+	Climbs           int  `gorm:"not null;default:0" json:"climbs"`
+	CheckIns         int  `gorm:"not null;default:0" json:"check_ins"`
+	Reviews          int  `gorm:"not null;default:0" json:"reviews"`
+	ReviewsDropped   int  `gorm:"not null;default:0" json:"reviews_dropped"` // Reviews by users who had also reviewed the survivor
+	Walls            int  `gorm:"not null;default:0" json:"walls"`
+	GymClimbs        int  `gorm:"not null;default:0" json:"gym_climbs"`
+	Claims           int  `gorm:"not null;default:0" json:"claims"`
+	Amenities        int  `gorm:"not null;default:0" json:"amenities"`    // Amenities the survivor did not list yet
+	Schedule         bool `gorm:"not null;default:false" json:"schedule"` // Opening hours, when the survivor had none
+	Prices           bool `gorm:"not null;default:false" json:"prices"`   // Pricing tiers, when the survivor had none
+}
@@ -0,0 +1,39 @@
+package models
+
+import (
+	"time"
+)
+
+// MergeGymRequest represents the request body for merging a duplicate gym into another gym
+type MergeGymRequest struct {
+	DuplicateGymID uint   `json:"duplicate_gym_id" validate:"required"`
+	Reason         string `json:"reason,omitempty" validate:"omitempty,max=2000"`
+}
+
+// GymMergeResponse represents a gym merge returned in API responses
+type GymMergeResponse struct {
+	ID             uint           `json:"id"`
+	SurvivorGymID  uint           `json:"survivor_gym_id"`
+	DuplicateGymID uint           `json:"duplicate_gym_id"`
+	DuplicateName  string         `json:"duplicate_name"`
+	MergedByID     uint           `json:"merged_by_id"`
+	Reason         string         `json:"reason,omitempty"`
+	Moved          GymMergeCounts `json:"moved"`
+
+	Survivor  *FullGymResponse `json:"survivor,omitempty"`
+	CreatedAt time.Time        `json:"created_at"`
+}
+
+// ToGymMergeResponse converts a GymMerge model to a GymMergeResponse DTO
+func (gm *GymMerge) ToGymMergeResponse() *GymMergeResponse {
+	return &GymMergeResponse{
+		ID:             gm.ID,
+		SurvivorGymID:  gm.SurvivorGymID,
+		DuplicateGymID: gm.DuplicateGymID,
+		DuplicateName:  gm.DuplicateName,
+		MergedByID:     gm.MergedByID,
+		Reason:         gm.Reason,
+		Moved:          gm.Moved,
+		CreatedAt:      gm.CreatedAt,
+	}
+}
@@ -0,0 +1,145 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Gym price product constants
+const (
+	GymPriceProductDayPass           = "day_pass"
+	GymPriceProductPunchCard         = "punch_card" // A pack of visits, see Visits
+	GymPriceProductMonthlyMembership = "monthly_membership"
+	GymPriceProductAnnualMembership  = "annual_membership"
+	GymPriceProductGearRental        = "gear_rental"
+)
+
+// Gym price audience constants
+const (
+	GymPriceAudienceAdult   = "adult"
+	GymPriceAudienceStudent = "student"
+	GymPriceAudienceYouth   = "youth"
+	GymPriceAudienceSenior  = "senior"
+	GymPriceAudienceFamily  = "family"
+)
+
+// Gym pricing limits
+const (
+	MaxGymPrices          = 50  // Pricing tiers per gym
+	MaxGymPunchCardVisits = 100 // Visits in a punch card
+)
+
+// GymPriceDateFormat is the format of validity dates in requests and responses
+const GymPriceDateFormat = "2006-01-02"
+
+// gymPriceProducts and gymPriceAudiences list the accepted values in display order
+var (
+	gymPriceProducts = []string{
+		GymPriceProductDayPass,
+		GymPriceProductPunchCard,
+		GymPriceProductMonthlyMembership,
+		GymPriceProductAnnualMembership,
+		GymPriceProductGearRental,
+	}
+	gymPriceAudiences = []string{
+		GymPriceAudienceAdult,
+		GymPriceAudienceStudent,
+		GymPriceAudienceYouth,
+		GymPriceAudienceSenior,
+		GymPriceAudienceFamily,
+	}
+)
+
+// GymPrice is one pricing tier of a gym, e.g. an adult day pass or a 10 visit student punch card.
+// Amounts are integers in the minor units of the ISO 4217 currency (cents for USD) so they are
+// exact. A tier without validity dates applies indefinitely
+type GymPrice struct {
+	gorm.Model
+
+	GymID       uint       `gorm:"not null;index" json:"gym_id"`
+	Product     string     `gorm:"size:30;not null;index" json:"product"`
+	Audience    string     `gorm:"size:20;not null;default:adult" json:"audience"`
+	Label       string     `gorm:"size:100" json:"label,omitempty"` // e.g. "Off-peak"
+	Visits      *int       `json:"visits,omitempty"`                // Punch cards only
+	AmountMinor int64      `gorm:"not null" json:"amount_minor"`
+	Currency    string     `gorm:"size:3;not null" json:"currency"`
+	ValidFrom   *time.Time `gorm:"type:date" json:"valid_from,omitempty"`
+	ValidUntil  *time.Time `gorm:"type:date" json:"valid_until,omitempty"` // Inclusive
+}
+
+// IsValidGymPriceProduct returns true if the product is a known gym price product
+func IsValidGymPriceProduct(product string) bool {
+	return gymPriceOrder(gymPriceProducts, product) >= 0
+}
+
+// IsValidGymPriceAudience returns true if the audience is a known gym price audience
+func IsValidGymPriceAudience(audience string) bool {
+	return gymPriceOrder(gymPriceAudiences, audience) >= 0
+}
+
+// LegacyGymPrices converts the flat, currency-less prices of a gym into adult pricing tiers in the
+// usual currency of the gym's country
+func LegacyGymPrices(g *Gym) []GymPrice {
+	currency := CurrencyForCountry(g.Country)
+	legacy := []struct {
+		product string
+		amount  float64
+	}{
+		{GymPriceProductDayPass, g.DayPassPrice},
+		{GymPriceProductMonthlyMembership, g.MonthlyPrice},
+		{GymPriceProductAnnualMembership, g.YearlyPrice},
+		{GymPriceProductGearRental, g.GearRentalPrice},
+	}
+
+	prices := []GymPrice{}
+	for _, l := range legacy {
+		if l.amount <= 0 {
+			continue
+		}
+		prices = append(prices, GymPrice{
+			GymID:       g.ID,
+			Product:     l.product,
+			Audience:    GymPriceAudienceAdult,
+			AmountMinor: ToMinorUnits(l.amount, currency),
+			Currency:    currency,
+		})
+	}
+	return prices
+}
+
+// LegacyPrice returns the flat price the legacy price fields showed for a product: the lowest
+// adult tier valid on now's date in the currency of the gym's country, in major units. Punch cards
+// and other currencies are ignored and 0 is returned when no tier matches. Prices must be preloaded
+func (g *Gym) LegacyPrice(product string, now time.Time) float64 {
+	currency := CurrencyForCountry(g.Country)
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+
+	var lowest *GymPrice
+	for i := range g.Prices {
+		p := &g.Prices[i]
+		if p.Product != product || p.Audience != GymPriceAudienceAdult || p.Currency != currency || p.Visits != nil {
+			continue
+		}
+		if (p.ValidFrom != nil && p.ValidFrom.After(today)) || (p.ValidUntil != nil && p.ValidUntil.Before(today)) {
+			continue
+		}
+		if lowest == nil || p.AmountMinor < lowest.AmountMinor {
+			lowest = p
+		}
+	}
+	if lowest == nil {
+		return 0
+	}
+	return FromMinorUnits(lowest.AmountMinor, currency)
+}
+
+// gymPriceOrder returns the position of a value in a list of accepted values, or -1
+func gymPriceOrder(values []string, value string) int {
+	for i, v := range values {
+		if v == value {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,274 @@
+package models
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// maxGymPriceAmountMinor bounds amounts to catch prices entered in the wrong units
+const maxGymPriceAmountMinor = 1_000_000_000
+
+// GymPriceRequest is one pricing tier in a gym request
+type GymPriceRequest struct {
+	Product     string `json:"product"`            // day_pass, punch_card, monthly_membership, annual_membership, gear_rental
+	Audience    string `json:"audience,omitempty"` // adult (default), student, youth, senior, family
+	Label       string `json:"label,omitempty"`
+	Visits      *int   `json:"visits,omitempty"` // Required for punch cards
+	AmountMinor int64  `json:"amount_minor"`     // In minor units, e.g. 2850 for 28.50 USD
+	Currency    string `json:"currency"`         // ISO 4217 code
+	ValidFrom   string `json:"valid_from,omitempty"`
+	ValidUntil  string `json:"valid_until,omitempty"`
+}
+
+// LegacyGymPriceFields are the flat, currency-less prices gym requests used to accept. They are
+// still accepted from older clients and converted into adult tiers when the request has no prices
+type LegacyGymPriceFields struct {
+	DayPassPrice    *float64 `json:"day_pass_price,omitempty"`
+	MonthlyPrice    *float64 `json:"monthly_price,omitempty"`
+	YearlyPrice     *float64 `json:"yearly_price,omitempty"`
+	GearRentalPrice *float64 `json:"gear_rental_price,omitempty"`
+}
+
+// legacyPrices pairs each legacy price field with its name and product
+func (l *LegacyGymPriceFields) legacyPrices() []struct {
+	name    string
+	product string
+	value   *float64
+} {
+	return []struct {
+		name    string
+		product string
+		value   *float64
+	}{
+		{"day_pass_price", GymPriceProductDayPass, l.DayPassPrice},
+		{"monthly_price", GymPriceProductMonthlyMembership, l.MonthlyPrice},
+		{"yearly_price", GymPriceProductAnnualMembership, l.YearlyPrice},
+		{"gear_rental_price", GymPriceProductGearRental, l.GearRentalPrice},
+	}
+}
+
+// LegacyPriceFields returns the names of the legacy price fields present in a request
+func (l *LegacyGymPriceFields) LegacyPriceFields() []string {
+	fields := []string{}
+	for _, f := range l.legacyPrices() {
+		if f.value != nil {
+			fields = append(fields, f.name)
+		}
+	}
+	return fields
+}
+
+// ApplyLegacyPrices converts the legacy price fields present in a request into adult tiers in the
+// usual currency of the country, as LegacyGymPrices does for stored gyms. Each field replaces the
+// adult tiers of its product in that currency, and a price of 0 removes them. Other tiers are kept.
+// Negative prices are kept so that validation rejects them
+func (l *LegacyGymPriceFields) ApplyLegacyPrices(prices []GymPriceRequest, country string) []GymPriceRequest {
+	currency := CurrencyForCountry(country)
+	for _, f := range l.legacyPrices() {
+		if f.value == nil {
+			continue
+		}
+
+		kept := make([]GymPriceRequest, 0, len(prices)+1)
+		for _, p := range prices {
+			audience := p.Audience
+			if audience == "" {
+				audience = GymPriceAudienceAdult
+			}
+			if p.Product == f.product && audience == GymPriceAudienceAdult && p.Visits == nil &&
+				strings.EqualFold(strings.TrimSpace(p.Currency), currency) {
+				continue
+			}
+			kept = append(kept, p)
+		}
+		if *f.value != 0 {
+			kept = append(kept, GymPriceRequest{
+				Product:     f.product,
+				Audience:    GymPriceAudienceAdult,
+				AmountMinor: ToMinorUnits(*f.value, currency),
+				Currency:    currency,
+			})
+		}
+		prices = kept
+	}
+	return prices
+}
+
+// ChangesPrices returns true if the update sets the prices or any legacy price field
+func (r *UpdateGymRequest) ChangesPrices() bool {
+	return r.Prices != nil || len(r.LegacyPriceFields()) > 0
+}
+
+// GymPriceResponse is one pricing tier of a gym
+type GymPriceResponse struct {
+	Product     string `json:"product"`
+	Audience    string `json:"audience"`
+	Label       string `json:"label,omitempty"`
+	Visits      *int   `json:"visits,omitempty"`
+	AmountMinor int64  `json:"amount_minor"`
+	Amount      string `json:"amount"` // Decimal in major units, e.g. "28.50"
+	Currency    string `json:"currency"`
+	ValidFrom   string `json:"valid_from,omitempty"`
+	ValidUntil  string `json:"valid_until,omitempty"`
+}
+
+// ToGymPrices converts pricing tier requests into models, validating the products, amounts,
+// currencies and dates. Tiers for the same product, audience, visits, label and currency must not
+// have overlapping validity periods
+func ToGymPrices(requests []GymPriceRequest) ([]GymPrice, error) {
+	if len(requests) > MaxGymPrices {
+		return nil, fmt.Errorf("a gym can have at most %d prices", MaxGymPrices)
+	}
+
+	prices := make([]GymPrice, 0, len(requests))
+	for _, req := range requests {
+		price := GymPrice{
+			Product:     req.Product,
+			Audience:    req.Audience,
+			Label:       strings.TrimSpace(req.Label),
+			Visits:      req.Visits,
+			AmountMinor: req.AmountMinor,
+			Currency:    strings.ToUpper(strings.TrimSpace(req.Currency)),
+		}
+		if price.Audience == "" {
+			price.Audience = GymPriceAudienceAdult
+		}
+
+		if !IsValidGymPriceProduct(price.Product) {
+			return nil, fmt.Errorf("product %q must be one of: %s", req.Product, strings.Join(gymPriceProducts, ", "))
+		}
+		if !IsValidGymPriceAudience(price.Audience) {
+			return nil, fmt.Errorf("audience %q must be one of: %s", req.Audience, strings.Join(gymPriceAudiences, ", "))
+		}
+		if len(price.Label) > 100 {
+			return nil, fmt.Errorf("price label must not exceed 100 characters")
+		}
+		if price.Product == GymPriceProductPunchCard {
+			if price.Visits == nil || *price.Visits < 2 || *price.Visits > MaxGymPunchCardVisits {
+				return nil, fmt.Errorf("punch card visits must be between 2 and %d", MaxGymPunchCardVisits)
+			}
+		} else if price.Visits != nil {
+			return nil, fmt.Errorf("visits can only be set on punch cards")
+		}
+		if price.AmountMinor < 0 || price.AmountMinor > maxGymPriceAmountMinor {
+			return nil, fmt.Errorf("amount_minor must be between 0 and %d", maxGymPriceAmountMinor)
+		}
+		if !IsValidCurrency(price.Currency) {
+			return nil, fmt.Errorf("currency %q must be one of: %s", req.Currency, strings.Join(SupportedCurrencies(), ", "))
+		}
+
+		var err error
+		if price.ValidFrom, err = parseGymPriceDate(req.ValidFrom, "valid_from"); err != nil {
+			return nil, err
+		}
+		if price.ValidUntil, err = parseGymPriceDate(req.ValidUntil, "valid_until"); err != nil {
+			return nil, err
+		}
+		if price.ValidFrom != nil && price.ValidUntil != nil && price.ValidUntil.Before(*price.ValidFrom) {
+			return nil, fmt.Errorf("price valid_until must not be before valid_from")
+		}
+
+		for _, other := range prices {
+			if gymPriceKey(&other) == gymPriceKey(&price) && gymPricesOverlap(&other, &price) {
+				return nil, fmt.Errorf("%s %s price in %s is listed more than once for the same dates", price.Audience, price.Product, price.Currency)
+			}
+		}
+		prices = append(prices, price)
+	}
+	return prices, nil
+}
+
+// parseGymPriceDate parses an optional YYYY-MM-DD validity date
+func parseGymPriceDate(value, field string) (*time.Time, error) {
+	if value == "" {
+		return nil, nil
+	}
+	date, err := time.Parse(GymPriceDateFormat, value)
+	if err != nil {
+		return nil, fmt.Errorf("price %s %q must be in YYYY-MM-DD format", field, value)
+	}
+	return &date, nil
+}
+
+// gymPriceKey identifies the tiers that cannot be valid at the same time
+func gymPriceKey(p *GymPrice) string {
+	visits := 0
+	if p.Visits != nil {
+		visits = *p.Visits
+	}
+	return fmt.Sprintf("%s|%s|%d|%s|%s", p.Product, p.Audience, visits, p.Currency, strings.ToLower(p.Label))
+}
+
+// gymPricesOverlap returns true if the validity periods of two tiers share a day. Missing dates
+// are open ended
+func gymPricesOverlap(a, b *GymPrice) bool {
+	if a.ValidUntil != nil && b.ValidFrom != nil && a.ValidUntil.Before(*b.ValidFrom) {
+		return false
+	}
+	if b.ValidUntil != nil && a.ValidFrom != nil && b.ValidUntil.Before(*a.ValidFrom) {
+		return false
+	}
+	return true
+}
+
+// formatGymPriceDate formats an optional validity date as YYYY-MM-DD
+func formatGymPriceDate(date *time.Time) string {
+	if date == nil {
+		return ""
+	}
+	return date.Format(GymPriceDateFormat)
+}
+
+// toGymPriceRequests converts pricing tiers back into requests
+func toGymPriceRequests(prices []GymPrice) []GymPriceRequest {
+	requests := make([]GymPriceRequest, len(prices))
+	for i, p := range prices {
+		requests[i] = GymPriceRequest{
+			Product:     p.Product,
+			Audience:    p.Audience,
+			Label:       p.Label,
+			Visits:      p.Visits,
+			AmountMinor: p.AmountMinor,
+			Currency:    p.Currency,
+			ValidFrom:   formatGymPriceDate(p.ValidFrom),
+			ValidUntil:  formatGymPriceDate(p.ValidUntil),
+		}
+	}
+	return requests
+}
+
+// toGymPriceResponses converts pricing tiers into responses ordered by product, audience and amount
+func toGymPriceResponses(prices []GymPrice) []GymPriceResponse {
+	sorted := append([]GymPrice{}, prices...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		a, b := &sorted[i], &sorted[j]
+		if pa, pb := gymPriceOrder(gymPriceProducts, a.Product), gymPriceOrder(gymPriceProducts, b.Product); pa != pb {
+			return pa < pb
+		}
+		if aa, ab := gymPriceOrder(gymPriceAudiences, a.Audience), gymPriceOrder(gymPriceAudiences, b.Audience); aa != ab {
+			return aa < ab
+		}
+		if a.Currency != b.Currency {
+			return a.Currency < b.Currency
+		}
+		return a.AmountMinor < b.AmountMinor
+	})
+
+	responses := make([]GymPriceResponse, len(sorted))
+	for i, p := range sorted {
+		responses[i] = GymPriceResponse{
+			Product:     p.Product,
+			Audience:    p.Audience,
+			Label:       p.Label,
+			Visits:      p.Visits,
+			AmountMinor: p.AmountMinor,
+			Amount:      FormatMinorUnits(p.AmountMinor, p.Currency),
+			Currency:    p.Currency,
+			ValidFrom:   formatGymPriceDate(p.ValidFrom),
+			ValidUntil:  formatGymPriceDate(p.ValidUntil),
+		}
+	}
+	return responses
+}
@@ -0,0 +1,65 @@
+package models
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestApplyLegacyPrices(t *testing.T) {
+	price := func(v float64) *float64 { return &v }
+	visits := 10
+
+	tests := []struct {
+		name    string
+		legacy  LegacyGymPriceFields
+		prices  []GymPriceRequest
+		country string
+		want    []GymPriceRequest
+	}{
+		{
+			name:    "legacy prices become adult tiers in the country's currency",
+			legacy:  LegacyGymPriceFields{DayPassPrice: price(28.5), MonthlyPrice: price(95)},
+			country: "Germany",
+			want: []GymPriceRequest{
+				{Product: GymPriceProductDayPass, Audience: GymPriceAudienceAdult, AmountMinor: 2850, Currency: "EUR"},
+				{Product: GymPriceProductMonthlyMembership, Audience: GymPriceAudienceAdult, AmountMinor: 9500, Currency: "EUR"},
+			},
+		},
+		{
+			name:   "legacy price replaces only the matching adult tier",
+			legacy: LegacyGymPriceFields{DayPassPrice: price(30)},
+			prices: []GymPriceRequest{
+				{Product: GymPriceProductDayPass, AmountMinor: 2500, Currency: "USD"},
+				{Product: GymPriceProductDayPass, Audience: GymPriceAudienceStudent, AmountMinor: 2000, Currency: "USD"},
+				{Product: GymPriceProductPunchCard, Visits: &visits, AmountMinor: 20000, Currency: "USD"},
+			},
+			country: "United States",
+			want: []GymPriceRequest{
+				{Product: GymPriceProductDayPass, Audience: GymPriceAudienceStudent, AmountMinor: 2000, Currency: "USD"},
+				{Product: GymPriceProductPunchCard, Visits: &visits, AmountMinor: 20000, Currency: "USD"},
+				{Product: GymPriceProductDayPass, Audience: GymPriceAudienceAdult, AmountMinor: 3000, Currency: "USD"},
+			},
+		},
+		{
+			name:   "zero removes the adult tier",
+			legacy: LegacyGymPriceFields{GearRentalPrice: price(0)},
+			prices: []GymPriceRequest{
+				{Product: GymPriceProductGearRental, Audience: GymPriceAudienceAdult, AmountMinor: 500, Currency: "USD"},
+				{Product: GymPriceProductDayPass, Audience: GymPriceAudienceAdult, AmountMinor: 2500, Currency: "USD"},
+			},
+			country: "United States",
+			want: []GymPriceRequest{
+				{Product: GymPriceProductDayPass, Audience: GymPriceAudienceAdult, AmountMinor: 2500, Currency: "USD"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.legacy.ApplyLegacyPrices(tt.prices, tt.country)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
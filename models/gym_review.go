@@ -0,0 +1,97 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Gym review rating bounds. Every rating is 1-5 where higher is better, so a crowding rating of 5
+// means the gym is rarely crowded
+const (
+	MinGymRating = 1
+	MaxGymRating = 5
+)
+
+// Gym review report reason constants
+const (
+	GymReviewReportReasonSpam               = "spam"
+	GymReviewReportReasonOffensive          = "offensive"
+	GymReviewReportReasonOffTopic           = "off_topic"
+	GymReviewReportReasonConflictOfInterest = "conflict_of_interest"
+	GymReviewReportReasonOther              = "other"
+)
+
+// Gym review report status constants
+const (
+	GymReviewReportStatusPending   = "pending"
+	GymReviewReportStatusUpheld    = "upheld"    // The review was hidden
+	GymReviewReportStatusDismissed = "dismissed" // The review was kept
+)
+
+// GymReview is a climber's rating of a gym. Each user has at most one review per gym, which they
+// can edit. Hidden reviews were removed by an admin and are excluded from the review list and the
+// gym's aggregated ratings. Deleting a review hard deletes the row so the user can review the gym again
+type GymReview struct {
+	gorm.Model
+
+	GymID  uint `gorm:"not null;uniqueIndex:idx_gym_review_user" json:"gym_id"`
+	Gym    Gym  `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE;" json:"-"`
+	UserID uint `gorm:"not null;uniqueIndex:idx_gym_review_user;index" json:"user_id"`
+	User   User `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE;" json:"-"`
+
+	// Ratings, 1-5. Only the overall rating is required
+	OverallRating     int  `gorm:"not null" json:"overall_rating"`
+	SettingRating     *int `json:"setting_rating,omitempty"`     // Quality of the route setting
+	CrowdingRating    *int `json:"crowding_rating,omitempty"`    // Higher is less crowded
+	CleanlinessRating *int `json:"cleanliness_rating,omitempty"` // Cleanliness of the facility
+	ValueRating       *int `json:"value_rating,omitempty"`       // Value for money
+
+	Title string `gorm:"size:150" json:"title,omitempty"`
+	Body  string `gorm:"type:text" json:"body,omitempty"`
+
+	// Moderation state
+	Hidden       bool       `gorm:"not null;default:false;index" json:"hidden"`
+	HiddenByID   *uint      `json:"hidden_by_id,omitempty"`
+	HiddenAt     *time.Time `json:"hidden_at,omitempty"`
+	HiddenReason string     `gorm:"type:text" json:"hidden_reason,omitempty"`
+}
+
+// GymReviewReport is a user's report that a gym review breaks the community guidelines. Reports stay
+// pending until an admin hides the review (upheld) or keeps it (dismissed)
+type GymReviewReport struct {
+	gorm.Model
+
+	GymReviewID uint      `gorm:"not null;uniqueIndex:idx_gym_review_report_user" json:"gym_review_id"`
+	GymReview   GymReview `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE;" json:"-"`
+	UserID      uint      `gorm:"not null;uniqueIndex:idx_gym_review_report_user" json:"user_id"`
+	User        User      `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE;" json:"-"`
+
+	Reason  string `gorm:"size:50;not null" json:"reason"`
+	Details string `gorm:"type:text" json:"details,omitempty"`
+
+	// Review state
+	Status       string     `gorm:"size:20;not null;default:pending;index" json:"status"` // pending, upheld, dismissed
+	ReviewedByID *uint      `json:"reviewed_by_id,omitempty"`
+	ReviewedAt   *time.Time `json:"reviewed_at,omitempty"`
+}
+
+// IsValidGymRating returns true if the rating is within the 1-5 scale
+func IsValidGymRating(rating int) bool {
+	return rating >= MinGymRating && rating <= MaxGymRating
+}
+
+// IsValidGymReviewReportReason returns true if the reason is a known report reason
+func IsValidGymReviewReportReason(reason string) bool {
+	switch reason {
+	case GymReviewReportReasonSpam, GymReviewReportReasonOffensive, GymReviewReportReasonOffTopic,
+		GymReviewReportReasonConflictOfInterest, GymReviewReportReasonOther:
+		return true
+	}
+	return false
+}
+
+// IsValidGymReviewReportStatus returns true if the status is a known report status
+func IsValidGymReviewReportStatus(status string) bool {
+	return status == GymReviewReportStatusPending || status == GymReviewReportStatusUpheld || status == GymReviewReportStatusDismissed
+}
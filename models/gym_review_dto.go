@@ -0,0 +1,127 @@
+package models
+
+import (
+	"math"
+	"time"
+)
+
+// CreateGymReviewRequest represents the request body for reviewing a gym. Posting again replaces
+// the user's existing review of the gym
+type CreateGymReviewRequest struct {
+	OverallRating     int    `json:"overall_rating" validate:"required,min=1,max=5"`
+	SettingRating     *int   `json:"setting_rating,omitempty" validate:"omitempty,min=1,max=5"`
+	CrowdingRating    *int   `json:"crowding_rating,omitempty" validate:"omitempty,min=1,max=5"`
+	CleanlinessRating *int   `json:"cleanliness_rating,omitempty" validate:"omitempty,min=1,max=5"`
+	ValueRating       *int   `json:"value_rating,omitempty" validate:"omitempty,min=1,max=5"`
+	Title             string `json:"title,omitempty" validate:"omitempty,max=150"`
+	Body              string `json:"body,omitempty" validate:"omitempty,max=5000"`
+}
+
+// CreateGymReviewReportRequest represents the request body for reporting a gym review
+type CreateGymReviewReportRequest struct {
+	Reason  string `json:"reason" validate:"required,oneof=spam offensive off_topic conflict_of_interest other"`
+	Details string `json:"details,omitempty" validate:"omitempty,max=2000"`
+}
+
+// ModerateGymReviewRequest represents the optional request body for hiding or restoring a gym review
+type ModerateGymReviewRequest struct {
+	Reason string `json:"reason,omitempty" validate:"omitempty,max=2000"`
+}
+
+// GymReviewResponse represents the gym review data returned in API responses
+type GymReviewResponse struct {
+	ID                uint      `json:"id"`
+	GymID             uint      `json:"gym_id"`
+	UserID            uint      `json:"user_id"`
+	Username          string    `json:"username,omitempty"`
+	OverallRating     int       `json:"overall_rating"`
+	SettingRating     *int      `json:"setting_rating,omitempty"`
+	CrowdingRating    *int      `json:"crowding_rating,omitempty"`
+	CleanlinessRating *int      `json:"cleanliness_rating,omitempty"`
+	ValueRating       *int      `json:"value_rating,omitempty"`
+	Title             string    `json:"title,omitempty"`
+	Body              string    `json:"body,omitempty"`
+	Hidden            bool      `json:"hidden"`
+	CreatedAt         time.Time `json:"created_at"`
+	UpdatedAt         time.Time `json:"updated_at"`
+
+	// Moderation details, only included for the review's author and admins
+	HiddenAt     *time.Time `json:"hidden_at,omitempty"`
+	HiddenReason string     `json:"hidden_reason,omitempty"`
+}
+
+// GymReviewReportResponse represents the gym review report data returned in API responses
+type GymReviewReportResponse struct {
+	ID           uint       `json:"id"`
+	GymReviewID  uint       `json:"gym_review_id"`
+	UserID       uint       `json:"user_id"`
+	Reason       string     `json:"reason"`
+	Details      string     `json:"details,omitempty"`
+	Status       string     `json:"status"`
+	ReviewedByID *uint      `json:"reviewed_by_id,omitempty"`
+	ReviewedAt   *time.Time `json:"reviewed_at,omitempty"`
+	CreatedAt    time.Time  `json:"created_at"`
+
+	// The reported review, included in the admin moderation queue
+	Review *GymReviewResponse `json:"review,omitempty"`
+}
+
+// ToGymReviewResponse converts a GymReview model to a GymReviewResponse DTO
+// User is included when it has been preloaded
+func (gr *GymReview) ToGymReviewResponse() *GymReviewResponse {
+	return &GymReviewResponse{
+		ID:                gr.ID,
+		GymID:             gr.GymID,
+		UserID:            gr.UserID,
+		Username:          gr.User.Username,
+		OverallRating:     gr.OverallRating,
+		SettingRating:     gr.SettingRating,
+		CrowdingRating:    gr.CrowdingRating,
+		CleanlinessRating: gr.CleanlinessRating,
+		ValueRating:       gr.ValueRating,
+		Title:             gr.Title,
+		Body:              gr.Body,
+		Hidden:            gr.Hidden,
+		CreatedAt:         gr.CreatedAt,
+		UpdatedAt:         gr.UpdatedAt,
+	}
+}
+
+// WithModeration adds the moderation details to the response for the review's author and admins
+func (r *GymReviewResponse) WithModeration(gr *GymReview) *GymReviewResponse {
+	r.HiddenAt = gr.HiddenAt
+	r.HiddenReason = gr.HiddenReason
+	return r
+}
+
+// ToGymReviewReportResponse converts a GymReviewReport model to a GymReviewReportResponse DTO
+func (grr *GymReviewReport) ToGymReviewReportResponse() *GymReviewReportResponse {
+	return &GymReviewReportResponse{
+		ID:           grr.ID,
+		GymReviewID:  grr.GymReviewID,
+		UserID:       grr.UserID,
+		Reason:       grr.Reason,
+		Details:      grr.Details,
+		Status:       grr.Status,
+		ReviewedByID: grr.ReviewedByID,
+		ReviewedAt:   grr.ReviewedAt,
+		CreatedAt:    grr.CreatedAt,
+	}
+}
+
+// ToGymReviewReportResponseWithReview converts a GymReviewReport model to a GymReviewReportResponse
+// DTO including the reported review. GymReview and GymReview.User must be preloaded
+func (grr *GymReviewReport) ToGymReviewReportResponseWithReview() *GymReviewReportResponse {
+	response := grr.ToGymReviewReportResponse()
+	response.Review = grr.GymReview.ToGymReviewResponse().WithModeration(&grr.GymReview)
+	return response
+}
+
+// roundGymRating rounds an aggregated rating to two decimals for display
+func roundGymRating(rating *float64) *float64 {
+	if rating == nil {
+		return nil
+	}
+	rounded := math.Round(*rating*100) / 100
+	return &rounded
+}
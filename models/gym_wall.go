@@ -0,0 +1,19 @@
+package models
+
+import (
+	"gorm.io/gorm"
+)
+
+// GymWall is a wall or area of a gym that problems and routes are set on (e.g. "The Cave", "Slab")
+type GymWall struct {
+	gorm.Model
+
+	GymID uint `gorm:"not null;index" json:"gym_id"`
+	Gym   Gym  `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE;" json:"-"`
+
+	Name        string `gorm:"size:100;not null" json:"name"`
+	Description string `gorm:"type:text" json:"description,omitempty"`
+
+	// Problems and routes set on the wall
+	Climbs []GymClimb `gorm:"constraint:OnUpdate:CASCADE,OnDelete:SET NULL;" json:"climbs,omitempty"`
+}
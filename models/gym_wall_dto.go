@@ -0,0 +1,43 @@
+package models
+
+import (
+	"time"
+)
+
+// CreateGymWallRequest represents the request body for adding a wall to a gym
+type CreateGymWallRequest struct {
+	Name        string `json:"name" validate:"required,min=1,max=100"`
+	Description string `json:"description,omitempty" validate:"omitempty,max=1000"`
+}
+
+// UpdateGymWallRequest represents the request body for updating a gym wall
+// Only the fields present in the request are changed
+type UpdateGymWallRequest struct {
+	Name        *string `json:"name" validate:"omitempty,min=1,max=100"`
+	Description *string `json:"description" validate:"omitempty,max=1000"`
+}
+
+// GymWallResponse represents the gym wall data returned in API responses
+type GymWallResponse struct {
+	ID          uint      `json:"id"`
+	GymID       uint      `json:"gym_id"`
+	Name        string    `json:"name"`
+	Description string    `json:"description,omitempty"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+
+	// Number of problems and routes currently set on the wall, included in wall lists
+	CurrentClimbs *int `json:"current_climbs,omitempty"`
+}
+
+// ToGymWallResponse converts a GymWall model to a GymWallResponse DTO
+func (gw *GymWall) ToGymWallResponse() *GymWallResponse {
+	return &GymWallResponse{
+		ID:          gw.ID,
+		GymID:       gw.GymID,
+		Name:        gw.Name,
+		Description: gw.Description,
+		CreatedAt:   gw.CreatedAt,
+		UpdatedAt:   gw.UpdatedAt,
+	}
+}
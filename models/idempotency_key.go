@@ -0,0 +1,38 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// IdempotencyKey stores the outcome of a POST request made with an Idempotency-Key header
+// so that client retries receive the original response instead of creating duplicates
+type IdempotencyKey struct {
+	gorm.Model
+
+	// User who made the request - keys are scoped per user
+	UserID uint `gorm:"not null;uniqueIndex:idx_idempotency_user_key" json:"user_id"`
+	User   User `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE;" json:"-"`
+
+	// Client supplied key
+	Key string `gorm:"size:255;not null;uniqueIndex:idx_idempotency_user_key" json:"key"`
+
+	// Request details
+	Method      string `gorm:"size:10;not null" json:"method"`
+	Path        string `gorm:"size:255;not null" json:"path"`
+	Fingerprint string `gorm:"size:64;not null" json:"fingerprint"` // SHA-256 of method, path and body
+
+	// Stored response - empty until the original request completes
+	Completed    bool   `gorm:"not null;default:false" json:"completed"`
+	StatusCode   int    `json:"status_code"`
+	ResponseBody []byte `gorm:"type:jsonb" json:"-"` // Full APIResponse as sent to the client
+
+	// When the key can be reused for a new request
+	ExpiresAt time.Time `gorm:"not null;index" json:"expires_at"`
+}
+
+// IsExpired returns true if the stored response should no longer be replayed
+func (k *IdempotencyKey) IsExpired() bool {
+	return time.Now().After(k.ExpiresAt)
+}
@@ -27,6 +27,13 @@ type IndoorBoulder struct {
 	ColorTag *string `gorm:"size:50" json:"color_tag,omitempty"` // Optional color tag (e.g., "Blue", "Red")
 	Outcome  string  `gorm:"size:20;not null" json:"outcome"`    // Fell, Flash, Onsite, Redpoint
 
+	// Optional problem from the gym's catalog that was climbed
+	GymClimbID *uint     `gorm:"index" json:"gym_climb_id,omitempty"`
+	GymClimb   *GymClimb `gorm:"constraint:OnUpdate:CASCADE,OnDelete:SET NULL;" json:"-"`
+
+	// Optional ordered attempts. When present the outcome is derived from them
+	Attempts []ClimbAttempt `gorm:"polymorphic:Parent;polymorphicValue:indoor_boulder" json:"attempts,omitempty"`
+
 	// Optional notes for this specific boulder
 	Notes string `gorm:"type:text" json:"notes,omitempty"`
 }
@@ -47,3 +54,29 @@ func (ib *IndoorBoulder) IsAttempted() bool {
 func (ib *IndoorBoulder) HasColorTag() bool {
 	return ib.ColorTag != nil && *ib.ColorTag != ""
 }
+
+// GetAttemptsToSend returns the attempt number of the first send, or 0 if the boulder has no
+// attempts logged or was not sent
+func (ib *IndoorBoulder) GetAttemptsToSend() int {
+	return attemptsToSend(ib.Attempts)
+}
+
+// DeriveOutcome sets the outcome from the logged attempts. A send on the first attempt is a flash,
+// or an onsight if reported as one; a later send is a redpoint. Boulders without attempts keep the
+// reported outcome
+func (ib *IndoorBoulder) DeriveOutcome(reported string) {
+	ib.Outcome = reported
+	if len(ib.Attempts) == 0 {
+		return
+	}
+	switch sendAttempt := ib.GetAttemptsToSend(); {
+	case sendAttempt == 0:
+		ib.Outcome = IndoorBoulderOutcomeFell
+	case sendAttempt > 1:
+		ib.Outcome = IndoorBoulderOutcomeRedpoint
+	case reported == IndoorBoulderOutcomeOnSight:
+		ib.Outcome = IndoorBoulderOutcomeOnSight
+	default:
+		ib.Outcome = IndoorBoulderOutcomeFlash
+	}
+}
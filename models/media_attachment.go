@@ -0,0 +1,64 @@
+package models
+
+import (
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// MediaParentType constants for the records a media attachment can belong to
+const (
+	MediaParentTypeClimb           = "climb"
+	MediaParentTypeTrainingSession = "training_session"
+)
+
+// MediaType constants for the kind of media stored in an attachment
+const (
+	MediaTypeImage = "image"
+	MediaTypeVideo = "video"
+)
+
+// MediaAttachment represents a photo or video uploaded to S3 and attached to a climb or training session.
+// The parent is polymorphic: ParentType identifies the table and ParentID the row.
+type MediaAttachment struct {
+	gorm.Model
+
+	// User who uploaded the attachment
+	UserID uint `gorm:"not null;index" json:"user_id"`
+	User   User `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE;" json:"-"`
+
+	// Parent record (climb or training_session)
+	ParentType string `gorm:"size:50;not null;index:idx_media_parent" json:"parent_type"`
+	ParentID   uint   `gorm:"not null;index:idx_media_parent" json:"parent_id"`
+
+	// S3 object details
+	S3Key       string `gorm:"size:500;not null" json:"-"`
+	ContentType string `gorm:"size:100;not null" json:"content_type"`
+	SizeBytes   int64  `json:"size_bytes"`
+
+	// Dimensions for images and videos, duration for videos (all optional)
+	Width           *int     `json:"width,omitempty"`
+	Height          *int     `json:"height,omitempty"`
+	DurationSeconds *float64 `json:"duration_seconds,omitempty"`
+
+	// Optional caption
+	Caption string `gorm:"size:500" json:"caption,omitempty"`
+}
+
+// IsImage returns true if the attachment is an image
+func (m *MediaAttachment) IsImage() bool {
+	return strings.HasPrefix(m.ContentType, "image/")
+}
+
+// IsVideo returns true if the attachment is a video
+func (m *MediaAttachment) IsVideo() bool {
+	return strings.HasPrefix(m.ContentType, "video/")
+}
+
+// GetMediaType returns "image" or "video" based on the content type
+func (m *MediaAttachment) GetMediaType() string {
+	if m.IsVideo() {
+		return MediaTypeVideo
+	}
+	return MediaTypeImage
+}
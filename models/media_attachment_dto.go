@@ -0,0 +1,51 @@
+package models
+
+import (
+	"time"
+)
+
+// MediaAttachmentResponse represents a media attachment returned in API responses
+type MediaAttachmentResponse struct {
+	ID              uint     `json:"id"`
+	ParentType      string   `json:"parent_type"`
+	ParentID        uint     `json:"parent_id"`
+	MediaType       string   `json:"media_type"`
+	ContentType     string   `json:"content_type"`
+	SizeBytes       int64    `json:"size_bytes"`
+	Width           *int     `json:"width,omitempty"`
+	Height          *int     `json:"height,omitempty"`
+	DurationSeconds *float64 `json:"duration_seconds,omitempty"`
+	Caption         string   `json:"caption,omitempty"`
+
+	// Presigned URL for downloading the media (omitted if it could not be generated)
+	URL          string `json:"url,omitempty"`
+	URLExpiresAt string `json:"url_expires_at,omitempty"`
+
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// ToMediaAttachmentResponse converts a MediaAttachment model to a MediaAttachmentResponse DTO
+// Note: This method does not include the presigned URL. Use ToMediaAttachmentResponseWithPresignedURL for that.
+func (m *MediaAttachment) ToMediaAttachmentResponse() *MediaAttachmentResponse {
+	return &MediaAttachmentResponse{
+		ID:              m.ID,
+		ParentType:      m.ParentType,
+		ParentID:        m.ParentID,
+		MediaType:       m.GetMediaType(),
+		ContentType:     m.ContentType,
+		SizeBytes:       m.SizeBytes,
+		Width:           m.Width,
+		Height:          m.Height,
+		DurationSeconds: m.DurationSeconds,
+		Caption:         m.Caption,
+		CreatedAt:       m.CreatedAt,
+	}
+}
+
+// ToMediaAttachmentResponseWithPresignedURL converts a MediaAttachment model to a MediaAttachmentResponse DTO with a presigned URL
+func (m *MediaAttachment) ToMediaAttachmentResponseWithPresignedURL(url, expiresAt string) *MediaAttachmentResponse {
+	response := m.ToMediaAttachmentResponse()
+	response.URL = url
+	response.URLExpiresAt = expiresAt
+	return response
+}
@@ -0,0 +1,56 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Planned session status constants
+const (
+	PlannedSessionStatusPlanned   = "planned"
+	PlannedSessionStatusCompleted = "completed"
+	PlannedSessionStatusMissed    = "missed"
+)
+
+// PlannedSession represents a workout template scheduled on a future date. Logging a training
+// session linked to the planned session completes it
+type PlannedSession struct {
+	gorm.Model
+
+	// User the session is planned for
+	UserID uint `gorm:"not null;index" json:"user_id"`
+	User   User `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE;" json:"-"`
+
+	// Workout to perform
+	WorkoutTemplateID uint            `gorm:"not null;index" json:"workout_template_id"`
+	WorkoutTemplate   WorkoutTemplate `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE;" json:"workout_template,omitempty"`
+
+	// Schedule
+	PlannedDate time.Time `gorm:"not null;index" json:"planned_date"`
+	GymID       *uint     `gorm:"index" json:"gym_id,omitempty"`
+	Gym         *Gym      `gorm:"constraint:OnUpdate:CASCADE,OnDelete:SET NULL;" json:"gym,omitempty"`
+	Notes       string    `gorm:"type:text" json:"notes,omitempty"`
+
+	// Logged session that completed the plan
+	TrainingSessionID *uint            `gorm:"uniqueIndex" json:"training_session_id,omitempty"`
+	TrainingSession   *TrainingSession `gorm:"constraint:OnUpdate:CASCADE,OnDelete:SET NULL;" json:"-"`
+}
+
+// IsCompleted returns true if a training session has been linked to the plan
+func (ps *PlannedSession) IsCompleted() bool {
+	return ps.TrainingSessionID != nil
+}
+
+// GetStatus returns planned, completed or missed. A plan is missed once its date has
+// passed without a linked training session
+func (ps *PlannedSession) GetStatus(now time.Time) string {
+	if ps.IsCompleted() {
+		return PlannedSessionStatusCompleted
+	}
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	if ps.PlannedDate.Before(today) {
+		return PlannedSessionStatusMissed
+	}
+	return PlannedSessionStatusPlanned
+}
@@ -0,0 +1,72 @@
+package models
+
+import (
+	"time"
+)
+
+// CreatePlannedSessionRequest represents the request body for scheduling a workout template
+type CreatePlannedSessionRequest struct {
+	WorkoutTemplateID uint      `json:"workout_template_id" validate:"required"`
+	PlannedDate       time.Time `json:"planned_date" validate:"required"`
+	GymID             *uint     `json:"gym_id,omitempty"`
+	Notes             string    `json:"notes,omitempty" validate:"omitempty,max=1000"`
+}
+
+// PlannedSessionResponse represents the planned session data returned in API responses
+type PlannedSessionResponse struct {
+	ID                uint      `json:"id"`
+	UserID            uint      `json:"user_id"`
+	WorkoutTemplateID uint      `json:"workout_template_id"`
+	PlannedDate       time.Time `json:"planned_date"`
+	GymID             *uint     `json:"gym_id,omitempty"`
+	Notes             string    `json:"notes,omitempty"`
+	Status            string    `json:"status"`
+	TrainingSessionID *uint     `json:"training_session_id,omitempty"`
+	CreatedAt         time.Time `json:"created_at"`
+	UpdatedAt         time.Time `json:"updated_at"`
+
+	// Nested relationships
+	WorkoutTemplate *WorkoutTemplateResponse `json:"workout_template,omitempty"`
+	Gym             *GymResponse             `json:"gym,omitempty"`
+
+	// Planned vs. actual volume and intensity, included once a training session is linked
+	Compliance *ComplianceResponse `json:"compliance,omitempty"`
+}
+
+// ToPlannedSessionResponse converts a PlannedSession model to a PlannedSessionResponse DTO
+// Compliance is computed when the workout template items and the linked training session are loaded
+func (ps *PlannedSession) ToPlannedSessionResponse() *PlannedSessionResponse {
+	response := &PlannedSessionResponse{
+		ID:                ps.ID,
+		UserID:            ps.UserID,
+		WorkoutTemplateID: ps.WorkoutTemplateID,
+		PlannedDate:       ps.PlannedDate,
+		GymID:             ps.GymID,
+		Notes:             ps.Notes,
+		Status:            ps.GetStatus(time.Now()),
+		TrainingSessionID: ps.TrainingSessionID,
+		CreatedAt:         ps.CreatedAt,
+		UpdatedAt:         ps.UpdatedAt,
+	}
+
+	// Include workout template if loaded
+	if ps.WorkoutTemplate.ID != 0 {
+		response.WorkoutTemplate = ps.WorkoutTemplate.ToWorkoutTemplateResponse()
+	}
+
+	// Include gym information if loaded
+	if ps.Gym != nil {
+		response.Gym = &GymResponse{
+			ID:   ps.Gym.ID,
+			Name: ps.Gym.Name,
+			City: ps.Gym.City,
+		}
+	}
+
+	// Include compliance if the linked training session is loaded
+	if ps.TrainingSession != nil && ps.WorkoutTemplate.ID != 0 {
+		response.Compliance = ComputeCompliance(ps.WorkoutTemplate.Items, ps.TrainingSession)
+	}
+
+	return response
+}
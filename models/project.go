@@ -0,0 +1,71 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ProjectStatus constants for the lifecycle of a project
+const (
+	ProjectStatusActive    = "active"
+	ProjectStatusSent      = "sent"
+	ProjectStatusAbandoned = "abandoned"
+)
+
+// Project represents a user working on a specific route or outdoor boulder across multiple sessions.
+// Climbs that reference the same route or boulder are linked to the project automatically.
+type Project struct {
+	gorm.Model
+
+	// User who is projecting
+	UserID uint `gorm:"not null;index" json:"user_id"`
+	User   User `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE;" json:"-"`
+
+	// Target of the project - exactly one of RouteID or OutdoorBoulderID is set
+	RouteID          *uint           `gorm:"index" json:"route_id,omitempty"`
+	Route            *Route          `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE;" json:"route,omitempty"`
+	OutdoorBoulderID *uint           `gorm:"index" json:"outdoor_boulder_id,omitempty"`
+	OutdoorBoulder   *OutdoorBoulder `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE;" json:"outdoor_boulder,omitempty"`
+
+	// Project progress
+	Status    string     `gorm:"size:20;not null;default:active;index" json:"status"` // active, sent, abandoned
+	HighPoint string     `gorm:"size:200" json:"high_point,omitempty"`                // Furthest point reached (e.g., "last bolt", "lip")
+	BetaNotes string     `gorm:"type:text" json:"beta_notes,omitempty"`               // Sequences, rests, gear, etc.
+	SentAt    *time.Time `json:"sent_at,omitempty"`                                   // When the project was sent
+
+	// Attempts on the project
+	Climbs []Climb `json:"climbs,omitempty"`
+}
+
+// IsActive returns true if the project is still being worked
+func (p *Project) IsActive() bool {
+	return p.Status == ProjectStatusActive
+}
+
+// IsSent returns true if the project has been sent
+func (p *Project) IsSent() bool {
+	return p.Status == ProjectStatusSent
+}
+
+// GetName returns the name of the route or boulder being projected, if loaded
+func (p *Project) GetName() string {
+	if p.Route != nil {
+		return p.Route.Name
+	}
+	if p.OutdoorBoulder != nil {
+		return p.OutdoorBoulder.Name
+	}
+	return ""
+}
+
+// GetGrade returns the grade of the route or boulder being projected, if loaded
+func (p *Project) GetGrade() string {
+	if p.Route != nil {
+		return p.Route.Grade
+	}
+	if p.OutdoorBoulder != nil {
+		return p.OutdoorBoulder.Grade
+	}
+	return ""
+}
@@ -0,0 +1,82 @@
+package models
+
+import (
+	"time"
+)
+
+// CreateProjectRequest represents the request body for starting a new project
+type CreateProjectRequest struct {
+	// Exactly one target is required
+	RouteID          *uint `json:"route_id,omitempty"`
+	OutdoorBoulderID *uint `json:"outdoor_boulder_id,omitempty"`
+
+	// Optional details
+	HighPoint string `json:"high_point,omitempty" validate:"omitempty,max=200"`
+	BetaNotes string `json:"beta_notes,omitempty" validate:"omitempty,max=5000"`
+}
+
+// UpdateProjectRequest represents the request body for updating a project
+// Only includes fields that can be updated
+type UpdateProjectRequest struct {
+	Status    *string `json:"status" validate:"omitempty,oneof=active sent abandoned"`
+	HighPoint *string `json:"high_point" validate:"omitempty,max=200"`
+	BetaNotes *string `json:"beta_notes" validate:"omitempty,max=5000"`
+}
+
+// ProjectResponse represents the project data returned in API responses
+type ProjectResponse struct {
+	ID               uint       `json:"id"`
+	UserID           uint       `json:"user_id"`
+	RouteID          *uint      `json:"route_id,omitempty"`
+	OutdoorBoulderID *uint      `json:"outdoor_boulder_id,omitempty"`
+	Name             string     `json:"name,omitempty"`
+	Grade            string     `json:"grade,omitempty"`
+	Status           string     `json:"status"`
+	HighPoint        string     `json:"high_point,omitempty"`
+	BetaNotes        string     `json:"beta_notes,omitempty"`
+	SentAt           *time.Time `json:"sent_at,omitempty"`
+
+	// Summary of linked climbs
+	TotalSessions int `json:"total_sessions"`
+	TotalAttempts int `json:"total_attempts"`
+	TotalFalls    int `json:"total_falls"`
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// ProjectSessionResponse groups the attempts made on a project on a single day
+type ProjectSessionResponse struct {
+	Date     string           `json:"date"` // YYYY-MM-DD
+	Attempts int              `json:"attempts"`
+	Falls    int              `json:"falls"`
+	Sent     bool             `json:"sent"`
+	Climbs   []*ClimbResponse `json:"climbs"`
+}
+
+// ProjectTimelineResponse represents every attempt session on a project leading up to the send
+type ProjectTimelineResponse struct {
+	Project        *ProjectResponse         `json:"project"`
+	Sessions       []ProjectSessionResponse `json:"sessions"`
+	FirstAttemptAt *time.Time               `json:"first_attempt_at,omitempty"`
+	SentAt         *time.Time               `json:"sent_at,omitempty"`
+	DaysToSend     *int                     `json:"days_to_send,omitempty"` // Days from the first attempt to the send
+}
+
+// ToProjectResponse converts a Project model to a ProjectResponse DTO
+func (p *Project) ToProjectResponse() *ProjectResponse {
+	return &ProjectResponse{
+		ID:               p.ID,
+		UserID:           p.UserID,
+		RouteID:          p.RouteID,
+		OutdoorBoulderID: p.OutdoorBoulderID,
+		Name:             p.GetName(),
+		Grade:            p.GetGrade(),
+		Status:           p.Status,
+		HighPoint:        p.HighPoint,
+		BetaNotes:        p.BetaNotes,
+		SentAt:           p.SentAt,
+		CreatedAt:        p.CreatedAt,
+		UpdatedAt:        p.UpdatedAt,
+	}
+}
@@ -32,6 +32,13 @@ type RopeClimb struct {
 	Grade     string `gorm:"size:20;not null" json:"grade"`      // YDS scale (e.g., "5.10a", "5.11d")
 	Outcome   string `gorm:"size:20;not null" json:"outcome"`    // Fell, Hung, Flash, Onsite, Redpoint
 
+	// Optional route from the gym's catalog that was climbed
+	GymClimbID *uint     `gorm:"index" json:"gym_climb_id,omitempty"`
+	GymClimb   *GymClimb `gorm:"constraint:OnUpdate:CASCADE,OnDelete:SET NULL;" json:"-"`
+
+	// Optional ordered attempts. When present the outcome is derived from them
+	Attempts []ClimbAttempt `gorm:"polymorphic:Parent;polymorphicValue:rope_climb" json:"attempts,omitempty"`
+
 	// Optional notes for this specific climb
 	Notes string `gorm:"type:text" json:"notes,omitempty"`
 }
@@ -57,3 +64,31 @@ func (rc *RopeClimb) IsSent() bool {
 func (rc *RopeClimb) IsAttempted() bool {
 	return rc.Outcome == RopeClimbOutcomeFell || rc.Outcome == RopeClimbOutcomeHung
 }
+
+// GetAttemptsToSend returns the attempt number of the first send, or 0 if the climb has no
+// attempts logged or was not sent
+func (rc *RopeClimb) GetAttemptsToSend() int {
+	return attemptsToSend(rc.Attempts)
+}
+
+// DeriveOutcome sets the outcome from the logged attempts. A send on the first attempt is a flash,
+// or an onsight if reported as one; a later send is a redpoint. Without a send the climb is hung if
+// any attempt weighted the rope. Climbs without attempts keep the reported outcome
+func (rc *RopeClimb) DeriveOutcome(reported string) {
+	rc.Outcome = reported
+	if len(rc.Attempts) == 0 {
+		return
+	}
+	switch sendAttempt := rc.GetAttemptsToSend(); {
+	case sendAttempt == 0 && hasHungAttempt(rc.Attempts):
+		rc.Outcome = RopeClimbOutcomeHung
+	case sendAttempt == 0:
+		rc.Outcome = RopeClimbOutcomeFell
+	case sendAttempt > 1:
+		rc.Outcome = RopeClimbOutcomeRedpoint
+	case reported == RopeClimbOutcomeOnSight:
+		rc.Outcome = RopeClimbOutcomeOnSight
+	default:
+		rc.Outcome = RopeClimbOutcomeFlash
+	}
+}
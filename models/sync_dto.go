@@ -0,0 +1,83 @@
+package models
+
+import (
+	"time"
+)
+
+// SyncEntityType constants for the record types exchanged by the sync endpoints
+const (
+	SyncEntityClimb           = "climb"
+	SyncEntityTrainingSession = "training_session"
+	SyncEntityRopeClimb       = "rope_climb"
+	SyncEntityIndoorBoulder   = "indoor_boulder"
+	SyncEntityExercise        = "exercise"
+)
+
+// SyncChangeStatus constants for the outcome of a pushed change
+const (
+	SyncChangeStatusCreated  = "created"
+	SyncChangeStatusUpdated  = "updated"
+	SyncChangeStatusDeleted  = "deleted"
+	SyncChangeStatusConflict = "conflict" // The server version was newer and was kept
+	SyncChangeStatusError    = "error"
+)
+
+// SyncTombstone identifies a record that was deleted since the last sync
+type SyncTombstone struct {
+	Type      string    `json:"type"` // climb, training_session, rope_climb, indoor_boulder
+	ID        uint      `json:"id"`
+	DeletedAt time.Time `json:"deleted_at"`
+}
+
+// SyncPullResponse represents everything the user changed since the sync token
+type SyncPullResponse struct {
+	Climbs           []*ClimbResponse           `json:"climbs"`
+	TrainingSessions []*TrainingSessionResponse `json:"training_sessions"` // Includes rope climbs and indoor boulders
+	Gyms             []*FullGymResponse         `json:"gyms"`              // Gyms referenced by the user's records
+	Tombstones       []SyncTombstone            `json:"tombstones"`
+	FullSync         bool                       `json:"full_sync"` // True when no since token was provided
+	SyncToken        string                     `json:"sync_token"`
+}
+
+// SyncClimbChange represents a climb created, edited or deleted on the client
+type SyncClimbChange struct {
+	ClientID  string              `json:"client_id"`       // Client identifier echoed back in the result
+	ID        *uint               `json:"id,omitempty"`    // Server ID, omitted for climbs created offline
+	UpdatedAt time.Time           `json:"updated_at"`      // When the client last modified the climb
+	Deleted   bool                `json:"deleted"`         // True if the climb was deleted on the client
+	Climb     *CreateClimbRequest `json:"climb,omitempty"` // Required unless deleted
+}
+
+// SyncTrainingSessionChange represents a training session created, edited or deleted on the client
+// The rope climbs and indoor boulders in the request replace those stored on the server
+type SyncTrainingSessionChange struct {
+	ClientID        string                        `json:"client_id"`
+	ID              *uint                         `json:"id,omitempty"`
+	UpdatedAt       time.Time                     `json:"updated_at"`
+	Deleted         bool                          `json:"deleted"`
+	TrainingSession *CreateTrainingSessionRequest `json:"training_session,omitempty"`
+}
+
+// SyncPushRequest represents the request body for pushing client-side changes
+type SyncPushRequest struct {
+	Climbs           []SyncClimbChange           `json:"climbs,omitempty"`
+	TrainingSessions []SyncTrainingSessionChange `json:"training_sessions,omitempty"`
+}
+
+// SyncChangeResult represents the outcome of a single pushed change
+type SyncChangeResult struct {
+	ClientID string      `json:"client_id"`
+	Type     string      `json:"type"`
+	ID       uint        `json:"id,omitempty"`
+	Status   string      `json:"status"`
+	Server   interface{} `json:"server,omitempty"` // Current server version (or tombstone) when the status is conflict
+	Error    *APIError   `json:"error,omitempty"`
+}
+
+// SyncPushResponse represents the per-change results of a push request
+type SyncPushResponse struct {
+	Applied   int                `json:"applied"`
+	Conflicts int                `json:"conflicts"`
+	Failed    int                `json:"failed"`
+	Results   []SyncChangeResult `json:"results"`
+}
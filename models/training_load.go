@@ -0,0 +1,187 @@
+package models
+
+import (
+	"math"
+	"time"
+)
+
+// Training load warning flag constants
+const (
+	TrainingLoadFlagACWRHigh     = "acwr_high"     // Acute:chronic workload ratio above the threshold
+	TrainingLoadFlagLoadSpike    = "load_spike"    // Weekly load increased more than the threshold over the previous week
+	TrainingLoadFlagHighMonotony = "high_monotony" // Daily load too uniform across the week
+)
+
+// acwrChronicWeeks is the number of weeks averaged for the chronic load
+const acwrChronicWeeks = 4
+
+// TrainingLoadThresholds are the limits past which a week is flagged as a possible overtraining risk
+type TrainingLoadThresholds struct {
+	ACWR           float64 `json:"acwr"`            // e.g. 1.5 flags weeks where the acute load is 50% above the chronic load
+	WeeklyIncrease float64 `json:"weekly_increase"` // e.g. 0.3 flags weeks with 30% more load than the previous week
+	Monotony       float64 `json:"monotony"`        // e.g. 2.0 flags weeks with little variation in daily load
+}
+
+// TrainingLoadWeekResponse summarizes the training load of one week
+type TrainingLoadWeekResponse struct {
+	WeekStart         time.Time `json:"week_start"`
+	WeekEnd           time.Time `json:"week_end"`
+	Sessions          int       `json:"sessions"`
+	UnratedSessions   int       `json:"unrated_sessions"` // Sessions without an RPE or duration, excluded from the load
+	PainSessions      int       `json:"pain_sessions"`    // Sessions with pain or injury flags
+	DailyLoads        []int     `json:"daily_loads"`
+	Load              int       `json:"load"`
+	Monotony          *float64  `json:"monotony"`
+	Strain            *float64  `json:"strain"`
+	ChronicLoad       float64   `json:"chronic_load"`
+	ACWR              *float64  `json:"acwr"`
+	LoadChangePercent *float64  `json:"load_change_percent"`
+	Flags             []string  `json:"flags"`
+}
+
+// TrainingLoadResponse represents the training load metrics of a user over a number of weeks
+type TrainingLoadResponse struct {
+	StartDate    time.Time                  `json:"start_date"`
+	EndDate      time.Time                  `json:"end_date"`
+	Thresholds   TrainingLoadThresholds     `json:"thresholds"`
+	Weeks        []TrainingLoadWeekResponse `json:"weeks"`
+	FlaggedWeeks int                        `json:"flagged_weeks"`
+}
+
+// TrainingLoadHistoryDays returns how many days of sessions ComputeTrainingLoad needs for the given
+// number of weeks, including the extra weeks used for the chronic load of the oldest week
+func TrainingLoadHistoryDays(weeks int) int {
+	return (weeks + acwrChronicWeeks - 1) * 7
+}
+
+// ComputeTrainingLoad computes weekly load, monotony, strain and acute:chronic workload ratio for
+// the weeks ending on the day of end, oldest week first. Session load is RPE x minutes.
+// Monotony is the mean daily load divided by its standard deviation and strain is the weekly load
+// multiplied by the monotony. The chronic load is the average weekly load of the last four weeks
+// including the current one. Sessions must cover TrainingLoadHistoryDays days before end
+func ComputeTrainingLoad(sessions []TrainingSession, end time.Time, weeks int, thresholds TrainingLoadThresholds) *TrainingLoadResponse {
+	lastDay := time.Date(end.Year(), end.Month(), end.Day(), 0, 0, 0, 0, end.Location())
+	totalDays := TrainingLoadHistoryDays(weeks)
+	firstDay := lastDay.AddDate(0, 0, -(totalDays - 1))
+
+	// Bucket sessions into days
+	dailyLoads := make([]int, totalDays)
+	dailySessions := make([]int, totalDays)
+	dailyUnrated := make([]int, totalDays)
+	dailyPain := make([]int, totalDays)
+	for i := range sessions {
+		session := &sessions[i]
+		date := session.SessionDate.In(end.Location())
+		day := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, end.Location())
+		index := int(math.Round(day.Sub(firstDay).Hours() / 24))
+		if index < 0 || index >= totalDays {
+			continue
+		}
+
+		dailySessions[index]++
+		if session.HasPain() {
+			dailyPain[index]++
+		}
+		if load, ok := session.GetSessionLoad(); ok {
+			dailyLoads[index] += load
+		} else {
+			dailyUnrated[index]++
+		}
+	}
+
+	// Sum each week, including the history weeks used for the chronic load
+	allWeeks := totalDays / 7
+	weeklyLoads := make([]int, allWeeks)
+	for day, load := range dailyLoads {
+		weeklyLoads[day/7] += load
+	}
+
+	response := &TrainingLoadResponse{
+		StartDate:  lastDay.AddDate(0, 0, -(weeks*7 - 1)),
+		EndDate:    lastDay.AddDate(0, 0, 1).Add(-time.Nanosecond),
+		Thresholds: thresholds,
+		Weeks:      make([]TrainingLoadWeekResponse, 0, weeks),
+	}
+
+	for w := allWeeks - weeks; w < allWeeks; w++ {
+		startIndex := w * 7
+		week := TrainingLoadWeekResponse{
+			WeekStart:  firstDay.AddDate(0, 0, startIndex),
+			WeekEnd:    firstDay.AddDate(0, 0, startIndex+7).Add(-time.Nanosecond),
+			DailyLoads: append([]int{}, dailyLoads[startIndex:startIndex+7]...),
+			Load:       weeklyLoads[w],
+			Flags:      []string{},
+		}
+		for day := startIndex; day < startIndex+7; day++ {
+			week.Sessions += dailySessions[day]
+			week.UnratedSessions += dailyUnrated[day]
+			week.PainSessions += dailyPain[day]
+		}
+
+		// Monotony and strain
+		if monotony, ok := computeMonotony(week.DailyLoads); ok {
+			strain := roundLoadMetric(float64(week.Load) * monotony)
+			monotony = roundLoadMetric(monotony)
+			week.Monotony = &monotony
+			week.Strain = &strain
+			if thresholds.Monotony > 0 && monotony > thresholds.Monotony {
+				week.Flags = append(week.Flags, TrainingLoadFlagHighMonotony)
+			}
+		}
+
+		// Acute:chronic workload ratio
+		chronicTotal := 0
+		for i := w - acwrChronicWeeks + 1; i <= w; i++ {
+			chronicTotal += weeklyLoads[i]
+		}
+		week.ChronicLoad = roundLoadMetric(float64(chronicTotal) / acwrChronicWeeks)
+		if chronicTotal > 0 {
+			acwr := roundLoadMetric(float64(week.Load) / (float64(chronicTotal) / acwrChronicWeeks))
+			week.ACWR = &acwr
+			if thresholds.ACWR > 0 && acwr > thresholds.ACWR {
+				week.Flags = append(week.Flags, TrainingLoadFlagACWRHigh)
+			}
+		}
+
+		// Week over week change
+		if previous := weeklyLoads[w-1]; previous > 0 {
+			change := roundLoadMetric(float64(week.Load-previous) / float64(previous) * 100)
+			week.LoadChangePercent = &change
+			if thresholds.WeeklyIncrease > 0 && float64(week.Load-previous)/float64(previous) > thresholds.WeeklyIncrease {
+				week.Flags = append(week.Flags, TrainingLoadFlagLoadSpike)
+			}
+		}
+
+		if len(week.Flags) > 0 {
+			response.FlaggedWeeks++
+		}
+		response.Weeks = append(response.Weeks, week)
+	}
+
+	return response
+}
+
+// computeMonotony returns the mean daily load divided by the standard deviation of the daily load.
+// The second value is false when the load does not vary, since monotony is undefined
+func computeMonotony(dailyLoads []int) (float64, bool) {
+	var sum float64
+	for _, load := range dailyLoads {
+		sum += float64(load)
+	}
+	mean := sum / float64(len(dailyLoads))
+
+	var variance float64
+	for _, load := range dailyLoads {
+		variance += (float64(load) - mean) * (float64(load) - mean)
+	}
+	stdDev := math.Sqrt(variance / float64(len(dailyLoads)))
+	if stdDev == 0 {
+		return 0, false
+	}
+	return mean / stdDev, true
+}
+
+// roundLoadMetric rounds a load metric to two decimal places
+func roundLoadMetric(value float64) float64 {
+	return math.Round(value*100) / 100
+}
@@ -1,11 +1,61 @@
 package models
 
 import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
 	"time"
 
 	"gorm.io/gorm"
 )
 
+// Pain flag constants for the body areas a user can report pain or injury in after a session
+const (
+	PainFlagFingers  = "fingers"
+	PainFlagWrist    = "wrist"
+	PainFlagElbow    = "elbow"
+	PainFlagShoulder = "shoulder"
+	PainFlagNeck     = "neck"
+	PainFlagBack     = "back"
+	PainFlagHip      = "hip"
+	PainFlagKnee     = "knee"
+	PainFlagAnkle    = "ankle"
+	PainFlagSkin     = "skin"
+	PainFlagOther    = "other"
+)
+
+// PainFlags is the list of body areas a user reported pain or injury in, stored as a JSON array
+type PainFlags []string
+
+// Value implements driver.Valuer so pain flags can be written with both structs and update maps
+func (pf PainFlags) Value() (driver.Value, error) {
+	if pf == nil {
+		return nil, nil
+	}
+	data, err := json.Marshal([]string(pf))
+	if err != nil {
+		return nil, err
+	}
+	return string(data), nil
+}
+
+// Scan implements sql.Scanner for reading pain flags from the database
+func (pf *PainFlags) Scan(value interface{}) error {
+	switch v := value.(type) {
+	case nil:
+		*pf = nil
+		return nil
+	case []byte:
+		return json.Unmarshal(v, (*[]string)(pf))
+	case string:
+		return json.Unmarshal([]byte(v), (*[]string)(pf))
+	default:
+		return fmt.Errorf("unsupported pain flags type %T", value)
+	}
+}
+
 // TrainingSession represents a gym climbing training session
 type TrainingSession struct {
 	gorm.Model
@@ -22,12 +72,33 @@ type TrainingSession struct {
 	SessionDate time.Time `gorm:"not null;index" json:"session_date"`
 	Description string    `gorm:"type:text" json:"description,omitempty"`
 
-	// Training partners (many-to-many relationship)
-	Partners []User `gorm:"many2many:training_session_partners;" json:"partners,omitempty"`
+	// Live session timing, set when the session is logged as it happens
+	StartedAt  *time.Time `gorm:"index" json:"started_at,omitempty"`
+	EndedAt    *time.Time `gorm:"index" json:"ended_at,omitempty"`
+	AutoClosed bool       `gorm:"not null;default:false" json:"auto_closed"` // Closed by the idle timeout rather than the user
+
+	// Effort and wellbeing reported by the user
+	RPE             *int      `json:"rpe,omitempty"`              // Session rating of perceived exertion from 1 to 10
+	DurationMinutes *int      `json:"duration_minutes,omitempty"` // Overrides the live session duration when set
+	PainFlags       PainFlags `gorm:"type:jsonb" json:"pain_flags,omitempty"`
+
+	// Training partners (many-to-many relationship through TrainingSessionPartner)
+	Partners           []User                   `gorm:"many2many:training_session_partners;" json:"partners,omitempty"`
+	PartnerInvitations []TrainingSessionPartner `gorm:"foreignKey:TrainingSessionID" json:"-"`
+
+	// Original session this one was copied from when a partner accepted an invitation with mirroring
+	MirroredFromID *uint            `gorm:"index" json:"mirrored_from_id,omitempty"`
+	MirroredFrom   *TrainingSession `gorm:"constraint:OnUpdate:CASCADE,OnDelete:SET NULL;" json:"-"`
 
 	// Climbs during this session
 	RopeClimbs     []RopeClimb     `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE;" json:"rope_climbs,omitempty"`
 	IndoorBoulders []IndoorBoulder `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE;" json:"indoor_boulders,omitempty"`
+
+	// Off-the-wall exercises during this session
+	Exercises []Exercise `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE;" json:"exercises,omitempty"`
+
+	// Photo and video attachments
+	Media []MediaAttachment `gorm:"polymorphic:Parent;polymorphicValue:training_session" json:"media,omitempty"`
 }
 
 // GetTotalClimbs returns the total number of climbs (ropes + indoor boulders) in the session
@@ -55,3 +126,97 @@ func (ts *TrainingSession) GetTotalSends() int {
 func (ts *TrainingSession) HasPartners() bool {
 	return len(ts.Partners) > 0
 }
+
+// GetPartnerInvitation returns the invitation of a tagged partner if the invitations are loaded
+func (ts *TrainingSession) GetPartnerInvitation(userID uint) *TrainingSessionPartner {
+	for i := range ts.PartnerInvitations {
+		if ts.PartnerInvitations[i].UserID == userID {
+			return &ts.PartnerInvitations[i]
+		}
+	}
+	return nil
+}
+
+// IsLive returns true if the session was started live and has not been finished yet
+func (ts *TrainingSession) IsLive() bool {
+	return ts.StartedAt != nil && ts.EndedAt == nil
+}
+
+// GetDuration returns the time between the start and end of a live session
+// Sessions still in progress are measured up to now
+func (ts *TrainingSession) GetDuration(now time.Time) time.Duration {
+	if ts.StartedAt == nil {
+		return 0
+	}
+	end := now
+	if ts.EndedAt != nil {
+		end = *ts.EndedAt
+	}
+	if end.Before(*ts.StartedAt) {
+		return 0
+	}
+	return end.Sub(*ts.StartedAt)
+}
+
+// GetClimbTimes returns the times the session's climbs were logged in chronological order
+func (ts *TrainingSession) GetClimbTimes() []time.Time {
+	times := make([]time.Time, 0, ts.GetTotalClimbs())
+	for _, rc := range ts.RopeClimbs {
+		times = append(times, rc.CreatedAt)
+	}
+	for _, ib := range ts.IndoorBoulders {
+		times = append(times, ib.CreatedAt)
+	}
+	sort.Slice(times, func(i, j int) bool { return times[i].Before(times[j]) })
+	return times
+}
+
+// GetRestIntervals returns the rest taken between consecutive climbs of the session
+func (ts *TrainingSession) GetRestIntervals() []time.Duration {
+	times := ts.GetClimbTimes()
+	if len(times) < 2 {
+		return []time.Duration{}
+	}
+	intervals := make([]time.Duration, len(times)-1)
+	for i := 1; i < len(times); i++ {
+		intervals[i-1] = times[i].Sub(times[i-1])
+	}
+	return intervals
+}
+
+// GetDurationMinutes returns the reported duration of the session, falling back to the measured
+// duration of a finished live session. The second value is false when the duration is unknown
+func (ts *TrainingSession) GetDurationMinutes() (int, bool) {
+	if ts.DurationMinutes != nil {
+		return *ts.DurationMinutes, true
+	}
+	if ts.StartedAt != nil && ts.EndedAt != nil {
+		return int(math.Round(ts.GetDuration(*ts.EndedAt).Minutes())), true
+	}
+	return 0, false
+}
+
+// GetSessionLoad returns the session RPE multiplied by the duration in minutes. The second value
+// is false when the session has no RPE or duration
+func (ts *TrainingSession) GetSessionLoad() (int, bool) {
+	minutes, ok := ts.GetDurationMinutes()
+	if ts.RPE == nil || !ok {
+		return 0, false
+	}
+	return *ts.RPE * minutes, true
+}
+
+// HasPain returns true if the user reported pain or injury after the session
+func (ts *TrainingSession) HasPain() bool {
+	return len(ts.PainFlags) > 0
+}
+
+// IsValidPainFlag returns true if the flag is one of the supported body areas
+func IsValidPainFlag(flag string) bool {
+	switch flag {
+	case PainFlagFingers, PainFlagWrist, PainFlagElbow, PainFlagShoulder, PainFlagNeck, PainFlagBack,
+		PainFlagHip, PainFlagKnee, PainFlagAnkle, PainFlagSkin, PainFlagOther:
+		return true
+	}
+	return false
+}
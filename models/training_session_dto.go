@@ -1,23 +1,41 @@
 package models
 
 import (
+	"math"
 	"time"
 )
 
+// ClimbAttemptRequest represents a single attempt on an indoor boulder or rope climb in the request
+type ClimbAttemptRequest struct {
+	AttemptedAt      *time.Time `json:"attempted_at,omitempty"`
+	Result           string     `json:"result" validate:"required,oneof=fell hung topped"`
+	HighPointPercent *int       `json:"high_point_percent,omitempty" validate:"omitempty,min=0,max=100"`
+}
+
 // IndoorBoulderRequest represents an indoor boulder problem in the create request
+// Outcome is only required without attempts; with attempts it is derived from them
 type IndoorBoulderRequest struct {
-	Grade    string  `json:"grade" validate:"required,min=1,max=20"`
-	ColorTag *string `json:"color_tag,omitempty" validate:"omitempty,max=50"`
-	Outcome  string  `json:"outcome" validate:"required,oneof=Fell Flash Onsite Redpoint"`
-	Notes    string  `json:"notes,omitempty" validate:"omitempty,max=1000"`
+	Grade    string                `json:"grade" validate:"required,min=1,max=20"`
+	ColorTag *string               `json:"color_tag,omitempty" validate:"omitempty,max=50"`
+	Outcome  string                `json:"outcome,omitempty" validate:"omitempty,oneof=Fell Flash Onsite Redpoint"`
+	Notes    string                `json:"notes,omitempty" validate:"omitempty,max=1000"`
+	Attempts []ClimbAttemptRequest `json:"attempts,omitempty"`
+
+	// Optional boulder problem from the session gym's catalog
+	GymClimbID *uint `json:"gym_climb_id,omitempty"`
 }
 
 // RopeClimbRequest represents a rope climb in the create request
+// Outcome is only required without attempts; with attempts it is derived from them
 type RopeClimbRequest struct {
-	ClimbType string `json:"climb_type" validate:"required,oneof=TR Lead"`
-	Grade     string `json:"grade" validate:"required,min=1,max=20"`
-	Outcome   string `json:"outcome" validate:"required,oneof=Fell Hung Flash Onsite Redpoint"`
-	Notes     string `json:"notes,omitempty" validate:"omitempty,max=1000"`
+	ClimbType string                `json:"climb_type" validate:"required,oneof=TR Lead"`
+	Grade     string                `json:"grade" validate:"required,min=1,max=20"`
+	Outcome   string                `json:"outcome,omitempty" validate:"omitempty,oneof=Fell Hung Flash Onsite Redpoint"`
+	Notes     string                `json:"notes,omitempty" validate:"omitempty,max=1000"`
+	Attempts  []ClimbAttemptRequest `json:"attempts,omitempty"`
+
+	// Optional route from the session gym's catalog
+	GymClimbID *uint `json:"gym_climb_id,omitempty"`
 }
 
 // CreateTrainingSessionRequest represents the request body for creating a new training session
@@ -33,6 +51,91 @@ type CreateTrainingSessionRequest struct {
 	// Climbs during the session
 	IndoorBoulders []IndoorBoulderRequest `json:"indoor_boulders,omitempty"`
 	RopeClimbs     []RopeClimbRequest     `json:"rope_climbs,omitempty"`
+
+	// Off-the-wall exercises during the session
+	Exercises []ExerciseRequest `json:"exercises,omitempty"`
+
+	// Effort and wellbeing
+	RPE             *int     `json:"rpe,omitempty" validate:"omitempty,min=1,max=10"`
+	DurationMinutes *int     `json:"duration_minutes,omitempty" validate:"omitempty,min=1,max=1440"`
+	PainFlags       []string `json:"pain_flags,omitempty"`
+
+	// Planned session completed by this session. Only applied when the session is created
+	PlannedSessionID *uint `json:"planned_session_id,omitempty"`
+}
+
+// IndoorBoulderUpdate replaces the details and attempts of an existing indoor boulder in the update request
+type IndoorBoulderUpdate struct {
+	ID uint `json:"id" validate:"required"`
+	IndoorBoulderRequest
+}
+
+// RopeClimbUpdate replaces the details and attempts of an existing rope climb in the update request
+type RopeClimbUpdate struct {
+	ID uint `json:"id" validate:"required"`
+	RopeClimbRequest
+}
+
+// IndoorBoulderChanges lists the indoor boulders to add, edit and remove in the update request
+type IndoorBoulderChanges struct {
+	Add    []IndoorBoulderRequest `json:"add,omitempty"`
+	Update []IndoorBoulderUpdate  `json:"update,omitempty"`
+	Remove []uint                 `json:"remove,omitempty"`
+}
+
+// RopeClimbChanges lists the rope climbs to add, edit and remove in the update request
+type RopeClimbChanges struct {
+	Add    []RopeClimbRequest `json:"add,omitempty"`
+	Update []RopeClimbUpdate  `json:"update,omitempty"`
+	Remove []uint             `json:"remove,omitempty"`
+}
+
+// UpdateTrainingSessionRequest represents the request body for updating a training session
+// Only includes fields that can be updated; PartnerIDs replaces the existing partners when provided
+type UpdateTrainingSessionRequest struct {
+	GymID       *uint      `json:"gym_id"`
+	SessionDate *time.Time `json:"session_date"`
+	Description *string    `json:"description" validate:"omitempty,max=1000"`
+	PartnerIDs  *[]uint    `json:"partner_ids"`
+
+	// Changes to the climbs during the session
+	IndoorBoulders *IndoorBoulderChanges `json:"indoor_boulders"`
+	RopeClimbs     *RopeClimbChanges     `json:"rope_climbs"`
+
+	// Changes to the off-the-wall exercises during the session
+	Exercises *ExerciseChanges `json:"exercises"`
+
+	// Effort and wellbeing. An empty pain_flags list clears the flags
+	RPE             *int      `json:"rpe" validate:"omitempty,min=1,max=10"`
+	DurationMinutes *int      `json:"duration_minutes" validate:"omitempty,min=1,max=1440"`
+	PainFlags       *[]string `json:"pain_flags"`
+}
+
+// StartTrainingSessionRequest represents the request body for starting a live training session
+// The session date and start time are set to the time the request is received
+type StartTrainingSessionRequest struct {
+	GymID       uint   `json:"gym_id" validate:"required"`
+	Description string `json:"description,omitempty" validate:"omitempty,max=1000"`
+	PartnerIDs  []uint `json:"partner_ids,omitempty"`
+}
+
+// FinishTrainingSessionRequest represents the optional request body for finishing a live training session
+type FinishTrainingSessionRequest struct {
+	RPE       *int     `json:"rpe,omitempty" validate:"omitempty,min=1,max=10"`
+	PainFlags []string `json:"pain_flags,omitempty"`
+}
+
+// AppendSessionClimbRequest represents the request body for logging one climb in a live session
+// Exactly one of IndoorBoulder or RopeClimb must be provided
+type AppendSessionClimbRequest struct {
+	IndoorBoulder *IndoorBoulderRequest `json:"indoor_boulder,omitempty"`
+	RopeClimb     *RopeClimbRequest     `json:"rope_climb,omitempty"`
+}
+
+// AcceptPartnerInvitationRequest represents the optional request body for accepting a partner invitation
+type AcceptPartnerInvitationRequest struct {
+	// Mirror copies the session's gym, date and description into the partner's own log
+	Mirror bool `json:"mirror"`
 }
 
 // IndoorBoulderResponse represents an indoor boulder problem in the response
@@ -42,9 +145,14 @@ type IndoorBoulderResponse struct {
 	Grade             string    `json:"grade"`
 	ColorTag          *string   `json:"color_tag,omitempty"`
 	Outcome           string    `json:"outcome"`
+	GymClimbID        *uint     `json:"gym_climb_id,omitempty"`
 	Notes             string    `json:"notes,omitempty"`
 	CreatedAt         time.Time `json:"created_at"`
 	UpdatedAt         time.Time `json:"updated_at"`
+
+	// Logged attempts and the attempt number of the first send, if any
+	Attempts       []ClimbAttemptResponse `json:"attempts,omitempty"`
+	AttemptsToSend *int                   `json:"attempts_to_send,omitempty"`
 }
 
 // RopeClimbResponse represents a rope climb in the response
@@ -54,9 +162,23 @@ type RopeClimbResponse struct {
 	ClimbType         string    `json:"climb_type"`
 	Grade             string    `json:"grade"`
 	Outcome           string    `json:"outcome"`
+	GymClimbID        *uint     `json:"gym_climb_id,omitempty"`
 	Notes             string    `json:"notes,omitempty"`
 	CreatedAt         time.Time `json:"created_at"`
 	UpdatedAt         time.Time `json:"updated_at"`
+
+	// Logged attempts and the attempt number of the first send, if any
+	Attempts       []ClimbAttemptResponse `json:"attempts,omitempty"`
+	AttemptsToSend *int                   `json:"attempts_to_send,omitempty"`
+}
+
+// ClimbAttemptResponse represents a single attempt on an indoor boulder or rope climb in the response
+type ClimbAttemptResponse struct {
+	ID               uint       `json:"id"`
+	AttemptNumber    int        `json:"attempt_number"`
+	AttemptedAt      *time.Time `json:"attempted_at,omitempty"`
+	Result           string     `json:"result"`
+	HighPointPercent *int       `json:"high_point_percent,omitempty"`
 }
 
 // PartnerResponse represents a training partner in the response
@@ -65,6 +187,9 @@ type PartnerResponse struct {
 	Username  string `json:"username"`
 	FirstName string `json:"first_name,omitempty"`
 	LastName  string `json:"last_name,omitempty"`
+
+	// Invitation status of the partner, included when invitations are loaded
+	InvitationStatus string `json:"invitation_status,omitempty"`
 }
 
 // GymResponse represents basic gym information in the response
@@ -84,15 +209,43 @@ type TrainingSessionResponse struct {
 	CreatedAt   time.Time `json:"created_at"`
 	UpdatedAt   time.Time `json:"updated_at"`
 
+	// Original session when this session was mirrored from a partner invitation
+	MirroredFromID *uint `json:"mirrored_from_id,omitempty"`
+
+	// Effort and wellbeing. Session load is RPE x duration in minutes, present when both are known
+	RPE             *int     `json:"rpe,omitempty"`
+	DurationMinutes *int     `json:"duration_minutes,omitempty"`
+	SessionLoad     *int     `json:"session_load,omitempty"`
+	PainFlags       []string `json:"pain_flags,omitempty"`
+
 	// Nested relationships
 	Gym            *GymResponse            `json:"gym,omitempty"`
 	Partners       []PartnerResponse       `json:"partners,omitempty"`
 	IndoorBoulders []IndoorBoulderResponse `json:"indoor_boulders,omitempty"`
 	RopeClimbs     []RopeClimbResponse     `json:"rope_climbs,omitempty"`
+	Exercises      []ExerciseResponse      `json:"exercises,omitempty"`
+
+	// Photo and video attachments with presigned URLs
+	Media []MediaAttachmentResponse `json:"media,omitempty"`
 
 	// Statistics
 	TotalClimbs int `json:"total_climbs"`
 	TotalSends  int `json:"total_sends"`
+
+	// Timing statistics, only present for sessions logged live
+	Timing *SessionTimingResponse `json:"timing,omitempty"`
+}
+
+// SessionTimingResponse represents the timing statistics of a live training session
+type SessionTimingResponse struct {
+	StartedAt            time.Time  `json:"started_at"`
+	EndedAt              *time.Time `json:"ended_at,omitempty"`
+	InProgress           bool       `json:"in_progress"`
+	AutoClosed           bool       `json:"auto_closed"`
+	DurationSeconds      int64      `json:"duration_seconds"`
+	ClimbsPerHour        float64    `json:"climbs_per_hour"`
+	RestIntervalsSeconds []int64    `json:"rest_intervals_seconds"`
+	AverageRestSeconds   int64      `json:"average_rest_seconds"`
 }
 
 // ToTrainingSessionResponse converts a TrainingSession model to a TrainingSessionResponse DTO
@@ -107,6 +260,24 @@ func (ts *TrainingSession) ToTrainingSessionResponse() *TrainingSessionResponse
 		UpdatedAt:   ts.UpdatedAt,
 		TotalClimbs: ts.GetTotalClimbs(),
 		TotalSends:  ts.GetTotalSends(),
+
+		MirroredFromID: ts.MirroredFromID,
+
+		RPE:       ts.RPE,
+		PainFlags: ts.PainFlags,
+	}
+
+	// Include duration and load when known
+	if minutes, ok := ts.GetDurationMinutes(); ok {
+		response.DurationMinutes = &minutes
+	}
+	if load, ok := ts.GetSessionLoad(); ok {
+		response.SessionLoad = &load
+	}
+
+	// Include timing statistics for live sessions
+	if ts.StartedAt != nil {
+		response.Timing = ts.toSessionTimingResponse(time.Now())
 	}
 
 	// Include gym information if loaded
@@ -128,6 +299,9 @@ func (ts *TrainingSession) ToTrainingSessionResponse() *TrainingSessionResponse
 				FirstName: partner.FirstName,
 				LastName:  partner.LastName,
 			}
+			if invitation := ts.GetPartnerInvitation(partner.ID); invitation != nil {
+				response.Partners[i].InvitationStatus = invitation.Status
+			}
 		}
 	}
 
@@ -141,9 +315,14 @@ func (ts *TrainingSession) ToTrainingSessionResponse() *TrainingSessionResponse
 				Grade:             boulder.Grade,
 				ColorTag:          boulder.ColorTag,
 				Outcome:           boulder.Outcome,
+				GymClimbID:        boulder.GymClimbID,
 				Notes:             boulder.Notes,
 				CreatedAt:         boulder.CreatedAt,
 				UpdatedAt:         boulder.UpdatedAt,
+				Attempts:          toClimbAttemptResponses(boulder.Attempts),
+			}
+			if sendAttempt := boulder.GetAttemptsToSend(); sendAttempt > 0 {
+				response.IndoorBoulders[i].AttemptsToSend = &sendAttempt
 			}
 		}
 	}
@@ -158,32 +337,167 @@ func (ts *TrainingSession) ToTrainingSessionResponse() *TrainingSessionResponse
 				ClimbType:         ropeClimb.ClimbType,
 				Grade:             ropeClimb.Grade,
 				Outcome:           ropeClimb.Outcome,
+				GymClimbID:        ropeClimb.GymClimbID,
 				Notes:             ropeClimb.Notes,
 				CreatedAt:         ropeClimb.CreatedAt,
 				UpdatedAt:         ropeClimb.UpdatedAt,
+				Attempts:          toClimbAttemptResponses(ropeClimb.Attempts),
+			}
+			if sendAttempt := ropeClimb.GetAttemptsToSend(); sendAttempt > 0 {
+				response.RopeClimbs[i].AttemptsToSend = &sendAttempt
 			}
 		}
 	}
 
+	// Include exercises if loaded
+	if len(ts.Exercises) > 0 {
+		response.Exercises = make([]ExerciseResponse, len(ts.Exercises))
+		for i := range ts.Exercises {
+			response.Exercises[i] = ts.Exercises[i].ToExerciseResponse()
+		}
+	}
+
 	return response
 }
 
+// toSessionTimingResponse computes the timing statistics of a live session as of now
+func (ts *TrainingSession) toSessionTimingResponse(now time.Time) *SessionTimingResponse {
+	duration := ts.GetDuration(now)
+	timing := &SessionTimingResponse{
+		StartedAt:            *ts.StartedAt,
+		EndedAt:              ts.EndedAt,
+		InProgress:           ts.IsLive(),
+		AutoClosed:           ts.AutoClosed,
+		DurationSeconds:      int64(duration.Seconds()),
+		RestIntervalsSeconds: []int64{},
+	}
+
+	if hours := duration.Hours(); hours > 0 {
+		timing.ClimbsPerHour = math.Round(float64(ts.GetTotalClimbs())/hours*100) / 100
+	}
+
+	var totalRest time.Duration
+	for _, interval := range ts.GetRestIntervals() {
+		timing.RestIntervalsSeconds = append(timing.RestIntervalsSeconds, int64(interval.Seconds()))
+		totalRest += interval
+	}
+	if len(timing.RestIntervalsSeconds) > 0 {
+		timing.AverageRestSeconds = int64(totalRest.Seconds()) / int64(len(timing.RestIntervalsSeconds))
+	}
+
+	return timing
+}
+
 // ToIndoorBoulder converts an IndoorBoulderRequest to an IndoorBoulder model
+// The outcome is derived from the attempts when any are logged
 func (ibr *IndoorBoulderRequest) ToIndoorBoulder() *IndoorBoulder {
-	return &IndoorBoulder{
-		Grade:    ibr.Grade,
-		ColorTag: ibr.ColorTag,
-		Outcome:  ibr.Outcome,
-		Notes:    ibr.Notes,
+	boulder := &IndoorBoulder{
+		Grade:      ibr.Grade,
+		ColorTag:   ibr.ColorTag,
+		GymClimbID: ibr.GymClimbID,
+		Notes:      ibr.Notes,
+		Attempts:   toClimbAttempts(ibr.Attempts),
 	}
+	boulder.DeriveOutcome(ibr.Outcome)
+	return boulder
 }
 
 // ToRopeClimb converts a RopeClimbRequest to a RopeClimb model
+// The outcome is derived from the attempts when any are logged
 func (rcr *RopeClimbRequest) ToRopeClimb() *RopeClimb {
-	return &RopeClimb{
-		ClimbType: rcr.ClimbType,
-		Grade:     rcr.Grade,
-		Outcome:   rcr.Outcome,
-		Notes:     rcr.Notes,
+	ropeClimb := &RopeClimb{
+		ClimbType:  rcr.ClimbType,
+		Grade:      rcr.Grade,
+		GymClimbID: rcr.GymClimbID,
+		Notes:      rcr.Notes,
+		Attempts:   toClimbAttempts(rcr.Attempts),
 	}
+	ropeClimb.DeriveOutcome(rcr.Outcome)
+	return ropeClimb
+}
+
+// toClimbAttempts converts attempt requests to ClimbAttempt models numbered in request order
+// Topped attempts always reach the top, so their high point is set to 100
+func toClimbAttempts(requests []ClimbAttemptRequest) []ClimbAttempt {
+	if len(requests) == 0 {
+		return nil
+	}
+	attempts := make([]ClimbAttempt, len(requests))
+	for i, req := range requests {
+		attempts[i] = ClimbAttempt{
+			AttemptNumber:    i + 1,
+			AttemptedAt:      req.AttemptedAt,
+			Result:           req.Result,
+			HighPointPercent: req.HighPointPercent,
+		}
+		if req.Result == AttemptResultTopped {
+			top := 100
+			attempts[i].HighPointPercent = &top
+		}
+	}
+	return attempts
+}
+
+// toClimbAttemptResponses converts loaded attempts to responses ordered by attempt number
+func toClimbAttemptResponses(attempts []ClimbAttempt) []ClimbAttemptResponse {
+	if len(attempts) == 0 {
+		return nil
+	}
+	sorted := sortAttempts(attempts)
+	responses := make([]ClimbAttemptResponse, len(sorted))
+	for i := range sorted {
+		responses[i] = ClimbAttemptResponse{
+			ID:               sorted[i].ID,
+			AttemptNumber:    sorted[i].AttemptNumber,
+			AttemptedAt:      sorted[i].AttemptedAt,
+			Result:           sorted[i].Result,
+			HighPointPercent: sorted[i].HighPointPercent,
+		}
+	}
+	return responses
+}
+
+// PartnerInvitationResponse represents an invitation to be tagged as a partner on another user's session
+type PartnerInvitationResponse struct {
+	TrainingSessionID uint       `json:"training_session_id"`
+	Status            string     `json:"status"`
+	InvitedAt         time.Time  `json:"invited_at"`
+	RespondedAt       *time.Time `json:"responded_at,omitempty"`
+	MirroredSessionID *uint      `json:"mirrored_session_id,omitempty"`
+
+	// Session shell shared by the owner
+	Owner       PartnerResponse `json:"owner"`
+	Gym         *GymResponse    `json:"gym,omitempty"`
+	SessionDate time.Time       `json:"session_date"`
+	Description string          `json:"description,omitempty"`
+}
+
+// ToPartnerInvitationResponse converts a partner invitation and the session it belongs to into a DTO
+// The session's User and Gym must be loaded for the owner and gym details to be included
+func (p *TrainingSessionPartner) ToPartnerInvitationResponse(session *TrainingSession) *PartnerInvitationResponse {
+	response := &PartnerInvitationResponse{
+		TrainingSessionID: p.TrainingSessionID,
+		Status:            p.Status,
+		InvitedAt:         p.CreatedAt,
+		RespondedAt:       p.RespondedAt,
+		MirroredSessionID: p.MirroredSessionID,
+		Owner: PartnerResponse{
+			ID:        session.User.ID,
+			Username:  session.User.Username,
+			FirstName: session.User.FirstName,
+			LastName:  session.User.LastName,
+		},
+		SessionDate: session.SessionDate,
+		Description: session.Description,
+	}
+
+	if session.Gym.ID != 0 {
+		response.Gym = &GymResponse{
+			ID:   session.Gym.ID,
+			Name: session.Gym.Name,
+			City: session.Gym.City,
+		}
+	}
+
+	return response
 }
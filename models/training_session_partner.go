@@ -0,0 +1,43 @@
+package models
+
+import (
+	"time"
+)
+
+// Partner invitation status constants
+const (
+	PartnerInvitationStatusPending  = "pending"
+	PartnerInvitationStatusAccepted = "accepted"
+	PartnerInvitationStatusDeclined = "declined"
+)
+
+// TrainingSessionPartner is the join table between training sessions and their tagged partners.
+// Tagging a partner creates a pending invitation that the partner can accept or decline.
+type TrainingSessionPartner struct {
+	TrainingSessionID uint `gorm:"primaryKey" json:"training_session_id"`
+	UserID            uint `gorm:"primaryKey;index" json:"user_id"`
+
+	// Invitation state
+	Status      string     `gorm:"size:20;not null;default:pending;index" json:"status"` // pending, accepted, declined
+	RespondedAt *time.Time `json:"responded_at,omitempty"`
+
+	// Copy of the session in the partner's own log, created when accepting with mirroring
+	MirroredSessionID *uint `json:"mirrored_session_id,omitempty"`
+
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// IsPending returns true if the partner has not responded to the invitation
+func (p *TrainingSessionPartner) IsPending() bool {
+	return p.Status == PartnerInvitationStatusPending
+}
+
+// IsAccepted returns true if the partner accepted the invitation
+func (p *TrainingSessionPartner) IsAccepted() bool {
+	return p.Status == PartnerInvitationStatusAccepted
+}
+
+// IsDeclined returns true if the partner declined the invitation
+func (p *TrainingSessionPartner) IsDeclined() bool {
+	return p.Status == PartnerInvitationStatusDeclined
+}
@@ -4,12 +4,24 @@ import (
 	"gorm.io/gorm"
 )
 
+// User role constants
+const (
+	UserRoleUser  = "user"
+	UserRoleAdmin = "admin" // Can approve gym claims and edit any gym
+)
+
 type User struct {
 	gorm.Model
 	Username          string `gorm:"size:50;uniqueIndex;not null" json:"username"`
 	Email             string `gorm:"size:100;uniqueIndex;not null" json:"email"`
-	PasswordHash      string `gorm:"size:255;not null" json:"-"`          // bcrypt hash, excluded from JSON
-	FirstName         string `gorm:"size:100" json:"first_name"`          // optional
-	LastName          string `gorm:"size:100" json:"last_name"`           // optional
-	ProfilePictureURI string `gorm:"size:255" json:"profile_picture_uri"` // S3 URI for profile picture
+	PasswordHash      string `gorm:"size:255;not null" json:"-"`                // bcrypt hash, excluded from JSON
+	FirstName         string `gorm:"size:100" json:"first_name"`                // optional
+	LastName          string `gorm:"size:100" json:"last_name"`                 // optional
+	ProfilePictureURI string `gorm:"size:255" json:"profile_picture_uri"`       // S3 URI for profile picture
+	Role              string `gorm:"size:20;not null;default:user" json:"role"` // user or admin
+}
+
+// IsAdmin returns true if the user has the admin role
+func (u *User) IsAdmin() bool {
+	return u.Role == UserRoleAdmin
 }
@@ -0,0 +1,19 @@
+package models
+
+import (
+	"gorm.io/gorm"
+)
+
+// UserBlock records that a user has blocked another user from tagging them as a training partner.
+// Unblocking hard deletes the row so the pair can be blocked again later.
+type UserBlock struct {
+	gorm.Model
+
+	// User who created the block
+	BlockerID uint `gorm:"not null;uniqueIndex:idx_user_block_pair" json:"blocker_id"`
+	Blocker   User `gorm:"foreignKey:BlockerID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;" json:"-"`
+
+	// User who is blocked
+	BlockedID uint `gorm:"not null;uniqueIndex:idx_user_block_pair;index" json:"blocked_id"`
+	Blocked   User `gorm:"foreignKey:BlockedID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE;" json:"-"`
+}
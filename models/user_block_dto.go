@@ -0,0 +1,27 @@
+package models
+
+import (
+	"time"
+)
+
+// BlockUserRequest represents the request body for blocking a user
+type BlockUserRequest struct {
+	UserID uint `json:"user_id" validate:"required"`
+}
+
+// UserBlockResponse represents a blocked user in API responses
+type UserBlockResponse struct {
+	UserID    uint      `json:"user_id"`
+	Username  string    `json:"username"`
+	BlockedAt time.Time `json:"blocked_at"`
+}
+
+// ToUserBlockResponse converts a UserBlock model to a UserBlockResponse DTO
+// The Blocked user must be loaded for the username to be included
+func (b *UserBlock) ToUserBlockResponse() *UserBlockResponse {
+	return &UserBlockResponse{
+		UserID:    b.BlockedID,
+		Username:  b.Blocked.Username,
+		BlockedAt: b.CreatedAt,
+	}
+}
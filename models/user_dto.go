@@ -19,6 +19,7 @@ type UserResponse struct {
 	LastName              string `json:"last_name,omitempty"`
 	ProfilePictureURL     string `json:"profile_picture_url,omitempty"`     // Presigned URL for profile picture
 	ProfilePictureExpires string `json:"profile_picture_expires,omitempty"` // When the presigned URL expires
+	Role                  string `json:"role"`
 	CreatedAt             string `json:"created_at"`
 	UpdatedAt             string `json:"updated_at"`
 }
@@ -41,6 +42,7 @@ func (u *User) ToUserResponse() *UserResponse {
 		Email:     u.Email,
 		FirstName: u.FirstName,
 		LastName:  u.LastName,
+		Role:      u.Role,
 		CreatedAt: u.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
 		UpdatedAt: u.UpdatedAt.Format("2006-01-02T15:04:05Z07:00"),
 	}
@@ -56,6 +58,7 @@ func (u *User) ToUserResponseWithPresignedURL(profilePictureURL, profilePictureE
 		LastName:              u.LastName,
 		ProfilePictureURL:     profilePictureURL,
 		ProfilePictureExpires: profilePictureExpires,
+		Role:                  u.Role,
 		CreatedAt:             u.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
 		UpdatedAt:             u.UpdatedAt.Format("2006-01-02T15:04:05Z07:00"),
 	}
@@ -0,0 +1,72 @@
+package models
+
+import (
+	"gorm.io/gorm"
+)
+
+// Workout template item kind constants
+const (
+	WorkoutItemKindIndoorBoulder = "indoor_boulder"
+	WorkoutItemKindRopeClimb     = "rope_climb"
+	WorkoutItemKindExercise      = "exercise"
+)
+
+// WorkoutTemplate represents a reusable workout (e.g., "4x4 boulders at V4" or "hangboard repeaters 7/3")
+// that can be scheduled on future dates as planned sessions
+type WorkoutTemplate struct {
+	gorm.Model
+
+	// User who created the template
+	UserID uint `gorm:"not null;index" json:"user_id"`
+	User   User `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE;" json:"-"`
+
+	// Template details
+	Name        string `gorm:"size:100;not null" json:"name"`
+	Description string `gorm:"type:text" json:"description,omitempty"`
+	IsPublic    bool   `gorm:"not null;default:false;index" json:"is_public"` // Public templates can be scheduled by anyone
+
+	// Planned volume and intensity
+	Items []WorkoutTemplateItem `gorm:"constraint:OnUpdate:CASCADE,OnDelete:CASCADE;" json:"items,omitempty"`
+}
+
+// WorkoutTemplateItem represents one block of a workout template. Climb items target a number of
+// climbs at or above a grade; exercise items target sets and reps of an off-the-wall exercise
+type WorkoutTemplateItem struct {
+	gorm.Model
+
+	// Link to workout template
+	WorkoutTemplateID uint `gorm:"not null;index" json:"workout_template_id"`
+
+	Kind     string `gorm:"size:20;not null" json:"kind"` // indoor_boulder, rope_climb, exercise
+	Position int    `gorm:"not null;default:0" json:"position"`
+
+	// Climb targets
+	Count int    `gorm:"not null;default:0" json:"count,omitempty"` // Number of climbs
+	Grade string `gorm:"size:20" json:"grade,omitempty"`            // Minimum grade, optional
+
+	// Exercise targets
+	ExerciseType  string   `gorm:"size:20" json:"exercise_type,omitempty"`
+	ExerciseName  string   `gorm:"size:100" json:"exercise_name,omitempty"`
+	Sets          int      `gorm:"not null;default:0" json:"sets,omitempty"`
+	Reps          int      `gorm:"not null;default:0" json:"reps,omitempty"`
+	HangSeconds   *int     `json:"hang_seconds,omitempty"`
+	RestSeconds   *int     `json:"rest_seconds,omitempty"`
+	AddedWeightKg *float64 `json:"added_weight_kg,omitempty"`
+	EdgeSizeMM    *int     `json:"edge_size_mm,omitempty"`
+	Grip          *string  `gorm:"size:30" json:"grip,omitempty"`
+
+	Notes string `gorm:"type:text" json:"notes,omitempty"`
+}
+
+// IsExercise returns true if the item targets an off-the-wall exercise rather than climbs
+func (i *WorkoutTemplateItem) IsExercise() bool {
+	return i.Kind == WorkoutItemKindExercise
+}
+
+// GetPlannedVolume returns the planned number of climbs, or total reps for exercise items
+func (i *WorkoutTemplateItem) GetPlannedVolume() int {
+	if i.IsExercise() {
+		return i.Sets * i.Reps
+	}
+	return i.Count
+}
@@ -0,0 +1,118 @@
+package models
+
+import (
+	"time"
+)
+
+// WorkoutTemplateItemRequest represents one block of a workout template in the create request
+// Climb items set count and an optional minimum grade; exercise items set exercise
+type WorkoutTemplateItemRequest struct {
+	Kind     string           `json:"kind" validate:"required,oneof=indoor_boulder rope_climb exercise"`
+	Count    int              `json:"count,omitempty"`
+	Grade    string           `json:"grade,omitempty" validate:"omitempty,max=20"`
+	Exercise *ExerciseRequest `json:"exercise,omitempty"`
+	Notes    string           `json:"notes,omitempty" validate:"omitempty,max=1000"`
+}
+
+// CreateWorkoutTemplateRequest represents the request body for creating a workout template
+type CreateWorkoutTemplateRequest struct {
+	Name        string                       `json:"name" validate:"required,max=100"`
+	Description string                       `json:"description,omitempty" validate:"omitempty,max=1000"`
+	IsPublic    bool                         `json:"is_public"`
+	Items       []WorkoutTemplateItemRequest `json:"items" validate:"required,min=1"`
+}
+
+// WorkoutTemplateItemResponse represents one block of a workout template in the response
+type WorkoutTemplateItemResponse struct {
+	ID       uint             `json:"id"`
+	Kind     string           `json:"kind"`
+	Position int              `json:"position"`
+	Count    int              `json:"count,omitempty"`
+	Grade    string           `json:"grade,omitempty"`
+	Exercise *ExerciseRequest `json:"exercise,omitempty"`
+	Notes    string           `json:"notes,omitempty"`
+}
+
+// WorkoutTemplateResponse represents the workout template data returned in API responses
+type WorkoutTemplateResponse struct {
+	ID          uint                          `json:"id"`
+	UserID      uint                          `json:"user_id"`
+	Name        string                        `json:"name"`
+	Description string                        `json:"description,omitempty"`
+	IsPublic    bool                          `json:"is_public"`
+	Items       []WorkoutTemplateItemResponse `json:"items,omitempty"`
+	CreatedAt   time.Time                     `json:"created_at"`
+	UpdatedAt   time.Time                     `json:"updated_at"`
+}
+
+// ToWorkoutTemplateItem converts a WorkoutTemplateItemRequest to a WorkoutTemplateItem model
+func (r *WorkoutTemplateItemRequest) ToWorkoutTemplateItem(position int) *WorkoutTemplateItem {
+	item := &WorkoutTemplateItem{
+		Kind:     r.Kind,
+		Position: position,
+		Notes:    r.Notes,
+	}
+
+	if r.Kind == WorkoutItemKindExercise && r.Exercise != nil {
+		item.ExerciseType = r.Exercise.Type
+		item.ExerciseName = r.Exercise.Name
+		item.Sets = r.Exercise.Sets
+		item.Reps = r.Exercise.Reps
+		item.HangSeconds = r.Exercise.HangSeconds
+		item.RestSeconds = r.Exercise.RestSeconds
+		item.AddedWeightKg = r.Exercise.AddedWeightKg
+		item.EdgeSizeMM = r.Exercise.EdgeSizeMM
+		item.Grip = r.Exercise.Grip
+	} else {
+		item.Count = r.Count
+		if r.Grade != "" {
+			item.Grade = NormalizeGrade(r.Grade)
+		}
+	}
+
+	return item
+}
+
+// ToWorkoutTemplateResponse converts a WorkoutTemplate model to a WorkoutTemplateResponse DTO
+func (wt *WorkoutTemplate) ToWorkoutTemplateResponse() *WorkoutTemplateResponse {
+	response := &WorkoutTemplateResponse{
+		ID:          wt.ID,
+		UserID:      wt.UserID,
+		Name:        wt.Name,
+		Description: wt.Description,
+		IsPublic:    wt.IsPublic,
+		CreatedAt:   wt.CreatedAt,
+		UpdatedAt:   wt.UpdatedAt,
+	}
+
+	// Include items if loaded
+	if len(wt.Items) > 0 {
+		response.Items = make([]WorkoutTemplateItemResponse, len(wt.Items))
+		for i := range wt.Items {
+			item := &wt.Items[i]
+			response.Items[i] = WorkoutTemplateItemResponse{
+				ID:       item.ID,
+				Kind:     item.Kind,
+				Position: item.Position,
+				Count:    item.Count,
+				Grade:    item.Grade,
+				Notes:    item.Notes,
+			}
+			if item.IsExercise() {
+				response.Items[i].Exercise = &ExerciseRequest{
+					Type:          item.ExerciseType,
+					Name:          item.ExerciseName,
+					Sets:          item.Sets,
+					Reps:          item.Reps,
+					HangSeconds:   item.HangSeconds,
+					RestSeconds:   item.RestSeconds,
+					AddedWeightKg: item.AddedWeightKg,
+					EdgeSizeMM:    item.EdgeSizeMM,
+					Grip:          item.Grip,
+				}
+			}
+		}
+	}
+
+	return response
+}